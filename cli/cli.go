@@ -6,11 +6,16 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/client/sidecar"
 	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
 	"github.com/figment-networks/polkadothub-indexer/store/psql"
+	"github.com/figment-networks/polkadothub-indexer/usecase/reward"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 	"github.com/figment-networks/polkadothub-indexer/utils/reporting"
 )
@@ -24,12 +29,28 @@ type Flags struct {
 	batchSize          int64
 	parallel           bool
 	force              bool
+	shadow             bool
 	targetIds          targetIds
 	trxKinds           trxKinds
 	startReindexHeight int64
 	endReindexHeight   int64
 	lastInEra          bool
 	lastInSession      bool
+	height             int64
+
+	exportTables  exportTables
+	exportFormat  string
+	exportOutput  string
+	exportAddress string
+	startEra      int64
+	endEra        int64
+
+	priceFile string
+	currency  string
+	period    string
+	startDate string
+	endDate   string
+	detailed  bool
 }
 
 type targetIds []int64
@@ -63,19 +84,51 @@ func (i *trxKinds) Set(value string) error {
 		return errors.New("trxKinds flag already set")
 	}
 	for _, raw := range strings.Split(value, ",") {
-		parts := strings.Split(raw, ".")
-		if len(parts) < 2 {
-			return fmt.Errorf("unexpected format for txkind")
+		kind, err := model.ParseTransactionKind(raw)
+		if err != nil {
+			return err
 		}
+		*i = append(*i, kind)
+	}
+	return nil
+}
+
+type exportTables []string
+
+func (i *exportTables) String() string {
+	return fmt.Sprint(*i)
+}
 
-		*i = append(*i, model.TransactionKind{
-			Section: parts[0],
-			Method:  parts[1],
-		})
+func (i *exportTables) Set(value string) error {
+	if len(*i) > 0 {
+		return errors.New("exportTables flag already set")
 	}
+	*i = strings.Split(value, ",")
 	return nil
 }
 
+// reportParams builds params of rewards_report cmd, start and end dates are in format 2006-01-02
+func (c *Flags) reportParams() (reward.ReportParams, error) {
+	params := reward.ReportParams{
+		StashAccount: c.exportAddress,
+		Currency:     c.currency,
+		Period:       c.period,
+	}
+
+	var err error
+	if c.startDate != "" {
+		if params.Start, err = time.Parse(model.PriceDateFormat, c.startDate); err != nil {
+			return params, err
+		}
+	}
+	if c.endDate != "" {
+		if params.End, err = time.Parse(model.PriceDateFormat, c.endDate); err != nil {
+			return params, err
+		}
+	}
+	return params, nil
+}
+
 func (c *Flags) Setup() {
 	flag.BoolVar(&c.showVersion, "v", false, "Show application version")
 	flag.StringVar(&c.configPath, "config", "", "Path to config")
@@ -85,12 +138,26 @@ func (c *Flags) Setup() {
 	flag.Int64Var(&c.batchSize, "batch_size", 0, "pipeline batch size")
 	flag.BoolVar(&c.parallel, "parallel", false, "should backfill be run in parallel with indexing")
 	flag.BoolVar(&c.force, "force", false, "remove existing reindexing reports")
+	flag.BoolVar(&c.shadow, "shadow", false, "should backfill write into shadow tables and swap them with live ones once it completes")
 	flag.Var(&c.targetIds, "target_ids", "comma separated list of integers")
 	flag.Var(&c.trxKinds, "trx_kinds", "comma separated list of transaction kinds to run in reindex cmd in the format section.method")
 	flag.BoolVar(&c.lastInEra, "last_in_era", false, "should reindex last in era for reindex cmd")
 	flag.BoolVar(&c.lastInSession, "last_in_session", false, "should reindex last in session for reindex cmd")
-	flag.Int64Var(&c.startReindexHeight, "start_height", 0, "start height for reindex cmd")
-	flag.Int64Var(&c.endReindexHeight, "end_height", 0, "end height for reindex cmd")
+	flag.Int64Var(&c.startReindexHeight, "start_height", 0, "start height for reindex, restore_archive and export cmds")
+	flag.Int64Var(&c.endReindexHeight, "end_height", 0, "end height for reindex, restore_archive and export cmds")
+	flag.Int64Var(&c.height, "height", 0, "height to run inspect cmd for")
+	flag.Var(&c.exportTables, "tables", "comma separated list of tables to export, all tables are exported when not set")
+	flag.StringVar(&c.exportFormat, "format", "jsonl", "export format (jsonl or csv)")
+	flag.StringVar(&c.exportOutput, "output", "", "output directory for export cmd, output file for rewards_report cmd")
+	flag.StringVar(&c.exportAddress, "address", "", "address to filter exported rows by, stash account for rewards_report cmd")
+	flag.Int64Var(&c.startEra, "start_era", -1, "start era for export cmd, takes precedence over start_height")
+	flag.Int64Var(&c.endEra, "end_era", -1, "end era for export cmd, takes precedence over end_height")
+	flag.StringVar(&c.priceFile, "file", "", "csv or json file of prices for prices_import cmd")
+	flag.StringVar(&c.currency, "currency", "", "currency of prices_import and rewards_report cmds")
+	flag.StringVar(&c.period, "period", "month", "period of sums for rewards_report cmd (day, week, month or year)")
+	flag.StringVar(&c.startDate, "start_date", "", "start day for rewards_report cmd in format 2006-01-02")
+	flag.StringVar(&c.endDate, "end_date", "", "end day for rewards_report cmd in format 2006-01-02, defaults to most recently indexed time")
+	flag.BoolVar(&c.detailed, "detailed", false, "should rewards_report cmd list every reward instead of sums of periods")
 }
 
 // Run executes the command line interface
@@ -171,10 +238,74 @@ func initLogger(cfg *config.Config) error {
 }
 
 func initClient(cfg *config.Config) (*client.Client, error) {
-	return client.New(cfg.ProxyUrl)
+	if cfg.UseSidecar() {
+		timeout, err := time.ParseDuration(cfg.SidecarTimeout)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("reading data from sidecar", logger.Field("app", "cli"), logger.Field("url", cfg.SidecarUrl))
+		return sidecar.New(cfg.SidecarUrl, sidecar.WithTimeout(timeout)), nil
+	}
+
+	timeout, err := time.ParseDuration(cfg.ProxyTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	methodTimeouts := map[string]time.Duration{}
+	for method, rawTimeout := range cfg.ProxyMethodTimeouts {
+		if methodTimeouts[method], err = time.ParseDuration(rawTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	retryBackoff, err := time.ParseDuration(cfg.ProxyRetryBackoff)
+	if err != nil {
+		return nil, err
+	}
+
+	breakerCooldown, err := time.ParseDuration(cfg.ProxyBreakerCooldown)
+	if err != nil {
+		return nil, err
+	}
+
+	healthCheckInterval, err := time.ParseDuration(cfg.ProxyHealthCheckInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	proxyEndpoints, err := cfg.ParseProxyEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]client.Endpoint, len(proxyEndpoints))
+	for i, e := range proxyEndpoints {
+		endpoints[i] = client.Endpoint{URL: e.URL, Role: client.Role(e.Role)}
+	}
+
+	opts := []client.Option{
+		client.WithTimeout(timeout),
+		client.WithMethodTimeouts(methodTimeouts),
+		client.WithRetries(int(cfg.ProxyMaxRetries), retryBackoff),
+		client.WithCircuitBreaker(int(cfg.ProxyBreakerFailures), breakerCooldown),
+		client.WithHealthCheck(healthCheckInterval),
+		client.WithTipDepth(cfg.ProxyTipDepth),
+		client.WithAuthToken(cfg.ProxyAuthToken),
+	}
+	if cfg.ProxyTLS {
+		opts = append(opts, client.WithTLS(cfg.ProxyTLSCAFile))
+	}
+
+	return client.NewWithEndpoints(endpoints, opts...)
 }
 
-func initPostgres(cfg *config.Config) (*psql.Store, error) {
+func initStore(cfg *config.Config) (store.Store, error) {
+	if cfg.IsMemoryStore() {
+		logger.Info("using in-memory store, data is lost when process exits", logger.Field("app", "cli"))
+		return memory.New(), nil
+	}
+
 	db, err := psql.New(cfg.DatabaseDSN)
 	if err != nil {
 		return nil, err
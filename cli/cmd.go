@@ -7,11 +7,12 @@ import (
 
 	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/usecase"
+	"github.com/figment-networks/polkadothub-indexer/usecase/export"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 )
 
 func runCmd(cfg *config.Config, flags Flags) error {
-	db, err := initPostgres(cfg)
+	db, err := initStore(cfg)
 	if err != nil {
 		return err
 	}
@@ -23,8 +24,8 @@ func runCmd(cfg *config.Config, flags Flags) error {
 	}
 	defer client.Close()
 
-	cmdHandlers := usecase.NewCmdHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetReports(),
-		db.GetRewards(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
+	cmdHandlers := usecase.NewCmdHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetPrices(),
+		db.GetReports(), db.GetRewards(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
 	)
 
 	logger.Info(fmt.Sprintf("executing cmd %s ...", flags.runCommand), logger.Field("app", "cli"))
@@ -36,13 +37,38 @@ func runCmd(cfg *config.Config, flags Flags) error {
 	case "indexer_start":
 		cmdHandlers.StartIndexer.Handle(ctx, flags.batchSize)
 	case "indexer_backfill":
-		cmdHandlers.BackfillIndexer.Handle(ctx, flags.parallel, flags.force, flags.targetIds)
+		cmdHandlers.BackfillIndexer.Handle(ctx, flags.parallel, flags.force, flags.targetIds, flags.shadow)
 	case "indexer_reindex":
 		cmdHandlers.ReindexIndexer.Handle(ctx, flags.parallel, flags.force, flags.targetIds, flags.lastInEra, flags.lastInSession, flags.trxKinds, flags.startReindexHeight, flags.endReindexHeight)
 	case "indexer_summarize":
 		cmdHandlers.SummarizeIndexer.Handle(ctx)
+	case "inspect":
+		cmdHandlers.Inspect.Handle(ctx, flags.height, flags.targetIds)
 	case "indexer_purge":
 		cmdHandlers.PurgeIndexer.Handle(ctx)
+	case "system_events_rewrite":
+		cmdHandlers.RewriteChangeSystemEvents.Handle(ctx, flags.batchSize)
+	case "restore_archive":
+		cmdHandlers.RestoreArchive.Handle(ctx, flags.startReindexHeight, flags.endReindexHeight)
+	case "export":
+		cmdHandlers.Export.Handle(ctx, export.Params{
+			Tables:      flags.exportTables,
+			Format:      flags.exportFormat,
+			Output:      flags.exportOutput,
+			Address:     flags.exportAddress,
+			StartHeight: flags.startReindexHeight,
+			EndHeight:   flags.endReindexHeight,
+			StartEra:    flags.startEra,
+			EndEra:      flags.endEra,
+		})
+	case "prices_import":
+		cmdHandlers.ImportPrices.Handle(ctx, flags.priceFile, flags.currency)
+	case "rewards_report":
+		params, err := flags.reportParams()
+		if err != nil {
+			return err
+		}
+		cmdHandlers.RewardsReport.Handle(ctx, params, flags.detailed, flags.exportOutput)
 	default:
 		return errors.New(fmt.Sprintf("command %s not found", flags.runCommand))
 	}
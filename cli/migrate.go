@@ -16,6 +16,11 @@ import (
 )
 
 func startMigrations(cfg *config.Config, version uint) error {
+	if cfg.IsMemoryStore() {
+		log.Println("in-memory store needs no migrations")
+		return nil
+	}
+
 	log.Println("getting current directory")
 	dir, err := os.Getwd()
 	if err != nil {
@@ -13,14 +13,14 @@ func startServer(cfg *config.Config) error {
 	}
 	defer client.Close()
 
-	db, err := initPostgres(cfg)
+	db, err := initStore(cfg)
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
-	httpHandlers := usecase.NewHttpHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(),
-		db.GetReports(), db.GetRewards(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
+	httpHandlers := usecase.NewHttpHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetJobs(), db.GetPrices(),
+		db.GetReports(), db.GetRewards(), db.GetSubscriptions(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
 	)
 
 	a, err := server.New(cfg, httpHandlers)
@@ -7,7 +7,7 @@ import (
 )
 
 func startWorker(cfg *config.Config) error {
-	db, err := initPostgres(cfg)
+	db, err := initStore(cfg)
 	if err != nil {
 		return err
 	}
@@ -19,8 +19,8 @@ func startWorker(cfg *config.Config) error {
 	}
 	defer client.Close()
 
-	workerHandlers := usecase.NewWorkerHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetReports(),
-		db.GetRewards(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
+	workerHandlers := usecase.NewWorkerHandlers(cfg, client, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetJobs(), db.GetReports(),
+		db.GetRewards(), db.GetSubscriptions(), db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators(),
 	)
 
 	w, err := worker.New(cfg, workerHandlers)
@@ -12,11 +12,11 @@ var (
 )
 
 type AccountClient interface {
-	GetIdentity(string) (*accountpb.GetIdentityResponse, error)
-	GetByHeight(string, int64) (*accountpb.GetByHeightResponse, error)
+	GetIdentity(context.Context, string) (*accountpb.GetIdentityResponse, error)
+	GetByHeight(context.Context, string, int64) (*accountpb.GetByHeightResponse, error)
 }
 
-func NewAccountClient(conn *grpc.ClientConn) *accountClient {
+func NewAccountClient(conn grpc.ClientConnInterface) *accountClient {
 	return &accountClient{
 		client: accountpb.NewAccountServiceClient(conn),
 	}
@@ -26,17 +26,13 @@ type accountClient struct {
 	client accountpb.AccountServiceClient
 }
 
-func (r *accountClient) GetIdentity(address string) (*accountpb.GetIdentityResponse, error) {
-	ctx := context.Background()
-
+func (r *accountClient) GetIdentity(ctx context.Context, address string) (*accountpb.GetIdentityResponse, error) {
 	return r.client.GetIdentity(ctx, &accountpb.GetIdentityRequest{Address: address})
 }
 
-func (r *accountClient) GetByHeight(address string, h int64) (*accountpb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *accountClient) GetByHeight(ctx context.Context, address string, h int64) (*accountpb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &accountpb.GetByHeightRequest{
 		Address: address,
-		Height: h,
+		Height:  h,
 	})
 }
@@ -12,10 +12,10 @@ var (
 )
 
 type BlockClient interface {
-	GetByHeight(int64) (*blockpb.GetByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*blockpb.GetByHeightResponse, error)
 }
 
-func NewBlockClient(conn *grpc.ClientConn) *blockClient {
+func NewBlockClient(conn grpc.ClientConnInterface) *blockClient {
 	return &blockClient{
 		client: blockpb.NewBlockServiceClient(conn),
 	}
@@ -25,8 +25,6 @@ type blockClient struct {
 	client blockpb.BlockServiceClient
 }
 
-func (r *blockClient) GetByHeight(h int64) (*blockpb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *blockClient) GetByHeight(ctx context.Context, h int64) (*blockpb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &blockpb.GetByHeightRequest{Height: h})
 }
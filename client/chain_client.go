@@ -12,12 +12,12 @@ var (
 
 type ChainClient interface {
 	//Queries
-	GetHead() (*chainpb.GetHeadResponse, error)
-	GeStatus() (*chainpb.GetStatusResponse, error)
-	GeMetaByHeight(int64) (*chainpb.GetMetaByHeightResponse, error)
+	GetHead(context.Context) (*chainpb.GetHeadResponse, error)
+	GeStatus(context.Context) (*chainpb.GetStatusResponse, error)
+	GeMetaByHeight(context.Context, int64) (*chainpb.GetMetaByHeightResponse, error)
 }
 
-func NewChainClient(conn *grpc.ClientConn) *chainClient {
+func NewChainClient(conn grpc.ClientConnInterface) *chainClient {
 	return &chainClient{
 		client: chainpb.NewChainServiceClient(conn),
 	}
@@ -27,20 +27,14 @@ type chainClient struct {
 	client chainpb.ChainServiceClient
 }
 
-func (r *chainClient) GetHead() (*chainpb.GetHeadResponse, error) {
-	ctx := context.Background()
-
+func (r *chainClient) GetHead(ctx context.Context) (*chainpb.GetHeadResponse, error) {
 	return r.client.GetHead(ctx, &chainpb.GetHeadRequest{})
 }
 
-func (r *chainClient) GeStatus() (*chainpb.GetStatusResponse, error) {
-	ctx := context.Background()
-
+func (r *chainClient) GeStatus(ctx context.Context) (*chainpb.GetStatusResponse, error) {
 	return r.client.GetStatus(ctx, &chainpb.GetStatusRequest{})
 }
 
-func (r *chainClient) GeMetaByHeight(h int64) (*chainpb.GetMetaByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *chainClient) GeMetaByHeight(ctx context.Context, h int64) (*chainpb.GetMetaByHeightResponse, error) {
 	return r.client.GetMetaByHeight(ctx, &chainpb.GetMetaByHeightRequest{Height: h})
-}
\ No newline at end of file
+}
@@ -1,20 +1,21 @@
 package client
 
-import (
-	"google.golang.org/grpc"
-)
-
 // maxMsgSize increases the grpc max message size from 4194304 to 419430400
 var maxMsgSize = 1024 * 1024 * 400
 
-func New(connStr string) (*Client, error) {
-	conn, err := grpc.Dial(
-		connStr,
-		grpc.WithInsecure(),
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(maxMsgSize),
-		),
-	)
+// New returns client of single archive proxy endpoint
+func New(connStr string, opts ...Option) (*Client, error) {
+	return NewWithEndpoints([]Endpoint{{URL: connStr, Role: RoleArchive}}, opts...)
+}
+
+// NewWithEndpoints returns client routing calls to given proxy endpoints
+func NewWithEndpoints(endpoints []Endpoint, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	conn, err := newPool(endpoints, o)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +36,7 @@ func New(connStr string) (*Client, error) {
 }
 
 type Client struct {
-	conn *grpc.ClientConn
+	conn *pool
 
 	Chain                ChainClient
 	Account              AccountClient
@@ -49,5 +50,9 @@ type Client struct {
 }
 
 func (c *Client) Close() error {
+	// Client sets built on other sources, ie. sidecar, have no connection to close
+	if c.conn == nil {
+		return nil
+	}
 	return c.conn.Close()
 }
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+)
+
+const (
+	// RoleArchive proxy is connected to archive node and serves calls for any height
+	RoleArchive Role = "archive"
+	// RoleTip proxy is connected to pruned node and serves calls for heights close to chain head
+	RoleTip Role = "tip"
+)
+
+// Role tells which calls proxy endpoint can serve
+type Role string
+
+// Valid returns true for known roles
+func (r Role) Valid() bool {
+	return r == RoleArchive || r == RoleTip
+}
+
+// Endpoint is address of proxy with its role
+type Endpoint struct {
+	URL  string
+	Role Role
+}
+
+// endpointConn is connection to single proxy endpoint
+type endpointConn struct {
+	Endpoint
+
+	conn *grpc.ClientConn
+
+	healthy int32
+	head    int64
+}
+
+func dialEndpoint(endpoint Endpoint, o *options) (*endpointConn, error) {
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMsgSize),
+		),
+	}
+
+	if o.tls {
+		creds, err := newTransportCredentials(o.tlsCAFile)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+
+	if o.authToken != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(tokenCredentials{token: o.authToken, requireTLS: o.tls}))
+	}
+
+	conn, err := grpc.Dial(endpoint.URL, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &endpointConn{Endpoint: endpoint, conn: conn}
+	e.setHealthy(true)
+	return e, nil
+}
+
+func (e *endpointConn) isHealthy() bool {
+	return atomic.LoadInt32(&e.healthy) == 1
+}
+
+func (e *endpointConn) setHealthy(healthy bool) {
+	value := int32(0)
+	if healthy {
+		value = 1
+	}
+	atomic.StoreInt32(&e.healthy, value)
+	metric.ClientEndpointHealthy.WithLabelValues(e.URL, string(e.Role)).Set(float64(value))
+}
+
+func (e *endpointConn) getHead() int64 {
+	return atomic.LoadInt64(&e.head)
+}
+
+func (e *endpointConn) setHead(height int64) {
+	atomic.StoreInt64(&e.head, height)
+}
+
+// newTransportCredentials returns TLS credentials verifying server with given CA file or with system roots when file is not given
+func newTransportCredentials(caFile string) (credentials.TransportCredentials, error) {
+	if caFile == "" {
+		return credentials.NewTLS(&tls.Config{}), nil
+	}
+	return credentials.NewClientTLSFromFile(caFile, "")
+}
+
+// tokenCredentials sends bearer token in metadata of every call
+type tokenCredentials struct {
+	token      string
+	requireTLS bool
+}
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (c tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
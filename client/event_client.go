@@ -12,10 +12,10 @@ var (
 )
 
 type EventClient interface {
-	GetByHeight(int64) (*eventpb.GetByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*eventpb.GetByHeightResponse, error)
 }
 
-func NewEventClient(conn *grpc.ClientConn) *eventClient {
+func NewEventClient(conn grpc.ClientConnInterface) *eventClient {
 	return &eventClient{
 		client: eventpb.NewEventServiceClient(conn),
 	}
@@ -25,8 +25,6 @@ type eventClient struct {
 	client eventpb.EventServiceClient
 }
 
-func (r *eventClient) GetByHeight(h int64) (*eventpb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *eventClient) GetByHeight(ctx context.Context, h int64) (*eventpb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &eventpb.GetByHeightRequest{Height: h})
 }
@@ -0,0 +1,90 @@
+package fakeproxy
+
+import (
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/account/accountpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/block/blockpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/event/eventpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/height/heightpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/transaction/transactionpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validator/validatorpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
+)
+
+// maxMsgSize matches max message size of client, so recorded responses fit through both connections
+const maxMsgSize = 1024 * 1024 * 400
+
+// Server is in-process fake of polkadothub-proxy. It serves every service used by client package, responses are
+// read from JSON fixture files named after method and request, ie. height.HeightService/GetAll/100.json.
+// Calls without fixture fail with NotFound status
+type Server struct {
+	grpc     *grpc.Server
+	listener net.Listener
+	conn     *grpc.ClientConn
+}
+
+// New starts fake proxy serving fixtures from given directory on random local port
+func New(dir string) (*Server, error) {
+	return start(fixtureSource{dir: dir}, nil)
+}
+
+// NewRecorder starts fake proxy which forwards calls to real proxy at proxyURL and saves its responses as fixtures
+// to given directory, so they can be served by New later
+func NewRecorder(dir, proxyURL string) (*Server, error) {
+	conn, err := grpc.Dial(proxyURL, grpc.WithInsecure(), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize)))
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := start(recordingSource{dir: dir, conn: conn}, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func start(src source, conn *grpc.ClientConn) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		grpc:     grpc.NewServer(grpc.MaxSendMsgSize(maxMsgSize)),
+		listener: listener,
+		conn:     conn,
+	}
+
+	accountpb.RegisterAccountServiceServer(s.grpc, &accountService{src: src})
+	blockpb.RegisterBlockServiceServer(s.grpc, &blockService{src: src})
+	chainpb.RegisterChainServiceServer(s.grpc, &chainService{src: src})
+	eventpb.RegisterEventServiceServer(s.grpc, &eventService{src: src})
+	heightpb.RegisterHeightServiceServer(s.grpc, &heightService{src: src})
+	stakingpb.RegisterStakingServiceServer(s.grpc, &stakingService{src: src})
+	transactionpb.RegisterTransactionServiceServer(s.grpc, &transactionService{src: src})
+	validatorpb.RegisterValidatorServiceServer(s.grpc, &validatorService{src: src})
+	validatorperformancepb.RegisterValidatorPerformanceServiceServer(s.grpc, &validatorPerformanceService{src: src})
+
+	go s.grpc.Serve(listener)
+
+	return s, nil
+}
+
+// Addr returns address to pass to client.New
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Stop stops the server and closes connection to real proxy of recorder
+func (s *Server) Stop() {
+	s.grpc.Stop()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
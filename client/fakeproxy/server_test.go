@@ -0,0 +1,109 @@
+package fakeproxy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+)
+
+const fixturesDir = "testdata/fixtures"
+
+func TestServer(t *testing.T) {
+	t.Run("serves fixtures through client", func(t *testing.T) {
+		cli := newTestClient(t, fixturesDir)
+
+		res, err := cli.Height.GetAll(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if res.GetBlock().GetBlock().GetBlockHash() != "0xabc" {
+			t.Errorf("want block hash %v; got %v", "0xabc", res.GetBlock().GetBlock().GetBlockHash())
+		}
+		if res.GetChain().GetSession() != 5 || !res.GetChain().GetLastInSession() {
+			t.Errorf("unexpected chain meta %v", res.GetChain())
+		}
+
+		head, err := cli.Chain.GetHead(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if head.GetHeight() != 10 {
+			t.Errorf("want head height %v; got %v", 10, head.GetHeight())
+		}
+	})
+
+	t.Run("returns NotFound when fixture is missing", func(t *testing.T) {
+		cli := newTestClient(t, fixturesDir)
+
+		_, err := cli.Height.GetAll(context.Background(), 11)
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("want %v; got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("recorder saves responses which are served afterwards", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "fakeproxy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		proxy, err := New(fixturesDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer proxy.Stop()
+
+		recorder, err := NewRecorder(dir, proxy.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer recorder.Stop()
+
+		recorderClient, err := client.New(recorder.Addr())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer recorderClient.Close()
+
+		recorded, err := recorderClient.Height.GetAll(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "height.HeightService", "GetAll", "10.json")); err != nil {
+			t.Fatalf("fixture not recorded: %v", err)
+		}
+
+		replayed, err := newTestClient(t, dir).Height.GetAll(context.Background(), 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !proto.Equal(recorded, replayed) {
+			t.Errorf("want %v; got %v", recorded, replayed)
+		}
+	})
+}
+
+func newTestClient(t *testing.T, dir string) *client.Client {
+	srv, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Stop)
+
+	cli, err := client.New(srv.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+
+	return cli
+}
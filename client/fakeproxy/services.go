@@ -0,0 +1,124 @@
+package fakeproxy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/account/accountpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/block/blockpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/event/eventpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/height/heightpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/transaction/transactionpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validator/validatorpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
+)
+
+// Chain head and status don't depend on request, they are stored under this key
+const latestKey = "latest"
+
+type accountService struct {
+	accountpb.UnimplementedAccountServiceServer
+	src source
+}
+
+func (s *accountService) GetIdentity(ctx context.Context, req *accountpb.GetIdentityRequest) (*accountpb.GetIdentityResponse, error) {
+	resp := &accountpb.GetIdentityResponse{}
+	return resp, s.src.respond(ctx, "/account.AccountService/GetIdentity", req.Address, req, resp)
+}
+
+func (s *accountService) GetByHeight(ctx context.Context, req *accountpb.GetByHeightRequest) (*accountpb.GetByHeightResponse, error) {
+	resp := &accountpb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/account.AccountService/GetByHeight", fmt.Sprintf("%s_%d", req.Address, req.Height), req, resp)
+}
+
+type blockService struct {
+	blockpb.UnimplementedBlockServiceServer
+	src source
+}
+
+func (s *blockService) GetByHeight(ctx context.Context, req *blockpb.GetByHeightRequest) (*blockpb.GetByHeightResponse, error) {
+	resp := &blockpb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/block.BlockService/GetByHeight", heightKey(req.Height), req, resp)
+}
+
+type chainService struct {
+	chainpb.UnimplementedChainServiceServer
+	src source
+}
+
+func (s *chainService) GetHead(ctx context.Context, req *chainpb.GetHeadRequest) (*chainpb.GetHeadResponse, error) {
+	resp := &chainpb.GetHeadResponse{}
+	return resp, s.src.respond(ctx, "/chain.ChainService/GetHead", latestKey, req, resp)
+}
+
+func (s *chainService) GetStatus(ctx context.Context, req *chainpb.GetStatusRequest) (*chainpb.GetStatusResponse, error) {
+	resp := &chainpb.GetStatusResponse{}
+	return resp, s.src.respond(ctx, "/chain.ChainService/GetStatus", latestKey, req, resp)
+}
+
+func (s *chainService) GetMetaByHeight(ctx context.Context, req *chainpb.GetMetaByHeightRequest) (*chainpb.GetMetaByHeightResponse, error) {
+	resp := &chainpb.GetMetaByHeightResponse{}
+	return resp, s.src.respond(ctx, "/chain.ChainService/GetMetaByHeight", heightKey(req.Height), req, resp)
+}
+
+type eventService struct {
+	eventpb.UnimplementedEventServiceServer
+	src source
+}
+
+func (s *eventService) GetByHeight(ctx context.Context, req *eventpb.GetByHeightRequest) (*eventpb.GetByHeightResponse, error) {
+	resp := &eventpb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/event.EventService/GetByHeight", heightKey(req.Height), req, resp)
+}
+
+type heightService struct {
+	heightpb.UnimplementedHeightServiceServer
+	src source
+}
+
+func (s *heightService) GetAll(ctx context.Context, req *heightpb.GetAllRequest) (*heightpb.GetAllResponse, error) {
+	resp := &heightpb.GetAllResponse{}
+	return resp, s.src.respond(ctx, "/height.HeightService/GetAll", heightKey(req.Height), req, resp)
+}
+
+type stakingService struct {
+	stakingpb.UnimplementedStakingServiceServer
+	src source
+}
+
+func (s *stakingService) GetByHeight(ctx context.Context, req *stakingpb.GetByHeightRequest) (*stakingpb.GetByHeightResponse, error) {
+	resp := &stakingpb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/staking.StakingService/GetByHeight", heightKey(req.Height), req, resp)
+}
+
+type transactionService struct {
+	transactionpb.UnimplementedTransactionServiceServer
+	src source
+}
+
+func (s *transactionService) GetByHeight(ctx context.Context, req *transactionpb.GetByHeightRequest) (*transactionpb.GetByHeightResponse, error) {
+	resp := &transactionpb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/transaction.TransactionService/GetByHeight", heightKey(req.Height), req, resp)
+}
+
+type validatorService struct {
+	validatorpb.UnimplementedValidatorServiceServer
+	src source
+}
+
+func (s *validatorService) GetAllByHeight(ctx context.Context, req *validatorpb.GetAllByHeightRequest) (*validatorpb.GetAllByHeightResponse, error) {
+	resp := &validatorpb.GetAllByHeightResponse{}
+	return resp, s.src.respond(ctx, "/validator.ValidatorService/GetAllByHeight", heightKey(req.Height), req, resp)
+}
+
+type validatorPerformanceService struct {
+	validatorperformancepb.UnimplementedValidatorPerformanceServiceServer
+	src source
+}
+
+func (s *validatorPerformanceService) GetByHeight(ctx context.Context, req *validatorperformancepb.GetByHeightRequest) (*validatorperformancepb.GetByHeightResponse, error) {
+	resp := &validatorperformancepb.GetByHeightResponse{}
+	return resp, s.src.respond(ctx, "/validatorPerformance.ValidatorPerformanceService/GetByHeight", heightKey(req.Height), req, resp)
+}
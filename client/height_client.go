@@ -13,10 +13,10 @@ var (
 )
 
 type HeightClient interface {
-	GetAll(int64) (*heightpb.GetAllResponse, error)
+	GetAll(context.Context, int64) (*heightpb.GetAllResponse, error)
 }
 
-func NewHeightClient(conn *grpc.ClientConn) *heightClient {
+func NewHeightClient(conn grpc.ClientConnInterface) *heightClient {
 	return &heightClient{
 		client: heightpb.NewHeightServiceClient(conn),
 	}
@@ -26,8 +26,6 @@ type heightClient struct {
 	client heightpb.HeightServiceClient
 }
 
-func (r *heightClient) GetAll(h int64) (*heightpb.GetAllResponse, error) {
-	ctx := context.Background()
-
+func (r *heightClient) GetAll(ctx context.Context, h int64) (*heightpb.GetAllResponse, error) {
 	return r.client.GetAll(ctx, &heightpb.GetAllRequest{Height: h})
 }
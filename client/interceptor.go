@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+)
+
+var (
+	// ErrCircuitOpen is returned without calling proxy while circuit breaker is open
+	ErrCircuitOpen = status.Error(codes.Unavailable, "proxy circuit breaker is open")
+)
+
+// newUnaryInterceptor returns interceptor which applies method deadline to every attempt of call,
+// retries calls failed with transient error and fails fast while proxy is unhealthy.
+// All proxy calls are queries so all of them are safe to retry.
+func newUnaryInterceptor(o *options, breaker *circuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := o.retryBackoff
+		for attempt := 0; ; attempt++ {
+			err := invokeAttempt(ctx, o.timeoutFor(method), breaker, method, req, reply, cc, invoker, opts...)
+			if err == nil || err == ErrCircuitOpen || attempt >= o.maxRetries || !isTransientCode(err) || ctx.Err() != nil {
+				return err
+			}
+
+			metric.ClientRequestRetries.WithLabelValues(methodKey(method)).Inc()
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return err
+			}
+			backoff *= 2
+		}
+	}
+}
+
+func invokeAttempt(ctx context.Context, timeout time.Duration, breaker *circuitBreaker, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !breaker.allow() {
+		metric.ClientCircuitBreakerRejections.Inc()
+		return ErrCircuitOpen
+	}
+
+	attemptCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := invoker(attemptCtx, method, req, reply, cc, opts...)
+
+	// Call cancelled or timed out by caller says nothing about proxy health
+	if err != nil && ctx.Err() != nil {
+		breaker.release()
+		return err
+	}
+
+	breaker.record(isTransientCode(err))
+	return err
+}
+
+// isTransientCode returns true when error signals unhealthy or overloaded proxy
+func isTransientCode(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitTest()
+	os.Exit(m.Run())
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.record(true)
+	if !b.allow() {
+		t.Fatal("breaker should be closed below threshold")
+	}
+
+	b.record(true)
+	if b.allow() {
+		t.Fatal("breaker should be open after threshold of failures")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker should let probe through after cooldown")
+	}
+	if b.allow() {
+		t.Fatal("breaker should let only single probe through")
+	}
+
+	b.record(true)
+	if b.allow() {
+		t.Fatal("breaker should open again when probe fails")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("breaker should let probe through after cooldown")
+	}
+	b.record(false)
+	if !b.allow() || !b.allow() {
+		t.Fatal("breaker should close when probe succeeds")
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		expectCalls int
+	}{
+		{"retries unavailable proxy", status.Error(codes.Unavailable, "test"), 3},
+		{"retries timed out call", status.Error(codes.DeadlineExceeded, "test"), 3},
+		{"does not retry not found", status.Error(codes.NotFound, "test"), 1},
+		{"does not retry successful call", nil, 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			o := &options{timeout: time.Second, maxRetries: 2, retryBackoff: time.Millisecond}
+			interceptor := newUnaryInterceptor(o, newCircuitBreaker(0, 0))
+
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("call should have deadline")
+				}
+				return tt.err
+			}
+
+			err := interceptor(context.Background(), "/height.HeightService/GetAll", nil, nil, nil, invoker)
+			if status.Code(err) != status.Code(tt.err) {
+				t.Errorf("unexpected error, want: %v; got: %v", tt.err, err)
+			}
+			if calls != tt.expectCalls {
+				t.Errorf("unexpected number of calls, want: %d; got: %d", tt.expectCalls, calls)
+			}
+		})
+	}
+
+	t.Run("fails fast while breaker is open", func(t *testing.T) {
+		o := &options{maxRetries: 5, retryBackoff: time.Millisecond}
+		interceptor := newUnaryInterceptor(o, newCircuitBreaker(2, time.Minute))
+
+		calls := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			return status.Error(codes.Unavailable, "test")
+		}
+
+		if err := interceptor(context.Background(), "/height.HeightService/GetAll", nil, nil, nil, invoker); err != ErrCircuitOpen {
+			t.Errorf("unexpected error, want: %v; got: %v", ErrCircuitOpen, err)
+		}
+		if calls != 2 {
+			t.Errorf("unexpected number of calls, want: 2; got: %d", calls)
+		}
+	})
+
+	t.Run("uses method timeout", func(t *testing.T) {
+		o := &options{timeout: time.Hour, methodTimeouts: map[string]time.Duration{"HeightService/GetAll": time.Second}}
+		interceptor := newUnaryInterceptor(o, newCircuitBreaker(0, 0))
+
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Second {
+				t.Errorf("unexpected deadline: %v", deadline)
+			}
+			return nil
+		}
+
+		if err := interceptor(context.Background(), "/height.HeightService/GetAll", nil, nil, nil, invoker); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
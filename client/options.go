@@ -0,0 +1,101 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+// Option configures client
+type Option func(*options)
+
+type options struct {
+	timeout         time.Duration
+	methodTimeouts  map[string]time.Duration
+	maxRetries      int
+	retryBackoff    time.Duration
+	breakerFailures int
+	breakerCooldown time.Duration
+
+	healthCheckInterval time.Duration
+	tipDepth            int64
+
+	tls       bool
+	tlsCAFile string
+	authToken string
+}
+
+// WithTimeout sets deadline of every call which context has no deadline yet (0 = no deadline)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithMethodTimeouts overrides timeout of given methods, methods are given as "<Service>/<Method>", ie. "HeightService/GetAll"
+func WithMethodTimeouts(timeouts map[string]time.Duration) Option {
+	return func(o *options) {
+		o.methodTimeouts = timeouts
+	}
+}
+
+// WithRetries retries failed calls up to maxRetries times, backoff is doubled after every attempt
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.maxRetries = maxRetries
+		o.retryBackoff = backoff
+	}
+}
+
+// WithCircuitBreaker fails calls fast for cooldown after given number of consecutive failures (0 = disabled)
+func WithCircuitBreaker(failures int, cooldown time.Duration) Option {
+	return func(o *options) {
+		o.breakerFailures = failures
+		o.breakerCooldown = cooldown
+	}
+}
+
+// WithHealthCheck checks health of every endpoint with given interval (0 = disabled)
+func WithHealthCheck(interval time.Duration) Option {
+	return func(o *options) {
+		o.healthCheckInterval = interval
+	}
+}
+
+// WithTipDepth sets how many blocks below chain head tip endpoints can serve, calls for deeper heights go to archive endpoints
+func WithTipDepth(depth int64) Option {
+	return func(o *options) {
+		o.tipDepth = depth
+	}
+}
+
+// WithTLS connects to endpoints over TLS, server certificate is verified with CA from given file or with system roots when file is empty
+func WithTLS(caFile string) Option {
+	return func(o *options) {
+		o.tls = true
+		o.tlsCAFile = caFile
+	}
+}
+
+// WithAuthToken sends bearer token in authorization metadata of every call
+func WithAuthToken(token string) Option {
+	return func(o *options) {
+		o.authToken = token
+	}
+}
+
+// timeoutFor returns timeout of method given by its full name, ie. "/height.HeightService/GetAll"
+func (o *options) timeoutFor(fullMethod string) time.Duration {
+	if timeout, ok := o.methodTimeouts[methodKey(fullMethod)]; ok {
+		return timeout
+	}
+	return o.timeout
+}
+
+// methodKey strips leading slash and package name from full method name
+func methodKey(fullMethod string) string {
+	key := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(key, "."); i >= 0 && i < strings.Index(key, "/") {
+		key = key[i+1:]
+	}
+	return key
+}
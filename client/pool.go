@@ -0,0 +1,206 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+const (
+	getHeadMethod = "/chain.ChainService/GetHead"
+)
+
+var (
+	_ grpc.ClientConnInterface = (*pool)(nil)
+
+	ErrNoEndpoints = errors.New("at least one proxy endpoint is required")
+	ErrInvalidRole = errors.New("proxy endpoint role must be archive or tip")
+)
+
+// heightRequest is request for data at given height
+type heightRequest interface {
+	GetHeight() int64
+}
+
+func newPool(endpoints []Endpoint, o *options) (*pool, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	p := &pool{
+		interceptor: newUnaryInterceptor(o, newCircuitBreaker(o.breakerFailures, o.breakerCooldown)),
+		tipDepth:    o.tipDepth,
+		stop:        make(chan struct{}),
+	}
+
+	for _, endpoint := range endpoints {
+		if !endpoint.Role.Valid() {
+			p.Close()
+			return nil, ErrInvalidRole
+		}
+
+		e, err := dialEndpoint(endpoint, o)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.endpoints = append(p.endpoints, e)
+	}
+
+	if o.healthCheckInterval > 0 {
+		p.wg.Add(1)
+		go p.runHealthChecks(o.healthCheckInterval, o.timeoutFor(getHeadMethod))
+	}
+	return p, nil
+}
+
+// pool routes calls to proxy endpoints. Calls for heights deeper than tipDepth below chain head are historical
+// and they go to archive endpoints, other calls go to tip endpoints. Calls are spread across healthy endpoints in round robin.
+type pool struct {
+	endpoints   []*endpointConn
+	interceptor grpc.UnaryClientInterceptor
+	tipDepth    int64
+	next        uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Invoke performs unary call on one of the endpoints
+func (p *pool) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	return p.interceptor(ctx, method, args, reply, nil, p.invokeEndpoint, opts...)
+}
+
+// NewStream begins streaming call on one of the tip endpoints
+func (p *pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.pick(RoleTip).conn.NewStream(ctx, desc, method, opts...)
+}
+
+// Close stops health checks and closes all connections
+func (p *pool) Close() error {
+	close(p.stop)
+	p.wg.Wait()
+
+	var err error
+	for _, e := range p.endpoints {
+		if closeErr := e.conn.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (p *pool) invokeEndpoint(ctx context.Context, method string, req, reply interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+	e := p.pick(p.route(req))
+
+	err := e.conn.Invoke(ctx, method, req, reply, opts...)
+	if status.Code(err) == codes.Unavailable {
+		// Let retry go to another endpoint, health check marks it healthy again once it recovers
+		e.setHealthy(false)
+	}
+	return err
+}
+
+// route returns role of endpoints which should serve request
+func (p *pool) route(req interface{}) Role {
+	r, ok := req.(heightRequest)
+	if !ok {
+		return RoleTip
+	}
+
+	head := p.head()
+	if head == 0 || head-r.GetHeight() > p.tipDepth {
+		return RoleArchive
+	}
+	return RoleTip
+}
+
+// pick returns next healthy endpoint of given role, endpoints of other role are used when no endpoint of given role is healthy.
+// When no endpoint is healthy at all every endpoint is tried, health may be out of date.
+func (p *pool) pick(role Role) *endpointConn {
+	other := RoleTip
+	if role == RoleTip {
+		other = RoleArchive
+	}
+
+	for _, candidates := range [][]*endpointConn{
+		p.filter(role, true),
+		p.filter(other, true),
+		p.filter(role, false),
+	} {
+		if len(candidates) > 0 {
+			return candidates[atomic.AddUint64(&p.next, 1)%uint64(len(candidates))]
+		}
+	}
+	return p.endpoints[atomic.AddUint64(&p.next, 1)%uint64(len(p.endpoints))]
+}
+
+func (p *pool) filter(role Role, healthyOnly bool) []*endpointConn {
+	var res []*endpointConn
+	for _, e := range p.endpoints {
+		if e.Role == role && (!healthyOnly || e.isHealthy()) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+// head returns most recent chain head seen by health checks
+func (p *pool) head() int64 {
+	var head int64
+	for _, e := range p.endpoints {
+		if h := e.getHead(); h > head {
+			head = h
+		}
+	}
+	return head
+}
+
+func (p *pool) runHealthChecks(interval, timeout time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.checkHealth(timeout)
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHealth asks every endpoint for chain head, endpoint is unhealthy when it's unavailable or doesn't answer in time
+func (p *pool) checkHealth(timeout time.Duration) {
+	for _, e := range p.endpoints {
+		ctx, cancel := context.Background(), func() {}
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+
+		res, err := chainpb.NewChainServiceClient(e.conn).GetHead(ctx, &chainpb.GetHeadRequest{})
+		cancel()
+
+		healthy := err == nil || (status.Code(err) != codes.Unavailable && status.Code(err) != codes.DeadlineExceeded)
+		if healthy != e.isHealthy() {
+			logger.Info("proxy endpoint health changed", logger.Field("endpoint", e.URL), logger.Field("role", string(e.Role)), logger.Field("healthy", healthy))
+		}
+		e.setHealthy(healthy)
+		if err == nil {
+			e.setHead(res.GetHeight())
+		}
+	}
+}
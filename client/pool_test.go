@@ -0,0 +1,107 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/height/heightpb"
+)
+
+func newTestPool(t *testing.T, endpoints ...Endpoint) *pool {
+	p, err := newPool(endpoints, &options{tipDepth: 256})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func TestPool_Route(t *testing.T) {
+	p := newTestPool(t, Endpoint{"127.0.0.1:1", RoleArchive}, Endpoint{"127.0.0.1:2", RoleTip})
+
+	if role := p.route(&heightpb.GetAllRequest{Height: 990}); role != RoleArchive {
+		t.Errorf("calls should go to archive while head is unknown, got: %s", role)
+	}
+
+	p.endpoints[1].setHead(1000)
+
+	tests := []struct {
+		description string
+		req         interface{}
+		expectRole  Role
+	}{
+		{"recent height goes to tip", &heightpb.GetAllRequest{Height: 990}, RoleTip},
+		{"height at tip depth goes to tip", &heightpb.GetAllRequest{Height: 744}, RoleTip},
+		{"historical height goes to archive", &heightpb.GetAllRequest{Height: 743}, RoleArchive},
+		{"request without height goes to tip", &chainpb.GetHeadRequest{}, RoleTip},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			if role := p.route(tt.req); role != tt.expectRole {
+				t.Errorf("unexpected role, want: %s; got: %s", tt.expectRole, role)
+			}
+		})
+	}
+}
+
+func TestPool_Pick(t *testing.T) {
+	p := newTestPool(t, Endpoint{"127.0.0.1:1", RoleArchive}, Endpoint{"127.0.0.1:2", RoleTip}, Endpoint{"127.0.0.1:3", RoleTip})
+	archive, tip1, tip2 := p.endpoints[0], p.endpoints[1], p.endpoints[2]
+
+	t.Run("round robin across healthy endpoints of role", func(t *testing.T) {
+		picked := map[*endpointConn]int{}
+		for i := 0; i < 4; i++ {
+			picked[p.pick(RoleTip)]++
+		}
+		if picked[tip1] != 2 || picked[tip2] != 2 {
+			t.Errorf("calls should be spread across tips, got: tip1=%d tip2=%d archive=%d", picked[tip1], picked[tip2], picked[archive])
+		}
+	})
+
+	t.Run("skips unhealthy endpoint", func(t *testing.T) {
+		tip1.setHealthy(false)
+		defer tip1.setHealthy(true)
+
+		for i := 0; i < 3; i++ {
+			if e := p.pick(RoleTip); e != tip2 {
+				t.Errorf("unexpected endpoint, want: %s; got: %s", tip2.URL, e.URL)
+			}
+		}
+	})
+
+	t.Run("fails over to other role", func(t *testing.T) {
+		archive.setHealthy(false)
+		defer archive.setHealthy(true)
+
+		if e := p.pick(RoleArchive); e.Role != RoleTip {
+			t.Errorf("unexpected endpoint role, want: %s; got: %s", RoleTip, e.Role)
+		}
+	})
+
+	t.Run("tries endpoints of role when none is healthy", func(t *testing.T) {
+		for _, e := range p.endpoints {
+			e.setHealthy(false)
+		}
+		defer func() {
+			for _, e := range p.endpoints {
+				e.setHealthy(true)
+			}
+		}()
+
+		if e := p.pick(RoleArchive); e != archive {
+			t.Errorf("unexpected endpoint, want: %s; got: %s", archive.URL, e.URL)
+		}
+	})
+}
+
+func TestNewPool(t *testing.T) {
+	if _, err := newPool(nil, &options{}); err != ErrNoEndpoints {
+		t.Errorf("unexpected error, want: %v; got: %v", ErrNoEndpoints, err)
+	}
+
+	if _, err := newPool([]Endpoint{{"127.0.0.1:1", "full"}}, &options{}); err != ErrInvalidRole {
+		t.Errorf("unexpected error, want: %v; got: %v", ErrInvalidRole, err)
+	}
+}
@@ -0,0 +1,120 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// api performs requests to sidecar REST API. Failed requests return gRPC status errors with code matching
+// HTTP status, so callers handle them the same way as proxy errors
+type api struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// errorResponse is body of failed sidecar request
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// storageResponse is response of pallet storage item request
+type storageResponse struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// get decodes JSON response of given path into dst
+func (a *api) get(ctx context.Context, path string, query url.Values, dst interface{}) error {
+	reqURL := a.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return status.Errorf(codes.DeadlineExceeded, "sidecar %s: %v", path, err)
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return status.Errorf(codes.Canceled, "sidecar %s: %v", path, err)
+		}
+		return status.Errorf(codes.Unavailable, "sidecar %s: %v", path, err)
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "sidecar %s: %v", path, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var errRes errorResponse
+		if json.Unmarshal(body, &errRes) != nil || errRes.Message == "" {
+			errRes.Message = http.StatusText(res.StatusCode)
+		}
+		return status.Errorf(codeFromHTTPStatus(res.StatusCode), "sidecar %s: %s", path, errRes.Message)
+	}
+
+	if err = json.Unmarshal(body, dst); err != nil {
+		return status.Errorf(codes.Internal, "sidecar %s: invalid response: %v", path, err)
+	}
+	return nil
+}
+
+// storage decodes value of pallet storage item at given height into dst, dst is left untouched when item has no value
+func (a *api) storage(ctx context.Context, height int64, pallet, item string, dst interface{}, keys ...string) error {
+	query := url.Values{"at": {strconv.FormatInt(height, 10)}}
+	for _, key := range keys {
+		query.Add("keys[]", key)
+	}
+
+	path := fmt.Sprintf("/pallets/%s/storage/%s", pallet, item)
+
+	var res storageResponse
+	if err := a.get(ctx, path, query, &res); err != nil {
+		return err
+	}
+
+	if len(res.Value) == 0 || string(res.Value) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(res.Value, dst); err != nil {
+		return status.Errorf(codes.Internal, "sidecar %s: invalid value: %v", path, err)
+	}
+	return nil
+}
+
+func codeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,193 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/block/blockpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/event/eventpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/transaction/transactionpb"
+)
+
+const (
+	phaseInitialization = "Initialization"
+	phaseApplyExtrinsic = "ApplyExtrinsic"
+	phaseFinalization   = "Finalization"
+
+	// noExtrinsicIndex is extrinsic index of events emitted outside of extrinsics
+	noExtrinsicIndex = -1
+)
+
+// callKeys are args of calls which dispatch other calls, ie. utility.batch and proxy.proxy
+var callKeys = []string{"calls", "call"}
+
+// block is block at height with events of all its phases
+type block struct {
+	*blockpb.Block
+
+	Events []*eventpb.Event
+}
+
+type callResponse struct {
+	Method palletMethod    `json:"method"`
+	Args   json.RawMessage `json:"args"`
+}
+
+func (s *source) block(ctx context.Context, height int64) (*block, error) {
+	var res blockResponse
+	if err := s.api.get(ctx, "/blocks/"+strconv.FormatInt(height, 10), nil, &res); err != nil {
+		return nil, err
+	}
+
+	t, err := s.time(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := s.spec(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	argTypes, err := s.eventArgTypes(ctx, height, spec.SpecVersion.String())
+	if err != nil {
+		return nil, err
+	}
+
+	b := &block{
+		Block: &blockpb.Block{
+			BlockHash: res.Hash,
+			Header: &blockpb.Header{
+				Time:           t,
+				ParentHash:     res.ParentHash,
+				Height:         res.Number.Int64(),
+				StateRoot:      res.StateRoot,
+				ExtrinsicsRoot: res.ExtrinsicsRoot,
+			},
+		},
+	}
+
+	for _, e := range res.OnInitialize.Events {
+		b.Events = append(b.Events, toEvent(e, int64(len(b.Events)), phaseInitialization, noExtrinsicIndex, argTypes))
+	}
+
+	for i, rawTx := range res.Extrinsics {
+		tx, err := toTransaction(rawTx, int64(i), t)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range rawTx.Events {
+			event := toEvent(e, int64(len(b.Events)), phaseApplyExtrinsic, int64(i), argTypes)
+			tx.Events = append(tx.Events, event)
+			b.Events = append(b.Events, event)
+		}
+		b.Extrinsics = append(b.Extrinsics, tx)
+	}
+
+	for _, e := range res.OnFinalize.Events {
+		b.Events = append(b.Events, toEvent(e, int64(len(b.Events)), phaseFinalization, noExtrinsicIndex, argTypes))
+	}
+
+	return b, nil
+}
+
+func toEvent(e eventResponse, index int64, phase string, extrinsicIndex int64, argTypes map[string][]string) *eventpb.Event {
+	types := argTypes[e.Method.Pallet+"."+e.Method.Method]
+
+	data := make([]*eventpb.EventData, len(e.Data))
+	for i, value := range e.Data {
+		data[i] = &eventpb.EventData{Value: stringValue(value)}
+		if i < len(types) {
+			data[i].Name = types[i]
+		}
+	}
+
+	return &eventpb.Event{
+		Index:          index,
+		Data:           data,
+		Phase:          phase,
+		Method:         e.Method.Method,
+		Section:        e.Method.Pallet,
+		ExtrinsicIndex: extrinsicIndex,
+	}
+}
+
+func toTransaction(rawTx extrinsicResponse, index int64, t *timestamp.Timestamp) (*transactionpb.Transaction, error) {
+	args, err := argsJSON(rawTx.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	callArgs, err := toCallArgs(rawTx.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &transactionpb.Transaction{
+		ExtrinsicIndex:      index,
+		Hash:                rawTx.Hash,
+		Time:                strconv.FormatInt(t.GetSeconds()*1000+int64(t.GetNanos())/int64(time.Millisecond), 10),
+		Nonce:               rawTx.Nonce.Int64(),
+		Method:              rawTx.Method.Method,
+		Section:             rawTx.Method.Pallet,
+		Args:                args,
+		IsSuccess:           rawTx.Success,
+		PartialFee:          rawTx.Info.PartialFee.String(),
+		Tip:                 rawTx.Tip.String(),
+		IsSignedTransaction: rawTx.Signature != nil,
+		CallArgs:            callArgs,
+	}
+
+	if rawTx.Signature != nil {
+		tx.Signature = rawTx.Signature.Signature
+		tx.Signer = string(rawTx.Signature.Signer)
+	}
+	return tx, nil
+}
+
+// toCallArgs returns calls dispatched by batch or proxy call, their args are encoded the same way as transaction args
+func toCallArgs(rawArgs json.RawMessage) ([]*transactionpb.CallArg, error) {
+	var args map[string]json.RawMessage
+	if json.Unmarshal(rawArgs, &args) != nil {
+		return nil, nil
+	}
+
+	var calls []callResponse
+	for _, key := range callKeys {
+		raw, ok := args[key]
+		if !ok {
+			continue
+		}
+
+		var batch []callResponse
+		if err := json.Unmarshal(raw, &batch); err == nil {
+			calls = append(calls, batch...)
+			continue
+		}
+
+		var call callResponse
+		if err := json.Unmarshal(raw, &call); err == nil {
+			calls = append(calls, call)
+		}
+	}
+
+	var callArgs []*transactionpb.CallArg
+	for _, call := range calls {
+		value, err := argsJSON(call.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		callArgs = append(callArgs, &transactionpb.CallArg{
+			Method:  call.Method.Method,
+			Section: call.Method.Pallet,
+			Value:   value,
+		})
+	}
+	return callArgs, nil
+}
@@ -0,0 +1,297 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/account/accountpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/block/blockpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/event/eventpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/height/heightpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/transaction/transactionpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validator/validatorpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+)
+
+var (
+	_ client.AccountClient              = (*accountClient)(nil)
+	_ client.BlockClient                = (*blockClient)(nil)
+	_ client.ChainClient                = (*chainClient)(nil)
+	_ client.EventClient                = (*eventClient)(nil)
+	_ client.HeightClient               = (*heightClient)(nil)
+	_ client.StakingClient              = (*stakingClient)(nil)
+	_ client.TransactionClient          = (*transactionClient)(nil)
+	_ client.ValidatorClient            = (*validatorClient)(nil)
+	_ client.ValidatorPerformanceClient = (*validatorPerformanceClient)(nil)
+)
+
+type heightClient struct {
+	source *source
+}
+
+// GetAll returns all data of height in one response like proxy HeightService.GetAll
+func (c *heightClient) GetAll(ctx context.Context, h int64) (*heightpb.GetAllResponse, error) {
+	meta, err := c.source.meta(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.source.block(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	staking, err := c.source.staking(ctx, h, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	performance, err := c.source.performance(ctx, h, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	annotated := make([]*transactionpb.Annotated, len(b.Extrinsics))
+	for i, tx := range b.Extrinsics {
+		annotated[i] = &transactionpb.Annotated{
+			ExtrinsicIndex: tx.GetExtrinsicIndex(),
+			Hash:           tx.GetHash(),
+			Method:         tx.GetMethod(),
+			Section:        tx.GetSection(),
+			IsSigned:       tx.GetIsSignedTransaction(),
+			Args:           tx.GetArgs(),
+		}
+	}
+
+	return &heightpb.GetAllResponse{
+		Chain:                meta,
+		Block:                &blockpb.GetByHeightResponse{Block: b.Block},
+		Event:                &eventpb.GetByHeightResponse{Events: b.Events},
+		Staking:              &stakingpb.GetByHeightResponse{Staking: staking},
+		Transaction:          &transactionpb.GetAnnotatedByHeightResponse{Transactions: annotated},
+		ValidatorPerformance: &validatorperformancepb.GetByHeightResponse{Validators: performance},
+	}, nil
+}
+
+type chainClient struct {
+	source *source
+}
+
+// GetHead returns height preceding chain head, because meta of height can be built only once next height exists
+func (c *chainClient) GetHead(ctx context.Context) (*chainpb.GetHeadResponse, error) {
+	head, err := c.source.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := c.source.meta(ctx, head-1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chainpb.GetHeadResponse{
+		Height:  head - 1,
+		Time:    meta.GetTime(),
+		Session: meta.GetSession(),
+		Era:     meta.GetEra(),
+	}, nil
+}
+
+func (c *chainClient) GeStatus(ctx context.Context) (*chainpb.GetStatusResponse, error) {
+	var version nodeVersionResponse
+	if err := c.source.api.get(ctx, "/node/version", nil, &version); err != nil {
+		return nil, err
+	}
+
+	var network nodeNetworkResponse
+	if err := c.source.api.get(ctx, "/node/network", nil, &network); err != nil {
+		return nil, err
+	}
+
+	head, err := c.source.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := c.source.spec(ctx, head)
+	if err != nil {
+		return nil, err
+	}
+
+	var genesis blockResponse
+	if err := c.source.api.get(ctx, "/blocks/0", nil, &genesis); err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, len(network.NodeRoles))
+	for i, role := range network.NodeRoles {
+		roles[i] = roleName(role)
+	}
+
+	return &chainpb.GetStatusResponse{
+		ClientInfo:       fmt.Sprintf("%s %s", version.ClientImplName, version.ClientVersion),
+		ChainName:        version.Chain,
+		ChainType:        spec.SpecName,
+		NodeName:         version.ClientImplName,
+		NodeHealth:       fmt.Sprintf("peers: %s, isSyncing: %t, shouldHavePeers: %t", network.NumPeers, network.IsSyncing, network.ShouldHavePeers),
+		NodeRoles:        roles,
+		NodeVersion:      version.ClientVersion,
+		NodeLocalPeerUid: network.LocalPeerID,
+		NodeProperties:   spec.Properties,
+		GenesisHash:      genesis.Hash,
+	}, nil
+}
+
+func (c *chainClient) GeMetaByHeight(ctx context.Context, h int64) (*chainpb.GetMetaByHeightResponse, error) {
+	return c.source.meta(ctx, h)
+}
+
+type accountClient struct {
+	source *source
+}
+
+func (c *accountClient) GetIdentity(ctx context.Context, address string) (*accountpb.GetIdentityResponse, error) {
+	head, err := c.source.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var identity identityResponse
+	if err := c.source.api.storage(ctx, head, "identity", "identityOf", &identity, address); err != nil {
+		return nil, err
+	}
+
+	return &accountpb.GetIdentityResponse{
+		Identity: &accountpb.AccountIdentity{
+			Deposit:     identity.Deposit.String(),
+			DisplayName: identity.Info.Display.String(),
+			LegalName:   identity.Info.Legal.String(),
+			WebName:     identity.Info.Web.String(),
+			RiotName:    identity.Info.Riot.String(),
+			EmailName:   identity.Info.Email.String(),
+			TwitterName: identity.Info.Twitter.String(),
+			Image:       identity.Info.Image.String(),
+		},
+	}, nil
+}
+
+func (c *accountClient) GetByHeight(ctx context.Context, address string, h int64) (*accountpb.GetByHeightResponse, error) {
+	var balance balanceInfoResponse
+	if err := c.source.api.get(ctx, "/accounts/"+address+"/balance-info", atQuery(h), &balance); err != nil {
+		return nil, err
+	}
+
+	return &accountpb.GetByHeightResponse{
+		Account: &accountpb.Account{
+			Nonce:      balance.Nonce.Int64(),
+			Free:       balance.Free.String(),
+			Reserved:   balance.Reserved.String(),
+			MiscFrozen: balance.MiscFrozen.String(),
+			FeeFrozen:  balance.FeeFrozen.String(),
+		},
+	}, nil
+}
+
+type blockClient struct {
+	source *source
+}
+
+func (c *blockClient) GetByHeight(ctx context.Context, h int64) (*blockpb.GetByHeightResponse, error) {
+	b, err := c.source.block(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	return &blockpb.GetByHeightResponse{Block: b.Block}, nil
+}
+
+type eventClient struct {
+	source *source
+}
+
+func (c *eventClient) GetByHeight(ctx context.Context, h int64) (*eventpb.GetByHeightResponse, error) {
+	b, err := c.source.block(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	return &eventpb.GetByHeightResponse{Events: b.Events}, nil
+}
+
+type transactionClient struct {
+	source *source
+}
+
+func (c *transactionClient) GetByHeight(ctx context.Context, h int64) (*transactionpb.GetByHeightResponse, error) {
+	b, err := c.source.block(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionpb.GetByHeightResponse{Transactions: b.Extrinsics}, nil
+}
+
+type stakingClient struct {
+	source *source
+}
+
+func (c *stakingClient) GetByHeight(ctx context.Context, h int64) (*stakingpb.GetByHeightResponse, error) {
+	meta, err := c.source.meta(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	staking, err := c.source.staking(ctx, h, meta)
+	if err != nil {
+		return nil, err
+	}
+	return &stakingpb.GetByHeightResponse{Staking: staking}, nil
+}
+
+type validatorClient struct {
+	source *source
+}
+
+func (c *validatorClient) GetByHeight(ctx context.Context, h int64) (*validatorpb.GetAllByHeightResponse, error) {
+	meta, err := c.source.meta(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := c.source.validatorBalances(ctx, h, meta)
+	if err != nil {
+		return nil, err
+	}
+	return &validatorpb.GetAllByHeightResponse{Validators: validators}, nil
+}
+
+type validatorPerformanceClient struct {
+	source *source
+}
+
+func (c *validatorPerformanceClient) GetByHeight(ctx context.Context, h int64) (*validatorperformancepb.GetByHeightResponse, error) {
+	meta, err := c.source.meta(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := c.source.performance(ctx, h, meta)
+	if err != nil {
+		return nil, err
+	}
+	return &validatorperformancepb.GetByHeightResponse{Validators: validators}, nil
+}
+
+// roleName returns name of node role, sidecar encodes roles as strings or as objects keyed by role name
+func roleName(raw json.RawMessage) string {
+	var role map[string]json.RawMessage
+	if json.Unmarshal(raw, &role) != nil {
+		return stringValue(raw)
+	}
+	for name := range role {
+		return name
+	}
+	return ""
+}
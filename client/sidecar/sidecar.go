@@ -0,0 +1,55 @@
+// Package sidecar implements client interfaces on top of substrate-api-sidecar REST API, so indexer can run
+// without polkadothub-proxy. Responses are built from sidecar blocks, runtime and pallet storage endpoints and have
+// the same shape as proxy responses.
+package sidecar
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+)
+
+// Option configures sidecar client
+type Option func(*options)
+
+type options struct {
+	timeout time.Duration
+}
+
+// WithTimeout sets timeout of every HTTP request to sidecar (0 = no timeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// New returns client set reading data from sidecar at given base url, ie. http://localhost:8080
+func New(baseURL string, opts ...Option) *client.Client {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	s := &source{
+		api: &api{
+			baseURL:    strings.TrimSuffix(baseURL, "/"),
+			httpClient: &http.Client{Timeout: o.timeout},
+		},
+		eras:      newEraCache(),
+		eventArgs: map[string]map[string][]string{},
+	}
+
+	return &client.Client{
+		Chain:                &chainClient{s},
+		Account:              &accountClient{s},
+		Block:                &blockClient{s},
+		Height:               &heightClient{s},
+		Transaction:          &transactionClient{s},
+		ValidatorPerformance: &validatorPerformanceClient{s},
+		Staking:              &stakingClient{s},
+		Event:                &eventClient{s},
+		Validator:            &validatorClient{s},
+	}
+}
@@ -0,0 +1,233 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+)
+
+const routesFile = "testdata/routes.json"
+
+// newTestClient returns client of stub sidecar serving responses from routes file by path and query
+func newTestClient(t *testing.T) *client.Client {
+	data, err := ioutil.ReadFile(routesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var routes map[string]json.RawMessage
+	if err = json.Unmarshal(data, &routes); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if query, _ := url.QueryUnescape(r.URL.RawQuery); query != "" {
+			route += "?" + query
+		}
+
+		if route == "/blocks/503" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		res, ok := routes[route]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code": 404, "message": "no route ` + route + `"}`))
+			return
+		}
+		w.Write(res)
+	}))
+	t.Cleanup(server.Close)
+
+	return New(server.URL)
+}
+
+func TestHeightClient_GetAll(t *testing.T) {
+	res, err := newTestClient(t).Height.GetAll(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("chain meta", func(t *testing.T) {
+		meta := res.GetChain()
+		if meta.GetSession() != 10 || meta.GetEra() != 5 || meta.GetActiveEra() != 5 {
+			t.Errorf("unexpected session and eras: %v", meta)
+		}
+		if !meta.GetLastInSession() || !meta.GetLastInEra() || !meta.GetLastInActiveEra() {
+			t.Errorf("height should be last in session and eras: %v", meta)
+		}
+		if meta.GetChain() != "Polkadot" || meta.GetSpecVersion() != "26" {
+			t.Errorf("unexpected chain: %v", meta)
+		}
+		if meta.GetTime().GetSeconds() != 1600000000 || meta.GetTime().GetNanos() != 123000000 {
+			t.Errorf("unexpected time: %v", meta.GetTime())
+		}
+	})
+
+	t.Run("block and transactions", func(t *testing.T) {
+		block := res.GetBlock().GetBlock()
+		if block.GetBlockHash() != "0xabc" || block.GetHeader().GetHeight() != 100 || block.GetHeader().GetParentHash() != "0x99" {
+			t.Errorf("unexpected block: %v", block)
+		}
+
+		txs := block.GetExtrinsics()
+		if len(txs) != 3 {
+			t.Fatalf("want 3 extrinsics; got %d", len(txs))
+		}
+
+		if txs[0].GetIsSignedTransaction() || txs[0].GetArgs() != `["1600000000123"]` {
+			t.Errorf("unexpected inherent: %v", txs[0])
+		}
+
+		payout := txs[1]
+		if !payout.GetIsSignedTransaction() || payout.GetSigner() != "nom1" || payout.GetNonce() != 7 || payout.GetPartialFee() != "150" {
+			t.Errorf("unexpected signed transaction: %v", payout)
+		}
+		if payout.GetSection() != "staking" || payout.GetMethod() != "payoutStakers" || payout.GetArgs() != `["val1","4"]` {
+			t.Errorf("unexpected payout args: %v", payout.GetArgs())
+		}
+
+		batch := txs[2]
+		if batch.GetSigner() != "nom2" || batch.GetIsSuccess() {
+			t.Errorf("unexpected batch: %v", batch)
+		}
+		var callValues []string
+		for _, call := range batch.GetCallArgs() {
+			if call.GetSection() != "staking" || call.GetMethod() != "payoutStakers" {
+				t.Errorf("unexpected call: %v", call)
+			}
+			callValues = append(callValues, call.GetValue())
+		}
+		if want := []string{`["val1","3"]`, `["val2","3"]`}; !reflect.DeepEqual(callValues, want) {
+			t.Errorf("want call values %v; got %v", want, callValues)
+		}
+
+		if len(res.GetTransaction().GetTransactions()) != 3 {
+			t.Errorf("want 3 annotated transactions; got %v", res.GetTransaction().GetTransactions())
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		events := res.GetEvent().GetEvents()
+		if len(events) != 4 {
+			t.Fatalf("want 4 events; got %d", len(events))
+		}
+
+		if events[0].GetPhase() != phaseInitialization || events[0].GetExtrinsicIndex() != noExtrinsicIndex {
+			t.Errorf("unexpected initialization event: %v", events[0])
+		}
+
+		reward := events[3]
+		if reward.GetIndex() != 3 || reward.GetExtrinsicIndex() != 1 || reward.GetSection() != "staking" || reward.GetMethod() != "Reward" {
+			t.Errorf("unexpected reward event: %v", reward)
+		}
+		data := reward.GetData()
+		if len(data) != 2 || data[0].GetName() != "AccountId" || data[0].GetValue() != "nom1" || data[1].GetName() != "Balance" || data[1].GetValue() != "2000" {
+			t.Errorf("unexpected reward event data: %v", data)
+		}
+
+		if data := events[1].GetData(); len(data) != 1 || data[0].GetValue() != `{"weight":"1","class":"Mandatory"}` {
+			t.Errorf("unexpected object event data: %v", data)
+		}
+	})
+
+	t.Run("staking", func(t *testing.T) {
+		staking := res.GetStaking().GetStaking()
+		if staking.GetEra() != 5 || staking.GetTotalStake() != 3000 || staking.GetTotalRewardPoints() != 100 || staking.GetTotalRewardPayout() != "1000000000" {
+			t.Errorf("unexpected staking: %v", staking)
+		}
+
+		validators := staking.GetValidators()
+		if len(validators) != 2 {
+			t.Fatalf("want 2 validators; got %d", len(validators))
+		}
+
+		val1 := validators[0]
+		if val1.GetStashAccount() != "val1" || val1.GetControllerAccount() != "ctrl1" || val1.GetCommission() != 100000000 || val1.GetRewardPoints() != 60 {
+			t.Errorf("unexpected validator: %v", val1)
+		}
+		if val1.GetTotalStake() != 2000 || val1.GetOwnStake() != 500 || val1.GetStakersStake() != 1500 {
+			t.Errorf("unexpected validator stake: %v", val1)
+		}
+		if !reflect.DeepEqual(val1.GetSessionKeys(), []string{"key1", "key2"}) {
+			t.Errorf("unexpected session keys: %v", val1.GetSessionKeys())
+		}
+
+		stakers := val1.GetStakers()
+		if len(stakers) != 2 || !stakers[0].GetIsRewardEligible() || stakers[1].GetIsRewardEligible() {
+			t.Errorf("only nominators in clipped exposure should be reward eligible: %v", stakers)
+		}
+	})
+
+	t.Run("validator performance", func(t *testing.T) {
+		validators := res.GetValidatorPerformance().GetValidators()
+		if len(validators) != 2 || !validators[0].GetOnline() || validators[1].GetOnline() {
+			t.Errorf("unexpected validator performance: %v", validators)
+		}
+	})
+}
+
+func TestChainClient_GetHead(t *testing.T) {
+	res, err := newTestClient(t).Chain.GetHead(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.GetHeight() != 100 || res.GetSession() != 10 || res.GetEra() != 5 {
+		t.Errorf("head should be height preceding chain head, got: %v", res)
+	}
+}
+
+func TestAccountClient(t *testing.T) {
+	cli := newTestClient(t)
+
+	identity, err := cli.Account.GetIdentity(context.Background(), "val1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.GetIdentity().GetDisplayName() != "Validator 1" || identity.GetIdentity().GetTwitterName() != "" || identity.GetIdentity().GetDeposit() != "200" {
+		t.Errorf("unexpected identity: %v", identity.GetIdentity())
+	}
+
+	account, err := cli.Account.GetByHeight(context.Background(), "nom1", 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.GetAccount().GetNonce() != 7 || account.GetAccount().GetFree() != "5000" || account.GetAccount().GetFeeFrozen() != "1500" {
+		t.Errorf("unexpected account: %v", account.GetAccount())
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cli := newTestClient(t)
+
+	tests := []struct {
+		description string
+		height      int64
+		expectCode  codes.Code
+	}{
+		{"missing height is NotFound", 200, codes.NotFound},
+		{"unavailable sidecar is Unavailable", 503, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			_, err := cli.Block.GetByHeight(context.Background(), tt.height)
+			if status.Code(err) != tt.expectCode {
+				t.Errorf("want %v; got %v", tt.expectCode, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package sidecar
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+)
+
+// source builds proxy responses from sidecar data
+type source struct {
+	api *api
+
+	eras *eraCache
+
+	mu        sync.Mutex
+	chain     string
+	eventArgs map[string]map[string][]string
+	lastMeta  *chainpb.GetMetaByHeightResponse
+	metaAt    int64
+}
+
+// heightState is state of session and eras at height
+type heightState struct {
+	session   int64
+	era       int64
+	activeEra int64
+}
+
+// meta returns chain meta at given height. Whether height is last in session or era is found by comparing its state
+// with state of next height, so next height must be already produced
+func (s *source) meta(ctx context.Context, height int64) (*chainpb.GetMetaByHeightResponse, error) {
+	// Fetcher tasks ask for meta of the same height one after another
+	s.mu.Lock()
+	if s.lastMeta != nil && s.metaAt == height {
+		defer s.mu.Unlock()
+		return s.lastMeta, nil
+	}
+	s.mu.Unlock()
+
+	state, err := s.state(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := s.state(ctx, height+1)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.time(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := s.spec(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	chain, err := s.chainName(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &chainpb.GetMetaByHeightResponse{
+		Time:            t,
+		Session:         state.session,
+		Era:             state.era,
+		ActiveEra:       state.activeEra,
+		LastInSession:   state.session != next.session,
+		LastInEra:       state.era != next.era,
+		LastInActiveEra: state.activeEra != next.activeEra,
+		Chain:           chain,
+		SpecVersion:     spec.SpecVersion.String(),
+	}
+
+	s.mu.Lock()
+	s.lastMeta, s.metaAt = meta, height
+	s.mu.Unlock()
+	return meta, nil
+}
+
+func (s *source) state(ctx context.Context, height int64) (heightState, error) {
+	var session, era number
+	var activeEra activeEraResponse
+
+	if err := s.api.storage(ctx, height, "session", "currentIndex", &session); err != nil {
+		return heightState{}, err
+	}
+	if err := s.api.storage(ctx, height, "staking", "currentEra", &era); err != nil {
+		return heightState{}, err
+	}
+	if err := s.api.storage(ctx, height, "staking", "activeEra", &activeEra); err != nil {
+		return heightState{}, err
+	}
+
+	return heightState{
+		session:   session.Int64(),
+		era:       era.Int64(),
+		activeEra: activeEra.Index.Int64(),
+	}, nil
+}
+
+// time returns time of block set by timestamp inherent
+func (s *source) time(ctx context.Context, height int64) (*timestamp.Timestamp, error) {
+	var now number
+	if err := s.api.storage(ctx, height, "timestamp", "now", &now); err != nil {
+		return nil, err
+	}
+
+	ms := now.Int64()
+	return &timestamp.Timestamp{Seconds: ms / 1000, Nanos: int32(ms%1000) * int32(time.Millisecond)}, nil
+}
+
+func (s *source) spec(ctx context.Context, height int64) (*specResponse, error) {
+	var spec specResponse
+	if err := s.api.get(ctx, "/runtime/spec", atQuery(height), &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (s *source) head(ctx context.Context) (int64, error) {
+	var header headerResponse
+	if err := s.api.get(ctx, "/blocks/head/header", nil, &header); err != nil {
+		return 0, err
+	}
+	return header.Number.Int64(), nil
+}
+
+// chainName returns name of chain, it never changes so it's requested only once
+func (s *source) chainName(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.chain != "" {
+		return s.chain, nil
+	}
+
+	var version nodeVersionResponse
+	if err := s.api.get(ctx, "/node/version", nil, &version); err != nil {
+		return "", err
+	}
+	s.chain = version.Chain
+	return s.chain, nil
+}
+
+// eventArgTypes returns types of event args by "<pallet>.<event>" for given runtime version. Sidecar events don't
+// include types of their data, they are read from runtime metadata once per runtime version. Metadata from V14 on
+// describes types by registry ids, so events of such runtimes have no arg types
+func (s *source) eventArgTypes(ctx context.Context, height int64, specVersion string) (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if args, ok := s.eventArgs[specVersion]; ok {
+		return args, nil
+	}
+
+	var metadata metadataResponse
+	if err := s.api.get(ctx, "/runtime/metadata", atQuery(height), &metadata); err != nil {
+		return nil, err
+	}
+
+	args := map[string][]string{}
+	for _, version := range metadata.Metadata {
+		for _, module := range version.Modules {
+			for _, event := range module.Events {
+				args[palletID(module.Name)+"."+event.Name] = event.Args
+			}
+		}
+	}
+
+	s.eventArgs[specVersion] = args
+	return args, nil
+}
+
+// palletID returns pallet name as used in sidecar urls and responses, ie. ImOnline -> imOnline
+func palletID(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func atQuery(height int64) url.Values {
+	return url.Values{"at": {strconv.FormatInt(height, 10)}}
+}
@@ -0,0 +1,237 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validator/validatorpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
+)
+
+// cachedEras is number of most recent eras kept in era cache
+const cachedEras = 2
+
+// eraValidator is staking data of validator which doesn't change during era
+type eraValidator struct {
+	controller  string
+	commission  int64
+	exposure    exposureResponse
+	eligible    map[string]bool
+	sessionKeys []string
+}
+
+// eraCache keeps staking data of validators by era. Exposures and preferences of validators are fixed for era,
+// so they are requested once per era and validator instead of every height
+type eraCache struct {
+	mu   sync.Mutex
+	eras map[int64]map[string]*eraValidator
+}
+
+func newEraCache() *eraCache {
+	return &eraCache{eras: map[int64]map[string]*eraValidator{}}
+}
+
+func (c *eraCache) get(era int64, stash string) (*eraValidator, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.eras[era][stash]
+	return v, ok
+}
+
+func (c *eraCache) set(era int64, stash string, v *eraValidator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.eras[era]; !ok {
+		c.eras[era] = map[string]*eraValidator{}
+		for e := range c.eras {
+			if e <= era-cachedEras {
+				delete(c.eras, e)
+			}
+		}
+	}
+	c.eras[era][stash] = v
+}
+
+// validators returns stash accounts of validators active at height
+func (s *source) validators(ctx context.Context, height int64) ([]string, error) {
+	var validators []string
+	if err := s.api.storage(ctx, height, "session", "validators", &validators); err != nil {
+		return nil, err
+	}
+	return validators, nil
+}
+
+func (s *source) eraValidator(ctx context.Context, height, era int64, stash string) (*eraValidator, error) {
+	if v, ok := s.eras.get(era, stash); ok {
+		return v, nil
+	}
+
+	eraKey := strconv.FormatInt(era, 10)
+
+	v := &eraValidator{eligible: map[string]bool{}}
+	if err := s.api.storage(ctx, height, "staking", "erasStakers", &v.exposure, eraKey, stash); err != nil {
+		return nil, err
+	}
+
+	// Only nominators in clipped exposure are paid out
+	var clipped exposureResponse
+	if err := s.api.storage(ctx, height, "staking", "erasStakersClipped", &clipped, eraKey, stash); err != nil {
+		return nil, err
+	}
+	for _, nominator := range clipped.Others {
+		v.eligible[nominator.Who] = true
+	}
+
+	var prefs validatorPrefsResponse
+	if err := s.api.storage(ctx, height, "staking", "erasValidatorPrefs", &prefs, eraKey, stash); err != nil {
+		return nil, err
+	}
+	v.commission = prefs.Commission.Int64()
+
+	if err := s.api.storage(ctx, height, "staking", "bonded", &v.controller, stash); err != nil {
+		return nil, err
+	}
+
+	var sessionKeys json.RawMessage
+	if err := s.api.storage(ctx, height, "session", "nextKeys", &sessionKeys, stash); err != nil {
+		return nil, err
+	}
+	keys, err := orderedValues(sessionKeys)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		v.sessionKeys = append(v.sessionKeys, stringValue(key))
+	}
+
+	s.eras.set(era, stash, v)
+	return v, nil
+}
+
+// staking returns staking state of active era at height. Reward payout of era is known only after era ends,
+// so it's set only at last height of active era
+func (s *source) staking(ctx context.Context, height int64, meta *chainpb.GetMetaByHeightResponse) (*stakingpb.Staking, error) {
+	eraKey := strconv.FormatInt(meta.GetActiveEra(), 10)
+
+	var points rewardPointsResponse
+	if err := s.api.storage(ctx, height, "staking", "erasRewardPoints", &points, eraKey); err != nil {
+		return nil, err
+	}
+
+	var totalStake number
+	if err := s.api.storage(ctx, height, "staking", "erasTotalStake", &totalStake, eraKey); err != nil {
+		return nil, err
+	}
+
+	var payout number
+	if meta.GetLastInActiveEra() {
+		if err := s.api.storage(ctx, height+1, "staking", "erasValidatorReward", &payout, eraKey); err != nil {
+			return nil, err
+		}
+	}
+
+	stashes, err := s.validators(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*stakingpb.Validator, len(stashes))
+	for i, stash := range stashes {
+		v, err := s.eraValidator(ctx, height, meta.GetActiveEra(), stash)
+		if err != nil {
+			return nil, err
+		}
+
+		validator := &stakingpb.Validator{
+			StashAccount:      stash,
+			ControllerAccount: v.controller,
+			Commission:        v.commission,
+			RewardPoints:      points.Individual[stash].Int64(),
+			TotalStake:        v.exposure.Total.Int64(),
+			OwnStake:          v.exposure.Own.Int64(),
+			StakersStake:      v.exposure.Total.Int64() - v.exposure.Own.Int64(),
+			SessionKeys:       v.sessionKeys,
+		}
+
+		for _, nominator := range v.exposure.Others {
+			validator.Stakers = append(validator.Stakers, &stakingpb.Stake{
+				StashAccount:     nominator.Who,
+				Stake:            nominator.Value.Int64(),
+				IsRewardEligible: v.eligible[nominator.Who],
+			})
+		}
+		validators[i] = validator
+	}
+
+	return &stakingpb.Staking{
+		Session:           meta.GetSession(),
+		Era:               meta.GetActiveEra(),
+		TotalStake:        totalStake.Int64(),
+		TotalRewardPayout: payout.String(),
+		TotalRewardPoints: points.Total.Int64(),
+		Validators:        validators,
+	}, nil
+}
+
+// validatorBalances returns validators active at height with their total stake
+func (s *source) validatorBalances(ctx context.Context, height int64, meta *chainpb.GetMetaByHeightResponse) ([]*validatorpb.Validator, error) {
+	stashes, err := s.validators(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*validatorpb.Validator, len(stashes))
+	for i, stash := range stashes {
+		v, err := s.eraValidator(ctx, height, meta.GetActiveEra(), stash)
+		if err != nil {
+			return nil, err
+		}
+
+		validators[i] = &validatorpb.Validator{
+			StashAccount: stash,
+			Balance:      v.exposure.Total.String(),
+		}
+	}
+	return validators, nil
+}
+
+// performance returns whether validators were online in session of height. Validator is online when it sent
+// heartbeat or authored block in session, which is final only at last height of session, so performance
+// is returned only for such heights
+func (s *source) performance(ctx context.Context, height int64, meta *chainpb.GetMetaByHeightResponse) ([]*validatorperformancepb.Validator, error) {
+	if !meta.GetLastInSession() {
+		return nil, nil
+	}
+
+	stashes, err := s.validators(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionKey := strconv.FormatInt(meta.GetSession(), 10)
+
+	validators := make([]*validatorperformancepb.Validator, len(stashes))
+	for i, stash := range stashes {
+		var heartbeat interface{}
+		if err := s.api.storage(ctx, height, "imOnline", "receivedHeartbeats", &heartbeat, sessionKey, strconv.Itoa(i)); err != nil {
+			return nil, err
+		}
+
+		var authoredBlocks number
+		if err := s.api.storage(ctx, height, "imOnline", "authoredBlocks", &authoredBlocks, sessionKey, stash); err != nil {
+			return nil, err
+		}
+
+		validators[i] = &validatorperformancepb.Validator{
+			StashAccount: stash,
+			Online:       heartbeat != nil || authoredBlocks.Int64() > 0,
+		}
+	}
+	return validators, nil
+}
@@ -0,0 +1,283 @@
+package sidecar
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// number is numeric value, sidecar encodes numbers as decimal strings, but storage values may also be
+// JSON numbers or hex strings
+type number string
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = ""
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		raw = string(data)
+	}
+
+	if strings.HasPrefix(raw, "0x") {
+		value, ok := new(big.Int).SetString(raw[2:], 16)
+		if !ok {
+			return fmt.Errorf("invalid hex number: %s", raw)
+		}
+		raw = value.String()
+	}
+
+	if _, ok := new(big.Int).SetString(raw, 10); !ok {
+		return fmt.Errorf("invalid number: %s", raw)
+	}
+	*n = number(raw)
+	return nil
+}
+
+// String returns decimal value, missing value is 0
+func (n number) String() string {
+	if n == "" {
+		return "0"
+	}
+	return string(n)
+}
+
+// Int64 returns value as int64, values which don't fit are truncated like in proxy responses
+func (n number) Int64() int64 {
+	value, err := strconv.ParseInt(n.String(), 10, 64)
+	if err != nil {
+		b, _ := new(big.Int).SetString(n.String(), 10)
+		return b.Int64()
+	}
+	return value
+}
+
+// address is account address, sidecar encodes MultiAddress as {"id": "<address>"} and AccountId as plain string
+type address string
+
+func (a *address) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*a = address(raw)
+		return nil
+	}
+
+	var multi struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	*a = address(multi.ID)
+	return nil
+}
+
+// bytesData is identity field, sidecar encodes it as {"raw": "<hex>"} or {"none": null}
+type bytesData struct {
+	Raw string `json:"raw"`
+}
+
+func (d bytesData) String() string {
+	if !strings.HasPrefix(d.Raw, "0x") {
+		return d.Raw
+	}
+
+	b, err := hex.DecodeString(d.Raw[2:])
+	if err != nil {
+		return d.Raw
+	}
+	return string(b)
+}
+
+type palletMethod struct {
+	Pallet string `json:"pallet"`
+	Method string `json:"method"`
+}
+
+type eventResponse struct {
+	Method palletMethod      `json:"method"`
+	Data   []json.RawMessage `json:"data"`
+}
+
+type signatureResponse struct {
+	Signature string  `json:"signature"`
+	Signer    address `json:"signer"`
+}
+
+type extrinsicResponse struct {
+	Method    palletMethod       `json:"method"`
+	Signature *signatureResponse `json:"signature"`
+	Nonce     number             `json:"nonce"`
+	Args      json.RawMessage    `json:"args"`
+	Tip       number             `json:"tip"`
+	Hash      string             `json:"hash"`
+	Info      struct {
+		PartialFee number `json:"partialFee"`
+	} `json:"info"`
+	Events  []eventResponse `json:"events"`
+	Success bool            `json:"success"`
+}
+
+type blockResponse struct {
+	Number         number `json:"number"`
+	Hash           string `json:"hash"`
+	ParentHash     string `json:"parentHash"`
+	StateRoot      string `json:"stateRoot"`
+	ExtrinsicsRoot string `json:"extrinsicsRoot"`
+	OnInitialize   struct {
+		Events []eventResponse `json:"events"`
+	} `json:"onInitialize"`
+	Extrinsics []extrinsicResponse `json:"extrinsics"`
+	OnFinalize struct {
+		Events []eventResponse `json:"events"`
+	} `json:"onFinalize"`
+}
+
+type headerResponse struct {
+	Number number `json:"number"`
+}
+
+type specResponse struct {
+	SpecName    string            `json:"specName"`
+	SpecVersion number            `json:"specVersion"`
+	Properties  map[string]string `json:"properties"`
+}
+
+type nodeVersionResponse struct {
+	ClientVersion  string `json:"clientVersion"`
+	ClientImplName string `json:"clientImplName"`
+	Chain          string `json:"chain"`
+}
+
+type nodeNetworkResponse struct {
+	NodeRoles       []json.RawMessage `json:"nodeRoles"`
+	NumPeers        number            `json:"numPeers"`
+	IsSyncing       bool              `json:"isSyncing"`
+	ShouldHavePeers bool              `json:"shouldHavePeers"`
+	LocalPeerID     string            `json:"localPeerId"`
+}
+
+type balanceInfoResponse struct {
+	Nonce      number `json:"nonce"`
+	Free       number `json:"free"`
+	Reserved   number `json:"reserved"`
+	MiscFrozen number `json:"miscFrozen"`
+	FeeFrozen  number `json:"feeFrozen"`
+}
+
+type identityResponse struct {
+	Deposit number `json:"deposit"`
+	Info    struct {
+		Display bytesData `json:"display"`
+		Legal   bytesData `json:"legal"`
+		Web     bytesData `json:"web"`
+		Riot    bytesData `json:"riot"`
+		Email   bytesData `json:"email"`
+		Twitter bytesData `json:"twitter"`
+		Image   bytesData `json:"image"`
+	} `json:"info"`
+}
+
+type activeEraResponse struct {
+	Index number `json:"index"`
+}
+
+type rewardPointsResponse struct {
+	Total      number            `json:"total"`
+	Individual map[string]number `json:"individual"`
+}
+
+type exposureResponse struct {
+	Total  number `json:"total"`
+	Own    number `json:"own"`
+	Others []struct {
+		Who   string `json:"who"`
+		Value number `json:"value"`
+	} `json:"others"`
+}
+
+type validatorPrefsResponse struct {
+	Commission number `json:"commission"`
+}
+
+type metadataResponse struct {
+	Metadata map[string]struct {
+		Modules []struct {
+			Name   string `json:"name"`
+			Events []struct {
+				Name string   `json:"name"`
+				Args []string `json:"args"`
+			} `json:"events"`
+		} `json:"modules"`
+	} `json:"metadata"`
+}
+
+// orderedValues returns values of JSON object in order of their keys, values of JSON array are returned as they are
+func orderedValues(raw json.RawMessage) ([]json.RawMessage, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	var values []json.RawMessage
+	for dec.More() {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// stringValue returns JSON string unquoted and any other JSON value as compact JSON text
+func stringValue(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// argsJSON encodes values of call args as JSON array of strings, which is format of args in proxy responses
+func argsJSON(raw json.RawMessage) (string, error) {
+	values, err := orderedValues(raw)
+	if err != nil {
+		return "", err
+	}
+
+	args := make([]string, len(values))
+	for i, value := range values {
+		args[i] = stringValue(value)
+	}
+
+	b, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -12,10 +12,10 @@ var (
 )
 
 type StakingClient interface {
-	GetByHeight(int64) (*stakingpb.GetByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*stakingpb.GetByHeightResponse, error)
 }
 
-func NewStakingClient(conn *grpc.ClientConn) *stakingClient {
+func NewStakingClient(conn grpc.ClientConnInterface) *stakingClient {
 	return &stakingClient{
 		client: stakingpb.NewStakingServiceClient(conn),
 	}
@@ -25,8 +25,6 @@ type stakingClient struct {
 	client stakingpb.StakingServiceClient
 }
 
-func (r *stakingClient) GetByHeight(h int64) (*stakingpb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *stakingClient) GetByHeight(ctx context.Context, h int64) (*stakingpb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &stakingpb.GetByHeightRequest{Height: h})
 }
@@ -12,10 +12,10 @@ var (
 )
 
 type TransactionClient interface {
-	GetByHeight(int64) (*transactionpb.GetByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*transactionpb.GetByHeightResponse, error)
 }
 
-func NewTransactionClient(conn *grpc.ClientConn) TransactionClient {
+func NewTransactionClient(conn grpc.ClientConnInterface) TransactionClient {
 	return &transactionClient{
 		client: transactionpb.NewTransactionServiceClient(conn),
 	}
@@ -25,9 +25,6 @@ type transactionClient struct {
 	client transactionpb.TransactionServiceClient
 }
 
-func (r *transactionClient) GetByHeight(h int64) (*transactionpb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *transactionClient) GetByHeight(ctx context.Context, h int64) (*transactionpb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &transactionpb.GetByHeightRequest{Height: h})
 }
-
@@ -13,10 +13,10 @@ var (
 )
 
 type ValidatorClient interface {
-	GetByHeight(int64) (*validatorpb.GetAllByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*validatorpb.GetAllByHeightResponse, error)
 }
 
-func NewValidatorClient(conn *grpc.ClientConn) *validatorClient {
+func NewValidatorClient(conn grpc.ClientConnInterface) *validatorClient {
 	return &validatorClient{
 		client: validatorpb.NewValidatorServiceClient(conn),
 	}
@@ -26,8 +26,6 @@ type validatorClient struct {
 	client validatorpb.ValidatorServiceClient
 }
 
-func (r *validatorClient) GetByHeight(h int64) (*validatorpb.GetAllByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *validatorClient) GetByHeight(ctx context.Context, h int64) (*validatorpb.GetAllByHeightResponse, error) {
 	return r.client.GetAllByHeight(ctx, &validatorpb.GetAllByHeightRequest{Height: h})
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
 	"google.golang.org/grpc"
-
 )
 
 var (
@@ -12,10 +11,10 @@ var (
 )
 
 type ValidatorPerformanceClient interface {
-	GetByHeight(int64) (*validatorperformancepb.GetByHeightResponse, error)
+	GetByHeight(context.Context, int64) (*validatorperformancepb.GetByHeightResponse, error)
 }
 
-func NewValidatorPerformanceClient(conn *grpc.ClientConn) *validatorPerformanceClient {
+func NewValidatorPerformanceClient(conn grpc.ClientConnInterface) *validatorPerformanceClient {
 	return &validatorPerformanceClient{
 		client: validatorperformancepb.NewValidatorPerformanceServiceClient(conn),
 	}
@@ -25,8 +24,6 @@ type validatorPerformanceClient struct {
 	client validatorperformancepb.ValidatorPerformanceServiceClient
 }
 
-func (r *validatorPerformanceClient) GetByHeight(h int64) (*validatorperformancepb.GetByHeightResponse, error) {
-	ctx := context.Background()
-
+func (r *validatorPerformanceClient) GetByHeight(ctx context.Context, h int64) (*validatorperformancepb.GetByHeightResponse, error) {
 	return r.client.GetByHeight(ctx, &validatorperformancepb.GetByHeightRequest{Height: h})
 }
@@ -5,64 +5,349 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"strings"
+	"time"
 
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
 	"github.com/kelseyhightower/envconfig"
 )
 
 const (
 	modeDevelopment = "development"
 	modeProduction  = "production"
+
+	ProxyRoleArchive = "archive"
+	ProxyRoleTip     = "tip"
+
+	StoreBackendPostgres = "postgres"
+	StoreBackendMemory   = "memory"
+
+	PublisherKindFile = "file"
+	PublisherKindNats = "nats"
+
+	// minDailySummariesRetention covers whole month, weekly and monthly summaries are rebuilt from daily ones until they are over
+	minDailySummariesRetention = 32 * 24 * time.Hour
 )
 
 var (
-	errEndpointRequired            = errors.New("proxy url is required")
+	errEndpointRequired            = errors.New("proxy url or sidecar url is required")
 	errDatabaseRequired            = errors.New("database credentials are required")
+	errInvalidStoreBackend         = errors.New("store backend must be postgres or memory")
 	errIndexWorkerIntervalRequired = errors.New("index worker interval is required")
+	errInvalidReadinessThreshold   = errors.New("readiness thresholds must be valid durations")
+	errInvalidJobDuration          = errors.New("job lock timeout must be positive duration, retry delay must not be negative")
+	errInvalidWebhookDuration      = errors.New("webhook timeout, lock timeout and retry delays must be valid durations")
+	errInvalidWebhookLimits        = errors.New("webhook batch size and max failures must be positive, height lag can't be negative")
+	errInvalidSystemEventRules     = errors.New("system event rules version and missed consecutive threshold must be positive")
+	errInvalidSystemEventBuckets   = errors.New("system event change buckets must be 3 positive ascending values")
+	errInvalidSystemEventKind      = errors.New("unknown system event kind")
+	errInvalidSystemEventNofM      = errors.New("system event missed n of m threshold must be positive and not greater than window")
+	errInvalidTotalDelegatedChange = errors.New("system event total delegated change thresholds must not be negative")
+	errInvalidPurgeInterval        = errors.New("invalid purge interval")
+	errInvalidPurgeArchive         = errors.New("purge archive partition size must be positive")
+	errInvalidPurgeDailySummaries  = errors.New("purge daily summaries interval must be 0 or at least 768h")
+	errInvalidProxyDuration        = errors.New("proxy timeouts, retry backoff and circuit breaker cooldown must be valid durations")
+	errInvalidProxyLimits          = errors.New("proxy max retries and circuit breaker failures must not be negative")
+	errInvalidProxyEndpoint        = errors.New("proxy endpoint must be given as <archive|tip>=<url>")
+	errInvalidSidecarTimeout       = errors.New("sidecar timeout must be valid duration")
+	errInvalidPublisherKind        = errors.New("publisher kind must be file or nats")
+	errPublisherTargetRequired     = errors.New("publisher file path or nats url is required")
+	errInvalidPublisherSettings    = errors.New("publisher timeout must be valid duration and batch size must be positive")
+	errInvalidShadowMaxRowLoss     = errors.New("shadow max row loss must be between 0 and 1")
+	errInvalidTokenDecimals        = errors.New("token decimals must not be negative")
+	errInvalidMaxNominatorRewarded = errors.New("max nominator rewarded per validator must be positive")
 )
 
 // Config holds the configuration data
 type Config struct {
-	AppEnv                       string `json:"app_env" envconfig:"APP_ENV" default:"development"`
-	ProxyUrl                     string `json:"proxy_url" envconfig:"PROXY_URL"`
-	ServerAddr                   string `json:"server_addr" envconfig:"SERVER_ADDR" default:"0.0.0.0"`
-	ServerPort                   int64  `json:"server_port" envconfig:"SERVER_PORT" default:"8081"`
-	FirstBlockHeight             int64  `json:"first_block_height" envconfig:"FIRST_BLOCK_HEIGHT" default:"1"`
-	IndexWorkerInterval          string `json:"index_worker_interval" envconfig:"INDEX_WORKER_INTERVAL" default:"@every 15m"`
-	SummarizeWorkerInterval      string `json:"summarize_worker_interval" envconfig:"SUMMARIZE_WORKER_INTERVAL" default:"@every 20m"`
-	PurgeWorkerInterval          string `json:"purge_worker_interval" envconfig:"PURGE_WORKER_INTERVAL" default:"@every 1h"`
-	DefaultBatchSize             int64  `json:"default_batch_size" envconfig:"DEFAULT_BATCH_SIZE" default:"0"`
-	DatabaseDSN                  string `json:"database_dsn" envconfig:"DATABASE_DSN"`
-	Debug                        bool   `json:"debug" envconfig:"DEBUG"`
-	LogLevel                     string `json:"log_level" envconfig:"LOG_LEVEL" default:"info"`
-	LogOutput                    string `json:"log_output" envconfig:"LOG_OUTPUT" default:"stdout"`
-	RollbarAccessToken           string `json:"rollbar_access_token" envconfig:"ROLLBAR_ACCESS_TOKEN"`
-	RollbarServerRoot            string `json:"rollbar_server_root" envconfig:"ROLLBAR_SERVER_ROOT"`
-	IndexerMetricAddr            string `json:"indexer_metric_addr" envconfig:"INDEXER_METRIC_ADDR" default:":8080"`
-	ServerMetricAddr             string `json:"server_metric_addr" envconfig:"SERVER_METRIC_ADDR" default:":8090"`
-	MetricServerUrl              string `json:"metric_server_url" envconfig:"METRIC_SERVER_URL" default:"/metrics"`
-	PurgeSequencesInterval       string `json:"purge_sequences_interval" envconfig:"PURGE_SEQUENCES_INTERVAL" default:"26h"`
-	PurgeHourlySummariesInterval string `json:"purge_hourly_summaries_interval" envconfig:"PURGE_HOURLY_SUMMARIES_INTERVAL" default:"26h"`
-	IndexerConfigFile            string `json:"indexer_config_file" envconfig:"INDEXER_CONFIG_FILE" default:"indexer_config.json"`
-	RouteToLive                  string `json:"route_to_live" envconfig:"ROUTE_TO_LIVE"`
+	AppEnv                        string `json:"app_env" envconfig:"APP_ENV" default:"development"`
+	ProxyUrl                      string `json:"proxy_url" envconfig:"PROXY_URL"`
+	ProxyTimeout                  string `json:"proxy_timeout" envconfig:"PROXY_TIMEOUT" default:"2m"`
+	ProxyMaxRetries               int64  `json:"proxy_max_retries" envconfig:"PROXY_MAX_RETRIES" default:"2"`
+	ProxyRetryBackoff             string `json:"proxy_retry_backoff" envconfig:"PROXY_RETRY_BACKOFF" default:"1s"`
+	ProxyBreakerFailures          int64  `json:"proxy_breaker_failures" envconfig:"PROXY_BREAKER_FAILURES" default:"5"`
+	ProxyBreakerCooldown          string `json:"proxy_breaker_cooldown" envconfig:"PROXY_BREAKER_COOLDOWN" default:"30s"`
+	ProxyHealthCheckInterval      string `json:"proxy_health_check_interval" envconfig:"PROXY_HEALTH_CHECK_INTERVAL" default:"10s"`
+	ProxyTipDepth                 int64  `json:"proxy_tip_depth" envconfig:"PROXY_TIP_DEPTH" default:"256"`
+	ProxyTLS                      bool   `json:"proxy_tls" envconfig:"PROXY_TLS"`
+	ProxyTLSCAFile                string `json:"proxy_tls_ca_file" envconfig:"PROXY_TLS_CA_FILE"`
+	ProxyAuthToken                string `json:"proxy_auth_token" envconfig:"PROXY_AUTH_TOKEN"`
+	SidecarUrl                    string `json:"sidecar_url" envconfig:"SIDECAR_URL"`
+	SidecarTimeout                string `json:"sidecar_timeout" envconfig:"SIDECAR_TIMEOUT" default:"2m"`
+	ServerAddr                    string `json:"server_addr" envconfig:"SERVER_ADDR" default:"0.0.0.0"`
+	ServerPort                    int64  `json:"server_port" envconfig:"SERVER_PORT" default:"8081"`
+	FirstBlockHeight              int64  `json:"first_block_height" envconfig:"FIRST_BLOCK_HEIGHT" default:"1"`
+	IndexWorkerInterval           string `json:"index_worker_interval" envconfig:"INDEX_WORKER_INTERVAL" default:"@every 15m"`
+	SummarizeWorkerInterval       string `json:"summarize_worker_interval" envconfig:"SUMMARIZE_WORKER_INTERVAL" default:"@every 20m"`
+	PurgeWorkerInterval           string `json:"purge_worker_interval" envconfig:"PURGE_WORKER_INTERVAL" default:"@every 1h"`
+	ProcessJobsWorkerInterval     string `json:"process_jobs_worker_interval" envconfig:"PROCESS_JOBS_WORKER_INTERVAL" default:"@every 5s"`
+	JobLockTimeout                string `json:"job_lock_timeout" envconfig:"JOB_LOCK_TIMEOUT" default:"5m"`
+	JobMaxAttempts                int64  `json:"job_max_attempts" envconfig:"JOB_MAX_ATTEMPTS" default:"3"`
+	JobRetryDelay                 string `json:"job_retry_delay" envconfig:"JOB_RETRY_DELAY" default:"1m"`
+	DefaultBatchSize              int64  `json:"default_batch_size" envconfig:"DEFAULT_BATCH_SIZE" default:"0"`
+	StoreBackend                  string `json:"store_backend" envconfig:"STORE_BACKEND" default:"postgres"`
+	DatabaseDSN                   string `json:"database_dsn" envconfig:"DATABASE_DSN"`
+	Debug                         bool   `json:"debug" envconfig:"DEBUG"`
+	LogLevel                      string `json:"log_level" envconfig:"LOG_LEVEL" default:"info"`
+	LogOutput                     string `json:"log_output" envconfig:"LOG_OUTPUT" default:"stdout"`
+	RollbarAccessToken            string `json:"rollbar_access_token" envconfig:"ROLLBAR_ACCESS_TOKEN"`
+	RollbarServerRoot             string `json:"rollbar_server_root" envconfig:"ROLLBAR_SERVER_ROOT"`
+	IndexerMetricAddr             string `json:"indexer_metric_addr" envconfig:"INDEXER_METRIC_ADDR" default:":8080"`
+	ServerMetricAddr              string `json:"server_metric_addr" envconfig:"SERVER_METRIC_ADDR" default:":8090"`
+	MetricServerUrl               string `json:"metric_server_url" envconfig:"METRIC_SERVER_URL" default:"/metrics"`
+	PurgeSequencesInterval        string `json:"purge_sequences_interval" envconfig:"PURGE_SEQUENCES_INTERVAL" default:"26h"`
+	PurgeHourlySummariesInterval  string `json:"purge_hourly_summaries_interval" envconfig:"PURGE_HOURLY_SUMMARIES_INTERVAL" default:"26h"`
+	PurgeDailySummariesInterval   string `json:"purge_daily_summaries_interval" envconfig:"PURGE_DAILY_SUMMARIES_INTERVAL" default:"0"`
+	PurgeWeeklySummariesInterval  string `json:"purge_weekly_summaries_interval" envconfig:"PURGE_WEEKLY_SUMMARIES_INTERVAL" default:"0"`
+	PurgeMonthlySummariesInterval string `json:"purge_monthly_summaries_interval" envconfig:"PURGE_MONTHLY_SUMMARIES_INTERVAL" default:"0"`
+	PurgeEraSummariesInterval     string `json:"purge_era_summaries_interval" envconfig:"PURGE_ERA_SUMMARIES_INTERVAL" default:"0"`
+	PurgeArchiveDir               string `json:"purge_archive_dir" envconfig:"PURGE_ARCHIVE_DIR"`
+	PurgeArchivePartitionSize     int64  `json:"purge_archive_partition_size" envconfig:"PURGE_ARCHIVE_PARTITION_SIZE" default:"100000"`
+	IndexerConfigFile             string `json:"indexer_config_file" envconfig:"INDEXER_CONFIG_FILE" default:"indexer_config.json"`
+	RouteToLive                   string `json:"route_to_live" envconfig:"ROUTE_TO_LIVE"`
+	ReadinessMaxLagBlocks         int64  `json:"readiness_max_lag_blocks" envconfig:"READINESS_MAX_LAG_BLOCKS" default:"100"`
+	ReadinessMaxLagInterval       string `json:"readiness_max_lag_interval" envconfig:"READINESS_MAX_LAG_INTERVAL" default:"10m"`
+	ReadinessMaxReportAge         string `json:"readiness_max_report_age" envconfig:"READINESS_MAX_REPORT_AGE" default:"0"`
+	ReadinessRequireProxy         bool   `json:"readiness_require_proxy" envconfig:"READINESS_REQUIRE_PROXY" default:"false"`
+	AdminToken                    string `json:"admin_token" envconfig:"ADMIN_TOKEN"`
+	WebhookWorkerInterval         string `json:"webhook_worker_interval" envconfig:"WEBHOOK_WORKER_INTERVAL" default:"@every 10s"`
+	WebhookTimeout                string `json:"webhook_timeout" envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+	WebhookLockTimeout            string `json:"webhook_lock_timeout" envconfig:"WEBHOOK_LOCK_TIMEOUT" default:"5m"`
+	WebhookBatchSize              int64  `json:"webhook_batch_size" envconfig:"WEBHOOK_BATCH_SIZE" default:"100"`
+	WebhookRetryDelay             string `json:"webhook_retry_delay" envconfig:"WEBHOOK_RETRY_DELAY" default:"30s"`
+	WebhookMaxRetryDelay          string `json:"webhook_max_retry_delay" envconfig:"WEBHOOK_MAX_RETRY_DELAY" default:"1h"`
+	WebhookMaxFailures            int64  `json:"webhook_max_failures" envconfig:"WEBHOOK_MAX_FAILURES" default:"10"`
+	WebhookHeightLag              int64  `json:"webhook_height_lag" envconfig:"WEBHOOK_HEIGHT_LAG" default:"2"`
+	PublisherKind                 string `json:"publisher_kind" envconfig:"PUBLISHER_KIND"`
+	PublisherFilePath             string `json:"publisher_file_path" envconfig:"PUBLISHER_FILE_PATH"`
+	PublisherNatsUrl              string `json:"publisher_nats_url" envconfig:"PUBLISHER_NATS_URL"`
+	PublisherNatsSubject          string `json:"publisher_nats_subject" envconfig:"PUBLISHER_NATS_SUBJECT" default:"polkadothub.heights"`
+	PublisherTimeout              string `json:"publisher_timeout" envconfig:"PUBLISHER_TIMEOUT" default:"10s"`
+	PublisherBatchSize            int64  `json:"publisher_batch_size" envconfig:"PUBLISHER_BATCH_SIZE" default:"100"`
+	PublisherWorkerInterval       string `json:"publisher_worker_interval" envconfig:"PUBLISHER_WORKER_INTERVAL" default:"@every 30s"`
+
+	SystemEventRulesVersion               int64     `json:"system_event_rules_version" envconfig:"SYSTEM_EVENT_RULES_VERSION" default:"1"`
+	SystemEventKinds                      []string  `json:"system_event_kinds" envconfig:"SYSTEM_EVENT_KINDS"`
+	SystemEventMissedConsecutive          int64     `json:"system_event_missed_consecutive" envconfig:"SYSTEM_EVENT_MISSED_CONSECUTIVE" default:"1"`
+	SystemEventMissedNofMThreshold        int64     `json:"system_event_missed_n_of_m_threshold" envconfig:"SYSTEM_EVENT_MISSED_N_OF_M_THRESHOLD" default:"3"`
+	SystemEventMissedNofMWindow           int64     `json:"system_event_missed_n_of_m_window" envconfig:"SYSTEM_EVENT_MISSED_N_OF_M_WINDOW" default:"10"`
+	SystemEventActiveBalanceChangeBuckets []float64 `json:"system_event_active_balance_change_buckets" envconfig:"SYSTEM_EVENT_ACTIVE_BALANCE_CHANGE_BUCKETS" default:"0.1,1,10"`
+	SystemEventCommissionChangeBuckets    []float64 `json:"system_event_commission_change_buckets" envconfig:"SYSTEM_EVENT_COMMISSION_CHANGE_BUCKETS" default:"0.1,1,10"`
+	SystemEventTotalDelegatedChange       float64   `json:"system_event_total_delegated_change" envconfig:"SYSTEM_EVENT_TOTAL_DELEGATED_CHANGE" default:"10"`
+	SystemEventTotalDelegatedChangeMin    string    `json:"system_event_total_delegated_change_min" envconfig:"SYSTEM_EVENT_TOTAL_DELEGATED_CHANGE_MIN" default:"0"`
+
+	ProxyMethodTimeouts map[string]string `json:"proxy_method_timeouts" envconfig:"PROXY_METHOD_TIMEOUTS"`
+	ProxyEndpoints      []string          `json:"proxy_endpoints" envconfig:"PROXY_ENDPOINTS"`
+
+	ShadowMaxRowLoss float64 `json:"shadow_max_row_loss" envconfig:"SHADOW_MAX_ROW_LOSS" default:"0"`
+	TokenDecimals    int64   `json:"token_decimals" envconfig:"TOKEN_DECIMALS" default:"10"`
+
+	MaxNominatorRewardedPerValidator int64 `json:"max_nominator_rewarded_per_validator" envconfig:"MAX_NOMINATOR_REWARDED_PER_VALIDATOR" default:"256"`
 }
 
 // Validate returns an error if config is invalid
 func (c *Config) Validate() error {
-	if c.ProxyUrl == "" {
-		return errEndpointRequired
+	if err := c.validateProxy(); err != nil {
+		return err
 	}
 
-	if c.DatabaseDSN == "" {
-		return errDatabaseRequired
+	switch c.StoreBackend {
+	case StoreBackendPostgres:
+		if c.DatabaseDSN == "" {
+			return errDatabaseRequired
+		}
+	case StoreBackendMemory:
+	default:
+		return errInvalidStoreBackend
 	}
 
 	if c.IndexWorkerInterval == "" {
 		return errIndexWorkerIntervalRequired
 	}
 
+	for _, threshold := range []string{c.ReadinessMaxLagInterval, c.ReadinessMaxReportAge} {
+		if _, err := time.ParseDuration(threshold); threshold != "" && err != nil {
+			return errInvalidReadinessThreshold
+		}
+	}
+
+	for _, d := range []string{c.PurgeSequencesInterval, c.PurgeHourlySummariesInterval, c.PurgeDailySummariesInterval, c.PurgeWeeklySummariesInterval, c.PurgeMonthlySummariesInterval, c.PurgeEraSummariesInterval} {
+		if _, err := time.ParseDuration(d); err != nil {
+			return errInvalidPurgeInterval
+		}
+	}
+
+	if d, _ := time.ParseDuration(c.PurgeDailySummariesInterval); d != 0 && d < minDailySummariesRetention {
+		return errInvalidPurgeDailySummaries
+	}
+
+	if c.PurgeArchiveDir != "" && c.PurgeArchivePartitionSize <= 0 {
+		return errInvalidPurgeArchive
+	}
+
+	lockTimeout, err := time.ParseDuration(c.JobLockTimeout)
+	if err != nil || lockTimeout <= 0 {
+		return errInvalidJobDuration
+	}
+	if retryDelay, err := time.ParseDuration(c.JobRetryDelay); err != nil || retryDelay < 0 {
+		return errInvalidJobDuration
+	}
+
+	for _, d := range []string{c.WebhookTimeout, c.WebhookLockTimeout, c.WebhookRetryDelay, c.WebhookMaxRetryDelay} {
+		if _, err := time.ParseDuration(d); err != nil {
+			return errInvalidWebhookDuration
+		}
+	}
+
+	if c.WebhookBatchSize <= 0 || c.WebhookMaxFailures <= 0 || c.WebhookHeightLag < 0 {
+		return errInvalidWebhookLimits
+	}
+
+	if err := c.validateSystemEventRules(); err != nil {
+		return err
+	}
+
+	if err := c.validatePublisher(); err != nil {
+		return err
+	}
+
+	if c.ShadowMaxRowLoss < 0 || c.ShadowMaxRowLoss >= 1 {
+		return errInvalidShadowMaxRowLoss
+	}
+
+	if c.TokenDecimals < 0 {
+		return errInvalidTokenDecimals
+	}
+
+	if c.MaxNominatorRewardedPerValidator <= 0 {
+		return errInvalidMaxNominatorRewarded
+	}
+
+	return nil
+}
+
+func (c *Config) validatePublisher() error {
+	switch c.PublisherKind {
+	case "":
+		return nil
+	case PublisherKindFile:
+		if c.PublisherFilePath == "" {
+			return errPublisherTargetRequired
+		}
+	case PublisherKindNats:
+		if c.PublisherNatsUrl == "" {
+			return errPublisherTargetRequired
+		}
+	default:
+		return errInvalidPublisherKind
+	}
+
+	if _, err := time.ParseDuration(c.PublisherTimeout); err != nil || c.PublisherBatchSize <= 0 {
+		return errInvalidPublisherSettings
+	}
 	return nil
 }
 
+func (c *Config) validateProxy() error {
+	if c.UseSidecar() {
+		if _, err := time.ParseDuration(c.SidecarTimeout); err != nil {
+			return errInvalidSidecarTimeout
+		}
+		return nil
+	}
+
+	endpoints, err := c.ParseProxyEndpoints()
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return errEndpointRequired
+	}
+
+	durations := []string{c.ProxyTimeout, c.ProxyRetryBackoff, c.ProxyBreakerCooldown, c.ProxyHealthCheckInterval}
+	for _, timeout := range c.ProxyMethodTimeouts {
+		durations = append(durations, timeout)
+	}
+	for _, d := range durations {
+		if _, err := time.ParseDuration(d); err != nil {
+			return errInvalidProxyDuration
+		}
+	}
+
+	if c.ProxyMaxRetries < 0 || c.ProxyBreakerFailures < 0 || c.ProxyTipDepth < 0 {
+		return errInvalidProxyLimits
+	}
+	return nil
+}
+
+func (c *Config) validateSystemEventRules() error {
+	if c.SystemEventRulesVersion <= 0 || c.SystemEventMissedConsecutive <= 0 {
+		return errInvalidSystemEventRules
+	}
+
+	if c.SystemEventMissedNofMThreshold <= 0 || c.SystemEventMissedNofMThreshold > c.SystemEventMissedNofMWindow {
+		return errInvalidSystemEventNofM
+	}
+
+	for _, buckets := range [][]float64{c.SystemEventActiveBalanceChangeBuckets, c.SystemEventCommissionChangeBuckets} {
+		if len(buckets) != 3 || buckets[0] <= 0 || buckets[0] >= buckets[1] || buckets[1] >= buckets[2] {
+			return errInvalidSystemEventBuckets
+		}
+	}
+
+	if c.SystemEventTotalDelegatedChange < 0 {
+		return errInvalidTotalDelegatedChange
+	}
+	if min, err := types.NewQuantityFromString(c.SystemEventTotalDelegatedChangeMin); err != nil || !min.Valid() {
+		return errInvalidTotalDelegatedChange
+	}
+
+	for _, kind := range c.SystemEventKinds {
+		if !model.SystemEventKind(kind).Valid() {
+			return fmt.Errorf("%w: %s", errInvalidSystemEventKind, kind)
+		}
+	}
+
+	return nil
+}
+
+// ProxyEndpoint is proxy address with role of its node
+type ProxyEndpoint struct {
+	Role string
+	URL  string
+}
+
+// ParseProxyEndpoints returns proxy endpoints, proxy url is used as single archive endpoint when no endpoints are given
+func (c *Config) ParseProxyEndpoints() ([]ProxyEndpoint, error) {
+	if len(c.ProxyEndpoints) == 0 {
+		if c.ProxyUrl == "" {
+			return nil, nil
+		}
+		return []ProxyEndpoint{{Role: ProxyRoleArchive, URL: c.ProxyUrl}}, nil
+	}
+
+	var endpoints []ProxyEndpoint
+	for _, raw := range c.ProxyEndpoints {
+		parts := strings.SplitN(strings.TrimSpace(raw), "=", 2)
+		if len(parts) != 2 || parts[1] == "" || (parts[0] != ProxyRoleArchive && parts[0] != ProxyRoleTip) {
+			return nil, fmt.Errorf("%w: %s", errInvalidProxyEndpoint, raw)
+		}
+		endpoints = append(endpoints, ProxyEndpoint{Role: parts[0], URL: parts[1]})
+	}
+	return endpoints, nil
+}
+
+// UseSidecar returns true if data is read from substrate-api-sidecar instead of proxy
+func (c *Config) UseSidecar() bool {
+	return c.SidecarUrl != ""
+}
+
+// PublisherEnabled returns true if processed heights are published to downstream consumers
+func (c *Config) PublisherEnabled() bool {
+	return c.PublisherKind != ""
+}
+
 // IsDevelopment returns true if app is in dev mode
 func (c *Config) IsDevelopment() bool {
 	return c.AppEnv == modeDevelopment
@@ -73,6 +358,11 @@ func (c *Config) IsProduction() bool {
 	return c.AppEnv == modeProduction
 }
 
+// IsMemoryStore returns true if data is kept in process memory instead of database
+func (c *Config) IsMemoryStore() bool {
+	return c.StoreBackend == StoreBackendMemory
+}
+
 // ListenAddr returns a full listen address and port
 func (c *Config) ListenAddr() string {
 	return fmt.Sprintf("%s:%d", c.ServerAddr, c.ServerPort)
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+)
+
+func newValidConfig(t *testing.T) *Config {
+	cfg := New()
+	if err := FromEnv(cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.ProxyUrl = "localhost:50051"
+	cfg.DatabaseDSN = "postgres://localhost/polkadothub"
+	return cfg
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := newValidConfig(t).Validate(); err != nil {
+		t.Fatalf("want default config to be valid; got %v", err)
+	}
+
+	tests := []struct {
+		description string
+		update      func(*Config)
+		want        error
+	}{
+		{"invalid job lock timeout", func(c *Config) { c.JobLockTimeout = "5" }, errInvalidJobDuration},
+		{"zero job lock timeout", func(c *Config) { c.JobLockTimeout = "0s" }, errInvalidJobDuration},
+		{"negative job lock timeout", func(c *Config) { c.JobLockTimeout = "-1m" }, errInvalidJobDuration},
+		{"invalid job retry delay", func(c *Config) { c.JobRetryDelay = "1" }, errInvalidJobDuration},
+		{"negative job retry delay", func(c *Config) { c.JobRetryDelay = "-1s" }, errInvalidJobDuration},
+		{"zero job retry delay", func(c *Config) { c.JobRetryDelay = "0s" }, nil},
+		{"tiny job lock timeout", func(c *Config) { c.JobLockTimeout = "1ns" }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			tt.update(cfg)
+
+			if err := cfg.Validate(); err != tt.want {
+				t.Errorf("want error %v; got %v", tt.want, err)
+			}
+		})
+	}
+}
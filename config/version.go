@@ -7,6 +7,10 @@ const (
 	AppVersion = "0.9.3"
 	GitCommit  = "-"
 	GoVersion  = "1.14"
+
+	// MigrationVersion is the database schema version this binary expects.
+	// Bump it together with every new file in migrations/
+	MigrationVersion = 31
 )
 
 func VersionString() string {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/figment-networks/indexing-engine/pipeline"
+	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
@@ -12,10 +13,12 @@ import (
 
 const (
 	ValidatorAggCreatorTaskName = "ValidatorAggCreator"
+	EraSummaryCreatorTaskName   = "EraSummaryCreator"
 )
 
 var (
 	_ pipeline.Task = (*validatorAggCreatorTask)(nil)
+	_ pipeline.Task = (*eraSummaryCreatorTask)(nil)
 )
 
 func NewValidatorAggCreatorTask(validatorAggDb store.ValidatorAgg) *validatorAggCreatorTask {
@@ -104,3 +107,51 @@ func (t *validatorAggCreatorTask) Run(ctx context.Context, p pipeline.Payload) e
 
 	return nil
 }
+
+// NewEraSummaryCreatorTask creates network wide summary of era at its last height
+func NewEraSummaryCreatorTask(cfg *config.Config, syncablesDb store.Syncables) *eraSummaryCreatorTask {
+	return &eraSummaryCreatorTask{
+		cfg:         cfg,
+		syncablesDb: syncablesDb,
+	}
+}
+
+type eraSummaryCreatorTask struct {
+	cfg         *config.Config
+	syncablesDb store.Syncables
+}
+
+func (t *eraSummaryCreatorTask) GetName() string {
+	return EraSummaryCreatorTaskName
+}
+
+func (t *eraSummaryCreatorTask) Run(ctx context.Context, p pipeline.Payload) error {
+	payload := p.(*payload)
+
+	if !payload.Syncable.LastInEra {
+		logger.Info(fmt.Sprintf("indexer task skipped because height is not last in era [stage=%s] [task=%s] [height=%d]", pipeline.StageAggregator, t.GetName(), payload.CurrentHeight))
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("running indexer task [stage=%s] [task=%s] [height=%d]", pipeline.StageAggregator, t.GetName(), payload.CurrentHeight))
+
+	var firstHeightInEra int64
+	lastSyncableInPrevEra, err := t.syncablesDb.FindLastInEra(payload.Syncable.Era - 1)
+	if err != nil {
+		if err == store.ErrNotFound {
+			firstHeightInEra = t.cfg.FirstBlockHeight
+		} else {
+			return err
+		}
+	} else {
+		firstHeightInEra = lastSyncableInPrevEra.Height + 1
+	}
+
+	eraSummary, err := ToEraSummary(payload.Syncable, firstHeightInEra, payload.RawStaking, payload.ValidatorEraSequences)
+	if err != nil {
+		return err
+	}
+
+	payload.EraSummary = eraSummary
+	return nil
+}
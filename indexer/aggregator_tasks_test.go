@@ -7,10 +7,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/figment-networks/polkadothub-indexer/config"
 	mock "github.com/figment-networks/polkadothub-indexer/mock/store"
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
 	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
 	"github.com/golang/mock/gomock"
 )
@@ -345,3 +347,105 @@ func TestValidatorAggCreatorTask_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestEraSummaryCreatorTask_Run(t *testing.T) {
+	syncTime := *types.NewTimeFromTime(time.Now())
+	syncable := &model.Syncable{Height: 100, Era: 7, Time: syncTime, LastInEra: true}
+
+	rawStaking := &stakingpb.Staking{
+		TotalStake:        1000,
+		TotalRewardPayout: "123456789012345678901234",
+		TotalRewardPoints: 60,
+		Validators: []*stakingpb.Validator{
+			{StashAccount: "v1", Stakers: []*stakingpb.Stake{{StashAccount: "n1"}, {StashAccount: "n2"}}},
+			{StashAccount: "v2", Stakers: []*stakingpb.Stake{{StashAccount: "n2"}, {StashAccount: "n3"}}},
+			{StashAccount: "v3"},
+			{StashAccount: "v4", Stakers: []*stakingpb.Stake{{StashAccount: "n1"}}},
+		},
+	}
+
+	validatorEraSeq := func(stash string, totalStake, commission int64) model.ValidatorEraSeq {
+		return model.ValidatorEraSeq{StashAccount: stash, TotalStake: types.NewQuantityFromInt64(totalStake), Commission: commission}
+	}
+
+	t.Run("skips height which is not last in era", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		task := NewEraSummaryCreatorTask(&config.Config{}, mock.NewMockSyncables(ctrl))
+		pl := &payload{Syncable: &model.Syncable{Height: 99, Era: 7}, RawStaking: rawStaking}
+
+		if err := task.Run(context.Background(), pl); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pl.EraSummary != nil {
+			t.Errorf("want no era summary; got %+v", pl.EraSummary)
+		}
+	})
+
+	t.Run("summarizes era", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		syncablesMock := mock.NewMockSyncables(ctrl)
+		syncablesMock.EXPECT().FindLastInEra(int64(6)).Return(&model.Syncable{Height: 80}, nil)
+
+		task := NewEraSummaryCreatorTask(&config.Config{}, syncablesMock)
+		pl := &payload{
+			Syncable:   syncable,
+			RawStaking: rawStaking,
+			ValidatorEraSequences: []model.ValidatorEraSeq{
+				validatorEraSeq("v1", 400, 10),
+				validatorEraSeq("v2", 100, 20),
+				validatorEraSeq("v3", 300, 0),
+				validatorEraSeq("v4", 200, 50),
+			},
+		}
+
+		if err := task.Run(context.Background(), pl); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		payout, _ := types.NewQuantityFromString("123456789012345678901234")
+		expect := &model.EraSummary{
+			EraSequence:           &model.EraSequence{Era: 7, StartHeight: 81, EndHeight: 100, Time: syncTime},
+			TotalStake:            types.NewQuantityFromInt64(1000),
+			ActiveValidatorsCount: 4,
+			NominatorsCount:       3,
+			ValidatorStakeMin:     types.NewQuantityFromInt64(100),
+			ValidatorStakeMax:     types.NewQuantityFromInt64(400),
+			ValidatorStakeMedian:  types.NewQuantityFromInt64(250),
+			TotalRewardPayout:     payout,
+			TotalRewardPoints:     60,
+			CommissionAvg:         20,
+		}
+		if !reflect.DeepEqual(pl.EraSummary, expect) {
+			t.Errorf("unexpected era summary, want %+v; got %+v", expect, pl.EraSummary)
+		}
+	})
+
+	t.Run("starts first era at first block height", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		syncablesMock := mock.NewMockSyncables(ctrl)
+		syncablesMock.EXPECT().FindLastInEra(int64(6)).Return(nil, store.ErrNotFound)
+
+		task := NewEraSummaryCreatorTask(&config.Config{FirstBlockHeight: 5}, syncablesMock)
+		pl := &payload{
+			Syncable:              syncable,
+			RawStaking:            rawStaking,
+			ValidatorEraSequences: []model.ValidatorEraSeq{validatorEraSeq("v1", 400, 10), validatorEraSeq("v2", 100, 20), validatorEraSeq("v3", 300, 0)},
+		}
+
+		if err := task.Run(context.Background(), pl); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pl.EraSummary.StartHeight != 5 {
+			t.Errorf("want start height 5; got %d", pl.EraSummary.StartHeight)
+		}
+		if median := pl.EraSummary.ValidatorStakeMedian.String(); median != "300" {
+			t.Errorf("want median validator stake 300; got %s", median)
+		}
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"sort"
 
 	"github.com/figment-networks/indexing-engine/pipeline"
@@ -25,21 +24,21 @@ const (
 var (
 	ErrActiveBalanceOutsideOfRange = errors.New("active balance is outside of specified buckets")
 	ErrCommissionOutsideOfRange    = errors.New("commission is outside of specified buckets")
-
-	missedConsecutiveThreshold int64 = 1
 )
 
 // NewSystemEventCreatorTask creates system events
 func NewSystemEventCreatorTask(cfg *config.Config, validatorSeqDb store.ValidatorSeq) *systemEventCreatorTask {
 	return &systemEventCreatorTask{
-		cfg: cfg,
+		cfg:   cfg,
+		rules: newSystemEventRules(cfg),
 
 		validatorSeqDb: validatorSeqDb,
 	}
 }
 
 type systemEventCreatorTask struct {
-	cfg *config.Config
+	cfg   *config.Config
+	rules systemEventRules
 
 	validatorSeqDb store.ValidatorSeq
 }
@@ -70,7 +69,8 @@ func (t *systemEventCreatorTask) Run(ctx context.Context, p pipeline.Payload) er
 func NewSessionSystemEventCreatorTask(cfg *config.Config, syncablesDb store.Syncables, systemEventDb store.SystemEvents, validatorSeqDb store.ValidatorSeq, validatorSessionSeqDb store.ValidatorSessionSeq,
 ) *sessionSystemEventCreatorTask {
 	return &sessionSystemEventCreatorTask{
-		cfg: cfg,
+		cfg:   cfg,
+		rules: newSystemEventRules(cfg),
 
 		syncablesDb:           syncablesDb,
 		systemEventDb:         systemEventDb,
@@ -80,7 +80,8 @@ func NewSessionSystemEventCreatorTask(cfg *config.Config, syncablesDb store.Sync
 }
 
 type sessionSystemEventCreatorTask struct {
-	cfg *config.Config
+	cfg   *config.Config
+	rules systemEventRules
 
 	syncablesDb           store.Syncables
 	systemEventDb         store.SystemEvents
@@ -122,6 +123,12 @@ func (t *sessionSystemEventCreatorTask) Run(ctx context.Context, p pipeline.Payl
 	}
 	payload.SystemEvents = append(payload.SystemEvents, missedBlocksSystemEvents...)
 
+	missedNofMSystemEvents, err := t.getMissedNofMSystemEvents(payload.ValidatorSessionSequences, payload.Syncable)
+	if err != nil {
+		return err
+	}
+	payload.SystemEvents = append(payload.SystemEvents, missedNofMSystemEvents...)
+
 	return nil
 }
 
@@ -129,13 +136,15 @@ func (t *sessionSystemEventCreatorTask) Run(ctx context.Context, p pipeline.Payl
 func NewEraSystemEventCreatorTask(cfg *config.Config, accountEraSeqDb store.AccountEraSeq, validatorEraSeqDb store.ValidatorEraSeq) *eraSystemEventCreatorTask {
 	return &eraSystemEventCreatorTask{
 		cfg:               cfg,
+		rules:             newSystemEventRules(cfg),
 		accountEraSeqDb:   accountEraSeqDb,
 		validatorEraSeqDb: validatorEraSeqDb,
 	}
 }
 
 type eraSystemEventCreatorTask struct {
-	cfg *config.Config
+	cfg   *config.Config
+	rules systemEventRules
 
 	accountEraSeqDb   store.AccountEraSeq
 	validatorEraSeqDb store.ValidatorEraSeq
@@ -164,6 +173,18 @@ func (t *eraSystemEventCreatorTask) Run(ctx context.Context, p pipeline.Payload)
 	}
 	payload.SystemEvents = append(payload.SystemEvents, delegationChangedSystemEvents...)
 
+	delegationStakeChangedSystemEvents, err := t.getDelegationStakeChangedSystemEvents(payload.AccountEraSequences, prevEraAccountSeqs, payload.Syncable)
+	if err != nil {
+		return err
+	}
+	payload.SystemEvents = append(payload.SystemEvents, delegationStakeChangedSystemEvents...)
+
+	totalDelegatedChangeSystemEvents, err := t.getTotalDelegatedChangeSystemEvents(payload.AccountEraSequences, prevEraAccountSeqs, payload.Syncable)
+	if err != nil {
+		return err
+	}
+	payload.SystemEvents = append(payload.SystemEvents, totalDelegatedChangeSystemEvents...)
+
 	prevEraValidatorSeqs, err := t.getPrevValidatorEraSequences(payload)
 	if err != nil {
 		return err
@@ -239,7 +260,11 @@ func (t *sessionSystemEventCreatorTask) getLastSessionHeight(payload *payload) (
 func (t *sessionSystemEventCreatorTask) getMissedBlocksSystemEvents(currSeqs []model.ValidatorSessionSeq, lastSessionHeight int64, syncable *model.Syncable) ([]model.SystemEvent, error) {
 	var systemEvents []model.SystemEvent
 
-	since := syncable.Session - missedConsecutiveThreshold
+	if !t.rules.isEnabled(model.SystemEventMissedNConsecutive) {
+		return systemEvents, nil
+	}
+
+	since := syncable.Session - t.rules.missedConsecutiveThreshold
 	if since < 0 {
 		return systemEvents, nil
 	}
@@ -265,10 +290,10 @@ func (t *sessionSystemEventCreatorTask) getMissedBlocksSystemEvents(currSeqs []m
 		}
 		missed++
 
-		if missed >= missedConsecutiveThreshold {
-			newSystemEvent, err := newSystemEvent(seq.StashAccount, syncable, model.SystemEventMissedNConsecutive, model.MissedNConsecutive{
+		if missed >= t.rules.missedConsecutiveThreshold {
+			newSystemEvent, err := t.rules.newSystemEvent(seq.StashAccount, syncable, model.SystemEventMissedNConsecutive, model.MissedNConsecutive{
 				Missed:    missed,
-				Threshold: missedConsecutiveThreshold,
+				Threshold: t.rules.missedConsecutiveThreshold,
 			})
 			if err != nil {
 				return nil, err
@@ -280,6 +305,71 @@ func (t *sessionSystemEventCreatorTask) getMissedBlocksSystemEvents(currSeqs []m
 	return systemEvents, nil
 }
 
+// getMissedNofMSystemEvents creates system event when number of sessions validator was offline in last M sessions reaches N
+func (t *sessionSystemEventCreatorTask) getMissedNofMSystemEvents(currSeqs []model.ValidatorSessionSeq, syncable *model.Syncable) ([]model.SystemEvent, error) {
+	var systemEvents []model.SystemEvent
+
+	if !t.rules.isEnabled(model.SystemEventMissedNofM) {
+		return systemEvents, nil
+	}
+
+	var anyOffline bool
+	for _, seq := range currSeqs {
+		if !seq.Online {
+			anyOffline = true
+			break
+		}
+	}
+	if !anyOffline {
+		return systemEvents, nil
+	}
+
+	// window ending in previous session is needed to tell if threshold was crossed in current session
+	window := t.rules.missedNofMWindow
+	prevSeqs, err := t.validatorSessionSeqDb.FindBySessionRange(syncable.Session-window, syncable.Session-1)
+	if err != nil {
+		return nil, err
+	}
+
+	offlineSessions := make(map[string][]int64)
+	for _, seq := range prevSeqs {
+		if !seq.Online {
+			offlineSessions[seq.StashAccount] = append(offlineSessions[seq.StashAccount], seq.Session)
+		}
+	}
+
+	oldestSession := syncable.Session - window
+	for _, seq := range currSeqs {
+		if seq.Online {
+			continue
+		}
+
+		var prevMissed, missed int64
+		for _, session := range offlineSessions[seq.StashAccount] {
+			prevMissed++
+			if session > oldestSession {
+				missed++
+			}
+		}
+		missed++
+
+		if missed < t.rules.missedNofMThreshold || prevMissed >= t.rules.missedNofMThreshold {
+			continue
+		}
+
+		newSystemEvent, err := t.rules.newSystemEvent(seq.StashAccount, syncable, model.SystemEventMissedNofM, model.MissedNofMData{
+			Missed:           missed,
+			Threshold:        t.rules.missedNofMThreshold,
+			MaxTotalSessions: window,
+		})
+		if err != nil {
+			return nil, err
+		}
+		systemEvents = append(systemEvents, newSystemEvent)
+	}
+	return systemEvents, nil
+}
+
 func (t *sessionSystemEventCreatorTask) getActiveSetPresenceChangeSystemEvents(currSeqs, prevSeqs []model.ValidatorSessionSeq, syncable *model.Syncable) ([]model.SystemEvent, error) {
 	var systemEvents []model.SystemEvent
 
@@ -294,18 +384,22 @@ func (t *sessionSystemEventCreatorTask) getActiveSetPresenceChangeSystemEvents(c
 	var i, j int
 	for i < len(currSeqs) || j < len(prevSeqs) {
 		if (i >= len(currSeqs)) || (j < len(prevSeqs) && prevSeqs[j].StashAccount < currSeqs[i].StashAccount) {
-			newSystemEvent, err := newSystemEvent(prevSeqs[j].StashAccount, syncable, model.SystemEventLeftSet, nil)
-			if err != nil {
-				return nil, err
+			if t.rules.isEnabled(model.SystemEventLeftSet) {
+				newSystemEvent, err := t.rules.newSystemEvent(prevSeqs[j].StashAccount, syncable, model.SystemEventLeftSet, nil)
+				if err != nil {
+					return nil, err
+				}
+				systemEvents = append(systemEvents, newSystemEvent)
 			}
-			systemEvents = append(systemEvents, newSystemEvent)
 			j++
 		} else if j >= len(prevSeqs) || prevSeqs[j].StashAccount > currSeqs[i].StashAccount {
-			newSystemEvent, err := newSystemEvent(currSeqs[i].StashAccount, syncable, model.SystemEventJoinedSet, nil)
-			if err != nil {
-				return nil, err
+			if t.rules.isEnabled(model.SystemEventJoinedSet) {
+				newSystemEvent, err := t.rules.newSystemEvent(currSeqs[i].StashAccount, syncable, model.SystemEventJoinedSet, nil)
+				if err != nil {
+					return nil, err
+				}
+				systemEvents = append(systemEvents, newSystemEvent)
 			}
-			systemEvents = append(systemEvents, newSystemEvent)
 			i++
 		} else {
 			i++
@@ -356,11 +450,11 @@ func (t *eraSystemEventCreatorTask) getDelegationChangedSystemEvents(currSeqs, p
 			}
 		}
 
-		if len(joined) == 0 {
+		if len(joined) == 0 || !t.rules.isEnabled(model.SystemEventDelegationJoined) {
 			continue
 		}
 
-		newSystemEvent, err := newSystemEvent(v, syncable, model.SystemEventDelegationJoined, model.DelegationChangeData{
+		newSystemEvent, err := t.rules.newSystemEvent(v, syncable, model.SystemEventDelegationJoined, model.DelegationChangeData{
 			StashAccounts: joined,
 		})
 		if err != nil {
@@ -384,11 +478,11 @@ func (t *eraSystemEventCreatorTask) getDelegationChangedSystemEvents(currSeqs, p
 			}
 		}
 
-		if len(left) == 0 {
+		if len(left) == 0 || !t.rules.isEnabled(model.SystemEventDelegationLeft) {
 			continue
 		}
 
-		newSystemEvent, err := newSystemEvent(v, syncable, model.SystemEventDelegationLeft, model.DelegationChangeData{
+		newSystemEvent, err := t.rules.newSystemEvent(v, syncable, model.SystemEventDelegationLeft, model.DelegationChangeData{
 			StashAccounts: left,
 		})
 		if err != nil {
@@ -400,6 +494,126 @@ func (t *eraSystemEventCreatorTask) getDelegationChangedSystemEvents(currSeqs, p
 	return systemEvents, nil
 }
 
+type stakeLookup map[string]types.Quantity
+
+func getStakesForValidators(seqs []model.AccountEraSeq) map[string]stakeLookup {
+	stakesForValidator := make(map[string]stakeLookup, len(seqs))
+	for _, seq := range seqs {
+		stakes, ok := stakesForValidator[seq.ValidatorStashAccount]
+		if !ok {
+			stakes = make(stakeLookup)
+			stakesForValidator[seq.ValidatorStashAccount] = stakes
+		}
+		stakes[seq.StashAccount] = seq.Stake
+	}
+	return stakesForValidator
+}
+
+func (t *eraSystemEventCreatorTask) getDelegationStakeChangedSystemEvents(currSeqs, prevSeqs []model.AccountEraSeq, syncable *model.Syncable) ([]model.SystemEvent, error) {
+	var systemEvents []model.SystemEvent
+
+	prevStakesForValidator := getStakesForValidators(prevSeqs)
+	currStakesForValidator := getStakesForValidators(currSeqs)
+
+	for v, currStakes := range currStakesForValidator {
+		prevStakes, ok := prevStakesForValidator[v]
+		if !ok {
+			// validator wasnt active in previous era
+			continue
+		}
+
+		increased := []model.DelegationStakeChange{}
+		decreased := []model.DelegationStakeChange{}
+		for d, currStake := range currStakes {
+			prevStake, ok := prevStakes[d]
+			if !ok {
+				// delegation joined, it's reported by delegation_joined event
+				continue
+			}
+
+			change := model.DelegationStakeChange{StashAccount: d, Before: prevStake, After: currStake}
+			switch currStake.Cmp(&prevStake.Int) {
+			case 1:
+				increased = append(increased, change)
+			case -1:
+				decreased = append(decreased, change)
+			}
+		}
+
+		sort.Slice(increased, func(i, j int) bool { return increased[i].StashAccount < increased[j].StashAccount })
+		sort.Slice(decreased, func(i, j int) bool { return decreased[i].StashAccount < decreased[j].StashAccount })
+
+		for _, c := range []struct {
+			kind        model.SystemEventKind
+			delegations []model.DelegationStakeChange
+		}{
+			{model.SystemEventDelegationIncreased, increased},
+			{model.SystemEventDelegationDecreased, decreased},
+		} {
+			if len(c.delegations) == 0 || !t.rules.isEnabled(c.kind) {
+				continue
+			}
+
+			newSystemEvent, err := t.rules.newSystemEvent(v, syncable, c.kind, &model.DelegationStakeChangeData{
+				Delegations: c.delegations,
+			})
+			if err != nil {
+				return nil, err
+			}
+			systemEvents = append(systemEvents, newSystemEvent)
+		}
+	}
+
+	return systemEvents, nil
+}
+
+func (t *eraSystemEventCreatorTask) getTotalDelegatedChangeSystemEvents(currSeqs, prevSeqs []model.AccountEraSeq, syncable *model.Syncable) ([]model.SystemEvent, error) {
+	var systemEvents []model.SystemEvent
+
+	if !t.rules.isEnabled(model.SystemEventTotalDelegatedChange) {
+		return systemEvents, nil
+	}
+
+	prevStakesForValidator := getStakesForValidators(prevSeqs)
+	currStakesForValidator := getStakesForValidators(currSeqs)
+
+	for v, currStakes := range currStakesForValidator {
+		prevStakes, ok := prevStakesForValidator[v]
+		if !ok {
+			// validator wasnt active in previous era
+			continue
+		}
+
+		currTotal := sumStakes(currStakes)
+		prevTotal := sumStakes(prevStakes)
+		changeRate := getRoundedQuantityChangeRate(currTotal, prevTotal)
+
+		if !t.rules.isTotalDelegatedChange(currTotal, prevTotal, changeRate) {
+			continue
+		}
+
+		newSystemEvent, err := t.rules.newSystemEvent(v, syncable, model.SystemEventTotalDelegatedChange, &model.TotalDelegatedChangeData{
+			Before: prevTotal,
+			After:  currTotal,
+			Change: changeRate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		systemEvents = append(systemEvents, newSystemEvent)
+	}
+
+	return systemEvents, nil
+}
+
+func sumStakes(stakes stakeLookup) types.Quantity {
+	total := types.NewQuantityFromInt64(0)
+	for _, stake := range stakes {
+		total.Add(stake)
+	}
+	return total
+}
+
 func (t *eraSystemEventCreatorTask) getPrevValidatorEraSequences(payload *payload) (prevEraSequences []model.ValidatorEraSeq, err error) {
 	if payload.CurrentHeight > t.cfg.FirstBlockHeight {
 		prevEraSequences, err = t.validatorEraSeqDb.FindByEra(payload.Syncable.Era - 1)
@@ -433,27 +647,12 @@ func (t *systemEventCreatorTask) getActiveBalanceChangeSystemEvents(currValidato
 }
 
 func (t *systemEventCreatorTask) getActiveBalanceChange(currValidatorSeq model.ValidatorSeq, prevValidatorSeq model.ValidatorSeq, syncable *model.Syncable) (model.SystemEvent, error) {
-	currValue := currValidatorSeq.ActiveBalance.Int64()
-	prevValue := prevValidatorSeq.ActiveBalance.Int64()
-	roundedChangeRate := getRoundedChangeRate(currValue, prevValue)
-	roundedAbsChangeRate := math.Abs(roundedChangeRate)
-
-	var kind model.SystemEventKind
-	if roundedAbsChangeRate >= 0.1 && roundedAbsChangeRate < 1 {
-		kind = model.SystemEventActiveBalanceChange1
-	} else if roundedAbsChangeRate >= 1 && roundedAbsChangeRate < 10 {
-		kind = model.SystemEventActiveBalanceChange2
-	} else if roundedAbsChangeRate >= 10 {
-		kind = model.SystemEventActiveBalanceChange3
-	} else {
-		return model.SystemEvent{}, ErrActiveBalanceOutsideOfRange
+	systemEvent, ok, err := t.rules.newChangeSystemEvent(currValidatorSeq.StashAccount, syncable, currValidatorSeq.ActiveBalance.Int64(), prevValidatorSeq.ActiveBalance.Int64(),
+		t.rules.activeBalanceChangeBuckets, activeBalanceChangeKinds)
+	if err == nil && !ok {
+		return systemEvent, ErrActiveBalanceOutsideOfRange
 	}
-
-	return newSystemEvent(currValidatorSeq.StashAccount, syncable, kind, model.PercentChangeData{
-		Before: prevValue,
-		After:  currValue,
-		Change: roundedChangeRate,
-	})
+	return systemEvent, err
 }
 
 func (t *eraSystemEventCreatorTask) getCommissionChangeSystemEvents(currSeqs, prevSeqs []model.ValidatorEraSeq, syncable *model.Syncable) ([]model.SystemEvent, error) {
@@ -481,53 +680,10 @@ func (t *eraSystemEventCreatorTask) getCommissionChangeSystemEvents(currSeqs, pr
 }
 
 func (t *eraSystemEventCreatorTask) getCommissionChange(currSeq, prevSeq model.ValidatorEraSeq, syncable *model.Syncable) (model.SystemEvent, error) {
-	currValue := currSeq.Commission
-	prevValue := prevSeq.Commission
-	roundedChangeRate := getRoundedChangeRate(currValue, prevValue)
-	roundedAbsChangeRate := math.Abs(roundedChangeRate)
-
-	var kind model.SystemEventKind
-	if roundedAbsChangeRate >= 0.1 && roundedAbsChangeRate < 1 {
-		kind = model.SystemEventCommissionChange1
-	} else if roundedAbsChangeRate >= 1 && roundedAbsChangeRate < 10 {
-		kind = model.SystemEventCommissionChange2
-	} else if roundedAbsChangeRate >= 10 {
-		kind = model.SystemEventCommissionChange3
-	} else {
-		return model.SystemEvent{}, ErrCommissionOutsideOfRange
-	}
-
-	return newSystemEvent(currSeq.StashAccount, syncable, kind, model.PercentChangeData{
-		Before: prevValue,
-		After:  currValue,
-		Change: roundedChangeRate,
-	})
-}
-
-func getRoundedChangeRate(currValue int64, prevValue int64) float64 {
-	var changeRate float64
-
-	if prevValue == 0 {
-		changeRate = float64(currValue)
-	} else {
-		changeRate = (float64(1) - (float64(currValue) / float64(prevValue))) * 100
+	systemEvent, ok, err := t.rules.newChangeSystemEvent(currSeq.StashAccount, syncable, currSeq.Commission, prevSeq.Commission,
+		t.rules.commissionChangeBuckets, commissionChangeKinds)
+	if err == nil && !ok {
+		return systemEvent, ErrCommissionOutsideOfRange
 	}
-
-	roundedChangeRate := math.Round(changeRate/0.1) * 0.1
-	return roundedChangeRate
-}
-
-func newSystemEvent(stashAccount string, syncable *model.Syncable, kind model.SystemEventKind, data interface{}) (model.SystemEvent, error) {
-	marshaledData, err := json.Marshal(data)
-	if err != nil {
-		return model.SystemEvent{}, err
-	}
-
-	return model.SystemEvent{
-		Height: syncable.Height,
-		Time:   syncable.Time,
-		Actor:  stashAccount,
-		Kind:   kind,
-		Data:   types.Jsonb{RawMessage: marshaledData},
-	}, nil
+	return systemEvent, err
 }
@@ -3,6 +3,7 @@ package indexer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -20,7 +21,15 @@ const (
 
 var (
 	testCfg = &config.Config{
-		FirstBlockHeight: 1,
+		FirstBlockHeight:                      1,
+		SystemEventRulesVersion:               1,
+		SystemEventMissedConsecutive:          1,
+		SystemEventMissedNofMThreshold:        3,
+		SystemEventMissedNofMWindow:           10,
+		SystemEventActiveBalanceChangeBuckets: []float64{0.1, 1, 10},
+		SystemEventCommissionChangeBuckets:    []float64{0.1, 1, 10},
+		SystemEventTotalDelegatedChange:       10,
+		SystemEventTotalDelegatedChangeMin:    "100",
 	}
 )
 
@@ -43,13 +52,17 @@ func TestSystemEventCreatorTask_getActiveBalanceChangeSystemEvents(t *testing.T)
 	}{
 		{"returns no system events when active balance haven't changed", 0, 0, ""},
 		{"returns no system events when active balance change smaller than 0.1", 0.09, 0, ""},
-		{"returns one activeBalanceChange1 system event when active balance change is 0.1", 0.1, 1, model.SystemEventActiveBalanceChange1},
-		{"returns one activeBalanceChange1 system events when active balance change is 0.9", 0.9, 1, model.SystemEventActiveBalanceChange1},
-		{"returns one activeBalanceChange2 system events when active balance change is 1", 1, 1, model.SystemEventActiveBalanceChange2},
-		{"returns one activeBalanceChange2 system events when active balance change is 9", 9, 1, model.SystemEventActiveBalanceChange2},
-		{"returns one activeBalanceChange3 system events when active balance change is 10", 10, 1, model.SystemEventActiveBalanceChange3},
-		{"returns one activeBalanceChange3 system events when active balance change is 100", 100, 1, model.SystemEventActiveBalanceChange3},
-		{"returns one activeBalanceChange3 system events when active balance change is 200", 200, 1, model.SystemEventActiveBalanceChange3},
+		{"returns one activeBalanceIncrease1 system event when active balance change is 0.1", 0.1, 1, model.SystemEventActiveBalanceIncrease1},
+		{"returns one activeBalanceIncrease1 system events when active balance change is 0.9", 0.9, 1, model.SystemEventActiveBalanceIncrease1},
+		{"returns one activeBalanceIncrease2 system events when active balance change is 1", 1, 1, model.SystemEventActiveBalanceIncrease2},
+		{"returns one activeBalanceIncrease2 system events when active balance change is 9", 9, 1, model.SystemEventActiveBalanceIncrease2},
+		{"returns one activeBalanceIncrease3 system events when active balance change is 10", 10, 1, model.SystemEventActiveBalanceIncrease3},
+		{"returns one activeBalanceIncrease3 system events when active balance change is 100", 100, 1, model.SystemEventActiveBalanceIncrease3},
+		{"returns one activeBalanceIncrease3 system events when active balance change is 200", 200, 1, model.SystemEventActiveBalanceIncrease3},
+		{"returns one activeBalanceDecrease1 system event when active balance change is -0.1", -0.1, 1, model.SystemEventActiveBalanceDecrease1},
+		{"returns one activeBalanceDecrease2 system event when active balance change is -1", -1, 1, model.SystemEventActiveBalanceDecrease2},
+		{"returns one activeBalanceDecrease3 system event when active balance change is -10", -10, 1, model.SystemEventActiveBalanceDecrease3},
+		{"returns one activeBalanceDecrease3 system event when active balance change is -100", -100, 1, model.SystemEventActiveBalanceDecrease3},
 	}
 
 	for _, tt := range tests {
@@ -109,12 +122,15 @@ func TestSystemEventCreatorTask_getCommissionChangeSystemEvents(t *testing.T) {
 	}{
 		{"returns no system events when commission haven't changed", 0, 0, ""},
 		{"returns no system events when commission change smaller than 0.1", 0.09, 0, ""},
-		{"returns one commissionChange1 system event when commission change is 0.1", 0.1, 1, model.SystemEventCommissionChange1},
-		{"returns one commissionChange1 system events when commission change is 0.9", 0.9, 1, model.SystemEventCommissionChange1},
-		{"returns one commissionChange2 system events when commission change is 1", 1, 1, model.SystemEventCommissionChange2},
-		{"returns one commissionChange2 system events when commission change is 9", 9, 1, model.SystemEventCommissionChange2},
-		{"returns one commissionChange3 system events when commission change is 10", 10, 1, model.SystemEventCommissionChange3},
-		{"returns one commissionChange3 system events when commission change is 100", 100, 1, model.SystemEventCommissionChange3},
+		{"returns one commissionIncrease1 system event when commission change is 0.1", 0.1, 1, model.SystemEventCommissionIncrease1},
+		{"returns one commissionIncrease1 system events when commission change is 0.9", 0.9, 1, model.SystemEventCommissionIncrease1},
+		{"returns one commissionIncrease2 system events when commission change is 1", 1, 1, model.SystemEventCommissionIncrease2},
+		{"returns one commissionIncrease2 system events when commission change is 9", 9, 1, model.SystemEventCommissionIncrease2},
+		{"returns one commissionIncrease3 system events when commission change is 10", 10, 1, model.SystemEventCommissionIncrease3},
+		{"returns one commissionIncrease3 system events when commission change is 100", 100, 1, model.SystemEventCommissionIncrease3},
+		{"returns one commissionDecrease1 system event when commission change is -0.1", -0.1, 1, model.SystemEventCommissionDecrease1},
+		{"returns one commissionDecrease2 system event when commission change is -9", -9, 1, model.SystemEventCommissionDecrease2},
+		{"returns one commissionDecrease3 system event when commission change is -100", -100, 1, model.SystemEventCommissionDecrease3},
 	}
 
 	for _, tt := range tests {
@@ -300,10 +316,11 @@ func TestSystemEventCreatorTask_getMissedBlocksSystemEvents(t *testing.T) {
 		t.Run(tt.description, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
-			missedConsecutiveThreshold = tt.missedConsecutiveThreshold
 			systemEventStoreMock := mock.NewMockSystemEvents(ctrl)
 
-			task := NewSessionSystemEventCreatorTask(testCfg, nil, systemEventStoreMock, nil, nil)
+			cfg := *testCfg
+			cfg.SystemEventMissedConsecutive = tt.missedConsecutiveThreshold
+			task := NewSessionSystemEventCreatorTask(&cfg, nil, systemEventStoreMock, nil, nil)
 
 			kind := model.SystemEventMissedNConsecutive
 			for _, seq := range tt.currSeqs {
@@ -352,6 +369,97 @@ func TestSystemEventCreatorTask_getMissedBlocksSystemEvents(t *testing.T) {
 	}
 }
 
+func TestSystemEventCreatorTask_getMissedNofMSystemEvents(t *testing.T) {
+	testSyncable := &model.Syncable{Height: 100, Session: 50}
+	testErr := errors.New("test err")
+
+	offlineSeq := func(session int64, stash string) model.ValidatorSessionSeq {
+		return model.ValidatorSessionSeq{SessionSequence: &model.SessionSequence{Session: session}, StashAccount: stash}
+	}
+
+	tests := []struct {
+		description string
+		currSeqs    []model.ValidatorSessionSeq
+		prevSeqs    []model.ValidatorSessionSeq
+		dbErr       error
+
+		expectedMissed []int64
+		expectedErr    error
+	}{
+		{
+			description: "returns no system events when validators are online",
+			currSeqs:    []model.ValidatorSessionSeq{{StashAccount: testValidatorAddress, Online: true}},
+		},
+		{
+			description: "returns no system events when offline sessions are below threshold",
+			currSeqs:    []model.ValidatorSessionSeq{offlineSeq(50, testValidatorAddress)},
+			prevSeqs:    []model.ValidatorSessionSeq{offlineSeq(45, testValidatorAddress)},
+		},
+		{
+			description:    "returns system event when offline sessions reach threshold",
+			currSeqs:       []model.ValidatorSessionSeq{offlineSeq(50, testValidatorAddress)},
+			prevSeqs:       []model.ValidatorSessionSeq{offlineSeq(41, testValidatorAddress), offlineSeq(49, testValidatorAddress)},
+			expectedMissed: []int64{3},
+		},
+		{
+			description: "returns no system events when threshold was already reached in previous window",
+			currSeqs:    []model.ValidatorSessionSeq{offlineSeq(50, testValidatorAddress)},
+			prevSeqs:    []model.ValidatorSessionSeq{offlineSeq(41, testValidatorAddress), offlineSeq(42, testValidatorAddress), offlineSeq(49, testValidatorAddress)},
+		},
+		{
+			description: "returns no system events when offline session leaves window",
+			currSeqs:    []model.ValidatorSessionSeq{offlineSeq(50, testValidatorAddress)},
+			prevSeqs:    []model.ValidatorSessionSeq{offlineSeq(40, testValidatorAddress), offlineSeq(49, testValidatorAddress)},
+		},
+		{
+			description: "returns error if db errors",
+			currSeqs:    []model.ValidatorSessionSeq{offlineSeq(50, testValidatorAddress)},
+			dbErr:       testErr,
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			validatorSessionSeqStoreMock := mock.NewMockValidatorSessionSeq(ctrl)
+			if len(tt.prevSeqs) > 0 || tt.dbErr != nil {
+				validatorSessionSeqStoreMock.EXPECT().FindBySessionRange(int64(40), int64(49)).Return(tt.prevSeqs, tt.dbErr).Times(1)
+			}
+
+			task := NewSessionSystemEventCreatorTask(testCfg, nil, nil, nil, validatorSessionSeqStoreMock)
+
+			createdSystemEvents, err := task.getMissedNofMSystemEvents(tt.currSeqs, testSyncable)
+			if err != tt.expectedErr {
+				t.Errorf("unexpected error, want %v; got %v", tt.expectedErr, err)
+				return
+			}
+
+			if len(createdSystemEvents) != len(tt.expectedMissed) {
+				t.Errorf("unexpected system event count, want %v; got %v", len(tt.expectedMissed), len(createdSystemEvents))
+				return
+			}
+
+			for i, missed := range tt.expectedMissed {
+				if createdSystemEvents[i].Kind != model.SystemEventMissedNofM {
+					t.Errorf("unexpected system event kind, want %v; got %v", model.SystemEventMissedNofM, createdSystemEvents[i].Kind)
+				}
+
+				data := &model.MissedNofMData{}
+				if err := json.Unmarshal(createdSystemEvents[i].Data.RawMessage, data); err != nil {
+					t.Errorf("unexpected error when unmarshalling data: %v", err)
+					return
+				}
+				if data.Missed != missed || data.Threshold != 3 || data.MaxTotalSessions != 10 {
+					t.Errorf("unexpected data, want missed %v of 10 with threshold 3; got %+v", missed, data)
+				}
+			}
+		})
+	}
+}
+
 func TestSystemEventCreatorTask_getDelegationChangedSystemEvents(t *testing.T) {
 	currSyncable := &model.Syncable{
 		Height: 20,
@@ -532,3 +640,186 @@ func TestSystemEventCreatorTask_getDelegationChangedSystemEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestEraSystemEventCreatorTask_getDelegationStakeChangedSystemEvents(t *testing.T) {
+	currSyncable := &model.Syncable{
+		Height: 20,
+		Time:   *types.NewTimeFromTime(time.Date(2020, 11, 10, 23, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		description         string
+		prevSeqs            []model.AccountEraSeq
+		currSeqs            []model.AccountEraSeq
+		expectedKinds       []model.SystemEventKind
+		expectedDelegations []model.DelegationStakeChange
+	}{
+		{
+			description: "returns no system events when stake hasn't changed",
+			prevSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+			currSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+		},
+		{
+			description: "returns no system events when delegator joined",
+			prevSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+			currSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+				{StashAccount: "addr2", ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+		},
+		{
+			description: "returns delegation_increased event when stake increased",
+			prevSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+			currSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(150)},
+			},
+			expectedKinds: []model.SystemEventKind{model.SystemEventDelegationIncreased},
+			expectedDelegations: []model.DelegationStakeChange{
+				{StashAccount: testDelegatorAddress, Before: types.NewQuantityFromInt64(100), After: types.NewQuantityFromInt64(150)},
+			},
+		},
+		{
+			description: "returns delegation_decreased event when stake decreased",
+			prevSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+			currSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(50)},
+			},
+			expectedKinds: []model.SystemEventKind{model.SystemEventDelegationDecreased},
+			expectedDelegations: []model.DelegationStakeChange{
+				{StashAccount: testDelegatorAddress, Before: types.NewQuantityFromInt64(100), After: types.NewQuantityFromInt64(50)},
+			},
+		},
+		{
+			description: "returns delegation_increased and delegation_decreased events for validator",
+			prevSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+				{StashAccount: "addr2", ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(100)},
+			},
+			currSeqs: []model.AccountEraSeq{
+				{StashAccount: testDelegatorAddress, ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(200)},
+				{StashAccount: "addr2", ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(10)},
+			},
+			expectedKinds: []model.SystemEventKind{model.SystemEventDelegationIncreased, model.SystemEventDelegationDecreased},
+			expectedDelegations: []model.DelegationStakeChange{
+				{StashAccount: testDelegatorAddress, Before: types.NewQuantityFromInt64(100), After: types.NewQuantityFromInt64(200)},
+				{StashAccount: "addr2", Before: types.NewQuantityFromInt64(100), After: types.NewQuantityFromInt64(10)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+
+			task := NewEraSystemEventCreatorTask(testCfg, nil, nil)
+			createdSystemEvents, err := task.getDelegationStakeChangedSystemEvents(tt.currSeqs, tt.prevSeqs, currSyncable)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if len(createdSystemEvents) != len(tt.expectedKinds) {
+				t.Errorf("unexpected system event count, want %v; got %v", len(tt.expectedKinds), len(createdSystemEvents))
+				return
+			}
+
+			for i, event := range createdSystemEvents {
+				if event.Kind != tt.expectedKinds[i] {
+					t.Errorf("unexpected system event kind, want %v; got %v", tt.expectedKinds[i], event.Kind)
+				}
+
+				data := &model.DelegationStakeChangeData{}
+				if err := json.Unmarshal(event.Data.RawMessage, data); err != nil {
+					t.Errorf("unexpected err when unmarshalling data: %v", err)
+					return
+				}
+
+				if len(data.Delegations) != 1 {
+					t.Errorf("unexpected delegations count, want %v; got %v", 1, len(data.Delegations))
+					return
+				}
+
+				expected := tt.expectedDelegations[i]
+				got := data.Delegations[0]
+				if got.StashAccount != expected.StashAccount || !got.Before.Equals(expected.Before) || !got.After.Equals(expected.After) {
+					t.Errorf("unexpected delegation, want %v %v %v; got %v %v %v", expected.StashAccount, expected.Before.String(), expected.After.String(),
+						got.StashAccount, got.Before.String(), got.After.String())
+				}
+			}
+		})
+	}
+}
+
+func TestEraSystemEventCreatorTask_getTotalDelegatedChangeSystemEvents(t *testing.T) {
+	currSyncable := &model.Syncable{
+		Height: 20,
+		Time:   *types.NewTimeFromTime(time.Date(2020, 11, 10, 23, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		description  string
+		prevStakes   []int64
+		currStakes   []int64
+		expectedData string
+	}{
+		{"returns no system events when total delegated hasn't changed", []int64{1000, 1000}, []int64{1500, 500}, ""},
+		{"returns no system events when change is below percentage threshold", []int64{1000, 1000}, []int64{1000, 1190}, ""},
+		{"returns no system events when change is below minimum amount", []int64{500}, []int64{590}, ""},
+		{"returns system event when total delegated increased", []int64{1000, 1000}, []int64{1000, 1200}, `{"after":2200,"before":2000,"change":10,"rule_version":1}`},
+		{"returns system event when total delegated decreased", []int64{1000, 1000}, []int64{500, 500}, `{"after":1000,"before":2000,"change":-50,"rule_version":1}`},
+		{"returns system event when total delegated increased from 0", []int64{0}, []int64{100}, `{"after":100,"before":0,"change":null,"rule_version":1}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+
+			var prevSeqs, currSeqs []model.AccountEraSeq
+			for i, stake := range tt.prevStakes {
+				prevSeqs = append(prevSeqs, model.AccountEraSeq{StashAccount: fmt.Sprintf("addr%d", i), ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(stake)})
+			}
+			for i, stake := range tt.currStakes {
+				currSeqs = append(currSeqs, model.AccountEraSeq{StashAccount: fmt.Sprintf("addr%d", i), ValidatorStashAccount: testValidatorAddress, Stake: types.NewQuantityFromInt64(stake)})
+			}
+
+			task := NewEraSystemEventCreatorTask(testCfg, nil, nil)
+			createdSystemEvents, err := task.getTotalDelegatedChangeSystemEvents(currSeqs, prevSeqs, currSyncable)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if tt.expectedData == "" {
+				if len(createdSystemEvents) != 0 {
+					t.Errorf("unexpected system event count, want %v; got %v", 0, len(createdSystemEvents))
+				}
+				return
+			}
+
+			if len(createdSystemEvents) != 1 {
+				t.Errorf("unexpected system event count, want %v; got %v", 1, len(createdSystemEvents))
+				return
+			}
+
+			if createdSystemEvents[0].Kind != model.SystemEventTotalDelegatedChange {
+				t.Errorf("unexpected system event kind, want %v; got %v", model.SystemEventTotalDelegatedChange, createdSystemEvents[0].Kind)
+			}
+
+			if string(createdSystemEvents[0].Data.RawMessage) != tt.expectedData {
+				t.Errorf("unexpected data, want %v; got %v", tt.expectedData, string(createdSystemEvents[0].Data.RawMessage))
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func NewFetcherTask(client FetcherClient) pipeline.Task {
 }
 
 type FetcherClient interface {
-	GetAll(int64) (*heightpb.GetAllResponse, error)
+	GetAll(context.Context, int64) (*heightpb.GetAllResponse, error)
 }
 
 type FetcherTask struct {
@@ -54,7 +54,7 @@ func (t *FetcherTask) Run(ctx context.Context, p pipeline.Payload) error {
 
 	logger.Info(fmt.Sprintf("running indexer task [stage=%s] [task=%s] [height=%d]", pipeline.StageFetcher, t.GetName(), payload.CurrentHeight))
 
-	resp, err := t.client.GetAll(payload.CurrentHeight)
+	resp, err := t.client.GetAll(ctx, payload.CurrentHeight)
 	if err != nil {
 		return err
 	}
@@ -104,7 +104,7 @@ func (t *ValidatorFetcherTask) GetName() string {
 
 func (t *ValidatorFetcherTask) Run(ctx context.Context, p pipeline.Payload) error {
 	payload := p.(*payload)
-	validators, err := t.client.GetByHeight(payload.CurrentHeight)
+	validators, err := t.client.GetByHeight(ctx, payload.CurrentHeight)
 	if err != nil {
 		return err
 	}
@@ -137,7 +137,7 @@ func (t *ValidatorPerformanceFetcherTask) GetName() string {
 
 func (t *ValidatorPerformanceFetcherTask) Run(ctx context.Context, p pipeline.Payload) error {
 	payload := p.(*payload)
-	validators, err := t.client.GetByHeight(payload.CurrentHeight)
+	validators, err := t.client.GetByHeight(ctx, payload.CurrentHeight)
 	if err != nil {
 		return err
 	}
@@ -31,7 +31,7 @@ func TestFetcher_Run(t *testing.T) {
 
 		pl := &payload{CurrentHeight: 20}
 
-		mockClient.EXPECT().GetAll(pl.CurrentHeight).Return(nil, errTestClient).Times(1)
+		mockClient.EXPECT().GetAll(gomock.Any(), pl.CurrentHeight).Return(nil, errTestClient).Times(1)
 
 		if err := task.Run(ctx, pl); err != errTestClient {
 			t.Errorf("want %v; got %v", errTestClient, err)
@@ -65,7 +65,7 @@ func TestFetcher_Run(t *testing.T) {
 
 		pl := &payload{CurrentHeight: 20}
 
-		mockClient.EXPECT().GetAll(pl.CurrentHeight).Return(&heightpb.GetAllResponse{
+		mockClient.EXPECT().GetAll(gomock.Any(), pl.CurrentHeight).Return(&heightpb.GetAllResponse{
 			Block: &blockpb.GetByHeightResponse{Block: expectBlock},
 			Chain: &chainpb.GetMetaByHeightResponse{
 				Chain:         expectHeightMeta.ChainUID,
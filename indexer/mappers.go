@@ -3,6 +3,8 @@ package indexer
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
+	"sort"
 
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/types"
@@ -22,6 +24,7 @@ var (
 	ErrAccountEraSequenceNotValid       = errors.New("account era sequence not valid")
 	ErrEventSequenceNotValid            = errors.New("event sequence not valid")
 	ErrTransactionSequenceNotValid      = errors.New("transaction sequence not valid")
+	ErrEraSummaryNotValid               = errors.New("era summary not valid")
 )
 
 func ToBlockSequence(syncable *model.Syncable, rawBlock *blockpb.Block, blockParsedData ParsedBlockData) (*model.BlockSeq, error) {
@@ -126,6 +129,64 @@ func ToValidatorEraSequence(syncable *model.Syncable, firstHeight int64, rawStak
 	return validators, nil
 }
 
+// ToEraSummary aggregates staking of era and its validator era sequences
+func ToEraSummary(syncable *model.Syncable, firstHeight int64, rawStaking *stakingpb.Staking, validatorEraSeqs []model.ValidatorEraSeq) (*model.EraSummary, error) {
+	e := &model.EraSummary{
+		EraSequence: &model.EraSequence{
+			Era:         syncable.Era,
+			StartHeight: firstHeight,
+			EndHeight:   syncable.Height,
+			Time:        syncable.Time,
+		},
+
+		TotalStake:            types.NewQuantityFromInt64(rawStaking.GetTotalStake()),
+		ActiveValidatorsCount: int64(len(validatorEraSeqs)),
+		TotalRewardPoints:     rawStaking.GetTotalRewardPoints(),
+	}
+
+	if payout := rawStaking.GetTotalRewardPayout(); payout != "" {
+		totalRewardPayout, err := types.NewQuantityFromString(payout)
+		if err != nil {
+			return nil, err
+		}
+		e.TotalRewardPayout = totalRewardPayout
+	}
+
+	nominators := map[string]struct{}{}
+	for _, rawValidator := range rawStaking.GetValidators() {
+		for _, staker := range rawValidator.GetStakers() {
+			nominators[staker.GetStashAccount()] = struct{}{}
+		}
+	}
+	e.NominatorsCount = int64(len(nominators))
+
+	if len(validatorEraSeqs) > 0 {
+		stakes := make([]*big.Int, len(validatorEraSeqs))
+		var commissionSum int64
+		for i := range validatorEraSeqs {
+			stakes[i] = new(big.Int).Set(&validatorEraSeqs[i].TotalStake.Int)
+			commissionSum += validatorEraSeqs[i].Commission
+		}
+		sort.Slice(stakes, func(i, j int) bool { return stakes[i].Cmp(stakes[j]) < 0 })
+
+		median := new(big.Int).Set(stakes[len(stakes)/2])
+		if len(stakes)%2 == 0 {
+			median.Add(median, stakes[len(stakes)/2-1])
+			median.Div(median, big.NewInt(2))
+		}
+
+		e.ValidatorStakeMin = types.NewQuantity(stakes[0])
+		e.ValidatorStakeMax = types.NewQuantity(stakes[len(stakes)-1])
+		e.ValidatorStakeMedian = types.NewQuantity(median)
+		e.CommissionAvg = float64(commissionSum) / float64(len(validatorEraSeqs))
+	}
+
+	if !e.Valid() {
+		return nil, ErrEraSummaryNotValid
+	}
+	return e, nil
+}
+
 func ToEventSequence(syncable *model.Syncable, rawEvents []*eventpb.Event) ([]model.EventSeq, error) {
 	var events []model.EventSeq
 	for _, rawEvent := range rawEvents {
@@ -225,6 +286,7 @@ func ToTransactionSequence(syncable *model.Syncable, rawTransactions []*transact
 			Hash:    rawTx.GetHash(),
 			Section: rawTx.GetSection(),
 			Method:  rawTx.GetMethod(),
+			Signer:  rawTx.GetSigner(),
 			Args:    rawTx.GetArgs(),
 		}
 
@@ -139,7 +139,7 @@ func (t *validatorsParserTask) Run(ctx context.Context, p pipeline.Payload) erro
 	for _, rawValidatorStakingInfo := range rawStakingState.GetValidators() {
 		stashAccount := rawValidatorStakingInfo.GetStashAccount()
 
-		identity, err := t.accountClient.GetIdentity(stashAccount)
+		identity, err := t.accountClient.GetIdentity(ctx, stashAccount)
 		if err != nil {
 			return err
 		}
@@ -132,7 +132,7 @@ func TestValidatorParserTask_Run(t *testing.T) {
 
 			mockClient := mock_client.NewMockAccountClient(ctrl)
 			for _, validator := range tt.rawStakingState.GetValidators() {
-				mockClient.EXPECT().GetIdentity(validator.StashAccount).Return(&accountpb.GetIdentityResponse{Identity: &accountpb.AccountIdentity{DisplayName: ""}}, nil)
+				mockClient.EXPECT().GetIdentity(gomock.Any(), validator.StashAccount).Return(&accountpb.GetIdentityResponse{Identity: &accountpb.AccountIdentity{DisplayName: ""}}, nil)
 			}
 
 			task := NewValidatorsParserTask(nil, mockClient, nil, nil, nil)
@@ -294,7 +294,7 @@ func TestValidatorParserTask_Run(t *testing.T) {
 			ctx := context.Background()
 
 			mockClient := mock_client.NewMockAccountClient(ctrl)
-			mockClient.EXPECT().GetIdentity(gomock.Any()).Return(nil, nil)
+			mockClient.EXPECT().GetIdentity(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 			task := NewValidatorsParserTask(nil, mockClient, nil, nil, nil)
 
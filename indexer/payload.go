@@ -54,6 +54,7 @@ type payload struct {
 	// Aggregator stage
 	NewValidatorAggregates     []model.ValidatorAgg
 	UpdatedValidatorAggregates []model.ValidatorAgg
+	EraSummary                 *model.EraSummary
 
 	// Sequencer stage
 	NewBlockSequence          *model.BlockSeq
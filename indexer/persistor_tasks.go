@@ -21,6 +21,7 @@ const (
 	ValidatorSeqPersistorTaskName        = "ValidatorSeqPersistor"
 	SystemEventPersistorTaskName         = "SystemEventPersistor"
 	RewardEraSeqPersistorTaskName        = "RewardEraSeqPersistor"
+	EraSummaryPersistorTaskName          = "EraSummaryPersistor"
 )
 
 // NewSyncerPersistorTask is responsible for storing syncable to persistence layer
@@ -323,3 +324,31 @@ func (t *RewardEraSeqPersistorTask) Run(ctx context.Context, p pipeline.Payload)
 	}
 	return nil
 }
+
+// NewEraSummaryPersistorTask is responsible for storing era summary to persistence layer
+func NewEraSummaryPersistorTask(eraSummaryDb store.EraSummary) pipeline.Task {
+	return &eraSummaryPersistorTask{
+		eraSummaryDb: eraSummaryDb,
+	}
+}
+
+type eraSummaryPersistorTask struct {
+	eraSummaryDb store.EraSummary
+}
+
+func (t *eraSummaryPersistorTask) GetName() string {
+	return EraSummaryPersistorTaskName
+}
+
+func (t *eraSummaryPersistorTask) Run(ctx context.Context, p pipeline.Payload) error {
+	payload := p.(*payload)
+
+	if !payload.Syncable.LastInEra {
+		logger.Info(fmt.Sprintf("indexer task skipped because height is not last in era [stage=%s] [task=%s] [height=%d]", pipeline.StagePersistor, t.GetName(), payload.CurrentHeight))
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("running indexer task [stage=%s] [task=%s] [height=%d]", pipeline.StagePersistor, t.GetName(), payload.CurrentHeight))
+
+	return t.eraSummaryDb.UpsertEraSummary(payload.EraSummary)
+}
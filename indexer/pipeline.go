@@ -9,6 +9,7 @@ import (
 	"github.com/figment-networks/polkadothub-indexer/client"
 	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/publisher"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 )
@@ -33,6 +34,8 @@ type indexingPipeline struct {
 	configParser ConfigParser
 	pipeline     pipeline.CustomPipeline
 
+	relay *publisher.Relay
+
 	databaseDb    store.Database
 	reportDb      store.Reports
 	syncableDb    store.Syncables
@@ -90,6 +93,7 @@ func NewPipeline(cfg *config.Config, cli *client.Client, accountDb store.Account
 		pipeline.NewStageWithTasks(
 			pipeline.StageAggregator,
 			pipeline.RetryingTask(NewValidatorAggCreatorTask(validatorDb), isTransient, maxRetries),
+			pipeline.RetryingTask(NewEraSummaryCreatorTask(cfg, syncableDb), isTransient, maxRetries),
 		),
 	)
 
@@ -118,9 +122,13 @@ func NewPipeline(cfg *config.Config, cli *client.Client, accountDb store.Account
 			pipeline.RetryingTask(NewTransactionSeqPersistorTask(transactionDb), isTransient, maxRetries),
 			pipeline.RetryingTask(NewSystemEventPersistorTask(systemEventDb), isTransient, maxRetries),
 			pipeline.RetryingTask(NewRewardEraSeqPersistorTask(rewardDb), isTransient, maxRetries),
+			pipeline.RetryingTask(NewEraSummaryPersistorTask(validatorDb), isTransient, maxRetries),
 		),
 	)
 
+	// Report stage output to stage observer
+	addStageObservers(p)
+
 	// Create config parser
 	configParser, err := NewConfigParser(cfg.IndexerConfigFile)
 	if err != nil {
@@ -133,6 +141,16 @@ func NewPipeline(cfg *config.Config, cli *client.Client, accountDb store.Account
 		return nil, err
 	}
 
+	// Processed heights are published to downstream consumers through outbox
+	var relay *publisher.Relay
+	if cfg.PublisherEnabled() {
+		pub, err := publisher.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		relay = publisher.NewRelay(pub, syncableDb, cfg.PublisherBatchSize)
+	}
+
 	return &indexingPipeline{
 		cfg:    cfg,
 		client: cli,
@@ -140,6 +158,7 @@ func NewPipeline(cfg *config.Config, cli *client.Client, accountDb store.Account
 		pipeline:     p,
 		status:       pipelineStatus,
 		configParser: configParser,
+		relay:        relay,
 
 		databaseDb:    databaseDb,
 		reportDb:      reportDb,
@@ -161,7 +180,7 @@ func (p *indexingPipeline) Start(ctx context.Context, indexCfg IndexConfig) erro
 
 	indexVersion := p.configParser.GetCurrentVersionId()
 
-	source, err := NewIndexSource(p.cfg, p.syncableDb, p.client, &IndexSourceConfig{
+	source, err := NewIndexSource(ctx, p.cfg, p.syncableDb, p.client, &IndexSourceConfig{
 		BatchSize:   indexCfg.BatchSize,
 		StartHeight: indexCfg.StartHeight,
 	})
@@ -169,7 +188,7 @@ func (p *indexingPipeline) Start(ctx context.Context, indexCfg IndexConfig) erro
 		return err
 	}
 
-	sink := NewSink(p.databaseDb, p.syncableDb, indexVersion)
+	sink := NewSink(p.databaseDb, p.syncableDb, p.relay, indexVersion)
 
 	reportCreator := &reportCreator{
 		kind:         model.ReportKindIndex,
@@ -233,7 +252,7 @@ func (p *indexingPipeline) Backfill(ctx context.Context, backfillCfg BackfillCon
 		return err
 	}
 
-	sink := NewSink(p.databaseDb, p.syncableDb, indexVersion)
+	sink := NewSink(p.databaseDb, p.syncableDb, p.relay, indexVersion)
 
 	kind := model.ReportKindSequentialReindex
 	if backfillCfg.Parallel {
@@ -310,7 +329,7 @@ func (p *indexingPipeline) Reindex(ctx context.Context, cfg ReindexConfig) error
 		return err
 	}
 
-	sink := NewSink(p.databaseDb, p.syncableDb, indexVersion)
+	sink := NewSink(p.databaseDb, p.syncableDb, p.relay, indexVersion)
 
 	kind := model.ReportKindSequentialReindex
 	if cfg.Parallel {
@@ -377,6 +396,7 @@ type RunConfig struct {
 	DesiredVersionIDs []int64
 	DesiredTargetIDs  []int64
 	Dry               bool
+	StageObserver     StageObserver
 }
 
 func (p *indexingPipeline) Run(ctx context.Context, runCfg RunConfig) (*payload, error) {
@@ -398,6 +418,10 @@ func (p *indexingPipeline) Run(ctx context.Context, runCfg RunConfig) (*payload,
 		logger.Field("targets", runCfg.DesiredTargetIDs),
 	)
 
+	if runCfg.StageObserver != nil {
+		ctx = context.WithValue(ctx, CtxStageObserver, runCfg.StageObserver)
+	}
+
 	runPayload, err := p.pipeline.Run(ctx, runCfg.Height, pipelineOptions)
 	if err != nil {
 		logger.Info(fmt.Sprintf("pipeline completed with error [Err: %+v]", err))
@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/client/fakeproxy"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/utils/projectpath"
+)
+
+// TestPipeline_RunWithFakeProxy indexes height of fake proxy fixtures through client, all pipeline stages and memory store
+func TestPipeline_RunWithFakeProxy(t *testing.T) {
+	proxy, err := fakeproxy.New(filepath.Join(projectpath.Root, "client", "fakeproxy", "testdata", "fixtures"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Stop()
+
+	cli, err := client.New(proxy.Addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	cfg := config.New()
+	if err := config.FromEnv(cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.IndexerConfigFile = filepath.Join(projectpath.Root, "indexer_config.json")
+
+	db := memory.New()
+	p, err := NewPipeline(cfg, cli, db.GetAccounts(), db.GetBlocks(), db.GetDatabase(), db.GetEvents(), db.GetReports(), db.GetRewards(),
+		db.GetSyncables(), db.GetSystemEvents(), db.GetTransactions(), db.GetValidators())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Run(context.Background(), RunConfig{Height: 10, DesiredVersionIDs: p.configParser.GetAllVersionedVersionIds()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	syncable, err := db.GetSyncables().FindByHeight(10)
+	if err != nil {
+		t.Fatalf("want syncable of height: %v", err)
+	}
+	if syncable.Session != 5 || syncable.Era != 2 || !syncable.LastInSession {
+		t.Errorf("want syncable of session 5 and era 2 which is last in session; got %+v", syncable)
+	}
+
+	block, err := db.GetBlocks().FindSeqByHeight(10)
+	if err != nil {
+		t.Fatalf("want block seq of height: %v", err)
+	}
+	if block.Time.UTC().Format("2006-01-02T15:04:05Z") != "2020-05-26T10:00:00Z" {
+		t.Errorf("want block time of fixture; got %s", block.Time)
+	}
+
+	validatorSeqs, err := db.GetValidators().FindAllByHeight(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balances := make(map[string]int64)
+	for _, seq := range validatorSeqs {
+		balances[seq.StashAccount] = seq.ActiveBalance.Int64()
+	}
+	if len(balances) != 2 || balances["stash1"] != 2000 || balances["stash2"] != 1000 {
+		t.Errorf("want active balances of validators stash1 and stash2; got %v", balances)
+	}
+
+	sessionSeqs, err := db.GetValidators().FindBySession(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	online := make(map[string]bool)
+	for _, seq := range sessionSeqs {
+		online[seq.StashAccount] = seq.Online
+	}
+	if len(online) != 2 || !online["stash1"] || online["stash2"] {
+		t.Errorf("want stash1 online and stash2 offline in session 5; got %v", online)
+	}
+
+	agg, err := db.GetValidators().FindAggByStashAccount("stash1")
+	if err != nil {
+		t.Fatalf("want validator aggregate: %v", err)
+	}
+	if agg.DisplayName != "Validator 1" || agg.RecentAsValidatorHeight != 10 {
+		t.Errorf("want aggregate with identity of validator; got %+v", agg)
+	}
+
+	event, err := db.GetEvents().FindByHeightAndIndex(10, 0)
+	if err != nil {
+		t.Fatalf("want event seq of height: %v", err)
+	}
+	if event.Section != "staking" || event.Method != "Bonded" {
+		t.Errorf("want staking bonded event; got %s.%s", event.Section, event.Method)
+	}
+}
@@ -0,0 +1,53 @@
+package indexer
+
+import (
+	"sort"
+
+	"github.com/figment-networks/indexing-engine/pipeline"
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+// shadowTables are tables written by persistor tasks
+var shadowTables = map[pipeline.TaskName]store.ShadowTable{
+	BlockSeqPersistorTaskName:            {Name: "block_sequences", HeightColumn: "height"},
+	ValidatorSeqPersistorTaskName:        {Name: "validator_sequences", HeightColumn: "height"},
+	ValidatorSessionSeqPersistorTaskName: {Name: "validator_session_sequences", HeightColumn: "end_height"},
+	ValidatorEraSeqPersistorTaskName:     {Name: "validator_era_sequences", HeightColumn: "end_height"},
+	ValidatorAggPersistorTaskName:        {Name: "validator_aggregates", HeightColumn: "recent_at_height", KeyColumns: []string{"stash_account"}},
+	EventSeqPersistorTaskName:            {Name: "event_sequences", HeightColumn: "height"},
+	AccountEraSeqPersistorTaskName:       {Name: "account_era_sequences", HeightColumn: "end_height"},
+	TransactionSeqPersistorTaskName:      {Name: "transaction_sequences", HeightColumn: "height"},
+	SystemEventPersistorTaskName:         {Name: "system_events", HeightColumn: "height"},
+	RewardEraSeqPersistorTaskName:        {Name: "reward_era_sequences", HeightColumn: "end_height"},
+	EraSummaryPersistorTaskName:          {Name: "era_summaries", HeightColumn: "end_height"},
+}
+
+// shadowSyncables track progress of shadow backfill, so that live syncables get new index version only once shadow
+// tables are swapped
+var shadowSyncables = store.ShadowTable{Name: "syncables", HeightColumn: "height", Seeded: true}
+
+// ShadowTables returns tables which backfill of missing versions writes to together with syncables
+func (p *indexingPipeline) ShadowTables() ([]store.ShadowTable, error) {
+	if len(p.status.missingVersionIds) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := p.configParser.GetAllTasks(p.status.missingVersionIds, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []store.ShadowTable
+	for _, task := range tasks {
+		if table, ok := shadowTables[task]; ok {
+			tables = append(tables, table)
+		}
+	}
+	if len(tables) == 0 {
+		return nil, nil
+	}
+	tables = append(tables, shadowSyncables)
+
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/figment-networks/indexing-engine/pipeline"
 	"github.com/figment-networks/polkadothub-indexer/metric"
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/publisher"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 	"github.com/pkg/errors"
@@ -16,10 +17,13 @@ var (
 	_ pipeline.Sink = (*sink)(nil)
 )
 
-func NewSink(databaseDb store.Database, syncablesDb store.Syncables, versionNumber int64) *sink {
+// NewSink creates sink marking heights as processed. When relay is given, message of every height is added to outbox
+// together with marking height as processed and published by relay
+func NewSink(databaseDb store.Database, syncablesDb store.Syncables, relay *publisher.Relay, versionNumber int64) *sink {
 	return &sink{
 		databaseDb:    databaseDb,
 		syncablesDb:   syncablesDb,
+		relay:         relay,
 		versionNumber: versionNumber,
 	}
 }
@@ -27,6 +31,7 @@ func NewSink(databaseDb store.Database, syncablesDb store.Syncables, versionNumb
 type sink struct {
 	databaseDb    store.Database
 	syncablesDb   store.Syncables
+	relay         *publisher.Relay
 	versionNumber int64
 
 	successCount int64
@@ -35,16 +40,27 @@ type sink struct {
 func (s *sink) Consume(ctx context.Context, p pipeline.Payload) error {
 	payload := p.(*payload)
 
+	// pipeline does not watch context itself, stop before marking height as processed
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(err, "pipeline cancelled")
+	}
+
 	logger.DebugJSON(payload,
 		logger.Field("process", "pipeline"),
 		logger.Field("stage", "sink"),
 		logger.Field("height", payload.CurrentHeight),
 	)
 
-	if err := s.setProcessed(payload.Syncable); err != nil {
+	if err := s.setProcessed(payload); err != nil {
+		return err
+	}
+
+	if err := s.publishHeightUpdate(payload); err != nil {
 		return err
 	}
 
+	s.publish()
+
 	if err := s.addMetrics(payload.Syncable); err != nil {
 		return err
 	}
@@ -56,14 +72,95 @@ func (s *sink) Consume(ctx context.Context, p pipeline.Payload) error {
 	return nil
 }
 
-func (s *sink) setProcessed(syncable *model.Syncable) error {
+func (s *sink) setProcessed(payload *payload) error {
+	syncable := payload.Syncable
 	syncable.MarkProcessed(s.versionNumber)
-	if err := s.syncablesDb.SaveSyncable(syncable); err != nil {
-		return errors.Wrap(err, "failed saving syncable in sink")
+
+	if s.relay == nil {
+		if err := s.syncablesDb.SaveSyncable(syncable); err != nil {
+			return errors.Wrap(err, "failed saving syncable in sink")
+		}
+		return nil
+	}
+
+	message, err := model.NewOutboxMessage(newHeightMessage(payload))
+	if err != nil {
+		return err
+	}
+
+	if err := s.syncablesDb.SaveSyncableWithMessage(syncable, message); err != nil {
+		return errors.Wrap(err, "failed saving syncable with outbox message in sink")
+	}
+	return nil
+}
+
+// publish starts publishing outbox to downstream consumers in background, so that slow or unavailable broker doesn't
+// hold up indexing. Messages stay in outbox until they are published by next flush of sink or publish worker
+func (s *sink) publish() {
+	if s.relay == nil {
+		return
+	}
+
+	s.relay.FlushAsync()
+}
+
+// publishHeightUpdate records processed height and notifies listeners, i.e. server streaming indexing updates
+func (s *sink) publishHeightUpdate(payload *payload) error {
+	update, err := model.NewHeightUpdate(payload.Syncable, rewardClaims(payload))
+	if err != nil {
+		return err
+	}
+
+	if err := s.syncablesDb.PublishHeightUpdate(update); err != nil {
+		return errors.Wrap(err, "failed publishing height update in sink")
 	}
 	return nil
 }
 
+// newHeightMessage returns message with sequences, rewards and system events indexer wrote for height
+func newHeightMessage(payload *payload) *model.HeightMessage {
+	syncable := payload.Syncable
+
+	blockSequence := payload.NewBlockSequence
+	if blockSequence == nil {
+		blockSequence = payload.UpdatedBlockSequence
+	}
+
+	return &model.HeightMessage{
+		Height:        syncable.Height,
+		Time:          syncable.Time,
+		Session:       syncable.Session,
+		Era:           syncable.Era,
+		LastInSession: syncable.LastInSession,
+		LastInEra:     syncable.LastInEra,
+		IndexVersion:  syncable.IndexVersion,
+
+		BlockSequence:             blockSequence,
+		ValidatorSequences:        payload.ValidatorSequences,
+		ValidatorSessionSequences: payload.ValidatorSessionSequences,
+		ValidatorEraSequences:     payload.ValidatorEraSequences,
+		EventSequences:            payload.EventSequences,
+		AccountEraSequences:       payload.AccountEraSequences,
+		TransactionSequences:      payload.TransactionSequences,
+		RewardEraSequences:        payload.RewardEraSequences,
+		EraSummary:                payload.EraSummary,
+		RewardsClaimed:            rewardClaims(payload),
+		SystemEvents:              payload.SystemEvents,
+	}
+}
+
+func rewardClaims(payload *payload) []model.RewardClaim {
+	rewardsClaimed := make([]model.RewardClaim, len(payload.RewardsClaimed))
+	for i, claim := range payload.RewardsClaimed {
+		rewardsClaimed[i] = model.RewardClaim{
+			Era:            claim.Era,
+			ValidatorStash: claim.ValidatorStash,
+			TxHash:         claim.TxHash,
+		}
+	}
+	return rewardsClaimed
+}
+
 func (s *sink) addMetrics(syncable *model.Syncable) error {
 	res, err := s.databaseDb.GetTotalSize()
 	if err != nil {
@@ -21,7 +21,7 @@ type IndexSourceConfig struct {
 	StartHeight int64
 }
 
-func NewIndexSource(cfg *config.Config, syncablesDb store.Syncables, client *client.Client, sourceCfg *IndexSourceConfig) (*indexSource, error) {
+func NewIndexSource(ctx context.Context, cfg *config.Config, syncablesDb store.Syncables, client *client.Client, sourceCfg *IndexSourceConfig) (*indexSource, error) {
 	src := &indexSource{
 		cfg:         cfg,
 		syncablesDb: syncablesDb,
@@ -29,7 +29,7 @@ func NewIndexSource(cfg *config.Config, syncablesDb store.Syncables, client *cli
 
 		sourceCfg: sourceCfg,
 	}
-	if err := src.init(); err != nil {
+	if err := src.init(ctx); err != nil {
 		return nil, err
 	}
 	return src, nil
@@ -73,11 +73,11 @@ func (s *indexSource) Len() int64 {
 	return s.endHeight - s.startHeight + 1
 }
 
-func (s *indexSource) init() error {
+func (s *indexSource) init(ctx context.Context) error {
 	if err := s.setStartHeight(); err != nil {
 		return err
 	}
-	if err := s.setEndHeight(); err != nil {
+	if err := s.setEndHeight(ctx); err != nil {
 		return err
 	}
 	if err := s.validate(); err != nil {
@@ -115,8 +115,8 @@ func (s *indexSource) setStartHeight() error {
 	return nil
 }
 
-func (s *indexSource) setEndHeight() error {
-	syncableFromNode, err := s.client.Chain.GetHead()
+func (s *indexSource) setEndHeight(ctx context.Context) error {
+	syncableFromNode, err := s.client.Chain.GetHead(ctx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,137 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/figment-networks/indexing-engine/pipeline"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-proxy/grpc/block/blockpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/event/eventpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/staking/stakingpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validator/validatorpb"
+	"github.com/figment-networks/polkadothub-proxy/grpc/validatorperformance/validatorperformancepb"
+)
+
+const (
+	CtxStageObserver = "context_stage_observer"
+)
+
+var (
+	// observedStages are stages which output is passed to stage observer, in order of execution
+	observedStages = []pipeline.StageName{
+		pipeline.StageFetcher,
+		pipeline.StageSyncer,
+		pipeline.StageParser,
+		pipeline.StageSequencer,
+		pipeline.StageAggregator,
+		StageAnalyzer,
+	}
+)
+
+// StageObserver is called with the payload fields produced by stage once the stage completes
+type StageObserver func(stage pipeline.StageName, output interface{})
+
+// FetcherOutput holds data fetched from proxy
+type FetcherOutput struct {
+	HeightMeta              HeightMeta
+	RawBlock                *blockpb.Block
+	RawValidatorPerformance []*validatorperformancepb.Validator
+	RawStaking              *stakingpb.Staking
+	RawEvents               []*eventpb.Event
+	RawValidators           []*validatorpb.Validator
+}
+
+// ParserOutput holds parsed block and validators
+type ParserOutput struct {
+	ParsedBlock      ParsedBlockData
+	ParsedValidators ParsedValidatorsData
+}
+
+// SequencerOutput holds sequences created for height
+type SequencerOutput struct {
+	NewBlockSequence          *model.BlockSeq
+	UpdatedBlockSequence      *model.BlockSeq
+	ValidatorSequences        []model.ValidatorSeq
+	ValidatorSessionSequences []model.ValidatorSessionSeq
+	ValidatorEraSequences     []model.ValidatorEraSeq
+	EventSequences            []model.EventSeq
+	AccountEraSequences       []model.AccountEraSeq
+	TransactionSequences      []model.TransactionSeq
+	RewardEraSequences        []model.RewardEraSeq
+	RewardsClaimed            []RewardsClaim
+}
+
+// AggregatorOutput holds validator aggregates created or updated for height and summary of era ending at height
+type AggregatorOutput struct {
+	NewValidatorAggregates     []model.ValidatorAgg
+	UpdatedValidatorAggregates []model.ValidatorAgg
+	EraSummary                 *model.EraSummary
+}
+
+// AnalyzerOutput holds system events created for height
+type AnalyzerOutput struct {
+	SystemEvents []model.SystemEvent
+}
+
+// addStageObservers adds stages reporting output of observed stages to stage observer found in context
+func addStageObservers(p pipeline.CustomPipeline) {
+	for _, stageName := range observedStages {
+		stageName := stageName
+		p.AddStageAfter(stageName, pipeline.NewCustomStage(stageName+"Observer", pipeline.StageRunnerFunc(
+			func(ctx context.Context, p pipeline.Payload, _ pipeline.TaskValidator) error {
+				observer, ok := ctx.Value(CtxStageObserver).(StageObserver)
+				if !ok || observer == nil {
+					return nil
+				}
+				observer(stageName, stageOutput(stageName, p.(*payload)))
+				return nil
+			},
+		)))
+	}
+}
+
+// stageOutput returns payload fields populated by given stage
+func stageOutput(stageName pipeline.StageName, payload *payload) interface{} {
+	switch stageName {
+	case pipeline.StageFetcher:
+		return FetcherOutput{
+			HeightMeta:              payload.HeightMeta,
+			RawBlock:                payload.RawBlock,
+			RawValidatorPerformance: payload.RawValidatorPerformance,
+			RawStaking:              payload.RawStaking,
+			RawEvents:               payload.RawEvents,
+			RawValidators:           payload.RawValidators,
+		}
+	case pipeline.StageSyncer:
+		return payload.Syncable
+	case pipeline.StageParser:
+		return ParserOutput{
+			ParsedBlock:      payload.ParsedBlock,
+			ParsedValidators: payload.ParsedValidators,
+		}
+	case pipeline.StageSequencer:
+		return SequencerOutput{
+			NewBlockSequence:          payload.NewBlockSequence,
+			UpdatedBlockSequence:      payload.UpdatedBlockSequence,
+			ValidatorSequences:        payload.ValidatorSequences,
+			ValidatorSessionSequences: payload.ValidatorSessionSequences,
+			ValidatorEraSequences:     payload.ValidatorEraSequences,
+			EventSequences:            payload.EventSequences,
+			AccountEraSequences:       payload.AccountEraSequences,
+			TransactionSequences:      payload.TransactionSequences,
+			RewardEraSequences:        payload.RewardEraSequences,
+			RewardsClaimed:            payload.RewardsClaimed,
+		}
+	case pipeline.StageAggregator:
+		return AggregatorOutput{
+			NewValidatorAggregates:     payload.NewValidatorAggregates,
+			UpdatedValidatorAggregates: payload.UpdatedValidatorAggregates,
+			EraSummary:                 payload.EraSummary,
+		}
+	case StageAnalyzer:
+		return AnalyzerOutput{
+			SystemEvents: payload.SystemEvents,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+const systemEventRuleVersionKey = "rule_version"
+
+var (
+	ErrNotLegacyChangeSystemEvent = errors.New("system event is not of legacy change kind")
+
+	activeBalanceChangeKinds = changeKinds{
+		increase: [3]model.SystemEventKind{model.SystemEventActiveBalanceIncrease1, model.SystemEventActiveBalanceIncrease2, model.SystemEventActiveBalanceIncrease3},
+		decrease: [3]model.SystemEventKind{model.SystemEventActiveBalanceDecrease1, model.SystemEventActiveBalanceDecrease2, model.SystemEventActiveBalanceDecrease3},
+	}
+	commissionChangeKinds = changeKinds{
+		increase: [3]model.SystemEventKind{model.SystemEventCommissionIncrease1, model.SystemEventCommissionIncrease2, model.SystemEventCommissionIncrease3},
+		decrease: [3]model.SystemEventKind{model.SystemEventCommissionDecrease1, model.SystemEventCommissionDecrease2, model.SystemEventCommissionDecrease3},
+	}
+)
+
+// changeKinds are kinds of change system events for each bucket
+type changeKinds struct {
+	increase [3]model.SystemEventKind
+	decrease [3]model.SystemEventKind
+}
+
+// systemEventRules holds thresholds used to create system events, they are configured per deployment
+type systemEventRules struct {
+	version                    int64
+	missedConsecutiveThreshold int64
+	missedNofMThreshold        int64
+	missedNofMWindow           int64
+	activeBalanceChangeBuckets []float64
+	commissionChangeBuckets    []float64
+	totalDelegatedChange       float64
+	totalDelegatedChangeMin    types.Quantity
+	enabledKinds               map[model.SystemEventKind]struct{}
+}
+
+func newSystemEventRules(cfg *config.Config) systemEventRules {
+	rules := systemEventRules{
+		version:                    cfg.SystemEventRulesVersion,
+		missedConsecutiveThreshold: cfg.SystemEventMissedConsecutive,
+		missedNofMThreshold:        cfg.SystemEventMissedNofMThreshold,
+		missedNofMWindow:           cfg.SystemEventMissedNofMWindow,
+		activeBalanceChangeBuckets: cfg.SystemEventActiveBalanceChangeBuckets,
+		commissionChangeBuckets:    cfg.SystemEventCommissionChangeBuckets,
+		totalDelegatedChange:       cfg.SystemEventTotalDelegatedChange,
+	}
+
+	if min, err := types.NewQuantityFromString(cfg.SystemEventTotalDelegatedChangeMin); err == nil {
+		rules.totalDelegatedChangeMin = min
+	}
+
+	if len(cfg.SystemEventKinds) > 0 {
+		rules.enabledKinds = make(map[model.SystemEventKind]struct{}, len(cfg.SystemEventKinds))
+		for _, kind := range cfg.SystemEventKinds {
+			rules.enabledKinds[model.SystemEventKind(kind)] = struct{}{}
+		}
+	}
+
+	return rules
+}
+
+// isEnabled returns true if kind is enabled, all kinds are enabled when none are configured
+func (r systemEventRules) isEnabled(kind model.SystemEventKind) bool {
+	if r.enabledKinds == nil {
+		return true
+	}
+	_, ok := r.enabledKinds[kind]
+	return ok
+}
+
+// getChangeKind returns kind for bucket which change falls in.
+// Change from 0 has no rate and falls in the last increase bucket
+func (r systemEventRules) getChangeKind(currValue, prevValue int64, changeRate *float64, buckets []float64, kinds changeKinds) (model.SystemEventKind, bool) {
+	if currValue == prevValue {
+		return "", false
+	}
+
+	if changeRate == nil {
+		kind := kinds.increase[len(kinds.increase)-1]
+		return kind, r.isEnabled(kind)
+	}
+
+	bucketKinds := kinds.increase
+	if *changeRate < 0 {
+		bucketKinds = kinds.decrease
+	}
+
+	absChangeRate := math.Abs(*changeRate)
+	for i := len(buckets) - 1; i >= 0; i-- {
+		if absChangeRate >= buckets[i] {
+			return bucketKinds[i], r.isEnabled(bucketKinds[i])
+		}
+	}
+	return "", false
+}
+
+// newChangeSystemEvent creates change system event, it returns false when change doesn't fall in any enabled bucket
+func (r systemEventRules) newChangeSystemEvent(stashAccount string, syncable *model.Syncable, currValue, prevValue int64, buckets []float64, kinds changeKinds) (model.SystemEvent, bool, error) {
+	changeRate := getRoundedChangeRate(currValue, prevValue)
+
+	kind, ok := r.getChangeKind(currValue, prevValue, changeRate, buckets, kinds)
+	if !ok {
+		return model.SystemEvent{}, false, nil
+	}
+
+	systemEvent, err := r.newSystemEvent(stashAccount, syncable, kind, model.PercentChangeData{
+		Before: prevValue,
+		After:  currValue,
+		Change: changeRate,
+	})
+	return systemEvent, err == nil, err
+}
+
+// isTotalDelegatedChange returns true when total delegated stake changed by at least both configured percentage and minimum amount.
+// Change from 0 has no rate and only minimum amount is checked
+func (r systemEventRules) isTotalDelegatedChange(curr, prev types.Quantity, changeRate *float64) bool {
+	if curr.Cmp(&prev.Int) == 0 {
+		return false
+	}
+
+	diff := new(big.Int).Sub(&curr.Int, &prev.Int)
+	if diff.CmpAbs(&r.totalDelegatedChangeMin.Int) < 0 {
+		return false
+	}
+
+	return changeRate == nil || math.Abs(*changeRate) >= r.totalDelegatedChange
+}
+
+// newSystemEvent creates system event with data tagged with rules version
+func (r systemEventRules) newSystemEvent(stashAccount string, syncable *model.Syncable, kind model.SystemEventKind, data interface{}) (model.SystemEvent, error) {
+	fields := map[string]interface{}{}
+	if data != nil {
+		marshaledData, err := json.Marshal(data)
+		if err != nil {
+			return model.SystemEvent{}, err
+		}
+
+		var rawFields map[string]json.RawMessage
+		if err := json.Unmarshal(marshaledData, &rawFields); err != nil {
+			return model.SystemEvent{}, err
+		}
+		for k, v := range rawFields {
+			fields[k] = v
+		}
+	}
+	fields[systemEventRuleVersionKey] = r.version
+
+	marshaledData, err := json.Marshal(fields)
+	if err != nil {
+		return model.SystemEvent{}, err
+	}
+
+	return model.SystemEvent{
+		Height: syncable.Height,
+		Time:   syncable.Time,
+		Actor:  stashAccount,
+		Kind:   kind,
+		Data:   types.Jsonb{RawMessage: marshaledData},
+	}, nil
+}
+
+// getRoundedChangeRate returns signed percentage change rounded to 0.1, it's nil when there is no baseline to compare to
+func getRoundedChangeRate(currValue int64, prevValue int64) *float64 {
+	if prevValue == 0 {
+		return nil
+	}
+
+	changeRate := (float64(currValue) - float64(prevValue)) / float64(prevValue) * 100
+	roundedChangeRate := math.Round(changeRate/0.1) * 0.1
+	return &roundedChangeRate
+}
+
+// getRoundedQuantityChangeRate returns signed percentage change of quantity rounded to 0.1, it's nil when there is no baseline to compare to
+func getRoundedQuantityChangeRate(curr, prev types.Quantity) *float64 {
+	if prev.IsZero() {
+		return nil
+	}
+
+	diff := new(big.Float).SetInt(new(big.Int).Sub(&curr.Int, &prev.Int))
+	changeRate, _ := diff.Quo(diff, new(big.Float).SetInt(&prev.Int)).Float64()
+	roundedChangeRate := math.Round(changeRate*100/0.1) * 0.1
+	return &roundedChangeRate
+}
+
+// RewriteLegacyChangeSystemEvent recreates system event of legacy change kind with direction aware kind using current rules.
+// It returns nil when change doesn't fall in any enabled bucket
+func RewriteLegacyChangeSystemEvent(cfg *config.Config, systemEvent model.SystemEvent) (*model.SystemEvent, error) {
+	rules := newSystemEventRules(cfg)
+
+	var buckets []float64
+	var kinds changeKinds
+	switch {
+	case isKindOf(systemEvent.Kind, model.LegacyActiveBalanceChangeKinds):
+		buckets, kinds = rules.activeBalanceChangeBuckets, activeBalanceChangeKinds
+	case isKindOf(systemEvent.Kind, model.LegacyCommissionChangeKinds):
+		buckets, kinds = rules.commissionChangeBuckets, commissionChangeKinds
+	default:
+		return nil, ErrNotLegacyChangeSystemEvent
+	}
+
+	data := &model.PercentChangeData{}
+	if err := json.Unmarshal(systemEvent.Data.RawMessage, data); err != nil {
+		return nil, err
+	}
+
+	syncable := &model.Syncable{Height: systemEvent.Height, Time: systemEvent.Time}
+	newSystemEvent, ok, err := rules.newChangeSystemEvent(systemEvent.Actor, syncable, data.After, data.Before, buckets, kinds)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &newSystemEvent, nil
+}
+
+func isKindOf(kind model.SystemEventKind, kinds []model.SystemEventKind) bool {
+	for _, k := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func TestSystemEventRules_getChangeKind(t *testing.T) {
+	tests := []struct {
+		description string
+		kinds       []string
+		buckets     []float64
+		prev        int64
+		curr        int64
+
+		expectedKind model.SystemEventKind
+		expectedOk   bool
+	}{
+		{"returns false when value hasn't changed", nil, []float64{0.1, 1, 10}, 1000, 1000, "", false},
+		{"returns false when value stays 0", nil, []float64{0.1, 1, 10}, 0, 0, "", false},
+		{"returns false when change is below first bucket", nil, []float64{0.1, 1, 10}, 10000, 10004, "", false},
+		{"returns first increase kind when change is in first bucket", nil, []float64{0.1, 1, 10}, 1000, 1001, model.SystemEventCommissionIncrease1, true},
+		{"returns second increase kind when change is in second bucket", nil, []float64{0.1, 1, 10}, 1000, 1099, model.SystemEventCommissionIncrease2, true},
+		{"returns third increase kind when change is above last edge", nil, []float64{0.1, 1, 10}, 1000, 1500, model.SystemEventCommissionIncrease3, true},
+		{"returns first decrease kind when change is in first bucket", nil, []float64{0.1, 1, 10}, 1000, 999, model.SystemEventCommissionDecrease1, true},
+		{"returns third decrease kind when value drops to 0", nil, []float64{0.1, 1, 10}, 1000, 0, model.SystemEventCommissionDecrease3, true},
+		{"returns third increase kind when value grows from 0", nil, []float64{0.1, 1, 10}, 0, 1, model.SystemEventCommissionIncrease3, true},
+		{"uses configured bucket edges", nil, []float64{5, 20, 50}, 1000, 1100, model.SystemEventCommissionIncrease1, true},
+		{"returns false when kind is disabled", []string{"commission_increase_3"}, []float64{0.1, 1, 10}, 1000, 1050, model.SystemEventCommissionIncrease2, false},
+		{"returns true when kind is enabled", []string{"commission_decrease_2"}, []float64{0.1, 1, 10}, 1000, 950, model.SystemEventCommissionDecrease2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cfg := *testCfg
+			cfg.SystemEventKinds = tt.kinds
+			cfg.SystemEventCommissionChangeBuckets = tt.buckets
+			rules := newSystemEventRules(&cfg)
+
+			kind, ok := rules.getChangeKind(tt.curr, tt.prev, getRoundedChangeRate(tt.curr, tt.prev), rules.commissionChangeBuckets, commissionChangeKinds)
+			if ok != tt.expectedOk {
+				t.Errorf("unexpected ok, want %v; got %v", tt.expectedOk, ok)
+			}
+			if kind != tt.expectedKind {
+				t.Errorf("unexpected kind, want %v; got %v", tt.expectedKind, kind)
+			}
+		})
+	}
+}
+
+func TestRewriteLegacyChangeSystemEvent(t *testing.T) {
+	tests := []struct {
+		description string
+		kind        model.SystemEventKind
+		data        string
+
+		expectedKind model.SystemEventKind
+		expectedData string
+		expectedNil  bool
+		expectedErr  error
+	}{
+		{"rewrites legacy active balance increase", model.SystemEventActiveBalanceChange2, `{"before":1000,"after":1050,"change":-5}`,
+			model.SystemEventActiveBalanceIncrease2, `{"after":1050,"before":1000,"change":5,"rule_version":1}`, false, nil},
+		{"rewrites legacy commission decrease", model.SystemEventCommissionChange3, `{"before":1000,"after":500,"change":50}`,
+			model.SystemEventCommissionDecrease3, `{"after":500,"before":1000,"change":-50,"rule_version":1}`, false, nil},
+		{"rewrites legacy change from 0", model.SystemEventCommissionChange3, `{"before":0,"after":500,"change":500}`,
+			model.SystemEventCommissionIncrease3, `{"after":500,"before":0,"change":null,"rule_version":1}`, false, nil},
+		{"returns nil when change is below first bucket", model.SystemEventCommissionChange1, `{"before":10000,"after":10004,"change":0}`,
+			"", "", true, nil},
+		{"returns error for other kinds", model.SystemEventJoinedSet, `null`,
+			"", "", true, ErrNotLegacyChangeSystemEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			legacy := model.SystemEvent{Height: 10, Actor: testValidatorAddress, Kind: tt.kind, Data: types.Jsonb{RawMessage: []byte(tt.data)}}
+
+			systemEvent, err := RewriteLegacyChangeSystemEvent(testCfg, legacy)
+			if err != tt.expectedErr {
+				t.Errorf("unexpected error, want %v; got %v", tt.expectedErr, err)
+				return
+			}
+
+			if tt.expectedNil {
+				if systemEvent != nil {
+					t.Errorf("unexpected system event %v", systemEvent)
+				}
+				return
+			}
+
+			if systemEvent.Kind != tt.expectedKind {
+				t.Errorf("unexpected kind, want %v; got %v", tt.expectedKind, systemEvent.Kind)
+			}
+			if systemEvent.Height != legacy.Height || systemEvent.Actor != legacy.Actor {
+				t.Errorf("unexpected height or actor, want %v %v; got %v %v", legacy.Height, legacy.Actor, systemEvent.Height, systemEvent.Actor)
+			}
+			if string(systemEvent.Data.RawMessage) != tt.expectedData {
+				t.Errorf("unexpected data, want %v; got %v", tt.expectedData, string(systemEvent.Data.RawMessage))
+			}
+		})
+	}
+}
+
+func TestSystemEventRules_newSystemEvent(t *testing.T) {
+	tests := []struct {
+		description string
+		data        interface{}
+
+		expectedData string
+	}{
+		{"tags empty data with rule version", nil, `{"rule_version":3}`},
+		{"tags data with rule version", model.DelegationChangeData{StashAccounts: []string{testDelegatorAddress}}, `{"rule_version":3,"stash_accounts":["test_del_address"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			cfg := *testCfg
+			cfg.SystemEventRulesVersion = 3
+			rules := newSystemEventRules(&cfg)
+
+			event, err := rules.newSystemEvent(testValidatorAddress, &model.Syncable{Height: 10}, model.SystemEventJoinedSet, tt.data)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if string(event.Data.RawMessage) != tt.expectedData {
+				t.Errorf("unexpected data, want %v; got %v", tt.expectedData, string(event.Data.RawMessage))
+			}
+		})
+	}
+}
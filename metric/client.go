@@ -0,0 +1,48 @@
+package metric
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ClientRequestRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "figment",
+			Subsystem: "client",
+			Name:      "request_retries",
+			Help:      "The number of retried proxy requests",
+		},
+		[]string{"method"},
+	)
+
+	ClientCircuitBreakerState = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "figment",
+		Subsystem: "client",
+		Name:      "circuit_breaker_state",
+		Help:      "The state of proxy circuit breaker (0 = closed, 1 = open, 2 = half open)",
+	})
+
+	ClientEndpointHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "figment",
+			Subsystem: "client",
+			Name:      "endpoint_healthy",
+			Help:      "Health of proxy endpoint (0 = unhealthy, 1 = healthy)",
+		},
+		[]string{"endpoint", "role"},
+	)
+
+	ClientCircuitBreakerRejections = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "figment",
+		Subsystem: "client",
+		Name:      "circuit_breaker_rejections",
+		Help:      "The number of proxy requests failed fast by open circuit breaker",
+	})
+)
+
+func registerClientMetrics() {
+	prometheus.MustRegister(ClientRequestRetries)
+	prometheus.MustRegister(ClientCircuitBreakerState)
+	prometheus.MustRegister(ClientCircuitBreakerRejections)
+	prometheus.MustRegister(ClientEndpointHealthy)
+}
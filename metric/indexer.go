@@ -27,6 +27,27 @@ var (
 		Name:      "db_size",
 		Help:      "The size of the database after indexing of height",
 	})
+
+	IndexerPublishedMessages = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "figment",
+		Subsystem: "indexer",
+		Name:      "published_messages",
+		Help:      "The total number of height messages published from outbox",
+	})
+
+	IndexerPublishFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "figment",
+		Subsystem: "indexer",
+		Name:      "publish_failures",
+		Help:      "The total number of failed attempts to publish height messages",
+	})
+
+	IndexerPublishStalledHeight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "figment",
+		Subsystem: "indexer",
+		Name:      "publish_stalled_height",
+		Help:      "The height of outbox message which publisher fails to deliver, 0 while messages are delivered",
+	})
 )
 
 // IndexerMetric handles HTTP requests
@@ -56,6 +77,10 @@ func (m *IndexerMetric) init() *IndexerMetric {
 
 	prometheus.MustRegister(IndexerUseCaseDuration)
 	prometheus.MustRegister(IndexerDbSizeAfterHeight)
+	prometheus.MustRegister(IndexerPublishedMessages)
+	prometheus.MustRegister(IndexerPublishFailures)
+	prometheus.MustRegister(IndexerPublishStalledHeight)
+	registerClientMetrics()
 
 	// Add Go module build info.
 	prometheus.MustRegister(prometheus.NewBuildInfoCollector())
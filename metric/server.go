@@ -57,6 +57,7 @@ func (m *ServerMetric) init() *ServerMetric {
 
 	prometheus.MustRegister(DatabaseQueryDuration)
 	prometheus.MustRegister(ServerRequestDuration)
+	registerClientMetrics()
 
 	// Add Go module build info.
 	prometheus.MustRegister(prometheus.NewBuildInfoCollector())
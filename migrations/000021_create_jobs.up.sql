@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS jobs
+(
+    id           BIGSERIAL                NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    kind         TEXT                     NOT NULL,
+    params       JSONB,
+    status       TEXT                     NOT NULL,
+    unique_key   TEXT,
+    attempts     INT                      NOT NULL DEFAULT 0,
+    max_attempts INT                      NOT NULL DEFAULT 1,
+    run_at       TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_by    TEXT,
+    locked_at    TIMESTAMP WITH TIME ZONE,
+    error        TEXT,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE index idx_jobs_status_run_at on jobs (status, run_at);
+CREATE UNIQUE index idx_jobs_unique_key on jobs (unique_key) WHERE status IN ('queued', 'running');
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS subscriptions
+(
+    id                   BIGSERIAL                NOT NULL,
+    created_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at           TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    url                  TEXT                     NOT NULL,
+    secret               TEXT                     NOT NULL,
+    actors               TEXT[]                   NOT NULL DEFAULT '{}',
+    kinds                TEXT[]                   NOT NULL DEFAULT '{}',
+    enabled              BOOLEAN                  NOT NULL DEFAULT TRUE,
+    last_system_event_id BIGINT                   NOT NULL DEFAULT 0,
+    failures             INT                      NOT NULL DEFAULT 0,
+    next_attempt_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_by            TEXT,
+    locked_at            TIMESTAMP WITH TIME ZONE,
+    disabled_at          TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS subscription_deliveries
+(
+    id              BIGSERIAL                NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    subscription_id BIGINT                   NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    system_event_id BIGINT                   NOT NULL,
+    attempt         INT                      NOT NULL,
+    status_code     INT,
+    error           TEXT,
+    duration_ms     BIGINT                   NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE index idx_subscriptions_enabled_next_attempt_at on subscriptions (enabled, next_attempt_at);
+CREATE index idx_subscription_deliveries_subscription_id on subscription_deliveries (subscription_id, id);
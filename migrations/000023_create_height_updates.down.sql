@@ -0,0 +1 @@
+DROP TABLE IF EXISTS height_updates;
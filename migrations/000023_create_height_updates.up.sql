@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS height_updates
+(
+    id              BIGSERIAL                NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    height          DECIMAL(65, 0)           NOT NULL,
+    time            TIMESTAMP WITH TIME ZONE NOT NULL,
+    session         DECIMAL(65, 0)           NOT NULL,
+    era             DECIMAL(65, 0)           NOT NULL,
+    last_in_session BOOLEAN                  NOT NULL,
+    last_in_era     BOOLEAN                  NOT NULL,
+    rewards_claimed JSONB,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE UNIQUE index idx_height_updates_height on height_updates (height);
+CREATE index idx_height_updates_time on height_updates (time);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS event_summary;
+DROP TABLE IF EXISTS transaction_summary;
+
+DROP index IF EXISTS idx_event_sequences_time;
+DROP index IF EXISTS idx_transaction_sequences_time;
+
+ALTER TABLE transaction_sequences DROP COLUMN IF EXISTS signer;
@@ -0,0 +1,47 @@
+ALTER TABLE transaction_sequences ADD COLUMN IF NOT EXISTS signer TEXT NOT NULL DEFAULT '';
+
+CREATE index IF NOT EXISTS idx_transaction_sequences_time on transaction_sequences (time);
+CREATE index IF NOT EXISTS idx_event_sequences_time on event_sequences (time);
+
+CREATE TABLE IF NOT EXISTS transaction_summary
+(
+    id                            BIGSERIAL                NOT NULL,
+    created_at                    TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at                    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    time_interval                 VARCHAR                  NOT NULL,
+    time_bucket                   TIMESTAMP WITH TIME ZONE NOT NULL,
+    index_version                 INT                      NOT NULL,
+
+    section                       TEXT                     NOT NULL,
+    method                        TEXT                     NOT NULL,
+    count                         BIGINT                   NOT NULL,
+    failed_count                  BIGINT                   NOT NULL,
+    signers_count                 BIGINT                   NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE UNIQUE index idx_transaction_summary_bucket on transaction_summary (time_interval, time_bucket, index_version, section, method);
+
+CREATE TABLE IF NOT EXISTS event_summary
+(
+    id                            BIGSERIAL                NOT NULL,
+    created_at                    TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at                    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    time_interval                 VARCHAR                  NOT NULL,
+    time_bucket                   TIMESTAMP WITH TIME ZONE NOT NULL,
+    index_version                 INT                      NOT NULL,
+
+    section                       TEXT                     NOT NULL,
+    method                        TEXT                     NOT NULL,
+    count                         BIGINT                   NOT NULL,
+    transfer_volume               DECIMAL(65, 0)           NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE UNIQUE index idx_event_summary_bucket on event_summary (time_interval, time_bucket, index_version, section, method);
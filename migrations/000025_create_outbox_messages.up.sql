@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox_messages
+(
+    id         BIGSERIAL                NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    height     DECIMAL(65, 0)           NOT NULL,
+    payload    JSONB                    NOT NULL,
+    attempts   INT                      NOT NULL DEFAULT 0,
+    last_error TEXT,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE index idx_outbox_messages_height on outbox_messages (height, id);
@@ -0,0 +1 @@
+ALTER TABLE reports ADD COLUMN details JSONB;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS era_summaries;
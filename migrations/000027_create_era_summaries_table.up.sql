@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS era_summaries
+(
+    id                      BIGSERIAL                NOT NULL,
+
+    era                     DECIMAL(65, 0)           NOT NULL,
+    start_height            DECIMAL(65, 0)           NOT NULL,
+    end_height              DECIMAL(65, 0)           NOT NULL,
+    time                    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    total_stake             DECIMAL(65, 0)           NOT NULL,
+    active_validators_count BIGINT                   NOT NULL,
+    nominators_count        BIGINT                   NOT NULL,
+    validator_stake_min     DECIMAL(65, 0)           NOT NULL,
+    validator_stake_max     DECIMAL(65, 0)           NOT NULL,
+    validator_stake_median  DECIMAL(65, 0)           NOT NULL,
+    total_reward_payout     DECIMAL(65, 0)           NOT NULL,
+    total_reward_points     DECIMAL(65, 0)           NOT NULL,
+    commission_avg          DECIMAL                  NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE UNIQUE INDEX idx_era_summaries_era on era_summaries (era);
+CREATE index idx_era_summaries_heights on era_summaries (start_height, end_height);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS prices
+(
+    id         BIGSERIAL                NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    date       DATE                     NOT NULL,
+    currency   TEXT                     NOT NULL,
+    price      DECIMAL                  NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE UNIQUE INDEX idx_prices_currency_date on prices (currency, date);
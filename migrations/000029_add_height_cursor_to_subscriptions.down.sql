@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_system_events_height_id;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS last_system_event_height;
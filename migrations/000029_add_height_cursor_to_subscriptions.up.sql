@@ -0,0 +1,8 @@
+ALTER TABLE subscriptions ADD COLUMN last_system_event_height BIGINT NOT NULL DEFAULT 0;
+
+-- existing subscriptions continue after height of last delivered system event
+UPDATE subscriptions
+SET last_system_event_height = COALESCE((SELECT MAX(height) FROM system_events WHERE id <= subscriptions.last_system_event_id), 0);
+
+-- Indexes
+CREATE index idx_system_events_height_id on system_events (height, id);
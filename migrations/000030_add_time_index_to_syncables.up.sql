@@ -0,0 +1,2 @@
+-- Indexes
+CREATE index idx_syncables_time on syncables (time);
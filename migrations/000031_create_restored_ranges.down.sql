@@ -0,0 +1 @@
+DROP TABLE IF EXISTS restored_ranges;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS restored_ranges
+(
+    id           BIGSERIAL                NOT NULL,
+
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    start_height DECIMAL(65, 0)           NOT NULL,
+    end_height   DECIMAL(65, 0)           NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+-- Indexes
+CREATE index idx_restored_ranges_heights on restored_ranges (start_height, end_height);
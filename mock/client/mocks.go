@@ -5,6 +5,7 @@
 package mock_client
 
 import (
+	context "context"
 	accountpb "github.com/figment-networks/polkadothub-proxy/grpc/account/accountpb"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -34,31 +35,31 @@ func (m *MockAccountClient) EXPECT() *MockAccountClientMockRecorder {
 }
 
 // GetByHeight mocks base method
-func (m *MockAccountClient) GetByHeight(arg0 string, arg1 int64) (*accountpb.GetByHeightResponse, error) {
+func (m *MockAccountClient) GetByHeight(arg0 context.Context, arg1 string, arg2 int64) (*accountpb.GetByHeightResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByHeight", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetByHeight", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*accountpb.GetByHeightResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByHeight indicates an expected call of GetByHeight
-func (mr *MockAccountClientMockRecorder) GetByHeight(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockAccountClientMockRecorder) GetByHeight(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockAccountClient)(nil).GetByHeight), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHeight", reflect.TypeOf((*MockAccountClient)(nil).GetByHeight), arg0, arg1, arg2)
 }
 
 // GetIdentity mocks base method
-func (m *MockAccountClient) GetIdentity(arg0 string) (*accountpb.GetIdentityResponse, error) {
+func (m *MockAccountClient) GetIdentity(arg0 context.Context, arg1 string) (*accountpb.GetIdentityResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetIdentity", arg0)
+	ret := m.ctrl.Call(m, "GetIdentity", arg0, arg1)
 	ret0, _ := ret[0].(*accountpb.GetIdentityResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetIdentity indicates an expected call of GetIdentity
-func (mr *MockAccountClientMockRecorder) GetIdentity(arg0 interface{}) *gomock.Call {
+func (mr *MockAccountClientMockRecorder) GetIdentity(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockAccountClient)(nil).GetIdentity), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockAccountClient)(nil).GetIdentity), arg0, arg1)
 }
@@ -5,6 +5,7 @@
 package mock_indexer
 
 import (
+	context "context"
 	pipeline "github.com/figment-networks/indexing-engine/pipeline"
 	model "github.com/figment-networks/polkadothub-indexer/model"
 	heightpb "github.com/figment-networks/polkadothub-proxy/grpc/height/heightpb"
@@ -159,18 +160,18 @@ func (m *MockFetcherClient) EXPECT() *MockFetcherClientMockRecorder {
 }
 
 // GetAll mocks base method
-func (m *MockFetcherClient) GetAll(arg0 int64) (*heightpb.GetAllResponse, error) {
+func (m *MockFetcherClient) GetAll(arg0 context.Context, arg1 int64) (*heightpb.GetAllResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0)
+	ret := m.ctrl.Call(m, "GetAll", arg0, arg1)
 	ret0, _ := ret[0].(*heightpb.GetAllResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll
-func (mr *MockFetcherClientMockRecorder) GetAll(arg0 interface{}) *gomock.Call {
+func (mr *MockFetcherClientMockRecorder) GetAll(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFetcherClient)(nil).GetAll), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockFetcherClient)(nil).GetAll), arg0, arg1)
 }
 
 // MockRewardsCalculator is a mock of RewardsCalculator interface
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/figment-networks/polkadothub-indexer/store (interfaces: AccountEraSeq,BlockSeq,BlockSummary,Database,EventSeq,Reports,Rewards,Syncables,SystemEvents,TransactionSeq,ValidatorAgg,ValidatorSeq,ValidatorEraSeq,ValidatorSessionSeq,ValidatorSummary)
+// Source: github.com/figment-networks/polkadothub-indexer/store (interfaces: AccountEraSeq,BlockSeq,BlockSummary,Database,EraSummary,EventSeq,EventSummary,Jobs,Reports,Rewards,Subscriptions,Syncables,SystemEvents,TransactionSeq,TransactionSummary,ValidatorAgg,ValidatorSeq,ValidatorEraSeq,ValidatorSessionSeq,ValidatorSummary)
 
 // Package mock_store is a generated GoMock package.
 package mock_store
@@ -65,6 +65,21 @@ func (mr *MockAccountEraSeqMockRecorder) FindByEra(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEra", reflect.TypeOf((*MockAccountEraSeq)(nil).FindByEra), arg0)
 }
 
+// FindForEraRange mocks base method
+func (m *MockAccountEraSeq) FindForEraRange(arg0, arg1 int64, arg2 string) ([]model.AccountEraSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForEraRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.AccountEraSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForEraRange indicates an expected call of FindForEraRange
+func (mr *MockAccountEraSeqMockRecorder) FindForEraRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForEraRange", reflect.TypeOf((*MockAccountEraSeq)(nil).FindForEraRange), arg0, arg1, arg2)
+}
+
 // FindLastByStashAccount mocks base method
 func (m *MockAccountEraSeq) FindLastByStashAccount(arg0 string) ([]model.AccountEraSeq, error) {
 	m.ctrl.T.Helper()
@@ -133,6 +148,35 @@ func (m *MockBlockSeq) EXPECT() *MockBlockSeqMockRecorder {
 	return m.recorder
 }
 
+// ArchiveSeqsOlderThan mocks base method
+func (m *MockBlockSeq) ArchiveSeqsOlderThan(arg0 time.Time, arg1 []store.ActivityPeriodRow, arg2 int64, arg3 func([]model.BlockSeq) error) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSeqsOlderThan", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSeqsOlderThan indicates an expected call of ArchiveSeqsOlderThan
+func (mr *MockBlockSeqMockRecorder) ArchiveSeqsOlderThan(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSeqsOlderThan", reflect.TypeOf((*MockBlockSeq)(nil).ArchiveSeqsOlderThan), arg0, arg1, arg2, arg3)
+}
+
+// CreateIfNotExists mocks base method
+func (m *MockBlockSeq) CreateIfNotExists(arg0 *model.BlockSeq) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIfNotExists", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIfNotExists indicates an expected call of CreateIfNotExists
+func (mr *MockBlockSeqMockRecorder) CreateIfNotExists(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIfNotExists", reflect.TypeOf((*MockBlockSeq)(nil).CreateIfNotExists), arg0)
+}
+
 // CreateSeq mocks base method
 func (m *MockBlockSeq) CreateSeq(arg0 *model.BlockSeq) error {
 	m.ctrl.T.Helper()
@@ -192,6 +236,21 @@ func (mr *MockBlockSeqMockRecorder) FindSeqByHeight(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSeqByHeight", reflect.TypeOf((*MockBlockSeq)(nil).FindSeqByHeight), arg0)
 }
 
+// FindSeqsForHeightRange mocks base method
+func (m *MockBlockSeq) FindSeqsForHeightRange(arg0, arg1 int64) ([]model.BlockSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSeqsForHeightRange", arg0, arg1)
+	ret0, _ := ret[0].([]model.BlockSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSeqsForHeightRange indicates an expected call of FindSeqsForHeightRange
+func (mr *MockBlockSeqMockRecorder) FindSeqsForHeightRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSeqsForHeightRange", reflect.TypeOf((*MockBlockSeq)(nil).FindSeqsForHeightRange), arg0, arg1)
+}
+
 // GetAvgRecentTimes mocks base method
 func (m *MockBlockSeq) GetAvgRecentTimes(arg0 int64) store.GetAvgRecentTimesResult {
 	m.ctrl.T.Helper()
@@ -235,6 +294,21 @@ func (mr *MockBlockSeqMockRecorder) Summarize(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summarize", reflect.TypeOf((*MockBlockSeq)(nil).Summarize), arg0, arg1)
 }
 
+// SummarizeByEra mocks base method
+func (m *MockBlockSeq) SummarizeByEra(arg0 time.Time) ([]model.BlockSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeByEra", arg0)
+	ret0, _ := ret[0].([]model.BlockSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeByEra indicates an expected call of SummarizeByEra
+func (mr *MockBlockSeqMockRecorder) SummarizeByEra(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeByEra", reflect.TypeOf((*MockBlockSeq)(nil).SummarizeByEra), arg0)
+}
+
 // MockBlockSummary is a mock of BlockSummary interface
 type MockBlockSummary struct {
 	ctrl     *gomock.Controller
@@ -376,6 +450,21 @@ func (mr *MockBlockSummaryMockRecorder) SaveSummary(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSummary", reflect.TypeOf((*MockBlockSummary)(nil).SaveSummary), arg0)
 }
 
+// SummarizeDailySummaries mocks base method
+func (m *MockBlockSummary) SummarizeDailySummaries(arg0 types.SummaryInterval, arg1 time.Time, arg2 int64) ([]model.BlockSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeDailySummaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.BlockSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeDailySummaries indicates an expected call of SummarizeDailySummaries
+func (mr *MockBlockSummaryMockRecorder) SummarizeDailySummaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeDailySummaries", reflect.TypeOf((*MockBlockSummary)(nil).SummarizeDailySummaries), arg0, arg1, arg2)
+}
+
 // MockDatabase is a mock of Database interface
 type MockDatabase struct {
 	ctrl     *gomock.Controller
@@ -399,6 +488,78 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// CheckShadowTables mocks base method
+func (m *MockDatabase) CheckShadowTables(arg0 []store.ShadowTable, arg1 int64) ([]store.ShadowTableCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckShadowTables", arg0, arg1)
+	ret0, _ := ret[0].([]store.ShadowTableCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckShadowTables indicates an expected call of CheckShadowTables
+func (mr *MockDatabaseMockRecorder) CheckShadowTables(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckShadowTables", reflect.TypeOf((*MockDatabase)(nil).CheckShadowTables), arg0, arg1)
+}
+
+// CreateRestoredRange mocks base method
+func (m *MockDatabase) CreateRestoredRange(arg0 *model.RestoredRange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRestoredRange", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRestoredRange indicates an expected call of CreateRestoredRange
+func (mr *MockDatabaseMockRecorder) CreateRestoredRange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRestoredRange", reflect.TypeOf((*MockDatabase)(nil).CreateRestoredRange), arg0)
+}
+
+// CreateShadowTables mocks base method
+func (m *MockDatabase) CreateShadowTables(arg0 []store.ShadowTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShadowTables", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShadowTables indicates an expected call of CreateShadowTables
+func (mr *MockDatabaseMockRecorder) CreateShadowTables(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShadowTables", reflect.TypeOf((*MockDatabase)(nil).CreateShadowTables), arg0)
+}
+
+// DropShadowTables mocks base method
+func (m *MockDatabase) DropShadowTables() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropShadowTables")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DropShadowTables indicates an expected call of DropShadowTables
+func (mr *MockDatabaseMockRecorder) DropShadowTables() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropShadowTables", reflect.TypeOf((*MockDatabase)(nil).DropShadowTables))
+}
+
+// GetMigrationVersion mocks base method
+func (m *MockDatabase) GetMigrationVersion() (*store.GetMigrationVersionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMigrationVersion")
+	ret0, _ := ret[0].(*store.GetMigrationVersionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMigrationVersion indicates an expected call of GetMigrationVersion
+func (mr *MockDatabaseMockRecorder) GetMigrationVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMigrationVersion", reflect.TypeOf((*MockDatabase)(nil).GetMigrationVersion))
+}
+
 // GetTotalSize mocks base method
 func (m *MockDatabase) GetTotalSize() (*store.GetTotalSizeResult, error) {
 	m.ctrl.T.Helper()
@@ -414,6 +575,116 @@ func (mr *MockDatabaseMockRecorder) GetTotalSize() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalSize", reflect.TypeOf((*MockDatabase)(nil).GetTotalSize))
 }
 
+// OpenShadowStore mocks base method
+func (m *MockDatabase) OpenShadowStore() (store.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenShadowStore")
+	ret0, _ := ret[0].(store.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenShadowStore indicates an expected call of OpenShadowStore
+func (mr *MockDatabaseMockRecorder) OpenShadowStore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenShadowStore", reflect.TypeOf((*MockDatabase)(nil).OpenShadowStore))
+}
+
+// Ping mocks base method
+func (m *MockDatabase) Ping() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping
+func (mr *MockDatabaseMockRecorder) Ping() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockDatabase)(nil).Ping))
+}
+
+// SwapShadowTables mocks base method
+func (m *MockDatabase) SwapShadowTables(arg0 []store.ShadowTable, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapShadowTables", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SwapShadowTables indicates an expected call of SwapShadowTables
+func (mr *MockDatabaseMockRecorder) SwapShadowTables(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapShadowTables", reflect.TypeOf((*MockDatabase)(nil).SwapShadowTables), arg0, arg1)
+}
+
+// MockEraSummary is a mock of EraSummary interface
+type MockEraSummary struct {
+	ctrl     *gomock.Controller
+	recorder *MockEraSummaryMockRecorder
+}
+
+// MockEraSummaryMockRecorder is the mock recorder for MockEraSummary
+type MockEraSummaryMockRecorder struct {
+	mock *MockEraSummary
+}
+
+// NewMockEraSummary creates a new mock instance
+func NewMockEraSummary(ctrl *gomock.Controller) *MockEraSummary {
+	mock := &MockEraSummary{ctrl: ctrl}
+	mock.recorder = &MockEraSummaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEraSummary) EXPECT() *MockEraSummaryMockRecorder {
+	return m.recorder
+}
+
+// FindEraSummaries mocks base method
+func (m *MockEraSummary) FindEraSummaries(arg0 int64) ([]store.EraSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEraSummaries", arg0)
+	ret0, _ := ret[0].([]store.EraSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEraSummaries indicates an expected call of FindEraSummaries
+func (mr *MockEraSummaryMockRecorder) FindEraSummaries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEraSummaries", reflect.TypeOf((*MockEraSummary)(nil).FindEraSummaries), arg0)
+}
+
+// FindEraSummaryByEra mocks base method
+func (m *MockEraSummary) FindEraSummaryByEra(arg0 int64) (*store.EraSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEraSummaryByEra", arg0)
+	ret0, _ := ret[0].(*store.EraSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEraSummaryByEra indicates an expected call of FindEraSummaryByEra
+func (mr *MockEraSummaryMockRecorder) FindEraSummaryByEra(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEraSummaryByEra", reflect.TypeOf((*MockEraSummary)(nil).FindEraSummaryByEra), arg0)
+}
+
+// UpsertEraSummary mocks base method
+func (m *MockEraSummary) UpsertEraSummary(arg0 *model.EraSummary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertEraSummary", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertEraSummary indicates an expected call of UpsertEraSummary
+func (mr *MockEraSummaryMockRecorder) UpsertEraSummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEraSummary", reflect.TypeOf((*MockEraSummary)(nil).UpsertEraSummary), arg0)
+}
+
 // MockEventSeq is a mock of EventSeq interface
 type MockEventSeq struct {
 	ctrl     *gomock.Controller
@@ -511,6 +782,21 @@ func (mr *MockEventSeqMockRecorder) FindByHeightAndIndex(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHeightAndIndex", reflect.TypeOf((*MockEventSeq)(nil).FindByHeightAndIndex), arg0, arg1)
 }
 
+// FindForHeightRange mocks base method
+func (m *MockEventSeq) FindForHeightRange(arg0, arg1 int64, arg2 string) ([]model.EventSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForHeightRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.EventSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForHeightRange indicates an expected call of FindForHeightRange
+func (mr *MockEventSeqMockRecorder) FindForHeightRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForHeightRange", reflect.TypeOf((*MockEventSeq)(nil).FindForHeightRange), arg0, arg1, arg2)
+}
+
 // FindRewardsForTimePeriod mocks base method
 func (m *MockEventSeq) FindRewardsForTimePeriod(arg0 string, arg1, arg2 time.Time) ([]model.EventSeq, error) {
 	m.ctrl.T.Helper()
@@ -526,6 +812,21 @@ func (mr *MockEventSeqMockRecorder) FindRewardsForTimePeriod(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRewardsForTimePeriod", reflect.TypeOf((*MockEventSeq)(nil).FindRewardsForTimePeriod), arg0, arg1, arg2)
 }
 
+// FindSlashesForHeightRange mocks base method
+func (m *MockEventSeq) FindSlashesForHeightRange(arg0, arg1 int64) ([]model.EventSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSlashesForHeightRange", arg0, arg1)
+	ret0, _ := ret[0].([]model.EventSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSlashesForHeightRange indicates an expected call of FindSlashesForHeightRange
+func (mr *MockEventSeqMockRecorder) FindSlashesForHeightRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSlashesForHeightRange", reflect.TypeOf((*MockEventSeq)(nil).FindSlashesForHeightRange), arg0, arg1)
+}
+
 // FindUnbonded mocks base method
 func (m *MockEventSeq) FindUnbonded(arg0 string) ([]model.EventSeqWithTxHash, error) {
 	m.ctrl.T.Helper()
@@ -556,6 +857,273 @@ func (mr *MockEventSeqMockRecorder) FindWithdrawn(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWithdrawn", reflect.TypeOf((*MockEventSeq)(nil).FindWithdrawn), arg0)
 }
 
+// MockEventSummary is a mock of EventSummary interface
+type MockEventSummary struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventSummaryMockRecorder
+}
+
+// MockEventSummaryMockRecorder is the mock recorder for MockEventSummary
+type MockEventSummaryMockRecorder struct {
+	mock *MockEventSummary
+}
+
+// NewMockEventSummary creates a new mock instance
+func NewMockEventSummary(ctrl *gomock.Controller) *MockEventSummary {
+	mock := &MockEventSummary{ctrl: ctrl}
+	mock.recorder = &MockEventSummaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockEventSummary) EXPECT() *MockEventSummaryMockRecorder {
+	return m.recorder
+}
+
+// DeleteEventSummariesOlderThan mocks base method
+func (m *MockEventSummary) DeleteEventSummariesOlderThan(arg0 types.SummaryInterval, arg1 time.Time) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventSummariesOlderThan", arg0, arg1)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventSummariesOlderThan indicates an expected call of DeleteEventSummariesOlderThan
+func (mr *MockEventSummaryMockRecorder) DeleteEventSummariesOlderThan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventSummariesOlderThan", reflect.TypeOf((*MockEventSummary)(nil).DeleteEventSummariesOlderThan), arg0, arg1)
+}
+
+// FindEventSummaries mocks base method
+func (m *MockEventSummary) FindEventSummaries(arg0 types.SummaryInterval, arg1 string, arg2 int64, arg3, arg4 string) ([]model.EventSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventSummaries", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.EventSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventSummaries indicates an expected call of FindEventSummaries
+func (mr *MockEventSummaryMockRecorder) FindEventSummaries(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventSummaries", reflect.TypeOf((*MockEventSummary)(nil).FindEventSummaries), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FindEventTotals mocks base method
+func (m *MockEventSummary) FindEventTotals(arg0 types.SummaryInterval, arg1 string, arg2 int64) ([]model.EventSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEventTotals", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.EventSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEventTotals indicates an expected call of FindEventTotals
+func (mr *MockEventSummaryMockRecorder) FindEventTotals(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventTotals", reflect.TypeOf((*MockEventSummary)(nil).FindEventTotals), arg0, arg1, arg2)
+}
+
+// FindMostRecentEventSummary mocks base method
+func (m *MockEventSummary) FindMostRecentEventSummary(arg0 types.SummaryInterval) (*model.EventSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMostRecentEventSummary", arg0)
+	ret0, _ := ret[0].(*model.EventSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMostRecentEventSummary indicates an expected call of FindMostRecentEventSummary
+func (mr *MockEventSummaryMockRecorder) FindMostRecentEventSummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMostRecentEventSummary", reflect.TypeOf((*MockEventSummary)(nil).FindMostRecentEventSummary), arg0)
+}
+
+// SummarizeEventSeqs mocks base method
+func (m *MockEventSummary) SummarizeEventSeqs(arg0 types.SummaryInterval, arg1 int64) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeEventSeqs", arg0, arg1)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeEventSeqs indicates an expected call of SummarizeEventSeqs
+func (mr *MockEventSummaryMockRecorder) SummarizeEventSeqs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeEventSeqs", reflect.TypeOf((*MockEventSummary)(nil).SummarizeEventSeqs), arg0, arg1)
+}
+
+// MockJobs is a mock of Jobs interface
+type MockJobs struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobsMockRecorder
+}
+
+// MockJobsMockRecorder is the mock recorder for MockJobs
+type MockJobsMockRecorder struct {
+	mock *MockJobs
+}
+
+// NewMockJobs creates a new mock instance
+func NewMockJobs(ctrl *gomock.Controller) *MockJobs {
+	mock := &MockJobs{ctrl: ctrl}
+	mock.recorder = &MockJobsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockJobs) EXPECT() *MockJobsMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method
+func (m *MockJobs) Cancel(arg0 types.ID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel
+func (mr *MockJobsMockRecorder) Cancel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockJobs)(nil).Cancel), arg0)
+}
+
+// Claim mocks base method
+func (m *MockJobs) Claim(arg0 string, arg1 []model.JobKind, arg2 time.Duration) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim
+func (mr *MockJobsMockRecorder) Claim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockJobs)(nil).Claim), arg0, arg1, arg2)
+}
+
+// Create mocks base method
+func (m *MockJobs) Create(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockJobsMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockJobs)(nil).Create), arg0)
+}
+
+// CreateUnique mocks base method
+func (m *MockJobs) CreateUnique(arg0 *model.Job, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUnique", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUnique indicates an expected call of CreateUnique
+func (mr *MockJobsMockRecorder) CreateUnique(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnique", reflect.TypeOf((*MockJobs)(nil).CreateUnique), arg0, arg1)
+}
+
+// FindByID mocks base method
+func (m *MockJobs) FindByID(arg0 types.ID) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID
+func (mr *MockJobsMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockJobs)(nil).FindByID), arg0)
+}
+
+// FindRecent mocks base method
+func (m *MockJobs) FindRecent(arg0 int64) ([]model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecent", arg0)
+	ret0, _ := ret[0].([]model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecent indicates an expected call of FindRecent
+func (mr *MockJobsMockRecorder) FindRecent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecent", reflect.TypeOf((*MockJobs)(nil).FindRecent), arg0)
+}
+
+// Finish mocks base method
+func (m *MockJobs) Finish(arg0 *model.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish
+func (mr *MockJobsMockRecorder) Finish(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockJobs)(nil).Finish), arg0)
+}
+
+// Heartbeat mocks base method
+func (m *MockJobs) Heartbeat(arg0 *model.Job) (model.JobStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Heartbeat", arg0)
+	ret0, _ := ret[0].(model.JobStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Heartbeat indicates an expected call of Heartbeat
+func (mr *MockJobsMockRecorder) Heartbeat(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockJobs)(nil).Heartbeat), arg0)
+}
+
+// Save mocks base method
+func (m *MockJobs) Save(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockJobsMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockJobs)(nil).Save), arg0)
+}
+
+// Update mocks base method
+func (m *MockJobs) Update(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockJobsMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockJobs)(nil).Update), arg0)
+}
+
 // MockReports is a mock of Reports interface
 type MockReports struct {
 	ctrl     *gomock.Controller
@@ -607,6 +1175,25 @@ func (mr *MockReportsMockRecorder) DeleteByKinds(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByKinds", reflect.TypeOf((*MockReports)(nil).DeleteByKinds), arg0)
 }
 
+// FindLastCompleted mocks base method
+func (m *MockReports) FindLastCompleted(arg0 ...model.ReportKind) (*model.Report, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindLastCompleted", varargs...)
+	ret0, _ := ret[0].(*model.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastCompleted indicates an expected call of FindLastCompleted
+func (mr *MockReportsMockRecorder) FindLastCompleted(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastCompleted", reflect.TypeOf((*MockReports)(nil).FindLastCompleted), arg0...)
+}
+
 // FindNotCompletedByIndexVersion mocks base method
 func (m *MockReports) FindNotCompletedByIndexVersion(arg0 int64, arg1 ...model.ReportKind) (*model.Report, error) {
 	m.ctrl.T.Helper()
@@ -646,6 +1233,21 @@ func (mr *MockReportsMockRecorder) FindNotCompletedByKind(arg0 ...interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotCompletedByKind", reflect.TypeOf((*MockReports)(nil).FindNotCompletedByKind), arg0...)
 }
 
+// FindRecent mocks base method
+func (m *MockReports) FindRecent(arg0 int64) ([]model.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecent", arg0)
+	ret0, _ := ret[0].([]model.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecent indicates an expected call of FindRecent
+func (mr *MockReportsMockRecorder) FindRecent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecent", reflect.TypeOf((*MockReports)(nil).FindRecent), arg0)
+}
+
 // Last mocks base method
 func (m *MockReports) Last() (*model.Report, error) {
 	m.ctrl.T.Helper()
@@ -726,6 +1328,21 @@ func (mr *MockRewardsMockRecorder) BulkUpsert(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockRewards)(nil).BulkUpsert), arg0)
 }
 
+// FindForEraRange mocks base method
+func (m *MockRewards) FindForEraRange(arg0, arg1 int64, arg2 string) ([]model.RewardEraSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForEraRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.RewardEraSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForEraRange indicates an expected call of FindForEraRange
+func (mr *MockRewardsMockRecorder) FindForEraRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForEraRange", reflect.TypeOf((*MockRewards)(nil).FindForEraRange), arg0, arg1, arg2)
+}
+
 // GetAll mocks base method
 func (m *MockRewards) GetAll(arg0, arg1 string, arg2, arg3 int64) ([]model.RewardEraSeq, error) {
 	m.ctrl.T.Helper()
@@ -800,6 +1417,202 @@ func (mr *MockRewardsMockRecorder) MarkAllClaimed(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllClaimed", reflect.TypeOf((*MockRewards)(nil).MarkAllClaimed), arg0, arg1, arg2)
 }
 
+// SumNominatorRewardsForEraRange mocks base method
+func (m *MockRewards) SumNominatorRewardsForEraRange(arg0, arg1 int64) ([]store.ValidatorEraRewardRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumNominatorRewardsForEraRange", arg0, arg1)
+	ret0, _ := ret[0].([]store.ValidatorEraRewardRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumNominatorRewardsForEraRange indicates an expected call of SumNominatorRewardsForEraRange
+func (mr *MockRewardsMockRecorder) SumNominatorRewardsForEraRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumNominatorRewardsForEraRange", reflect.TypeOf((*MockRewards)(nil).SumNominatorRewardsForEraRange), arg0, arg1)
+}
+
+// MockSubscriptions is a mock of Subscriptions interface
+type MockSubscriptions struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionsMockRecorder
+}
+
+// MockSubscriptionsMockRecorder is the mock recorder for MockSubscriptions
+type MockSubscriptionsMockRecorder struct {
+	mock *MockSubscriptions
+}
+
+// NewMockSubscriptions creates a new mock instance
+func NewMockSubscriptions(ctrl *gomock.Controller) *MockSubscriptions {
+	mock := &MockSubscriptions{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubscriptions) EXPECT() *MockSubscriptionsMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method
+func (m *MockSubscriptions) Claim(arg0 string, arg1 time.Duration, arg2 time.Time) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim
+func (mr *MockSubscriptionsMockRecorder) Claim(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockSubscriptions)(nil).Claim), arg0, arg1, arg2)
+}
+
+// Create mocks base method
+func (m *MockSubscriptions) Create(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockSubscriptionsMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSubscriptions)(nil).Create), arg0)
+}
+
+// CreateDelivery mocks base method
+func (m *MockSubscriptions) CreateDelivery(arg0 *model.SubscriptionDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery
+func (mr *MockSubscriptionsMockRecorder) CreateDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockSubscriptions)(nil).CreateDelivery), arg0)
+}
+
+// Delete mocks base method
+func (m *MockSubscriptions) Delete(arg0 types.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockSubscriptionsMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSubscriptions)(nil).Delete), arg0)
+}
+
+// FindAll mocks base method
+func (m *MockSubscriptions) FindAll() ([]model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll
+func (mr *MockSubscriptionsMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSubscriptions)(nil).FindAll))
+}
+
+// FindByID mocks base method
+func (m *MockSubscriptions) FindByID(arg0 types.ID) (*model.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0)
+	ret0, _ := ret[0].(*model.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID
+func (mr *MockSubscriptionsMockRecorder) FindByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSubscriptions)(nil).FindByID), arg0)
+}
+
+// FindDeliveries mocks base method
+func (m *MockSubscriptions) FindDeliveries(arg0 types.ID, arg1 int64) ([]model.SubscriptionDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]model.SubscriptionDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveries indicates an expected call of FindDeliveries
+func (mr *MockSubscriptionsMockRecorder) FindDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveries", reflect.TypeOf((*MockSubscriptions)(nil).FindDeliveries), arg0, arg1)
+}
+
+// Release mocks base method
+func (m *MockSubscriptions) Release(arg0 *model.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release
+func (mr *MockSubscriptionsMockRecorder) Release(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockSubscriptions)(nil).Release), arg0)
+}
+
+// Save mocks base method
+func (m *MockSubscriptions) Save(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save
+func (mr *MockSubscriptionsMockRecorder) Save(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSubscriptions)(nil).Save), arg0)
+}
+
+// SaveSettings mocks base method
+func (m *MockSubscriptions) SaveSettings(arg0 *model.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSettings indicates an expected call of SaveSettings
+func (mr *MockSubscriptionsMockRecorder) SaveSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSettings", reflect.TypeOf((*MockSubscriptions)(nil).SaveSettings), arg0)
+}
+
+// Update mocks base method
+func (m *MockSubscriptions) Update(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockSubscriptionsMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSubscriptions)(nil).Update), arg0)
+}
+
 // MockSyncables is a mock of Syncables interface
 type MockSyncables struct {
 	ctrl     *gomock.Controller
@@ -826,15 +1639,44 @@ func (m *MockSyncables) EXPECT() *MockSyncablesMockRecorder {
 // CreateOrUpdate mocks base method
 func (m *MockSyncables) CreateOrUpdate(arg0 *model.Syncable) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0)
+	ret := m.ctrl.Call(m, "CreateOrUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdate indicates an expected call of CreateOrUpdate
+func (mr *MockSyncablesMockRecorder) CreateOrUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockSyncables)(nil).CreateOrUpdate), arg0)
+}
+
+// DeleteHeightUpdatesOlderThan mocks base method
+func (m *MockSyncables) DeleteHeightUpdatesOlderThan(arg0 time.Time) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHeightUpdatesOlderThan", arg0)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHeightUpdatesOlderThan indicates an expected call of DeleteHeightUpdatesOlderThan
+func (mr *MockSyncablesMockRecorder) DeleteHeightUpdatesOlderThan(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHeightUpdatesOlderThan", reflect.TypeOf((*MockSyncables)(nil).DeleteHeightUpdatesOlderThan), arg0)
+}
+
+// DeleteOutboxMessages mocks base method
+func (m *MockSyncables) DeleteOutboxMessages(arg0 []types.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutboxMessages", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateOrUpdate indicates an expected call of CreateOrUpdate
-func (mr *MockSyncablesMockRecorder) CreateOrUpdate(arg0 interface{}) *gomock.Call {
+// DeleteOutboxMessages indicates an expected call of DeleteOutboxMessages
+func (mr *MockSyncablesMockRecorder) DeleteOutboxMessages(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdate", reflect.TypeOf((*MockSyncables)(nil).CreateOrUpdate), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutboxMessages", reflect.TypeOf((*MockSyncables)(nil).DeleteOutboxMessages), arg0)
 }
 
 // FindAllByLastInSessionOrEra mocks base method
@@ -882,6 +1724,36 @@ func (mr *MockSyncablesMockRecorder) FindFirstByDifferentIndexVersion(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirstByDifferentIndexVersion", reflect.TypeOf((*MockSyncables)(nil).FindFirstByDifferentIndexVersion), arg0)
 }
 
+// FindFirstHeightUpdate mocks base method
+func (m *MockSyncables) FindFirstHeightUpdate() (*model.HeightUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFirstHeightUpdate")
+	ret0, _ := ret[0].(*model.HeightUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFirstHeightUpdate indicates an expected call of FindFirstHeightUpdate
+func (mr *MockSyncablesMockRecorder) FindFirstHeightUpdate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFirstHeightUpdate", reflect.TypeOf((*MockSyncables)(nil).FindFirstHeightUpdate))
+}
+
+// FindHeightUpdatesAfter mocks base method
+func (m *MockSyncables) FindHeightUpdatesAfter(arg0, arg1 int64) ([]model.HeightUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindHeightUpdatesAfter", arg0, arg1)
+	ret0, _ := ret[0].([]model.HeightUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindHeightUpdatesAfter indicates an expected call of FindHeightUpdatesAfter
+func (mr *MockSyncablesMockRecorder) FindHeightUpdatesAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindHeightUpdatesAfter", reflect.TypeOf((*MockSyncables)(nil).FindHeightUpdatesAfter), arg0, arg1)
+}
+
 // FindLastEndOfEra mocks base method
 func (m *MockSyncables) FindLastEndOfEra() (*model.Syncable, error) {
 	m.ctrl.T.Helper()
@@ -912,6 +1784,21 @@ func (mr *MockSyncablesMockRecorder) FindLastEndOfSession() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastEndOfSession", reflect.TypeOf((*MockSyncables)(nil).FindLastEndOfSession))
 }
 
+// FindLastHeightUpdate mocks base method
+func (m *MockSyncables) FindLastHeightUpdate() (*model.HeightUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastHeightUpdate")
+	ret0, _ := ret[0].(*model.HeightUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastHeightUpdate indicates an expected call of FindLastHeightUpdate
+func (mr *MockSyncablesMockRecorder) FindLastHeightUpdate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastHeightUpdate", reflect.TypeOf((*MockSyncables)(nil).FindLastHeightUpdate))
+}
+
 // FindLastInEra mocks base method
 func (m *MockSyncables) FindLastInEra(arg0 int64) (*model.Syncable, error) {
 	m.ctrl.T.Helper()
@@ -987,6 +1874,21 @@ func (mr *MockSyncablesMockRecorder) FindMostRecentByDifferentIndexVersion(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMostRecentByDifferentIndexVersion", reflect.TypeOf((*MockSyncables)(nil).FindMostRecentByDifferentIndexVersion), arg0)
 }
 
+// FindOutboxMessages mocks base method
+func (m *MockSyncables) FindOutboxMessages(arg0 int64) ([]model.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOutboxMessages", arg0)
+	ret0, _ := ret[0].([]model.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOutboxMessages indicates an expected call of FindOutboxMessages
+func (mr *MockSyncablesMockRecorder) FindOutboxMessages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOutboxMessages", reflect.TypeOf((*MockSyncables)(nil).FindOutboxMessages), arg0)
+}
+
 // FindSmallestIndexVersion mocks base method
 func (m *MockSyncables) FindSmallestIndexVersion() (*int64, error) {
 	m.ctrl.T.Helper()
@@ -1002,6 +1904,49 @@ func (mr *MockSyncablesMockRecorder) FindSmallestIndexVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSmallestIndexVersion", reflect.TypeOf((*MockSyncables)(nil).FindSmallestIndexVersion))
 }
 
+// GetReportProgress mocks base method
+func (m *MockSyncables) GetReportProgress(arg0 types.ID) (*store.ReportProgressRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportProgress", arg0)
+	ret0, _ := ret[0].(*store.ReportProgressRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportProgress indicates an expected call of GetReportProgress
+func (mr *MockSyncablesMockRecorder) GetReportProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportProgress", reflect.TypeOf((*MockSyncables)(nil).GetReportProgress), arg0)
+}
+
+// MarkOutboxMessagesFailed mocks base method
+func (m *MockSyncables) MarkOutboxMessagesFailed(arg0 []types.ID, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxMessagesFailed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxMessagesFailed indicates an expected call of MarkOutboxMessagesFailed
+func (mr *MockSyncablesMockRecorder) MarkOutboxMessagesFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxMessagesFailed", reflect.TypeOf((*MockSyncables)(nil).MarkOutboxMessagesFailed), arg0, arg1)
+}
+
+// PublishHeightUpdate mocks base method
+func (m *MockSyncables) PublishHeightUpdate(arg0 *model.HeightUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishHeightUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishHeightUpdate indicates an expected call of PublishHeightUpdate
+func (mr *MockSyncablesMockRecorder) PublishHeightUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishHeightUpdate", reflect.TypeOf((*MockSyncables)(nil).PublishHeightUpdate), arg0)
+}
+
 // SaveSyncable mocks base method
 func (m *MockSyncables) SaveSyncable(arg0 *model.Syncable) error {
 	m.ctrl.T.Helper()
@@ -1016,6 +1961,20 @@ func (mr *MockSyncablesMockRecorder) SaveSyncable(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSyncable", reflect.TypeOf((*MockSyncables)(nil).SaveSyncable), arg0)
 }
 
+// SaveSyncableWithMessage mocks base method
+func (m *MockSyncables) SaveSyncableWithMessage(arg0 *model.Syncable, arg1 *model.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSyncableWithMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSyncableWithMessage indicates an expected call of SaveSyncableWithMessage
+func (mr *MockSyncablesMockRecorder) SaveSyncableWithMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSyncableWithMessage", reflect.TypeOf((*MockSyncables)(nil).SaveSyncableWithMessage), arg0, arg1)
+}
+
 // SetProcessedAtForRange mocks base method
 func (m *MockSyncables) SetProcessedAtForRange(arg0 types.ID, arg1, arg2 int64) error {
 	m.ctrl.T.Helper()
@@ -1082,6 +2041,80 @@ func (mr *MockSystemEventsMockRecorder) FindByActor(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByActor", reflect.TypeOf((*MockSystemEvents)(nil).FindByActor), arg0, arg1, arg2)
 }
 
+// FindByKinds mocks base method
+func (m *MockSystemEvents) FindByKinds(arg0 []model.SystemEventKind, arg1 int64) ([]model.SystemEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByKinds", arg0, arg1)
+	ret0, _ := ret[0].([]model.SystemEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByKinds indicates an expected call of FindByKinds
+func (mr *MockSystemEventsMockRecorder) FindByKinds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByKinds", reflect.TypeOf((*MockSystemEvents)(nil).FindByKinds), arg0, arg1)
+}
+
+// FindForHeightRange mocks base method
+func (m *MockSystemEvents) FindForHeightRange(arg0, arg1 int64, arg2, arg3 []string) ([]model.SystemEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForHeightRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.SystemEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForHeightRange indicates an expected call of FindForHeightRange
+func (mr *MockSystemEventsMockRecorder) FindForHeightRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForHeightRange", reflect.TypeOf((*MockSystemEvents)(nil).FindForHeightRange), arg0, arg1, arg2, arg3)
+}
+
+// FindForSubscription mocks base method
+func (m *MockSystemEvents) FindForSubscription(arg0 *model.Subscription, arg1, arg2 int64) ([]model.SystemEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForSubscription", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.SystemEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForSubscription indicates an expected call of FindForSubscription
+func (mr *MockSystemEventsMockRecorder) FindForSubscription(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForSubscription", reflect.TypeOf((*MockSystemEvents)(nil).FindForSubscription), arg0, arg1, arg2)
+}
+
+// FindLastID mocks base method
+func (m *MockSystemEvents) FindLastID() (types.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastID")
+	ret0, _ := ret[0].(types.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastID indicates an expected call of FindLastID
+func (mr *MockSystemEventsMockRecorder) FindLastID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastID", reflect.TypeOf((*MockSystemEvents)(nil).FindLastID))
+}
+
+// ReplaceByIDs mocks base method
+func (m *MockSystemEvents) ReplaceByIDs(arg0 []types.ID, arg1 []model.SystemEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceByIDs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceByIDs indicates an expected call of ReplaceByIDs
+func (mr *MockSystemEventsMockRecorder) ReplaceByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceByIDs", reflect.TypeOf((*MockSystemEvents)(nil).ReplaceByIDs), arg0, arg1)
+}
+
 // MockTransactionSeq is a mock of TransactionSeq interface
 type MockTransactionSeq struct {
 	ctrl     *gomock.Controller
@@ -1119,6 +2152,21 @@ func (mr *MockTransactionSeqMockRecorder) BulkUpsert(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpsert", reflect.TypeOf((*MockTransactionSeq)(nil).BulkUpsert), arg0)
 }
 
+// FindForHeightRange mocks base method
+func (m *MockTransactionSeq) FindForHeightRange(arg0, arg1 int64, arg2 string) ([]model.TransactionSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForHeightRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TransactionSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForHeightRange indicates an expected call of FindForHeightRange
+func (mr *MockTransactionSeqMockRecorder) FindForHeightRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForHeightRange", reflect.TypeOf((*MockTransactionSeq)(nil).FindForHeightRange), arg0, arg1, arg2)
+}
+
 // GetTransactionsByTransactionKind mocks base method
 func (m *MockTransactionSeq) GetTransactionsByTransactionKind(arg0 model.TransactionKind, arg1, arg2 int64) ([]model.TransactionSeq, error) {
 	m.ctrl.T.Helper()
@@ -1134,6 +2182,104 @@ func (mr *MockTransactionSeqMockRecorder) GetTransactionsByTransactionKind(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionsByTransactionKind", reflect.TypeOf((*MockTransactionSeq)(nil).GetTransactionsByTransactionKind), arg0, arg1, arg2)
 }
 
+// MockTransactionSummary is a mock of TransactionSummary interface
+type MockTransactionSummary struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionSummaryMockRecorder
+}
+
+// MockTransactionSummaryMockRecorder is the mock recorder for MockTransactionSummary
+type MockTransactionSummaryMockRecorder struct {
+	mock *MockTransactionSummary
+}
+
+// NewMockTransactionSummary creates a new mock instance
+func NewMockTransactionSummary(ctrl *gomock.Controller) *MockTransactionSummary {
+	mock := &MockTransactionSummary{ctrl: ctrl}
+	mock.recorder = &MockTransactionSummaryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTransactionSummary) EXPECT() *MockTransactionSummaryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTransactionSummariesOlderThan mocks base method
+func (m *MockTransactionSummary) DeleteTransactionSummariesOlderThan(arg0 types.SummaryInterval, arg1 time.Time) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransactionSummariesOlderThan", arg0, arg1)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTransactionSummariesOlderThan indicates an expected call of DeleteTransactionSummariesOlderThan
+func (mr *MockTransactionSummaryMockRecorder) DeleteTransactionSummariesOlderThan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransactionSummariesOlderThan", reflect.TypeOf((*MockTransactionSummary)(nil).DeleteTransactionSummariesOlderThan), arg0, arg1)
+}
+
+// FindMostRecentTransactionSummary mocks base method
+func (m *MockTransactionSummary) FindMostRecentTransactionSummary(arg0 types.SummaryInterval) (*model.TransactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMostRecentTransactionSummary", arg0)
+	ret0, _ := ret[0].(*model.TransactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMostRecentTransactionSummary indicates an expected call of FindMostRecentTransactionSummary
+func (mr *MockTransactionSummaryMockRecorder) FindMostRecentTransactionSummary(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMostRecentTransactionSummary", reflect.TypeOf((*MockTransactionSummary)(nil).FindMostRecentTransactionSummary), arg0)
+}
+
+// FindTransactionSummaries mocks base method
+func (m *MockTransactionSummary) FindTransactionSummaries(arg0 types.SummaryInterval, arg1 string, arg2 int64, arg3, arg4 string) ([]model.TransactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionSummaries", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.TransactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionSummaries indicates an expected call of FindTransactionSummaries
+func (mr *MockTransactionSummaryMockRecorder) FindTransactionSummaries(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionSummaries", reflect.TypeOf((*MockTransactionSummary)(nil).FindTransactionSummaries), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FindTransactionTotals mocks base method
+func (m *MockTransactionSummary) FindTransactionTotals(arg0 types.SummaryInterval, arg1 string, arg2 int64) ([]model.TransactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTransactionTotals", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TransactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTransactionTotals indicates an expected call of FindTransactionTotals
+func (mr *MockTransactionSummaryMockRecorder) FindTransactionTotals(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTransactionTotals", reflect.TypeOf((*MockTransactionSummary)(nil).FindTransactionTotals), arg0, arg1, arg2)
+}
+
+// SummarizeTransactionSeqs mocks base method
+func (m *MockTransactionSummary) SummarizeTransactionSeqs(arg0 types.SummaryInterval, arg1 int64) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeTransactionSeqs", arg0, arg1)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeTransactionSeqs indicates an expected call of SummarizeTransactionSeqs
+func (mr *MockTransactionSummaryMockRecorder) SummarizeTransactionSeqs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeTransactionSeqs", reflect.TypeOf((*MockTransactionSummary)(nil).SummarizeTransactionSeqs), arg0, arg1)
+}
+
 // MockValidatorAgg is a mock of ValidatorAgg interface
 type MockValidatorAgg struct {
 	ctrl     *gomock.Controller
@@ -1268,6 +2414,21 @@ func (m *MockValidatorSeq) EXPECT() *MockValidatorSeqMockRecorder {
 	return m.recorder
 }
 
+// ArchiveSeqsOlderThan mocks base method
+func (m *MockValidatorSeq) ArchiveSeqsOlderThan(arg0 time.Time, arg1 int64, arg2 func([]model.ValidatorSeq) error) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSeqsOlderThan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSeqsOlderThan indicates an expected call of ArchiveSeqsOlderThan
+func (mr *MockValidatorSeqMockRecorder) ArchiveSeqsOlderThan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSeqsOlderThan", reflect.TypeOf((*MockValidatorSeq)(nil).ArchiveSeqsOlderThan), arg0, arg1, arg2)
+}
+
 // BulkUpsertSeqs mocks base method
 func (m *MockValidatorSeq) BulkUpsertSeqs(arg0 []model.ValidatorSeq) error {
 	m.ctrl.T.Helper()
@@ -1327,6 +2488,21 @@ func (mr *MockValidatorSeqMockRecorder) FindMostRecentSeq() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMostRecentSeq", reflect.TypeOf((*MockValidatorSeq)(nil).FindMostRecentSeq))
 }
 
+// FindSeqsForHeightRange mocks base method
+func (m *MockValidatorSeq) FindSeqsForHeightRange(arg0, arg1 int64, arg2 string) ([]model.ValidatorSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSeqsForHeightRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.ValidatorSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSeqsForHeightRange indicates an expected call of FindSeqsForHeightRange
+func (mr *MockValidatorSeqMockRecorder) FindSeqsForHeightRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSeqsForHeightRange", reflect.TypeOf((*MockValidatorSeq)(nil).FindSeqsForHeightRange), arg0, arg1, arg2)
+}
+
 // MockValidatorEraSeq is a mock of ValidatorEraSeq interface
 type MockValidatorEraSeq struct {
 	ctrl     *gomock.Controller
@@ -1424,6 +2600,21 @@ func (mr *MockValidatorEraSeqMockRecorder) FindEraSeqsByHeight(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEraSeqsByHeight", reflect.TypeOf((*MockValidatorEraSeq)(nil).FindEraSeqsByHeight), arg0)
 }
 
+// FindEraSeqsForEraRange mocks base method
+func (m *MockValidatorEraSeq) FindEraSeqsForEraRange(arg0, arg1 int64, arg2 string) ([]model.ValidatorEraSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEraSeqsForEraRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.ValidatorEraSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEraSeqsForEraRange indicates an expected call of FindEraSeqsForEraRange
+func (mr *MockValidatorEraSeqMockRecorder) FindEraSeqsForEraRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEraSeqsForEraRange", reflect.TypeOf((*MockValidatorEraSeq)(nil).FindEraSeqsForEraRange), arg0, arg1, arg2)
+}
+
 // FindLastEraSeqByStashAccount mocks base method
 func (m *MockValidatorEraSeq) FindLastEraSeqByStashAccount(arg0 string, arg1 int64) ([]model.ValidatorEraSeq, error) {
 	m.ctrl.T.Helper()
@@ -1469,6 +2660,21 @@ func (mr *MockValidatorEraSeqMockRecorder) SummarizeEraSeqs(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeEraSeqs", reflect.TypeOf((*MockValidatorEraSeq)(nil).SummarizeEraSeqs), arg0, arg1)
 }
 
+// SummarizeEraSeqsByEra mocks base method
+func (m *MockValidatorEraSeq) SummarizeEraSeqsByEra(arg0 time.Time) ([]model.ValidatorEraSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeEraSeqsByEra", arg0)
+	ret0, _ := ret[0].([]model.ValidatorEraSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeEraSeqsByEra indicates an expected call of SummarizeEraSeqsByEra
+func (mr *MockValidatorEraSeqMockRecorder) SummarizeEraSeqsByEra(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeEraSeqsByEra", reflect.TypeOf((*MockValidatorEraSeq)(nil).SummarizeEraSeqsByEra), arg0)
+}
+
 // MockValidatorSessionSeq is a mock of ValidatorSessionSeq interface
 type MockValidatorSessionSeq struct {
 	ctrl     *gomock.Controller
@@ -1492,6 +2698,21 @@ func (m *MockValidatorSessionSeq) EXPECT() *MockValidatorSessionSeqMockRecorder
 	return m.recorder
 }
 
+// ArchiveSessionSeqsOlderThan mocks base method
+func (m *MockValidatorSessionSeq) ArchiveSessionSeqsOlderThan(arg0 time.Time, arg1 int64, arg2 func([]model.ValidatorSessionSeq) error) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveSessionSeqsOlderThan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveSessionSeqsOlderThan indicates an expected call of ArchiveSessionSeqsOlderThan
+func (mr *MockValidatorSessionSeqMockRecorder) ArchiveSessionSeqsOlderThan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveSessionSeqsOlderThan", reflect.TypeOf((*MockValidatorSessionSeq)(nil).ArchiveSessionSeqsOlderThan), arg0, arg1, arg2)
+}
+
 // BulkUpsertSessionSeqs mocks base method
 func (m *MockValidatorSessionSeq) BulkUpsertSessionSeqs(arg0 []model.ValidatorSessionSeq) error {
 	m.ctrl.T.Helper()
@@ -1551,6 +2772,21 @@ func (mr *MockValidatorSessionSeqMockRecorder) FindBySessionAndStashAccount(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionAndStashAccount", reflect.TypeOf((*MockValidatorSessionSeq)(nil).FindBySessionAndStashAccount), arg0, arg1)
 }
 
+// FindBySessionRange mocks base method
+func (m *MockValidatorSessionSeq) FindBySessionRange(arg0, arg1 int64) ([]model.ValidatorSessionSeq, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySessionRange", arg0, arg1)
+	ret0, _ := ret[0].([]model.ValidatorSessionSeq)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySessionRange indicates an expected call of FindBySessionRange
+func (mr *MockValidatorSessionSeqMockRecorder) FindBySessionRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySessionRange", reflect.TypeOf((*MockValidatorSessionSeq)(nil).FindBySessionRange), arg0, arg1)
+}
+
 // FindLastSessionSeqByStashAccount mocks base method
 func (m *MockValidatorSessionSeq) FindLastSessionSeqByStashAccount(arg0 string, arg1 int64) ([]model.ValidatorSessionSeq, error) {
 	m.ctrl.T.Helper()
@@ -1611,6 +2847,21 @@ func (mr *MockValidatorSessionSeqMockRecorder) SummarizeSessionSeqs(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeSessionSeqs", reflect.TypeOf((*MockValidatorSessionSeq)(nil).SummarizeSessionSeqs), arg0, arg1)
 }
 
+// SummarizeSessionSeqsByEra mocks base method
+func (m *MockValidatorSessionSeq) SummarizeSessionSeqsByEra(arg0 time.Time) ([]model.ValidatorSessionSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeSessionSeqsByEra", arg0)
+	ret0, _ := ret[0].([]model.ValidatorSessionSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeSessionSeqsByEra indicates an expected call of SummarizeSessionSeqsByEra
+func (mr *MockValidatorSessionSeqMockRecorder) SummarizeSessionSeqsByEra(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeSessionSeqsByEra", reflect.TypeOf((*MockValidatorSessionSeq)(nil).SummarizeSessionSeqsByEra), arg0)
+}
+
 // MockValidatorSummary is a mock of ValidatorSummary interface
 type MockValidatorSummary struct {
 	ctrl     *gomock.Controller
@@ -1766,3 +3017,33 @@ func (mr *MockValidatorSummaryMockRecorder) SaveSummary(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSummary", reflect.TypeOf((*MockValidatorSummary)(nil).SaveSummary), arg0)
 }
+
+// SummarizeDailyEraSummaries mocks base method
+func (m *MockValidatorSummary) SummarizeDailyEraSummaries(arg0 types.SummaryInterval, arg1 time.Time, arg2 int64) ([]model.ValidatorEraSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeDailyEraSummaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.ValidatorEraSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeDailyEraSummaries indicates an expected call of SummarizeDailyEraSummaries
+func (mr *MockValidatorSummaryMockRecorder) SummarizeDailyEraSummaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeDailyEraSummaries", reflect.TypeOf((*MockValidatorSummary)(nil).SummarizeDailyEraSummaries), arg0, arg1, arg2)
+}
+
+// SummarizeDailySessionSummaries mocks base method
+func (m *MockValidatorSummary) SummarizeDailySessionSummaries(arg0 types.SummaryInterval, arg1 time.Time, arg2 int64) ([]model.ValidatorSessionSeqSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SummarizeDailySessionSummaries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.ValidatorSessionSeqSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SummarizeDailySessionSummaries indicates an expected call of SummarizeDailySessionSummaries
+func (mr *MockValidatorSummaryMockRecorder) SummarizeDailySessionSummaries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SummarizeDailySessionSummaries", reflect.TypeOf((*MockValidatorSummary)(nil).SummarizeDailySessionSummaries), arg0, arg1, arg2)
+}
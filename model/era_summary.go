@@ -0,0 +1,30 @@
+package model
+
+import "github.com/figment-networks/polkadothub-indexer/types"
+
+// EraSummary holds network wide staking aggregates of era, recorded at last height of era
+type EraSummary struct {
+	ID types.ID `json:"id"`
+
+	*EraSequence
+
+	TotalStake            types.Quantity `json:"total_stake"`
+	ActiveValidatorsCount int64          `json:"active_validators_count"`
+	NominatorsCount       int64          `json:"nominators_count"`
+	ValidatorStakeMin     types.Quantity `json:"validator_stake_min"`
+	ValidatorStakeMax     types.Quantity `json:"validator_stake_max"`
+	ValidatorStakeMedian  types.Quantity `json:"validator_stake_median"`
+	TotalRewardPayout     types.Quantity `json:"total_reward_payout"`
+	TotalRewardPoints     int64          `json:"total_reward_points"`
+	CommissionAvg         float64        `json:"commission_avg"`
+}
+
+func (EraSummary) TableName() string {
+	return "era_summaries"
+}
+
+func (s *EraSummary) Valid() bool {
+	return s.EraSequence.Valid() &&
+		s.ActiveValidatorsCount >= 0 &&
+		s.NominatorsCount >= 0
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// HeightUpdatesChannel is Postgres notification channel which indexer notifies once height update is persisted
+const HeightUpdatesChannel = "height_updates"
+
+// HeightUpdate is recorded by indexer for every processed height so readers can follow indexing progress
+type HeightUpdate struct {
+	*Model
+
+	Height         int64       `json:"height"`
+	Time           types.Time  `json:"time"`
+	Session        int64       `json:"session"`
+	Era            int64       `json:"era"`
+	LastInSession  bool        `json:"last_in_session"`
+	LastInEra      bool        `json:"last_in_era"`
+	RewardsClaimed types.Jsonb `json:"rewards_claimed"`
+}
+
+// RewardClaim is payout of validator rewards for era
+type RewardClaim struct {
+	Era            int64  `json:"era"`
+	ValidatorStash string `json:"validator_stash"`
+	TxHash         string `json:"tx_hash"`
+}
+
+// NewHeightUpdate creates height update for processed syncable
+func NewHeightUpdate(syncable *Syncable, rewardsClaimed []RewardClaim) (*HeightUpdate, error) {
+	if rewardsClaimed == nil {
+		rewardsClaimed = []RewardClaim{}
+	}
+
+	raw, err := json.Marshal(rewardsClaimed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeightUpdate{
+		Height:         syncable.Height,
+		Time:           syncable.Time,
+		Session:        syncable.Session,
+		Era:            syncable.Era,
+		LastInSession:  syncable.LastInSession,
+		LastInEra:      syncable.LastInEra,
+		RewardsClaimed: types.Jsonb{RawMessage: raw},
+	}, nil
+}
+
+func (HeightUpdate) TableName() string {
+	return "height_updates"
+}
+
+// GetRewardsClaimed decodes rewards claimed at height
+func (u *HeightUpdate) GetRewardsClaimed() ([]RewardClaim, error) {
+	var claims []RewardClaim
+	if len(u.RewardsClaimed.RawMessage) == 0 {
+		return claims, nil
+	}
+	err := json.Unmarshal(u.RewardsClaimed.RawMessage, &claims)
+	return claims, err
+}
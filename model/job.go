@@ -0,0 +1,140 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+const (
+	JobKindIndex     JobKind = "index"
+	JobKindBackfill  JobKind = "backfill"
+	JobKindReindex   JobKind = "reindex"
+	JobKindPurge     JobKind = "purge"
+	JobKindSummarize JobKind = "summarize"
+
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+
+	// JobErrorLockExpired is error message of job whose worker stopped during its last attempt
+	JobErrorLockExpired = "job lock expired during last attempt"
+)
+
+// JobKindGroups are kinds of jobs claimed by separate worker loops, so long running backfill, reindex or purge
+// doesn't hold back indexing of new heights
+var JobKindGroups = [][]JobKind{
+	{JobKindIndex},
+	{JobKindSummarize},
+	{JobKindPurge},
+	{JobKindBackfill, JobKindReindex},
+}
+
+type JobKind string
+
+func (k JobKind) String() string {
+	return string(k)
+}
+
+func (k JobKind) Valid() bool {
+	switch k {
+	case JobKindIndex, JobKindBackfill, JobKindReindex, JobKindPurge, JobKindSummarize:
+		return true
+	default:
+		return false
+	}
+}
+
+type JobStatus string
+
+func (s JobStatus) String() string {
+	return string(s)
+}
+
+// JobParams holds the same parameters as the indexer commands accept from cli flags
+type JobParams struct {
+	BatchSize     int64             `json:"batch_size,omitempty"`
+	Parallel      bool              `json:"parallel,omitempty"`
+	Force         bool              `json:"force,omitempty"`
+	Shadow        bool              `json:"shadow,omitempty"`
+	TargetIds     []int64           `json:"target_ids,omitempty"`
+	TrxKinds      []TransactionKind `json:"trx_kinds,omitempty"`
+	LastInSession bool              `json:"last_in_session,omitempty"`
+	LastInEra     bool              `json:"last_in_era,omitempty"`
+	StartHeight   int64             `json:"start_height,omitempty"`
+	EndHeight     int64             `json:"end_height,omitempty"`
+}
+
+type Job struct {
+	*Model
+
+	Kind        JobKind     `json:"kind"`
+	Params      types.Jsonb `json:"params"`
+	Status      JobStatus   `json:"status"`
+	UniqueKey   *string     `json:"unique_key,omitempty"`
+	Attempts    int64       `json:"attempts"`
+	MaxAttempts int64       `json:"max_attempts"`
+	RunAt       types.Time  `json:"run_at"`
+	LockedBy    *string     `json:"locked_by,omitempty"`
+	LockedAt    *types.Time `json:"locked_at,omitempty"`
+	Error       *string     `json:"error,omitempty"`
+	CompletedAt *types.Time `json:"completed_at,omitempty"`
+}
+
+// NewJob creates queued job which can be run right away
+func NewJob(kind JobKind, params JobParams, maxAttempts int64) (*Job, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Job{
+		Kind:        kind,
+		Params:      types.Jsonb{RawMessage: raw},
+		Status:      JobStatusQueued,
+		MaxAttempts: maxAttempts,
+		RunAt:       *types.NewTimeFromTime(time.Now()),
+	}, nil
+}
+
+func (Job) TableName() string {
+	return "jobs"
+}
+
+func (j *Job) Valid() bool {
+	return j.Kind.Valid() && j.MaxAttempts > 0
+}
+
+// GetParams decodes job params
+func (j *Job) GetParams() (JobParams, error) {
+	var params JobParams
+	if len(j.Params.RawMessage) == 0 {
+		return params, nil
+	}
+	err := json.Unmarshal(j.Params.RawMessage, &params)
+	return params, err
+}
+
+// Succeed marks job as completed
+func (j *Job) Succeed() {
+	j.Status = JobStatusCompleted
+	j.CompletedAt = types.NewTimeFromTime(time.Now())
+}
+
+// Fail schedules retry after delay multiplied by number of attempts or marks job as failed when attempts are exhausted
+func (j *Job) Fail(err error, retryDelay time.Duration) {
+	errMsg := err.Error()
+	j.Error = &errMsg
+
+	if j.Attempts < j.MaxAttempts {
+		j.Status = JobStatusQueued
+		j.RunAt = *types.NewTimeFromTime(time.Now().Add(retryDelay * time.Duration(j.Attempts)))
+		return
+	}
+
+	j.Status = JobStatusFailed
+	j.CompletedAt = types.NewTimeFromTime(time.Now())
+}
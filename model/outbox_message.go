@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// OutboxMessage is height message saved together with processed height, it's kept until publisher delivers it
+type OutboxMessage struct {
+	*Model
+
+	Height    int64       `json:"height"`
+	Payload   types.Jsonb `json:"payload"`
+	Attempts  int64       `json:"attempts"`
+	LastError *string     `json:"last_error"`
+}
+
+// HeightMessage is published to downstream consumers for every processed height with data indexer wrote for it.
+// Message of the same height can be published more than once, consumers should deduplicate them by height
+type HeightMessage struct {
+	Height        int64      `json:"height"`
+	Time          types.Time `json:"time"`
+	Session       int64      `json:"session"`
+	Era           int64      `json:"era"`
+	LastInSession bool       `json:"last_in_session"`
+	LastInEra     bool       `json:"last_in_era"`
+	IndexVersion  int64      `json:"index_version"`
+
+	BlockSequence             *BlockSeq             `json:"block_sequence"`
+	ValidatorSequences        []ValidatorSeq        `json:"validator_sequences"`
+	ValidatorSessionSequences []ValidatorSessionSeq `json:"validator_session_sequences"`
+	ValidatorEraSequences     []ValidatorEraSeq     `json:"validator_era_sequences"`
+	EventSequences            []EventSeq            `json:"event_sequences"`
+	AccountEraSequences       []AccountEraSeq       `json:"account_era_sequences"`
+	TransactionSequences      []TransactionSeq      `json:"transaction_sequences"`
+	RewardEraSequences        []RewardEraSeq        `json:"reward_era_sequences"`
+	EraSummary                *EraSummary           `json:"era_summary"`
+	RewardsClaimed            []RewardClaim         `json:"rewards_claimed"`
+	SystemEvents              []SystemEvent         `json:"system_events"`
+}
+
+// NewOutboxMessage creates outbox message for height message
+func NewOutboxMessage(message *HeightMessage) (*OutboxMessage, error) {
+	raw, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxMessage{
+		Height:  message.Height,
+		Payload: types.Jsonb{RawMessage: raw},
+	}, nil
+}
+
+func (OutboxMessage) TableName() string {
+	return "outbox_messages"
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"math/big"
+
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// PriceDateFormat is format of price date, prices are kept per UTC day
+const PriceDateFormat = "2006-01-02"
+
+// Price is price of token in currency for day, imported from external price history
+type Price struct {
+	*Model
+
+	Date     types.Time `json:"date"`
+	Currency string     `json:"currency"`
+	Price    string     `json:"price"`
+}
+
+func (Price) TableName() string {
+	return "prices"
+}
+
+func (p *Price) Valid() bool {
+	if p.Date.IsZero() || p.Currency == "" {
+		return false
+	}
+	price, ok := new(big.Rat).SetString(p.Price)
+	return ok && price.Sign() >= 0
+}
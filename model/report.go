@@ -10,6 +10,7 @@ const (
 	ReportKindIndex ReportKind = iota + 1
 	ReportKindParallelReindex
 	ReportKindSequentialReindex
+	ReportKindShadowSwap
 )
 
 type Report struct {
@@ -24,6 +25,7 @@ type Report struct {
 	ErrorMsg     *string
 	Duration     time.Duration
 	CompletedAt  *types.Time
+	Details      *types.Jsonb
 }
 
 type ReportKind int
@@ -36,6 +38,8 @@ func (k ReportKind) String() string {
 		return "parallel_reindex"
 	case ReportKindSequentialReindex:
 		return "sequential_reindex"
+	case ReportKindShadowSwap:
+		return "shadow_swap"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,13 @@
+package model
+
+// RestoredRange is height range restored from purge archive, purge keeps sequences within it
+type RestoredRange struct {
+	*Model
+
+	StartHeight int64 `json:"start_height"`
+	EndHeight   int64 `json:"end_height"`
+}
+
+func (RestoredRange) TableName() string {
+	return "restored_ranges"
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidSubscriptionURL    = errors.New("subscription url must be absolute http or https url")
+	ErrInvalidSubscriptionSecret = errors.New("subscription secret is required")
+	ErrInvalidSubscriptionKind   = errors.New("unknown system event kind")
+)
+
+// Subscription delivers system events of given kinds for given actors to url.
+// Empty actors or kinds match all of them
+type Subscription struct {
+	*Model
+
+	URL                   string         `json:"url"`
+	Secret                string         `json:"-"`
+	Actors                pq.StringArray `json:"actors"`
+	Kinds                 pq.StringArray `json:"kinds"`
+	Enabled               bool           `json:"enabled"`
+	LastSystemEventHeight int64          `json:"last_system_event_height"`
+	LastSystemEventID     types.ID       `json:"last_system_event_id"`
+	Failures              int64          `json:"failures"`
+	NextAttemptAt         types.Time     `json:"next_attempt_at"`
+	LockedBy              *string        `json:"locked_by,omitempty"`
+	LockedAt              *types.Time    `json:"locked_at,omitempty"`
+	DisabledAt            *types.Time    `json:"disabled_at,omitempty"`
+}
+
+// NewSubscription creates enabled subscription which delivers system events ordered after
+// lastSystemEventHeight and lastSystemEventID
+func NewSubscription(rawURL, secret string, actors, kinds []string, lastSystemEventHeight int64, lastSystemEventID types.ID) *Subscription {
+	// nil arrays are stored as NULL
+	if actors == nil {
+		actors = []string{}
+	}
+	if kinds == nil {
+		kinds = []string{}
+	}
+
+	return &Subscription{
+		URL:                   rawURL,
+		Secret:                secret,
+		Actors:                pq.StringArray(actors),
+		Kinds:                 pq.StringArray(kinds),
+		Enabled:               true,
+		LastSystemEventHeight: lastSystemEventHeight,
+		LastSystemEventID:     lastSystemEventID,
+		NextAttemptAt:         *types.NewTimeFromTime(time.Now()),
+	}
+}
+
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// Validate returns an error if subscription can't be delivered
+func (s *Subscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidSubscriptionURL
+	}
+
+	if s.Secret == "" {
+		return ErrInvalidSubscriptionSecret
+	}
+
+	for _, kind := range s.Kinds {
+		if !SystemEventKind(kind).Valid() {
+			return ErrInvalidSubscriptionKind
+		}
+	}
+	return nil
+}
+
+// Delivered moves subscription past delivered system event and resets failures
+func (s *Subscription) Delivered(systemEvent SystemEvent) {
+	s.LastSystemEventHeight = systemEvent.Height
+	s.LastSystemEventID = systemEvent.ID
+	s.Failures = 0
+}
+
+// Fail schedules retry with exponential backoff capped at maxRetryDelay, subscription is disabled once failures reach maxFailures
+func (s *Subscription) Fail(retryDelay, maxRetryDelay time.Duration, maxFailures int64) {
+	s.Failures++
+
+	if s.Failures >= maxFailures {
+		s.Enabled = false
+		s.DisabledAt = types.NewTimeFromTime(time.Now())
+		return
+	}
+
+	delay := maxRetryDelay
+	if s.Failures < 32 {
+		if d := retryDelay * time.Duration(1<<uint(s.Failures-1)); d > 0 && d < maxRetryDelay {
+			delay = d
+		}
+	}
+	s.NextAttemptAt = *types.NewTimeFromTime(time.Now().Add(delay))
+}
+
+// Enable enables subscription and resets failures
+func (s *Subscription) Enable() {
+	s.Enabled = true
+	s.Failures = 0
+	s.DisabledAt = nil
+	s.NextAttemptAt = *types.NewTimeFromTime(time.Now())
+}
+
+// Disable disables subscription
+func (s *Subscription) Disable() {
+	s.Enabled = false
+	s.DisabledAt = types.NewTimeFromTime(time.Now())
+}
+
+// SubscriptionDelivery is a log of single attempt to deliver system event to subscription
+type SubscriptionDelivery struct {
+	*Model
+
+	SubscriptionID types.ID `json:"subscription_id"`
+	SystemEventID  types.ID `json:"system_event_id"`
+	Attempt        int64    `json:"attempt"`
+	StatusCode     *int64   `json:"status_code,omitempty"`
+	Error          *string  `json:"error,omitempty"`
+	DurationMs     int64    `json:"duration_ms"`
+}
+
+func (SubscriptionDelivery) TableName() string {
+	return "subscription_deliveries"
+}
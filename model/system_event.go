@@ -3,25 +3,92 @@ package model
 import "github.com/figment-networks/polkadothub-indexer/types"
 
 const (
+	SystemEventActiveBalanceIncrease1 SystemEventKind = "active_balance_increase_1"
+	SystemEventActiveBalanceIncrease2 SystemEventKind = "active_balance_increase_2"
+	SystemEventActiveBalanceIncrease3 SystemEventKind = "active_balance_increase_3"
+	SystemEventActiveBalanceDecrease1 SystemEventKind = "active_balance_decrease_1"
+	SystemEventActiveBalanceDecrease2 SystemEventKind = "active_balance_decrease_2"
+	SystemEventActiveBalanceDecrease3 SystemEventKind = "active_balance_decrease_3"
+	SystemEventCommissionIncrease1    SystemEventKind = "commission_increase_1"
+	SystemEventCommissionIncrease2    SystemEventKind = "commission_increase_2"
+	SystemEventCommissionIncrease3    SystemEventKind = "commission_increase_3"
+	SystemEventCommissionDecrease1    SystemEventKind = "commission_decrease_1"
+	SystemEventCommissionDecrease2    SystemEventKind = "commission_decrease_2"
+	SystemEventCommissionDecrease3    SystemEventKind = "commission_decrease_3"
+	SystemEventJoinedSet              SystemEventKind = "joined_set"
+	SystemEventLeftSet                SystemEventKind = "left_set"
+	SystemEventMissedNConsecutive     SystemEventKind = "missed_n_consecutive"
+	SystemEventMissedNofM             SystemEventKind = "missed_n_of_m"
+	SystemEventDelegationLeft         SystemEventKind = "delegation_left"
+	SystemEventDelegationJoined       SystemEventKind = "delegation_joined"
+	SystemEventDelegationIncreased    SystemEventKind = "delegation_increased"
+	SystemEventDelegationDecreased    SystemEventKind = "delegation_decreased"
+	SystemEventTotalDelegatedChange   SystemEventKind = "total_delegated_change"
+
+	// Legacy change kinds don't tell increase from decrease, they are no longer created
+	// and are only kept to rewrite historical system events
 	SystemEventActiveBalanceChange1 SystemEventKind = "active_balance_change_1"
 	SystemEventActiveBalanceChange2 SystemEventKind = "active_balance_change_2"
 	SystemEventActiveBalanceChange3 SystemEventKind = "active_balance_change_3"
 	SystemEventCommissionChange1    SystemEventKind = "commission_change_1"
 	SystemEventCommissionChange2    SystemEventKind = "commission_change_2"
 	SystemEventCommissionChange3    SystemEventKind = "commission_change_3"
-	SystemEventJoinedSet            SystemEventKind = "joined_set"
-	SystemEventLeftSet              SystemEventKind = "left_set"
-	SystemEventMissedNConsecutive   SystemEventKind = "missed_n_consecutive"
-	SystemEventDelegationLeft       SystemEventKind = "delegation_left"
-	SystemEventDelegationJoined     SystemEventKind = "delegation_joined"
 )
 
+// SystemEventKinds lists all kinds of system events which are created
+var SystemEventKinds = []SystemEventKind{
+	SystemEventActiveBalanceIncrease1,
+	SystemEventActiveBalanceIncrease2,
+	SystemEventActiveBalanceIncrease3,
+	SystemEventActiveBalanceDecrease1,
+	SystemEventActiveBalanceDecrease2,
+	SystemEventActiveBalanceDecrease3,
+	SystemEventCommissionIncrease1,
+	SystemEventCommissionIncrease2,
+	SystemEventCommissionIncrease3,
+	SystemEventCommissionDecrease1,
+	SystemEventCommissionDecrease2,
+	SystemEventCommissionDecrease3,
+	SystemEventJoinedSet,
+	SystemEventLeftSet,
+	SystemEventMissedNConsecutive,
+	SystemEventMissedNofM,
+	SystemEventDelegationLeft,
+	SystemEventDelegationJoined,
+	SystemEventDelegationIncreased,
+	SystemEventDelegationDecreased,
+	SystemEventTotalDelegatedChange,
+}
+
+// LegacyActiveBalanceChangeKinds lists legacy kinds of active balance change system events
+var LegacyActiveBalanceChangeKinds = []SystemEventKind{
+	SystemEventActiveBalanceChange1,
+	SystemEventActiveBalanceChange2,
+	SystemEventActiveBalanceChange3,
+}
+
+// LegacyCommissionChangeKinds lists legacy kinds of commission change system events
+var LegacyCommissionChangeKinds = []SystemEventKind{
+	SystemEventCommissionChange1,
+	SystemEventCommissionChange2,
+	SystemEventCommissionChange3,
+}
+
 type SystemEventKind string
 
 func (o SystemEventKind) String() string {
 	return string(o)
 }
 
+func (o SystemEventKind) Valid() bool {
+	for _, kind := range SystemEventKinds {
+		if o == kind {
+			return true
+		}
+	}
+	return false
+}
+
 type SystemEvent struct {
 	*Model
 
@@ -37,11 +104,32 @@ type DelegationChangeData struct {
 	StashAccounts []string `json:"stash_accounts"`
 }
 
-// PercentChangeData is data format for change system events
+// DelegationStakeChangeData is data format for delegation stake change system events
+type DelegationStakeChangeData struct {
+	Delegations []DelegationStakeChange `json:"delegations"`
+}
+
+// DelegationStakeChange is stake of nominator in previous and current era
+type DelegationStakeChange struct {
+	StashAccount string         `json:"stash_account"`
+	Before       types.Quantity `json:"before"`
+	After        types.Quantity `json:"after"`
+}
+
+// TotalDelegatedChangeData is data format for total delegated change system events.
+// Change is signed percentage change from Before, it's nil when Before is 0
+type TotalDelegatedChangeData struct {
+	Before types.Quantity `json:"before"`
+	After  types.Quantity `json:"after"`
+	Change *float64       `json:"change"`
+}
+
+// PercentChangeData is data format for change system events.
+// Change is signed percentage change from Before, it's nil when Before is 0
 type PercentChangeData struct {
-	Before int64   `json:"before"`
-	After  int64   `json:"after"`
-	Change float64 `json:"change"`
+	Before int64    `json:"before"`
+	After  int64    `json:"after"`
+	Change *float64 `json:"change"`
 }
 
 // MissedNofMData is data format for missedNofM system events
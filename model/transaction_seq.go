@@ -1,9 +1,16 @@
 package model
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/figment-networks/polkadothub-indexer/types"
 )
 
+var (
+	ErrInvalidTransactionKind = errors.New("unexpected format for txkind")
+)
+
 type TransactionSeq struct {
 	ID types.ID `json:"id"`
 
@@ -14,6 +21,7 @@ type TransactionSeq struct {
 	Hash    string `json:"hash"`
 	Method  string `json:"method"`
 	Section string `json:"section"`
+	Signer  string `json:"signer"`
 	Args    string `json:"args"`
 }
 
@@ -32,3 +40,16 @@ type TransactionKind struct {
 	Section string `json:"section"`
 	Method  string `json:"method"`
 }
+
+// ParseTransactionKind parses transaction kind in the format section.method
+func ParseTransactionKind(raw string) (TransactionKind, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) < 2 {
+		return TransactionKind{}, ErrInvalidTransactionKind
+	}
+
+	return TransactionKind{
+		Section: parts[0],
+		Method:  parts[1],
+	}, nil
+}
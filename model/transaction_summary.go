@@ -0,0 +1,36 @@
+package model
+
+import "github.com/figment-networks/polkadothub-indexer/types"
+
+// TransactionSummary holds transaction activity for time bucket.
+// Summary with empty section and method holds totals of all transactions in time bucket
+type TransactionSummary struct {
+	*Model
+	*Summary
+
+	Section      string `json:"section"`
+	Method       string `json:"method"`
+	Count        int64  `json:"count"`
+	FailedCount  int64  `json:"failed_count"`
+	SignersCount int64  `json:"signers_count"`
+}
+
+func (TransactionSummary) TableName() string {
+	return "transaction_summary"
+}
+
+// EventSummary holds event activity for time bucket.
+// Summary with empty section and method holds totals of all events in time bucket
+type EventSummary struct {
+	*Model
+	*Summary
+
+	Section        string         `json:"section"`
+	Method         string         `json:"method"`
+	Count          int64          `json:"count"`
+	TransferVolume types.Quantity `json:"transfer_volume"`
+}
+
+func (EventSummary) TableName() string {
+	return "event_summary"
+}
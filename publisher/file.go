@@ -0,0 +1,65 @@
+package publisher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+)
+
+var (
+	_ Publisher = (*filePublisher)(nil)
+)
+
+// NewFilePublisher returns publisher appending messages to JSONL file, one message per line
+func NewFilePublisher(path string) *filePublisher {
+	return &filePublisher{path: path}
+}
+
+type filePublisher struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// Publish appends messages to file and syncs it to disk before returning
+func (p *filePublisher) Publish(ctx context.Context, messages []model.OutboxMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, message := range messages {
+		// Postgres may format JSONB with spaces, but never across lines; compact it anyway to keep one message per line
+		var line bytes.Buffer
+		if err := json.Compact(&line, message.Payload.RawMessage); err != nil {
+			return err
+		}
+		line.WriteByte('\n')
+
+		if _, err := w.Write(line.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	return f.Close()
+}
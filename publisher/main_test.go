@@ -0,0 +1,13 @@
+package publisher
+
+import (
+	"os"
+	"testing"
+
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitTest()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,159 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+)
+
+const (
+	natsClientName = "polkadothub-indexer"
+
+	// natsHeaderSize is part of server max payload kept for headers of message, the rest of it is left for data
+	natsHeaderSize = 512
+
+	// NatsHeightHeader is header with height of message
+	NatsHeightHeader = "Height"
+	// NatsPartHeader is header with number of message part, starting from 1
+	NatsPartHeader = "Part"
+	// NatsPartsHeader is header with number of parts which message is split into
+	NatsPartsHeader = "Parts"
+)
+
+var (
+	_ Publisher = (*natsPublisher)(nil)
+)
+
+// NewNatsPublisher returns publisher sending messages to JetStream stream bound to NATS subject. Connection is opened
+// by first publish and it's reused, client reconnects to server on its own. Message is accepted only once JetStream
+// acknowledges that it stored it. Message larger than server max payload is split into parts, consumers join data
+// of parts in order of Part header to get height payload
+func NewNatsPublisher(url string, subject string, timeout time.Duration) *natsPublisher {
+	return &natsPublisher{
+		url:     url,
+		subject: subject,
+		timeout: timeout,
+	}
+}
+
+type natsPublisher struct {
+	url     string
+	subject string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+	js   nats.JetStreamContext
+}
+
+// Publish publishes messages to subject and waits until JetStream acknowledges each of them. Every part is published
+// with Nats-Msg-Id header derived from id of outbox message, so stream drops parts published again within its
+// duplicate window
+func (p *natsPublisher) Publish(ctx context.Context, messages []model.OutboxMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	for i, message := range messages {
+		if err := p.publishMessage(ctx, message); err != nil {
+			return &RejectedError{Index: i, Err: err}
+		}
+	}
+	return nil
+}
+
+// Close closes connection to server
+func (p *natsPublisher) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.js = nil, nil
+	}
+}
+
+func (p *natsPublisher) connect() error {
+	if p.conn != nil && !p.conn.IsClosed() {
+		return nil
+	}
+
+	conn, err := nats.Connect(p.url,
+		nats.Name(natsClientName),
+		nats.Timeout(p.timeout),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed connecting to nats")
+	}
+
+	js, err := conn.JetStream(nats.MaxWait(p.timeout))
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed getting jetstream context")
+	}
+
+	p.conn, p.js = conn, js
+	return nil
+}
+
+func (p *natsPublisher) publishMessage(ctx context.Context, message model.OutboxMessage) error {
+	parts, err := splitPayload(message.Payload.RawMessage, p.conn.MaxPayload()-natsHeaderSize)
+	if err != nil {
+		return errors.Wrapf(err, "failed splitting message of height %d", message.Height)
+	}
+
+	for i, data := range parts {
+		msg := nats.NewMsg(p.subject)
+		msg.Data = data
+		msg.Header.Set(NatsHeightHeader, strconv.FormatInt(message.Height, 10))
+		msg.Header.Set(NatsPartHeader, strconv.Itoa(i+1))
+		msg.Header.Set(NatsPartsHeader, strconv.Itoa(len(parts)))
+
+		if err = p.publishMsg(ctx, msg, natsMsgId(message, i, len(parts))); err != nil {
+			return errors.Wrapf(err, "failed publishing part %d of %d of height %d", i+1, len(parts), message.Height)
+		}
+	}
+	return nil
+}
+
+func (p *natsPublisher) publishMsg(ctx context.Context, msg *nats.Msg, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	_, err := p.js.PublishMsg(msg, nats.MsgId(id), nats.Context(ctx))
+	return err
+}
+
+// natsMsgId returns id of message part. Message which is not split has id of outbox message, id of part includes
+// number of parts, so that parts of different split of the same message are never dropped as duplicates
+func natsMsgId(message model.OutboxMessage, part int, parts int) string {
+	if parts == 1 {
+		return strconv.FormatInt(int64(message.ID), 10)
+	}
+	return fmt.Sprintf("%d:%d/%d", message.ID, part+1, parts)
+}
+
+// splitPayload splits payload into parts of at most size bytes
+func splitPayload(payload []byte, size int64) ([][]byte, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("nats max payload leaves no room for data")
+	}
+
+	var parts [][]byte
+	for int64(len(payload)) > size {
+		parts = append(parts, payload[:size])
+		payload = payload[size:]
+	}
+	return append(parts, payload), nil
+}
@@ -0,0 +1,50 @@
+// Package publisher delivers height messages recorded in outbox to downstream consumers
+package publisher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+)
+
+var (
+	errUnknownKind = errors.New("unknown publisher kind")
+)
+
+// Publisher delivers outbox messages to downstream consumers
+type Publisher interface {
+	// Publish delivers messages in given order, it returns nil only once all messages are accepted. When publisher
+	// knows which message failed, it returns RejectedError
+	Publish(ctx context.Context, messages []model.OutboxMessage) error
+}
+
+// RejectedError is returned by publisher when delivery of message fails, all messages before it are accepted
+type RejectedError struct {
+	Index int
+	Err   error
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("message %d rejected: %v", e.Index, e.Err)
+}
+
+// New returns publisher of kind set in config
+func New(cfg *config.Config) (Publisher, error) {
+	timeout, err := time.ParseDuration(cfg.PublisherTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.PublisherKind {
+	case config.PublisherKindFile:
+		return NewFilePublisher(cfg.PublisherFilePath), nil
+	case config.PublisherKindNats:
+		return NewNatsPublisher(cfg.PublisherNatsUrl, cfg.PublisherNatsSubject, timeout), nil
+	default:
+		return nil, errUnknownKind
+	}
+}
@@ -0,0 +1,410 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func newMessage(height int64, payload string) model.OutboxMessage {
+	return model.OutboxMessage{
+		Model:   &model.Model{},
+		Height:  height,
+		Payload: types.Jsonb{RawMessage: []byte(payload)},
+	}
+}
+
+func TestFilePublisher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heights.jsonl")
+	p := NewFilePublisher(path)
+
+	for _, batch := range [][]model.OutboxMessage{
+		{newMessage(1, `{"height": 1}`), newMessage(2, `{"height": 2}`)},
+		{newMessage(3, "{\n\"height\": 3\n}")},
+	} {
+		if err := p.Publish(context.Background(), batch); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "{\"height\":1}\n{\"height\":2}\n{\"height\":3}\n"
+	if string(data) != want {
+		t.Errorf("want file content %q; got %q", want, string(data))
+	}
+}
+
+// fakeNats speaks enough of NATS protocol for client to publish to JetStream, it records messages published to it and
+// acknowledges them like JetStream does
+type fakeNats struct {
+	listener   net.Listener
+	maxPayload int
+	// noStream makes server reply that no stream is bound to subject
+	noStream bool
+	// rejected holds JetStream errors of messages by their order
+	rejected    map[int]string
+	published   chan natsRecord
+	connections int32
+}
+
+// natsRecord is message published to fake server
+type natsRecord struct {
+	subject string
+	header  map[string]string
+	data    string
+}
+
+func newFakeNats(t *testing.T, maxPayload int) *fakeNats {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeNats{listener: listener, maxPayload: maxPayload, rejected: map[int]string{}, published: make(chan natsRecord, 10)}
+	go s.serve()
+	return s
+}
+
+func (s *fakeNats) url() string {
+	return "nats://token@" + s.listener.Addr().String()
+}
+
+func (s *fakeNats) serve() {
+	count := 0
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&s.connections, 1)
+		s.handle(conn, &count)
+	}
+}
+
+func (s *fakeNats) handle(conn net.Conn, count *int) {
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"fake\",\"version\":\"2.2.0\",\"max_payload\":%d,\"headers\":true,\"auth_required\":true}\r\n", s.maxPayload)
+
+	r := bufio.NewReader(conn)
+	var sid string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			if !strings.Contains(line, `"auth_token":"token"`) || !strings.Contains(line, `"headers":true`) {
+				conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+				return
+			}
+		case "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case "SUB":
+			sid = fields[2]
+		case "PUB", "HPUB":
+			// PUB <subject> <reply> <size> or HPUB <subject> <reply> <header size> <size>
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[3])
+			}
+			data := make([]byte, size+2)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return
+			}
+
+			if fields[1] == "$JS.API.INFO" {
+				reply(conn, fields[2], sid, `{"type":"io.nats.jetstream.api.v1.account_info_response","streams":1}`)
+				continue
+			}
+
+			record := natsRecord{subject: fields[1], header: map[string]string{}, data: string(data[headerSize:size])}
+			for _, h := range strings.Split(string(data[:headerSize]), "\r\n")[1:] {
+				if kv := strings.SplitN(h, ": ", 2); len(kv) == 2 {
+					record.header[kv[0]] = kv[1]
+				}
+			}
+			s.published <- record
+
+			if s.noStream {
+				status := "NATS/1.0 503\r\n\r\n"
+				fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s\r\n", fields[2], sid, len(status), len(status), status)
+				continue
+			}
+
+			ack := fmt.Sprintf(`{"stream":"HEIGHTS","seq":%d}`, *count+1)
+			if description, ok := s.rejected[*count]; ok {
+				ack = fmt.Sprintf(`{"error":{"code":503,"description":%q}}`, description)
+			}
+			reply(conn, fields[2], sid, ack)
+			*count++
+		}
+	}
+}
+
+func reply(conn net.Conn, subject string, sid string, payload string) {
+	fmt.Fprintf(conn, "MSG %s %s %d\r\n%s\r\n", subject, sid, len(payload), payload)
+}
+
+func newNatsMessage(id types.ID, height int64, payload string) model.OutboxMessage {
+	message := newMessage(height, payload)
+	message.ID = id
+	return message
+}
+
+func TestNatsPublisher(t *testing.T) {
+	t.Run("publishes messages to subject", func(t *testing.T) {
+		server := newFakeNats(t, 1024)
+		p := NewNatsPublisher(server.url(), "heights", time.Second)
+		defer p.Close()
+
+		for _, batch := range [][]model.OutboxMessage{
+			{newNatsMessage(7, 1, `{"height":1}`), newNatsMessage(8, 2, `{"height":2}`)},
+			{newNatsMessage(9, 3, `{"height":3}`)},
+		} {
+			if err := p.Publish(context.Background(), batch); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		for _, want := range []natsRecord{
+			{"heights", map[string]string{"Nats-Msg-Id": "7", "Height": "1", "Part": "1", "Parts": "1"}, `{"height":1}`},
+			{"heights", map[string]string{"Nats-Msg-Id": "8", "Height": "2", "Part": "1", "Parts": "1"}, `{"height":2}`},
+			{"heights", map[string]string{"Nats-Msg-Id": "9", "Height": "3", "Part": "1", "Parts": "1"}, `{"height":3}`},
+		} {
+			if got := <-server.published; !reflect.DeepEqual(got, want) {
+				t.Errorf("want published %+v; got %+v", want, got)
+			}
+		}
+		if connections := atomic.LoadInt32(&server.connections); connections != 1 {
+			t.Errorf("want connection reused by batches; got %d connections", connections)
+		}
+	})
+
+	t.Run("splits message larger than max payload", func(t *testing.T) {
+		server := newFakeNats(t, natsHeaderSize+16)
+		p := NewNatsPublisher(server.url(), "heights", time.Second)
+		defer p.Close()
+
+		payload := `{"height":1,"data":"` + strings.Repeat("x", 20) + `"}`
+		if err := p.Publish(context.Background(), []model.OutboxMessage{newNatsMessage(7, 1, payload)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var data string
+		for i, wantID := range []string{"7:1/3", "7:2/3", "7:3/3"} {
+			got := <-server.published
+			if got.header["Nats-Msg-Id"] != wantID || got.header["Part"] != strconv.Itoa(i+1) || got.header["Parts"] != "3" || got.header["Height"] != "1" {
+				t.Errorf("want part %d of 3 with id %s; got %+v", i+1, wantID, got.header)
+			}
+			if len(got.data) > 16 {
+				t.Errorf("want part of at most 16 bytes; got %d", len(got.data))
+			}
+			data += got.data
+		}
+		if data != payload {
+			t.Errorf("want parts joined into payload %s; got %s", payload, data)
+		}
+	})
+
+	t.Run("returns message rejected by jetstream", func(t *testing.T) {
+		server := newFakeNats(t, 1024)
+		server.rejected[1] = "maximum messages exceeded"
+		p := NewNatsPublisher(server.url(), "heights", time.Second)
+		defer p.Close()
+
+		err := p.Publish(context.Background(), []model.OutboxMessage{newNatsMessage(1, 1, `{"height":1}`), newNatsMessage(2, 2, `{"height":2}`), newNatsMessage(3, 3, `{"height":3}`)})
+		rejected, ok := err.(*RejectedError)
+		if !ok {
+			t.Fatalf("want rejected error; got %v", err)
+		}
+		if rejected.Index != 1 || !strings.Contains(rejected.Err.Error(), "maximum messages exceeded") {
+			t.Errorf("want second message rejected with jetstream error; got %v", rejected)
+		}
+	})
+
+	t.Run("fails when no stream is bound to subject", func(t *testing.T) {
+		server := newFakeNats(t, 1024)
+		server.noStream = true
+		p := NewNatsPublisher(server.url(), "heights", time.Second)
+		defer p.Close()
+
+		err := p.Publish(context.Background(), []model.OutboxMessage{newNatsMessage(1, 1, `{"height":1}`)})
+		rejected, ok := err.(*RejectedError)
+		if !ok || rejected.Index != 0 || !strings.Contains(rejected.Error(), "no response from stream") {
+			t.Errorf("want no stream error; got %v", err)
+		}
+	})
+
+	t.Run("fails when server is down", func(t *testing.T) {
+		server := newFakeNats(t, 1024)
+		server.listener.Close()
+		p := NewNatsPublisher(server.url(), "heights", time.Second)
+		defer p.Close()
+
+		if err := p.Publish(context.Background(), []model.OutboxMessage{newNatsMessage(1, 1, `{"height":1}`)}); err == nil {
+			t.Error("want error when server is down")
+		}
+	})
+}
+
+// stubPublisher records published heights, it fails while err is set and it rejects message of rejectHeight
+type stubPublisher struct {
+	err          error
+	rejectHeight int64
+	heights      []int64
+}
+
+func (p *stubPublisher) Publish(ctx context.Context, messages []model.OutboxMessage) error {
+	if p.err != nil {
+		return p.err
+	}
+	for i, message := range messages {
+		if message.Height == p.rejectHeight {
+			return &RejectedError{Index: i, Err: errors.New("message too large")}
+		}
+		p.heights = append(p.heights, message.Height)
+	}
+	return nil
+}
+
+func newOutbox(t *testing.T, heights ...int64) store.Outbox {
+	outboxDb := memory.New().GetSyncables()
+	for _, height := range heights {
+		message, err := model.NewOutboxMessage(&model.HeightMessage{Height: height})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = outboxDb.SaveSyncableWithMessage(&model.Syncable{Height: height}, message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return outboxDb
+}
+
+func TestRelay_Flush(t *testing.T) {
+	outboxDb := newOutbox(t, 12, 10, 11)
+
+	pub := &stubPublisher{err: errors.New("broker down")}
+	relay := NewRelay(pub, outboxDb, 2)
+
+	if _, err := relay.Flush(context.Background()); err == nil {
+		t.Fatal("want error when publisher fails")
+	}
+
+	pending, _ := outboxDb.FindOutboxMessages(10)
+	if len(pending) != 3 {
+		t.Fatalf("failed messages should stay in outbox; got %d", len(pending))
+	}
+	if pending[0].Attempts != 1 || pending[0].LastError == nil || *pending[0].LastError != "broker down" {
+		t.Errorf("failed attempt should be recorded: %+v", pending[0])
+	}
+	if pending[2].Attempts != 0 {
+		t.Errorf("messages outside failed batch should not be marked: %+v", pending[2])
+	}
+
+	pub.err = nil
+	published, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 3 {
+		t.Errorf("want 3 published messages; got %d", published)
+	}
+	if want := []int64{10, 11, 12}; !reflect.DeepEqual(pub.heights, want) {
+		t.Errorf("want heights published in order %v; got %v", want, pub.heights)
+	}
+
+	if pending, _ = outboxDb.FindOutboxMessages(10); len(pending) != 0 {
+		t.Errorf("published messages should be deleted from outbox; got %d", len(pending))
+	}
+}
+
+func TestRelay_FlushStallsAtRejectedMessage(t *testing.T) {
+	outboxDb := newOutbox(t, 10, 11, 12, 13)
+	pub := &stubPublisher{rejectHeight: 11}
+	relay := NewRelay(pub, outboxDb, 10)
+
+	for attempt := int64(1); attempt <= 2; attempt++ {
+		if _, err := relay.Flush(context.Background()); err == nil {
+			t.Fatal("want error when message is rejected")
+		}
+
+		pending, _ := outboxDb.FindOutboxMessages(10)
+		if len(pending) != 3 || pending[0].Height != 11 || pending[0].Attempts != attempt || pending[1].Attempts != 0 {
+			t.Fatalf("rejected message and messages after it should stay in outbox; got %+v", pending)
+		}
+		if got := testutil.ToFloat64(metric.IndexerPublishStalledHeight); got != 11 {
+			t.Errorf("want stalled height 11; got %v", got)
+		}
+	}
+	if want := []int64{10}; !reflect.DeepEqual(pub.heights, want) {
+		t.Errorf("want no heights published after rejected one; got %v", pub.heights)
+	}
+
+	pub.rejectHeight = 0
+	published, err := relay.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 3 {
+		t.Errorf("want 3 published messages; got %d", published)
+	}
+	if want := []int64{10, 11, 12, 13}; !reflect.DeepEqual(pub.heights, want) {
+		t.Errorf("want heights %v published in order; got %v", want, pub.heights)
+	}
+	if got := testutil.ToFloat64(metric.IndexerPublishStalledHeight); got != 0 {
+		t.Errorf("want stalled height reset; got %v", got)
+	}
+}
+
+func TestRelay_FlushAsync(t *testing.T) {
+	outboxDb := newOutbox(t, 10, 11)
+	relay := NewRelay(&stubPublisher{}, outboxDb, 10)
+
+	relay.FlushAsync()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		pending, _ := outboxDb.FindOutboxMessages(10)
+		if len(pending) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("want outbox flushed in background; got %d messages left", len(pending))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
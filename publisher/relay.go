@@ -0,0 +1,137 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+// flushMu serializes flushes of all relays in process, so that messages are published in order of heights
+var flushMu sync.Mutex
+
+// NewRelay returns relay publishing outbox messages with given publisher
+func NewRelay(publisher Publisher, outboxDb store.Outbox, batchSize int64) *Relay {
+	return &Relay{
+		publisher: publisher,
+		outboxDb:  outboxDb,
+		batchSize: batchSize,
+	}
+}
+
+// Relay moves messages from outbox to publisher
+type Relay struct {
+	publisher Publisher
+	outboxDb  store.Outbox
+	batchSize int64
+
+	// flushing is set while flush started by FlushAsync runs
+	flushing int32
+}
+
+// Flush publishes outbox messages in batches until outbox is empty. Messages are deleted from outbox only after
+// publisher accepts them, so every message is published at least once; batch which failed stays in outbox
+// and is published again by next flush
+func (r *Relay) Flush(ctx context.Context) (int64, error) {
+	flushMu.Lock()
+	defer flushMu.Unlock()
+
+	var published int64
+	for {
+		messages, err := r.outboxDb.FindOutboxMessages(r.batchSize)
+		if err != nil {
+			return published, err
+		}
+		if len(messages) == 0 {
+			return published, nil
+		}
+
+		accepted, err := r.publish(ctx, messages)
+		published += accepted
+		if err != nil {
+			return published, err
+		}
+
+		if int64(len(messages)) < r.batchSize {
+			return published, nil
+		}
+	}
+}
+
+// FlushAsync starts flush in background unless one is already running. Messages it does not pick up are published
+// by next flush, errors are only logged
+func (r *Relay) FlushAsync() {
+	if !atomic.CompareAndSwapInt32(&r.flushing, 0, 1) {
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&r.flushing, 0)
+
+		if _, err := r.Flush(context.Background()); err != nil {
+			logger.Error(err, logger.Field("process", "publisher"))
+		}
+	}()
+}
+
+// publish publishes batch of messages and returns number of accepted ones. Publishing stops at message which failed,
+// messages after it wait in outbox until it's delivered, so consumers never miss a height. Stalled publishing is reported
+// by error and by publish_stalled_height metric, which is set to height of failed message until it's delivered
+func (r *Relay) publish(ctx context.Context, messages []model.OutboxMessage) (int64, error) {
+	err := r.publisher.Publish(ctx, messages)
+	if err == nil {
+		metric.IndexerPublishStalledHeight.Set(0)
+		return int64(len(messages)), r.deleteMessages(messages)
+	}
+
+	metric.IndexerPublishFailures.Inc()
+
+	var accepted []model.OutboxMessage
+	failed := messages
+	if rejected, ok := err.(*RejectedError); ok {
+		accepted, failed = messages[:rejected.Index], messages[rejected.Index:rejected.Index+1]
+		err = rejected.Err
+	}
+
+	if deleteErr := r.deleteMessages(accepted); deleteErr != nil {
+		return 0, deleteErr
+	}
+	r.markFailed(failed, err)
+
+	message := failed[0]
+	metric.IndexerPublishStalledHeight.Set(float64(message.Height))
+	return int64(len(accepted)), errors.Wrapf(err, "publishing stalled at height %d after %d attempts", message.Height, message.Attempts+1)
+}
+
+func (r *Relay) deleteMessages(messages []model.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	if err := r.outboxDb.DeleteOutboxMessages(messageIDs(messages)); err != nil {
+		return err
+	}
+	metric.IndexerPublishedMessages.Add(float64(len(messages)))
+	return nil
+}
+
+func (r *Relay) markFailed(messages []model.OutboxMessage, err error) {
+	if markErr := r.outboxDb.MarkOutboxMessagesFailed(messageIDs(messages), err.Error()); markErr != nil {
+		logger.Error(markErr, logger.Field("process", "publisher"))
+	}
+}
+
+func messageIDs(messages []model.OutboxMessage) []types.ID {
+	ids := make([]types.ID, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	return ids
+}
@@ -1,10 +1,13 @@
 package server
 
 import (
+	"crypto/subtle"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/metric"
+	usecasehttp "github.com/figment-networks/polkadothub-indexer/usecase/http"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,3 +27,18 @@ func MetricMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// AdminAuthMiddleware is a middleware that allows only requests with matching bearer token
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, usecasehttp.ErrorResponse{
+				Status: http.StatusUnauthorized,
+				Error:  "unauthorized",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -10,6 +10,33 @@ import (
 // setupRoutes sets up routes for gin application
 func (s *Server) setupRoutes() {
 	s.engine.GET("/health", s.handlers.Health.Handle)
+	s.engine.GET("/health/live", s.handlers.Health.Handle)
+	// swagger:route GET /health/ready getHealthReady
+	//
+	// Gets readiness of the replica
+	//
+	// Returns 503 when database, migrations, indexer lag or last report age checks fail. Unreachable proxy fails readiness
+	// only when READINESS_REQUIRE_PROXY is set, otherwise indexed data keeps being served
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: HealthDetailsView
+	//       503: HealthDetailsView
+	s.engine.GET("/health/ready", s.handlers.GetHealthReady.Handle)
+	// swagger:route GET /health/details getHealthDetails
+	//
+	// Gets detailed health checks
+	//
+	// This will show database connectivity, migration version, proxy reachability, indexer lag and age of last completed report
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: HealthDetailsView
+	s.engine.GET("/health/details", s.handlers.GetHealthDetails.Handle)
 	// swagger:route GET /status getStatus
 	//
 	// Gets latest status
@@ -60,7 +87,8 @@ func (s *Server) setupRoutes() {
 	//
 	// Gets blocks summary
 	//
-	// Gets summary for blocks
+	// Gets summary for blocks for given interval ("hour", "day", "week", "month" or "era"). Era summaries are aligned to staking eras,
+	// their time bucket is time of first block of era.
 	//
 	//     Consumes:
 	//     - application/json
@@ -89,6 +117,24 @@ func (s *Server) setupRoutes() {
 	//       200: TransactionsView
 	//       400: BadRequestResponse
 	s.engine.GET("/transactions", s.handlers.GetTransactionsByHeight.Handle)
+	// swagger:route GET /transactions_summary getTransactionsSummary
+	//
+	// Gets transactions and events summary
+	//
+	// Returns hourly or daily counts of transactions and events by section and method for given period preceding most recent summary,
+	// along with failed transactions, unique signers and balances transfer volume, for current index version. Totals of all sections
+	// and methods are returned separately and are not filtered. Results can be filtered by "section" and "method".
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: TransactionsSummaryView
+	//       400: BadRequestResponse
+	s.engine.GET("/transactions_summary", s.handlers.GetTransactionsSummary.Handle)
 	// swagger:route GET /account_details/:stash_account getAccountDetails
 	//
 	// Gets latest account details
@@ -143,7 +189,7 @@ func (s *Server) setupRoutes() {
 	//
 	// Gets system events for an address
 	//
-	// Returns lists of system events of given "kind" if specified in query (eg."active_balance_change_1", "commission_change_1", left_set", "joined_set", "missed_n_consecutive", etc. )
+	// Returns lists of system events of given "kind" if specified in query (eg."active_balance_increase_1", "commission_decrease_1", "left_set", "joined_set", "delegation_increased", "total_delegated_change", "missed_n_consecutive", "missed_n_of_m", etc. )
 	// after given block height if provided. Otherwise returns all system events for account.
 	//
 	//     Consumes:
@@ -205,11 +251,29 @@ func (s *Server) setupRoutes() {
 	//       200: ValidatorsView
 	//       400: BadRequestResponse
 	s.engine.GET("/validators", s.handlers.GetValidatorsByHeight.Handle)
+	// swagger:route GET /validators/ranking getValidatorRanking
+	//
+	// Gets ranking of validators
+	//
+	// Returns validators of most recent era ordered by nominator APR averaged over trailing eras, uptime, commission, stake saturation
+	// (total stake relative to average validator stake) or number of slashes in trailing eras. Validators can be filtered by the same values.
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: ValidatorRankingView
+	//       400: BadRequestResponse
+	s.engine.GET("/validators/ranking", s.handlers.GetValidatorRanking.Handle)
 	// swagger:route GET /validators_summary getValidatorSummary
 	//
 	// Gets all validators for height
 	//
-	// Returns validator summaries for all accounts (or single account if stash_account query is provided)
+	// Returns validator summaries for all accounts (or single account if stash_account query is provided) for given interval
+	// ("hour", "day", "week", "month" or "era"). Era summaries are aligned to staking eras, their time bucket is time of first block of era.
 	//
 	//     Consumes:
 	//     - application/json
@@ -238,12 +302,31 @@ func (s *Server) setupRoutes() {
 	//       200: RewardsForErasView
 	//       400: BadRequestResponse
 	s.engine.GET("/rewards/:stash_account", s.handlers.GetRewardsForStashAccount.Handle)
+	// swagger:route GET /rewards/:stash_account/report getRewardsReport
+	//
+	// Gets rewards report of account as CSV
+	//
+	// This will join rewards of account from "start" to "end" days with prices of "currency" on reward days, and sum them by
+	// "period" (day, week, month (default) or year). If "end" is not specified, will report rewards until most recently indexed
+	// block. Rewards are priced on UTC day of era they were earned in, not on day of their payout claim. When "detailed" is set,
+	// every reward is listed with its price.
+	//
+	//     Produces:
+	//     - text/csv
+	//
+	//     Responses:
+	//       200:
+	//       400: BadRequestResponse
+	s.engine.GET("/rewards/:stash_account/report", s.handlers.GetRewardsReport.Handle)
 	// swagger:route GET /apr getAPR
 	//
 	// Gets apr for account
 	//
-	// This will show daily reward aprs for an account for given time period from "start" to "end". If "end" is not specified,
-	// will return aprs until most recently indexed block.
+	// This will show era reward aprs for an account for given time period from "start" to "end". If "end" is not specified,
+	// will return aprs until most recently indexed block. Era rewards are annualized with duration of era, apy compounds them
+	// every era when "reward_destination" is "staked". Payee of account isn't indexed, so "reward_destination" is
+	// supplied by caller and returned with every apr, it's "unknown" and apy equals apr when caller omits it. When "aggregate" is set, rewards of all validators in era are combined
+	// weighted by stake.
 	//
 	//     Consumes:
 	//     - application/json
@@ -255,6 +338,186 @@ func (s *Server) setupRoutes() {
 	//       200: RewardsForErasView
 	//       400: BadRequestResponse
 	s.engine.GET("/apr", s.handlers.GetAPRByAddress.Handle)
+	// swagger:route GET /eras getEras
+	//
+	// Gets summaries of most recent eras
+	//
+	// Returns network wide summaries of last "limit" eras (50 by default), most recent era first. Eras are summarized
+	// at their last height.
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: EraListView
+	//       400: BadRequestResponse
+	s.engine.GET("/eras", s.handlers.GetEras.Handle)
+	// swagger:route GET /eras/:era getEra
+	//
+	// Gets summary of era
+	//
+	// Returns total stake, active validators and nominators, validator stake distribution, rewards and average commission of era
+	// together with its start and end heights and times.
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: EraView
+	//       400: BadRequestResponse
+	s.engine.GET("/eras/:era", s.handlers.GetEra.Handle)
+	// swagger:route GET /stream getStream
+	//
+	// Streams indexing updates
+	//
+	// Pushes server-sent events for every indexed height ("height"), session and era transitions ("session_ended", "era_ended"),
+	// claimed rewards ("reward_claimed") and created system events ("system_event"). Events can be filtered by comma separated "actors", "kinds" and "types".
+	// Every "height" event has its height as event id, stream resumes after height given in Last-Event-ID header or "after" query.
+	// When heights after cursor were already purged, "reset" event is sent first and stream continues from oldest retained height.
+	//
+	//     Produces:
+	//     - text/event-stream
+	//
+	//     Responses:
+	//       400: BadRequestResponse
+	s.engine.GET("/stream", s.handlers.GetStream.Handle)
+
+	if s.cfg.AdminToken != "" {
+		s.setupAdminRoutes()
+	}
+}
+
+// setupAdminRoutes sets up routes for managing indexing jobs, all of them require admin token
+func (s *Server) setupAdminRoutes() {
+	admin := s.engine.Group("/admin", AdminAuthMiddleware(s.cfg.AdminToken))
+
+	// swagger:route POST /admin/jobs/:kind createJob
+	//
+	// Enqueues indexing job
+	//
+	// Kind is one of backfill, reindex, purge or summarize. Body accepts the same params as indexer commands.
+	// Jobs are run one at a time in order they were enqueued.
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: Job
+	//       400: BadRequestResponse
+	admin.POST("/jobs/:kind", s.handlers.CreateJob.Handle)
+	// swagger:route GET /admin/jobs getJobs
+	//
+	// Gets all jobs
+	//
+	// Returns queued, running and finished jobs starting from the most recent one
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: []Job
+	admin.GET("/jobs", s.handlers.GetJobs.Handle)
+	// swagger:route DELETE /admin/jobs/:id cancelJob
+	//
+	// Cancels job
+	//
+	// Queued job is dropped, running job stops after currently processed height
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: Job
+	//       400: BadRequestResponse
+	admin.DELETE("/jobs/:id", s.handlers.CancelJob.Handle)
+	// swagger:route GET /admin/reports getReports
+	//
+	// Gets recent reports
+	//
+	// Returns most recent reports with processed heights, progress and estimated time to finish
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: []ReportView
+	//       400: BadRequestResponse
+	admin.GET("/reports", s.handlers.GetReports.Handle)
+	// swagger:route POST /admin/subscriptions createSubscription
+	//
+	// Creates webhook subscription
+	//
+	// System events of given kinds for given actors created from now on are posted to url, signed with secret
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: Subscription
+	//       400: BadRequestResponse
+	admin.POST("/subscriptions", s.handlers.CreateSubscription.Handle)
+	// swagger:route GET /admin/subscriptions getSubscriptions
+	//
+	// Gets all webhook subscriptions
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: []Subscription
+	admin.GET("/subscriptions", s.handlers.GetSubscriptions.Handle)
+	// swagger:route GET /admin/subscriptions/:id getSubscription
+	//
+	// Gets webhook subscription
+	//
+	// Returns subscription with its most recent delivery attempts
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: SubscriptionView
+	//       400: BadRequestResponse
+	admin.GET("/subscriptions/:id", s.handlers.GetSubscription.Handle)
+	// swagger:route PUT /admin/subscriptions/:id updateSubscription
+	//
+	// Updates webhook subscription
+	//
+	// Only provided fields are updated, enabling disabled subscription resets its failures
+	//
+	//     Consumes:
+	//     - application/json
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: Subscription
+	//       400: BadRequestResponse
+	admin.PUT("/subscriptions/:id", s.handlers.UpdateSubscription.Handle)
+	// swagger:route DELETE /admin/subscriptions/:id deleteSubscription
+	//
+	// Deletes webhook subscription together with its delivery logs
+	//
+	//     Produces:
+	//     - application/json
+	//
+	//     Responses:
+	//       200: Subscription
+	//       400: BadRequestResponse
+	admin.DELETE("/subscriptions/:id", s.handlers.DeleteSubscription.Handle)
 }
 
 func (s *Server) ReverseProxy() gin.HandlerFunc {
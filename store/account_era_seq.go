@@ -8,6 +8,7 @@ import (
 type AccountEraSeq interface {
 	BulkUpsert(records []model.AccountEraSeq) error
 	FindByEra(era int64) ([]model.AccountEraSeq, error)
+	FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.AccountEraSeq, error)
 	FindLastByStashAccount(stashAccount string) ([]model.AccountEraSeq, error)
 	FindLastByValidatorStashAccount(validatorStashAccount string) ([]model.AccountEraSeq, error)
 	GetAllByTime(stash string, start, end types.Time) ([]model.AccountEraSeq, error)
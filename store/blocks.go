@@ -10,12 +10,17 @@ import (
 type BlockSeq interface {
 	CreateSeq(*model.BlockSeq) error
 	DeleteSeqOlderThan(purgeThreshold time.Time, activityPeriods []ActivityPeriodRow) (*int64, error)
+	// ArchiveSeqsOlderThan passes at most limit sequences which DeleteSeqOlderThan deletes to archive and deletes them once it succeeds
+	ArchiveSeqsOlderThan(purgeThreshold time.Time, activityPeriods []ActivityPeriodRow, limit int64, archive func([]model.BlockSeq) error) (*int64, error)
 	FindSeqByHeight(height int64) (*model.BlockSeq, error)
+	FindSeqsForHeightRange(startHeight, endHeight int64) ([]model.BlockSeq, error)
+	CreateIfNotExists(block *model.BlockSeq) error
 	// FindByID(id int64) (*model.BlockSeq, error)
 	FindMostRecentSeq() (*model.BlockSeq, error)
 	GetAvgRecentTimes(limit int64) GetAvgRecentTimesResult
 	SaveSeq(*model.BlockSeq) error
 	Summarize(interval types.SummaryInterval, activityPeriods []ActivityPeriodRow) ([]model.BlockSeqSummary, error)
+	SummarizeByEra(since time.Time) ([]model.BlockSeqSummary, error)
 }
 
 type BlockSummary interface {
@@ -27,6 +32,7 @@ type BlockSummary interface {
 	FindMostRecentByInterval(interval types.SummaryInterval) (*model.BlockSummary, error)
 	FindSummaries(interval types.SummaryInterval, period string) ([]model.BlockSummary, error)
 	SaveSummary(val *model.BlockSummary) error
+	SummarizeDailySummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.BlockSeqSummary, error)
 }
 
 // swagger:response BlockTimesView
@@ -4,15 +4,26 @@ import (
 	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
 )
 
 type EventSeq interface {
 	BulkUpsert(records []model.EventSeq) error
 	FindByHeightAndIndex(height int64, index int64) (*model.EventSeq, error)
+	FindForHeightRange(startHeight, endHeight int64, address string) ([]model.EventSeq, error)
 	FindBalanceDeposits(address string) ([]model.EventSeqWithTxHash, error)
 	FindBalanceTransfers(address string) ([]model.EventSeqWithTxHash, error)
 	FindBonded(address string) ([]model.EventSeqWithTxHash, error)
 	FindRewardsForTimePeriod(address string, start, end time.Time) ([]model.EventSeq, error)
+	FindSlashesForHeightRange(startHeight, endHeight int64) ([]model.EventSeq, error)
 	FindUnbonded(address string) ([]model.EventSeqWithTxHash, error)
 	FindWithdrawn(address string) ([]model.EventSeqWithTxHash, error)
 }
+
+type EventSummary interface {
+	SummarizeEventSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error)
+	FindEventSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.EventSummary, error)
+	FindEventTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.EventSummary, error)
+	FindMostRecentEventSummary(interval types.SummaryInterval) (*model.EventSummary, error)
+	DeleteEventSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error)
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewAccountEraSeqStore(db *db) *AccountEraSeqStore {
+	return &AccountEraSeqStore{scoped(db, model.AccountEraSeq{})}
+}
+
+// AccountEraSeqStore handles operations on accounts
+type AccountEraSeqStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new records and updates existing ones
+func (s AccountEraSeqStore) BulkUpsert(records []model.AccountEraSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			e := row.(*model.AccountEraSeq)
+			return e.Era == r.Era && e.StashAccount == r.StashAccount && e.ValidatorStashAccount == r.ValidatorStashAccount
+		}, func(row interface{}) {
+			e := row.(*model.AccountEraSeq)
+			e.ControllerAccount = r.ControllerAccount
+			e.ValidatorControllerAccount = r.ValidatorControllerAccount
+			e.Stake = r.Stake.Clone()
+		})
+	}
+	return nil
+}
+
+// GetAllByTime Gets all seqs for given stash
+func (s AccountEraSeqStore) GetAllByTime(stash string, start, end types.Time) ([]model.AccountEraSeq, error) {
+	res := s.find(func(e *model.AccountEraSeq) bool {
+		return e.StashAccount == stash && !e.Time.Before(start.Time) && !e.Time.After(end.Time)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time.Time) })
+	return res, nil
+}
+
+// FindByEra finds account era sequences by era
+func (s AccountEraSeqStore) FindByEra(era int64) ([]model.AccountEraSeq, error) {
+	return s.find(func(e *model.AccountEraSeq) bool {
+		return e.Era == era
+	}), nil
+}
+
+// FindLastByStashAccount finds last account era sequences for given stash account
+func (s AccountEraSeqStore) FindLastByStashAccount(stashAccount string) ([]model.AccountEraSeq, error) {
+	return s.findInFirstEra(func(e *model.AccountEraSeq) bool {
+		return e.StashAccount == stashAccount
+	}), nil
+}
+
+// FindLastByValidatorStashAccount finds last account era sequences for given validator stash account
+func (s AccountEraSeqStore) FindLastByValidatorStashAccount(validatorStashAccount string) ([]model.AccountEraSeq, error) {
+	return s.findInFirstEra(func(e *model.AccountEraSeq) bool {
+		return e.ValidatorStashAccount == validatorStashAccount
+	}), nil
+}
+
+// FindForEraRange returns account era sequences between given eras (inclusive), non empty stashAccount matches both nominator and validator stash
+func (s AccountEraSeqStore) FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.AccountEraSeq, error) {
+	res := s.find(func(e *model.AccountEraSeq) bool {
+		if stashAccount != "" && e.StashAccount != stashAccount && e.ValidatorStashAccount != stashAccount {
+			return false
+		}
+		return e.Era >= startEra && e.Era <= endEra
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Era != res[j].Era {
+			return res[i].Era < res[j].Era
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+// findInFirstEra returns matching sequences of the lowest era any of them has, same as the postgres queries do
+func (s AccountEraSeqStore) findInFirstEra(fn func(*model.AccountEraSeq) bool) []model.AccountEraSeq {
+	seqs := s.find(fn)
+	if len(seqs) == 0 {
+		return nil
+	}
+
+	era := seqs[0].Era
+	for _, seq := range seqs {
+		if seq.Era < era {
+			era = seq.Era
+		}
+	}
+
+	var res []model.AccountEraSeq
+	for _, seq := range seqs {
+		if seq.Era == era {
+			res = append(res, seq)
+		}
+	}
+	return res
+}
+
+func (s AccountEraSeqStore) find(fn func(*model.AccountEraSeq) bool) []model.AccountEraSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.AccountEraSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.AccountEraSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.AccountEraSeq))
+		}
+	}
+	return res
+}
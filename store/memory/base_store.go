@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"reflect"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+// baseStore implements generic store operations
+type baseStore struct {
+	db    *db
+	model interface{}
+}
+
+// Create creates a new record. Must pass a pointer.
+func (s baseStore) Create(record interface{}) error {
+	if err := checkPtr(record); err != nil {
+		return err
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.table(record).insert(record)
+	return nil
+}
+
+// Update updates the existing record. Must pass a pointer.
+func (s baseStore) Update(record interface{}) error {
+	return s.Save(record)
+}
+
+// Save saves record to database
+func (s baseStore) Save(record interface{}) error {
+	if err := checkPtr(record); err != nil {
+		return err
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.table(record).save(record)
+	return nil
+}
+
+// Truncate removes all records from the table
+func (s baseStore) Truncate() error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().delete(func(interface{}) bool { return true })
+	return nil
+}
+
+// rows returns table of the store model, callers must hold the lock
+func (s baseStore) rows() *table {
+	return s.db.table(s.model)
+}
+
+func scoped(db *db, m interface{}) baseStore {
+	return baseStore{db, m}
+}
+
+func checkPtr(record interface{}) error {
+	v := reflect.ValueOf(record)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errNotPointer
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return err == store.ErrNotFound
+}
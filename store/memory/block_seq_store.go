@@ -0,0 +1,294 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+func NewBlockSeqStore(db *db) *BlockSeqStore {
+	return &BlockSeqStore{scoped(db, model.BlockSeq{})}
+}
+
+// BlockSeqStore handles operations on blocks
+type BlockSeqStore struct {
+	baseStore
+}
+
+// CreateSeq creates the block
+func (s BlockSeqStore) CreateSeq(block *model.BlockSeq) error {
+	return s.Create(block)
+}
+
+// SaveSeq saves the block
+func (s BlockSeqStore) SaveSeq(block *model.BlockSeq) error {
+	return s.Save(block)
+}
+
+// CreateIfNotExists creates the block if it does not exist
+func (s BlockSeqStore) CreateIfNotExists(block *model.BlockSeq) error {
+	_, err := s.FindSeqByHeight(block.Height)
+	if isNotFound(err) {
+		return s.Create(block)
+	}
+	return nil
+}
+
+// FindByID returns a block with matching ID
+func (s BlockSeqStore) FindByID(id int64) (*model.BlockSeq, error) {
+	return s.first(func(b *model.BlockSeq) bool {
+		return int64(b.ID) == id
+	})
+}
+
+// FindSeqByHeight returns a block with the matching height
+func (s BlockSeqStore) FindSeqByHeight(height int64) (*model.BlockSeq, error) {
+	return s.first(func(b *model.BlockSeq) bool {
+		return b.Height == height
+	})
+}
+
+// GetAvgRecentTimes Gets average block times for recent blocks by limit
+func (s *BlockSeqStore) GetAvgRecentTimes(limit int64) store.GetAvgRecentTimesResult {
+	var res store.GetAvgRecentTimesResult
+
+	seqs := s.find(func(*model.BlockSeq) bool { return true })
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].Height > seqs[j].Height })
+	if int64(len(seqs)) > limit {
+		seqs = seqs[:limit]
+	}
+	if len(seqs) == 0 {
+		return res
+	}
+
+	res.StartHeight, res.EndHeight = seqs[0].Height, seqs[0].Height
+	startTime, endTime := seqs[0].Time.Time, seqs[0].Time.Time
+	for _, seq := range seqs {
+		if seq.Height < res.StartHeight {
+			res.StartHeight = seq.Height
+		}
+		if seq.Height > res.EndHeight {
+			res.EndHeight = seq.Height
+		}
+		if seq.Time.Before(startTime) {
+			startTime = seq.Time.Time
+		}
+		if seq.Time.After(endTime) {
+			endTime = seq.Time.Time
+		}
+	}
+
+	res.StartTime = startTime.Format(time.RFC3339Nano)
+	res.EndTime = endTime.Format(time.RFC3339Nano)
+	res.Count = int64(len(seqs))
+	res.Diff = endTime.Sub(startTime).Seconds()
+	res.Avg = res.Diff / float64(res.Count)
+	return res
+}
+
+// FindMostRecentSeq finds most recent block sequence
+func (s *BlockSeqStore) FindMostRecentSeq() (*model.BlockSeq, error) {
+	seqs := s.find(func(*model.BlockSeq) bool { return true })
+	if len(seqs) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].Time.After(seqs[j].Time.Time) })
+	return &seqs[0], nil
+}
+
+// DeleteSeqOlderThan deletes block sequence older than given threshold
+func (s *BlockSeqStore) DeleteSeqOlderThan(purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow) (*int64, error) {
+	var deletedCount int64
+
+	fn, ok := blockSeqPurgeScope(purgeThreshold, activityPeriods)
+	if !ok {
+		logger.Info("no block sequences to purge")
+		return &deletedCount, nil
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount = s.rows().delete(func(row interface{}) bool {
+		return fn(s.db, row.(*model.BlockSeq))
+	})
+	return &deletedCount, nil
+}
+
+// ArchiveSeqsOlderThan passes at most limit block sequences which DeleteSeqOlderThan deletes for the same arguments
+// to archive and deletes exactly them, nothing is deleted when archive fails
+func (s *BlockSeqStore) ArchiveSeqsOlderThan(purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow, limit int64, archive func([]model.BlockSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	fn, ok := blockSeqPurgeScope(purgeThreshold, activityPeriods)
+	if !ok {
+		return &deletedCount, nil
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var seqs []model.BlockSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.BlockSeq); fn(s.db, r) {
+			seqs = append(seqs, *copyOf(r).(*model.BlockSeq))
+		}
+	}
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].Height < seqs[j].Height })
+	if int64(len(seqs)) > limit {
+		seqs = seqs[:limit]
+	}
+	if len(seqs) == 0 {
+		return &deletedCount, nil
+	}
+
+	if err := archive(seqs); err != nil {
+		return nil, err
+	}
+
+	ids := map[types.ID]bool{}
+	for _, seq := range seqs {
+		ids[seq.ID] = true
+	}
+	deletedCount = s.rows().delete(func(row interface{}) bool {
+		return ids[row.(*model.BlockSeq).ID]
+	})
+	return &deletedCount, nil
+}
+
+// blockSeqPurgeScope returns filter of block sequences to purge, it returns false when there is nothing to purge.
+// Filter must be called with the lock held, sequences within restored ranges are never purged
+func blockSeqPurgeScope(purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow) (func(*db, *model.BlockSeq) bool, bool) {
+	var periods []store.ActivityPeriodRow
+	for _, activityPeriod := range activityPeriods {
+		// Make sure that there are many intervals (ie. days) in period
+		if !activityPeriod.Min.Equal(activityPeriod.Max) {
+			periods = append(periods, activityPeriod)
+		}
+	}
+
+	return func(d *db, b *model.BlockSeq) bool {
+		for _, period := range periods {
+			// Thus, we do not add 1 day to Max because we don't want to purge sequences within last day of period
+			if b.Time.Before(period.Min.Time) || !b.Time.Before(period.Max.Time) {
+				return false
+			}
+		}
+		return b.Time.Before(purgeThreshold) && !isRestored(d, b.Height)
+	}, len(periods) > 0
+}
+
+// Summarize gets the summarized version of block sequences
+func (s *BlockSeqStore) Summarize(interval types.SummaryInterval, activityPeriods []store.ActivityPeriodRow) ([]model.BlockSeqSummary, error) {
+	var filterErr error
+	seqs := s.find(func(b *model.BlockSeq) bool {
+		ok, err := outsideActivityPeriods(interval, activityPeriods, b.Time.Time)
+		if err != nil {
+			filterErr = err
+		}
+		return ok
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	groups := map[time.Time][]model.BlockSeq{}
+	var buckets []time.Time
+	for _, seq := range seqs {
+		bucket, err := truncateTime(interval, seq.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := groups[bucket]; !ok {
+			buckets = append(buckets, bucket)
+		}
+		groups[bucket] = append(groups[bucket], seq)
+	}
+
+	return summarizeBlockSeqs(buckets, groups), nil
+}
+
+// SummarizeByEra gets the summarized version of block sequences for eras which started at or after given time
+func (s *BlockSeqStore) SummarizeByEra(since time.Time) ([]model.BlockSeqSummary, error) {
+	s.db.mu.RLock()
+	eras := erasSince(s.db.table(model.Syncable{}).rows, since)
+	s.db.mu.RUnlock()
+
+	groups := map[time.Time][]model.BlockSeq{}
+	var buckets []time.Time
+	for _, era := range eras {
+		seqs := s.find(func(b *model.BlockSeq) bool {
+			return b.Height >= era.startHeight && b.Height <= era.endHeight
+		})
+		if len(seqs) == 0 {
+			continue
+		}
+		if _, ok := groups[era.startTime]; !ok {
+			buckets = append(buckets, era.startTime)
+		}
+		groups[era.startTime] = append(groups[era.startTime], seqs...)
+	}
+
+	return summarizeBlockSeqs(buckets, groups), nil
+}
+
+// FindSeqsForHeightRange returns block sequences between given heights (inclusive)
+func (s *BlockSeqStore) FindSeqsForHeightRange(startHeight, endHeight int64) ([]model.BlockSeq, error) {
+	res := s.find(func(b *model.BlockSeq) bool {
+		return b.Height >= startHeight && b.Height <= endHeight
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Height < res[j].Height })
+	return res, nil
+}
+
+func summarizeBlockSeqs(buckets []time.Time, groups map[time.Time][]model.BlockSeq) []model.BlockSeqSummary {
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Before(buckets[j]) })
+
+	var models []model.BlockSeqSummary
+	for _, bucket := range buckets {
+		seqs := groups[bucket]
+
+		minTime, maxTime := seqs[0].Time.Time, seqs[0].Time.Time
+		for _, seq := range seqs {
+			if seq.Time.Before(minTime) {
+				minTime = seq.Time.Time
+			}
+			if seq.Time.After(maxTime) {
+				maxTime = seq.Time.Time
+			}
+		}
+
+		models = append(models, model.BlockSeqSummary{
+			TimeBucket:   *types.NewTimeFromTime(bucket),
+			Count:        int64(len(seqs)),
+			BlockTimeAvg: maxTime.Sub(minTime).Seconds() / float64(len(seqs)),
+		})
+	}
+	return models
+}
+
+func (s BlockSeqStore) first(fn func(*model.BlockSeq) bool) (*model.BlockSeq, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.BlockSeq{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+func (s BlockSeqStore) find(fn func(*model.BlockSeq) bool) []model.BlockSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.BlockSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.BlockSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.BlockSeq))
+		}
+	}
+	return res
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewBlockSummaryStore(db *db) *BlockSummaryStore {
+	return &BlockSummaryStore{scoped(db, model.BlockSummary{})}
+}
+
+// BlockSummaryStore handles operations on block summary
+type BlockSummaryStore struct {
+	baseStore
+}
+
+// CreateSummary creates the block summary
+func (s BlockSummaryStore) CreateSummary(val *model.BlockSummary) error {
+	return s.Create(val)
+}
+
+// SaveSummary saves the block summary
+func (s BlockSummaryStore) SaveSummary(val *model.BlockSummary) error {
+	return s.Save(val)
+}
+
+// FindSummary find block summary by query
+func (s BlockSummaryStore) FindSummary(query *model.BlockSummary) (*model.BlockSummary, error) {
+	res := s.find(func(b *model.BlockSummary) bool {
+		return matchesQuery(b, query)
+	})
+	if len(res) == 0 {
+		return &model.BlockSummary{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// FindMostRecentSummary finds most recent block summary
+func (s *BlockSummaryStore) FindMostRecentSummary() (*model.BlockSummary, error) {
+	return s.mostRecent(func(*model.BlockSummary) bool { return true })
+}
+
+// FindMostRecentByInterval finds most recent block summary for interval
+func (s *BlockSummaryStore) FindMostRecentByInterval(interval types.SummaryInterval) (*model.BlockSummary, error) {
+	return s.mostRecent(func(b *model.BlockSummary) bool {
+		return b.TimeInterval == interval
+	})
+}
+
+// FindActivityPeriods Finds activity periods
+func (s *BlockSummaryStore) FindActivityPeriods(interval types.SummaryInterval, indexVersion int64) ([]store.ActivityPeriodRow, error) {
+	summaries := s.find(func(b *model.BlockSummary) bool {
+		return b.TimeInterval == interval && b.IndexVersion == indexVersion
+	})
+
+	buckets := make([]time.Time, len(summaries))
+	for i, summary := range summaries {
+		buckets[i] = summary.TimeBucket.Time
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Before(buckets[j]) })
+
+	return findActivityPeriods(buckets, interval.MaxBucketGap())
+}
+
+// FindSummaries Gets average block times for interval
+func (s *BlockSummaryStore) FindSummaries(interval types.SummaryInterval, period string) ([]model.BlockSummary, error) {
+	latest, err := s.FindMostRecentByInterval(interval)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	since, err := subtractPeriod(latest.TimeBucket.Time, period)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.find(func(b *model.BlockSummary) bool {
+		return b.TimeInterval == interval && !b.TimeBucket.Before(since)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.Before(res[j].TimeBucket.Time) })
+	return res, nil
+}
+
+// DeleteOlderThan deleted block summary records older than given threshold
+func (s *BlockSummaryStore) DeleteOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		b := row.(*model.BlockSummary)
+		return b.TimeInterval == interval && b.TimeBucket.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// SummarizeDailySummaries rolls daily block summaries with time bucket at or after given time up into buckets of given interval
+func (s *BlockSummaryStore) SummarizeDailySummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.BlockSeqSummary, error) {
+	summaries := s.find(func(b *model.BlockSummary) bool {
+		return b.TimeInterval == types.IntervalDaily && b.IndexVersion == indexVersion && !b.TimeBucket.Before(since)
+	})
+
+	groups := map[time.Time][]model.BlockSummary{}
+	var buckets []time.Time
+	for _, summary := range summaries {
+		bucket, err := truncateTime(interval, summary.TimeBucket.Time)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := groups[bucket]; !ok {
+			buckets = append(buckets, bucket)
+		}
+		groups[bucket] = append(groups[bucket], summary)
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Before(buckets[j]) })
+
+	var models []model.BlockSeqSummary
+	for _, bucket := range buckets {
+		rollup := model.BlockSeqSummary{TimeBucket: *types.NewTimeFromTime(bucket)}
+
+		var blockTimeSum float64
+		for _, summary := range groups[bucket] {
+			rollup.Count += summary.Count
+			blockTimeSum += summary.BlockTimeAvg * float64(summary.Count)
+		}
+		if rollup.Count > 0 {
+			rollup.BlockTimeAvg = blockTimeSum / float64(rollup.Count)
+		}
+		models = append(models, rollup)
+	}
+	return models, nil
+}
+
+func (s BlockSummaryStore) mostRecent(fn func(*model.BlockSummary) bool) (*model.BlockSummary, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.BlockSummary{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.After(res[j].TimeBucket.Time) })
+	return &res[0], nil
+}
+
+func (s BlockSummaryStore) find(fn func(*model.BlockSummary) bool) []model.BlockSummary {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.BlockSummary
+	for _, row := range s.rows().rows {
+		if r := row.(*model.BlockSummary); fn(r) {
+			res = append(res, *copyOf(r).(*model.BlockSummary))
+		}
+	}
+	return res
+}
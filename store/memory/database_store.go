@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+func NewDatabaseStore(db *db) *DatabaseStore {
+	return &DatabaseStore{
+		db: db,
+	}
+}
+
+// DatabaseStore handles operations on database
+type DatabaseStore struct {
+	db *db
+}
+
+// GetTotalSize returns zero size as in-memory store has no database files
+func (s *DatabaseStore) GetTotalSize() (*store.GetTotalSizeResult, error) {
+	return &store.GetTotalSizeResult{}, nil
+}
+
+// GetMigrationVersion returns the schema version the binary expects, in-memory store does not need migrations
+func (s *DatabaseStore) GetMigrationVersion() (*store.GetMigrationVersionResult, error) {
+	return &store.GetMigrationVersionResult{
+		Version: config.MigrationVersion,
+	}, nil
+}
+
+// Ping checks the database connection
+func (s *DatabaseStore) Ping() error {
+	return nil
+}
@@ -0,0 +1,498 @@
+package memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var (
+	quantityType = reflect.TypeOf(types.Quantity{})
+	modelType    = reflect.TypeOf(&model.Model{})
+	idType       = reflect.TypeOf(types.ID(0))
+)
+
+// db holds all tables of the in-memory store
+type db struct {
+	mu     sync.RWMutex
+	tables map[reflect.Type]*table
+}
+
+// table holds records of single model, records are kept in insertion order
+type table struct {
+	nextID types.ID
+	rows   []interface{}
+}
+
+func newDB() *db {
+	return &db{
+		tables: newTables(),
+	}
+}
+
+// newTables creates tables for all models up front, so that readers never have to create one
+func newTables() map[reflect.Type]*table {
+	tables := map[reflect.Type]*table{}
+	for _, m := range []interface{}{
+		model.AccountEraSeq{},
+		model.BlockSeq{},
+		model.BlockSummary{},
+		model.EraSummary{},
+		model.EventSeq{},
+		model.EventSummary{},
+		model.HeightUpdate{},
+		model.Job{},
+		model.OutboxMessage{},
+		model.Price{},
+		model.Report{},
+		model.RestoredRange{},
+		model.RewardEraSeq{},
+		model.Subscription{},
+		model.SubscriptionDelivery{},
+		model.Syncable{},
+		model.SystemEvent{},
+		model.TransactionSeq{},
+		model.TransactionSummary{},
+		model.ValidatorAgg{},
+		model.ValidatorEraSeq{},
+		model.ValidatorSeq{},
+		model.ValidatorSessionSeq{},
+		model.ValidatorSummary{},
+	} {
+		tables[reflect.TypeOf(m)] = &table{nextID: 1}
+	}
+	return tables
+}
+
+// table returns table for given model, record can be a pointer or a value. Tables of unknown models are created on demand, which requires the write lock
+func (d *db) table(record interface{}) *table {
+	t := reflect.TypeOf(record)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	tbl, ok := d.tables[t]
+	if !ok {
+		tbl = &table{nextID: 1}
+		d.tables[t] = tbl
+	}
+	return tbl
+}
+
+// insert assigns ID and timestamps to record and stores a copy of it
+func (t *table) insert(record interface{}) {
+	now := time.Now()
+	v := reflect.ValueOf(record).Elem()
+
+	if f := v.FieldByName("Model"); f.IsValid() && f.Type() == modelType {
+		if f.IsNil() {
+			f.Set(reflect.ValueOf(&model.Model{}))
+		}
+		m := f.Interface().(*model.Model)
+		if m.CreatedAt.IsZero() {
+			m.CreatedAt = *types.NewTimeFromTime(now)
+		}
+		if m.UpdatedAt.IsZero() {
+			m.UpdatedAt = *types.NewTimeFromTime(now)
+		}
+	}
+
+	if id, ok := idField(v); ok && id.Int() == 0 {
+		id.SetInt(int64(t.nextID))
+		t.nextID++
+	} else if ok && types.ID(id.Int()) >= t.nextID {
+		t.nextID = types.ID(id.Int()) + 1
+	}
+
+	t.rows = append(t.rows, copyOf(record))
+}
+
+// save replaces record with the same ID or inserts record when there is none
+func (t *table) save(record interface{}) {
+	v := reflect.ValueOf(record).Elem()
+
+	id, ok := idField(v)
+	if !ok || id.Int() == 0 {
+		t.insert(record)
+		return
+	}
+
+	if f := v.FieldByName("Model"); f.IsValid() && f.Type() == modelType {
+		f.Interface().(*model.Model).UpdatedAt = *types.NewTimeFromTime(time.Now())
+	}
+
+	for i, row := range t.rows {
+		rowID, _ := idField(reflect.ValueOf(row).Elem())
+		if rowID.Int() == id.Int() {
+			t.rows[i] = copyOf(record)
+			return
+		}
+	}
+	t.insert(record)
+}
+
+// upsert stores copy of record when no row matches, otherwise it calls update with matching row
+func (t *table) upsert(record interface{}, match func(row interface{}) bool, update func(row interface{})) int64 {
+	for _, row := range t.rows {
+		if match(row) {
+			if update == nil {
+				return 0
+			}
+			update(row)
+			return 1
+		}
+	}
+	t.insert(copyOf(record))
+	return 1
+}
+
+// delete removes records matching fn and returns number of removed records
+func (t *table) delete(fn func(row interface{}) bool) int64 {
+	var count int64
+	rows := t.rows[:0]
+	for _, row := range t.rows {
+		if fn(row) {
+			count++
+			continue
+		}
+		rows = append(rows, row)
+	}
+	for i := len(rows); i < len(t.rows); i++ {
+		t.rows[i] = nil
+	}
+	t.rows = rows
+	return count
+}
+
+// idField returns ID field of the record, it does not traverse nil embedded structs
+func idField(v reflect.Value) (reflect.Value, bool) {
+	if f := v.FieldByName("Model"); f.IsValid() && f.Type() == modelType {
+		if f.IsNil() {
+			return reflect.Value{}, false
+		}
+		return f.Elem().FieldByName("ID"), true
+	}
+	if f, ok := v.Type().FieldByName("ID"); ok && f.Type == idType && len(f.Index) == 1 {
+		return v.Field(f.Index[0]), true
+	}
+	return reflect.Value{}, false
+}
+
+// copyOf returns deep copy of given value so that stored records are never shared with callers
+func copyOf(src interface{}) interface{} {
+	return deepCopy(reflect.ValueOf(src)).Interface()
+}
+
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		if v.Type() == quantityType {
+			q := v.Interface().(types.Quantity)
+			c.Set(reflect.ValueOf(q.Clone()))
+			return c
+		}
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	default:
+		return v
+	}
+}
+
+// truncateTime truncates time to the start of its bucket the same way as DATE_TRUNC does in UTC
+func truncateTime(interval types.SummaryInterval, t time.Time) (time.Time, error) {
+	t = t.UTC()
+	switch interval {
+	case types.IntervalHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.UTC), nil
+	case types.IntervalDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	case types.IntervalWeekly:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), nil
+	case types.IntervalMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	default:
+		return time.Time{}, fmt.Errorf("summary interval %s has no time buckets", interval)
+	}
+}
+
+// subtractPeriod subtracts Postgres interval (ie. "1 day", "2 weeks 3 hours") from given time
+func subtractPeriod(t time.Time, period string) (time.Time, error) {
+	parts := strings.Fields(period)
+	if len(parts) == 0 || len(parts)%2 != 0 {
+		return time.Time{}, fmt.Errorf("invalid period %q", period)
+	}
+
+	for i := 0; i < len(parts); i += 2 {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid period %q", period)
+		}
+
+		switch strings.TrimSuffix(strings.ToLower(parts[i+1]), "s") {
+		case "second", "sec":
+			t = t.Add(-time.Duration(n) * time.Second)
+		case "minute", "min":
+			t = t.Add(-time.Duration(n) * time.Minute)
+		case "hour":
+			t = t.Add(-time.Duration(n) * time.Hour)
+		case "day":
+			t = t.AddDate(0, 0, -n)
+		case "week":
+			t = t.AddDate(0, 0, -7*n)
+		case "month", "mon":
+			t = t.AddDate(0, -n, 0)
+		case "year":
+			t = t.AddDate(-n, 0, 0)
+		default:
+			return time.Time{}, fmt.Errorf("invalid period %q", period)
+		}
+	}
+	return t, nil
+}
+
+// outsideActivityPeriods reports whether sequence time is outside of already summarized activity periods
+func outsideActivityPeriods(interval types.SummaryInterval, activityPeriods []store.ActivityPeriodRow, t time.Time) (bool, error) {
+	if len(activityPeriods) == 0 {
+		return true, nil
+	}
+
+	if len(activityPeriods) == 1 {
+		activityPeriod := activityPeriods[0]
+		return t.Before(activityPeriod.Min.Time) || !t.Before(activityPeriod.Max.Time), nil
+	}
+
+	for i, activityPeriod := range activityPeriods {
+		isLast := i == len(activityPeriods)-1
+
+		if isLast {
+			if !t.Before(activityPeriod.Max.Time) {
+				return true, nil
+			}
+		} else {
+			nextBucket, err := interval.NextBucket(activityPeriod.Max.Time)
+			if err != nil {
+				return false, err
+			}
+			if !t.Before(nextBucket) && t.Before(activityPeriods[i+1].Min.Time) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// findActivityPeriods groups sorted time buckets into periods in which consecutive buckets are at most maxBucketGap apart
+func findActivityPeriods(buckets []time.Time, maxBucketGap string) ([]store.ActivityPeriodRow, error) {
+	var res []store.ActivityPeriodRow
+	for i, bucket := range buckets {
+		newPeriod := i == 0
+		if !newPeriod {
+			threshold, err := subtractPeriod(bucket, maxBucketGap)
+			if err != nil {
+				return nil, err
+			}
+			newPeriod = buckets[i-1].Before(threshold)
+		}
+
+		if newPeriod {
+			res = append(res, store.ActivityPeriodRow{
+				Period: int64(len(res) + 1),
+				Min:    *types.NewTimeFromTime(bucket),
+			})
+		}
+		res[len(res)-1].Max = *types.NewTimeFromTime(bucket)
+	}
+	return res, nil
+}
+
+// eraRange describes eras as they are reported by syncables
+type eraRange struct {
+	era         int64
+	startHeight int64
+	endHeight   int64
+	startTime   time.Time
+}
+
+// erasSince returns eras of syncables at or after given time ordered by start time, since is expected to be start of era
+func erasSince(syncables []interface{}, since time.Time) []eraRange {
+	byEra := map[int64]*eraRange{}
+	var order []int64
+	for _, row := range syncables {
+		s := row.(*model.Syncable)
+		if s.Time.Before(since) {
+			continue
+		}
+		e, ok := byEra[s.Era]
+		if !ok {
+			byEra[s.Era] = &eraRange{era: s.Era, startHeight: s.Height, endHeight: s.Height, startTime: s.Time.Time}
+			order = append(order, s.Era)
+			continue
+		}
+		if s.Height < e.startHeight {
+			e.startHeight = s.Height
+		}
+		if s.Height > e.endHeight {
+			e.endHeight = s.Height
+		}
+		if s.Time.Before(e.startTime) {
+			e.startTime = s.Time.Time
+		}
+	}
+
+	var res []eraRange
+	for _, era := range order {
+		res = append(res, *byEra[era])
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].startTime.Before(res[j].startTime) })
+	return res
+}
+
+// jsonbValue returns text of "value" key of i-th element of jsonb array, same as data->i->>'value'
+func jsonbValue(data types.Jsonb, i int) (string, bool) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data.RawMessage, &items); err != nil || i >= len(items) {
+		return "", false
+	}
+
+	raw, ok := items[i]["value"]
+	if !ok || string(raw) == "null" {
+		return "", false
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, true
+	}
+	return string(raw), true
+}
+
+// jsonbContainsValue reports whether jsonb array contains element with given value, same as data @> '[{"value": ...}]'
+func jsonbContainsValue(data types.Jsonb, value string) bool {
+	var items []map[string]interface{}
+	if err := json.Unmarshal(data.RawMessage, &items); err != nil {
+		return false
+	}
+	for _, item := range items {
+		if v, ok := item["value"].(string); ok && v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// quantityAvg returns integer average of quantities
+func quantityAvg(sum *big.Int, count int64) types.Quantity {
+	if count == 0 {
+		return types.NewQuantityFromInt64(0)
+	}
+	return types.NewQuantity(new(big.Int).Quo(sum, big.NewInt(count)))
+}
+
+// quantityStats returns integer average, minimum and maximum of quantities, same as AVG, MIN and MAX of numeric column
+func quantityStats(values []types.Quantity) (avg, min, max types.Quantity) {
+	sum := new(big.Int)
+	for i := range values {
+		sum.Add(sum, &values[i].Int)
+		if i == 0 || values[i].Cmp(&min.Int) < 0 {
+			min = values[i].Clone()
+		}
+		if i == 0 || values[i].Cmp(&max.Int) > 0 {
+			max = values[i].Clone()
+		}
+	}
+	return quantityAvg(sum, int64(len(values))), min, max
+}
+
+// int64Stats returns average, minimum and maximum of values
+func int64Stats(values []int64) (avg float64, min, max int64) {
+	var sum int64
+	for i, v := range values {
+		sum += v
+		if i == 0 || v < min {
+			min = v
+		}
+		if i == 0 || v > max {
+			max = v
+		}
+	}
+	if len(values) > 0 {
+		avg = float64(sum) / float64(len(values))
+	}
+	return avg, min, max
+}
+
+// matchesQuery reports whether row has all non-zero fields of query, same as gorm Where with struct does
+func matchesQuery(row, query interface{}) bool {
+	return matchesFields(reflect.ValueOf(row).Elem(), reflect.ValueOf(query).Elem())
+}
+
+func matchesFields(row, query reflect.Value) bool {
+	for i := 0; i < query.NumField(); i++ {
+		field := query.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		q := query.Field(i)
+		r := row.Field(i)
+		if field.Anonymous && q.Kind() == reflect.Ptr {
+			if q.IsNil() {
+				continue
+			}
+			if r.IsNil() {
+				r = reflect.New(r.Type().Elem())
+			}
+			if !matchesFields(r.Elem(), q.Elem()) {
+				return false
+			}
+			continue
+		}
+
+		if q.IsZero() {
+			continue
+		}
+		if t, ok := q.Interface().(types.Time); ok {
+			if !t.Time.Equal(r.Interface().(types.Time).Time) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(q.Interface(), r.Interface()) {
+			return false
+		}
+	}
+	return true
+}
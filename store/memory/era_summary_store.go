@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewEraSummaryStore(db *db) *EraSummaryStore {
+	return &EraSummaryStore{scoped(db, model.EraSummary{})}
+}
+
+// EraSummaryStore handles operations on era summaries
+type EraSummaryStore struct {
+	baseStore
+}
+
+// UpsertEraSummary creates era summary or updates existing one of the same era
+func (s EraSummaryStore) UpsertEraSummary(r *model.EraSummary) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().upsert(r, func(row interface{}) bool {
+		return row.(*model.EraSummary).Era == r.Era
+	}, func(row interface{}) {
+		e := row.(*model.EraSummary)
+		id := e.ID
+		*e = *copyOf(r).(*model.EraSummary)
+		e.ID = id
+	})
+	return nil
+}
+
+// FindEraSummaries returns most recent era summaries
+func (s EraSummaryStore) FindEraSummaries(limit int64) ([]store.EraSummaryRow, error) {
+	res := s.find(func(*model.EraSummary) bool { return true })
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Era > res[j].Era })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// FindEraSummaryByEra returns summary of given era
+func (s EraSummaryStore) FindEraSummaryByEra(era int64) (*store.EraSummaryRow, error) {
+	res := s.find(func(e *model.EraSummary) bool {
+		return e.Era == era
+	})
+	if len(res) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// find returns era summaries matching fn with time of their first height, same as join with syncables
+func (s EraSummaryStore) find(fn func(*model.EraSummary) bool) []store.EraSummaryRow {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	startTimes := map[int64]types.Time{}
+	for _, row := range s.db.table(model.Syncable{}).rows {
+		syncable := row.(*model.Syncable)
+		startTimes[syncable.Height] = syncable.Time
+	}
+
+	var res []store.EraSummaryRow
+	for _, row := range s.rows().rows {
+		if r := row.(*model.EraSummary); fn(r) {
+			summary := store.EraSummaryRow{EraSummary: *copyOf(r).(*model.EraSummary)}
+			if t, ok := startTimes[r.StartHeight]; ok {
+				summary.StartTime = &t
+			}
+			res = append(res, summary)
+		}
+	}
+	return res
+}
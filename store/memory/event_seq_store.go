@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewEventSeqStore(db *db) *EventSeqStore {
+	return &EventSeqStore{scoped(db, model.EventSeq{})}
+}
+
+// EventSeqStore handles operations on events
+type EventSeqStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new records and updates existing ones
+func (s EventSeqStore) BulkUpsert(records []model.EventSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			e := row.(*model.EventSeq)
+			return e.Height == r.Height && e.Index == r.Index
+		}, func(row interface{}) {
+			e := row.(*model.EventSeq)
+			e.ExtrinsicIndex = r.ExtrinsicIndex
+			e.Data = *copyOf(&r.Data).(*types.Jsonb)
+			e.Phase = r.Phase
+			e.Method = r.Method
+			e.Section = r.Section
+		})
+	}
+	return nil
+}
+
+// FindByHeightAndIndex finds event by height and index
+func (s EventSeqStore) FindByHeightAndIndex(height int64, index int64) (*model.EventSeq, error) {
+	res := s.find(func(e *model.EventSeq) bool {
+		return e.Height == height && e.Index == index
+	})
+	if len(res) == 0 {
+		return &model.EventSeq{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// FindByHeight finds event sequences by height
+func (s EventSeqStore) FindByHeight(height int64) ([]model.EventSeq, error) {
+	return s.find(func(e *model.EventSeq) bool {
+		return e.Height == height
+	}), nil
+}
+
+// FindBalanceTransfers finds balance transfers event sequences for given address
+func (s EventSeqStore) FindBalanceTransfers(address string) ([]model.EventSeqWithTxHash, error) {
+	return s.findWithTxHash("balances", "Transfer", address)
+}
+
+// FindBalanceDeposits finds balance deposits event sequences for given address
+func (s EventSeqStore) FindBalanceDeposits(address string) ([]model.EventSeqWithTxHash, error) {
+	return s.findWithTxHash("balances", "Deposit", address)
+}
+
+// FindBonded finds bonded event sequences for given address
+func (s EventSeqStore) FindBonded(address string) ([]model.EventSeqWithTxHash, error) {
+	return s.findWithTxHash("staking", "Bonded", address)
+}
+
+// FindUnbonded finds unbonded event sequences for given address
+func (s EventSeqStore) FindUnbonded(address string) ([]model.EventSeqWithTxHash, error) {
+	return s.findWithTxHash("staking", "Unbonded", address)
+}
+
+// FindWithdrawn finds withdrawn event sequences for given address
+func (s EventSeqStore) FindWithdrawn(address string) ([]model.EventSeqWithTxHash, error) {
+	return s.findWithTxHash("staking", "Withdrawn", address)
+}
+
+// FindMostRecent finds most recent event sequence
+func (s *EventSeqStore) FindMostRecent() (*model.EventSeq, error) {
+	res := s.find(func(*model.EventSeq) bool { return true })
+	if len(res) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.After(res[j].Time.Time) })
+	return &res[0], nil
+}
+
+// DeleteOlderThan deletes event sequence older than given threshold
+func (s *EventSeqStore) DeleteOlderThan(purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		return row.(*model.EventSeq).Time.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// FindRewardsForTimePeriod find rewards events for an account for given time period
+func (s *EventSeqStore) FindRewardsForTimePeriod(address string, start, end time.Time) ([]model.EventSeq, error) {
+	res := s.find(func(e *model.EventSeq) bool {
+		if e.Section != "staking" || e.Method != "Reward" {
+			return false
+		}
+		if value, ok := jsonbValue(e.Data, 0); !ok || value != address {
+			return false
+		}
+		if !start.IsZero() && e.Time.Before(start) {
+			return false
+		}
+		return end.IsZero() || e.Time.Before(end)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time.Time) })
+	return res, nil
+}
+
+// FindForHeightRange returns event sequences between given heights (inclusive), non empty address matches events with address in data
+func (s EventSeqStore) FindForHeightRange(startHeight, endHeight int64, address string) ([]model.EventSeq, error) {
+	res := s.find(func(e *model.EventSeq) bool {
+		if address != "" && !jsonbContainsValue(e.Data, address) {
+			return false
+		}
+		return e.Height >= startHeight && e.Height <= endHeight
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].Index < res[j].Index
+	})
+	return res, nil
+}
+
+// FindSlashesForHeightRange returns staking slash event sequences between given heights (inclusive), slashed stash is first item of data
+func (s EventSeqStore) FindSlashesForHeightRange(startHeight, endHeight int64) ([]model.EventSeq, error) {
+	res := s.find(func(e *model.EventSeq) bool {
+		if e.Section != "staking" || (e.Method != "Slash" && e.Method != "Slashed") {
+			return false
+		}
+		return e.Height >= startHeight && e.Height <= endHeight
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].Index < res[j].Index
+	})
+	return res, nil
+}
+
+// findWithTxHash finds events of given kind joined with transactions which emitted them, transfers match both source and target address
+func (s EventSeqStore) findWithTxHash(section, method, address string) ([]model.EventSeqWithTxHash, error) {
+	events := s.find(func(e *model.EventSeq) bool {
+		if e.Section != section || e.Method != method {
+			return false
+		}
+		if value, ok := jsonbValue(e.Data, 0); ok && value == address {
+			return true
+		}
+		if method == "Transfer" {
+			value, ok := jsonbValue(e.Data, 1)
+			return ok && value == address
+		}
+		return false
+	})
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []model.EventSeqWithTxHash
+	for _, e := range events {
+		for _, row := range s.db.table(model.TransactionSeq{}).rows {
+			t := row.(*model.TransactionSeq)
+			if t.Height != e.Height || t.Index != e.ExtrinsicIndex {
+				continue
+			}
+			result = append(result, model.EventSeqWithTxHash{
+				Height:  e.Height,
+				Data:    e.Data,
+				Method:  e.Method,
+				Section: e.Section,
+				TxHash:  t.Hash,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (s EventSeqStore) find(fn func(*model.EventSeq) bool) []model.EventSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.EventSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.EventSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.EventSeq))
+		}
+	}
+	return res
+}
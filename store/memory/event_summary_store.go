@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewEventSummaryStore(db *db) *EventSummaryStore {
+	return &EventSummaryStore{scoped(db, model.EventSummary{})}
+}
+
+// EventSummaryStore handles operations on event summary
+type EventSummaryStore struct {
+	baseStore
+}
+
+// activityKey identifies summary row, empty section and method mark totals of time bucket
+type activityKey struct {
+	bucket  time.Time
+	section string
+	method  string
+}
+
+// SummarizeEventSeqs summarizes event sequences starting with most recent time bucket, it returns number of saved summaries.
+// Older buckets with heights indexed since last run, ie. by backfill, are summarized again.
+// Existing summary is never replaced with one of lower count, so summaries outlive purged sequences
+func (s *EventSummaryStore) SummarizeEventSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	since, lastRun := lastEventSummaryRun(s.rows().rows, interval, indexVersion)
+	touched, err := touchedBuckets(s.db.table(model.Syncable{}).rows, interval, since, lastRun)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[activityKey]int64{}
+	volumes := map[activityKey]*big.Int{}
+	var keys []activityKey
+	for _, row := range s.db.table(model.EventSeq{}).rows {
+		e := row.(*model.EventSeq)
+
+		bucket, err := truncateTime(interval, e.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		if since != nil && e.Time.Before(*since) && !touched[bucket] {
+			continue
+		}
+
+		amount := new(big.Int)
+		if e.Section == "balances" && e.Method == "Transfer" {
+			value, ok := jsonbValue(e.Data, 2)
+			if _, valid := amount.SetString(value, 10); ok && !valid {
+				return nil, fmt.Errorf("invalid transfer amount %q at height %d", value, e.Height)
+			}
+		}
+
+		for _, key := range []activityKey{{bucket, e.Section, e.Method}, {bucket, "", ""}} {
+			if _, ok := counts[key]; !ok {
+				keys = append(keys, key)
+				volumes[key] = new(big.Int)
+			}
+			counts[key]++
+			volumes[key].Add(volumes[key], amount)
+		}
+	}
+
+	var rowsAffected int64
+	for _, key := range keys {
+		key := key
+		summary := &model.EventSummary{
+			Summary: &model.Summary{
+				TimeInterval: interval,
+				TimeBucket:   *types.NewTimeFromTime(key.bucket),
+				IndexVersion: indexVersion,
+			},
+			Section:        key.section,
+			Method:         key.method,
+			Count:          counts[key],
+			TransferVolume: types.NewQuantity(volumes[key]),
+		}
+
+		var existing *model.EventSummary
+		for _, row := range s.rows().rows {
+			e := row.(*model.EventSummary)
+			if e.TimeInterval == interval && e.TimeBucket.Time.Equal(key.bucket) && e.IndexVersion == indexVersion && e.Section == key.section && e.Method == key.method {
+				existing = e
+				break
+			}
+		}
+
+		if existing == nil {
+			s.rows().insert(summary)
+			rowsAffected++
+		} else if existing.Count <= summary.Count {
+			existing.UpdatedAt = *types.NewTimeFromTime(time.Now())
+			existing.Count = summary.Count
+			existing.TransferVolume = summary.TransferVolume
+			rowsAffected++
+		}
+	}
+	return &rowsAffected, nil
+}
+
+// FindEventSummaries returns event summaries of given index version by section and method for period preceding most recent time bucket,
+// empty section or method match all of them
+func (s *EventSummaryStore) FindEventSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.EventSummary, error) {
+	return s.findForPeriod(interval, period, indexVersion, func(e *model.EventSummary) bool {
+		if e.Section == "" && e.Method == "" {
+			return false
+		}
+		if section != "" && e.Section != section {
+			return false
+		}
+		return method == "" || e.Method == method
+	})
+}
+
+// FindEventTotals returns event summaries of given index version which hold totals of all sections and methods
+// for period preceding most recent time bucket
+func (s *EventSummaryStore) FindEventTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.EventSummary, error) {
+	return s.findForPeriod(interval, period, indexVersion, func(e *model.EventSummary) bool {
+		return e.Section == "" && e.Method == ""
+	})
+}
+
+// findForPeriod returns matching summaries of index version for period preceding most recent time bucket ordered by time bucket, section and method
+func (s *EventSummaryStore) findForPeriod(interval types.SummaryInterval, period string, indexVersion int64, fn func(*model.EventSummary) bool) ([]model.EventSummary, error) {
+	summaries := s.find(func(e *model.EventSummary) bool {
+		return e.TimeInterval == interval && e.IndexVersion == indexVersion
+	})
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+
+	latest := summaries[0].TimeBucket.Time
+	for _, e := range summaries {
+		if e.TimeBucket.After(latest) {
+			latest = e.TimeBucket.Time
+		}
+	}
+
+	since, err := subtractPeriod(latest, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []model.EventSummary
+	for i := range summaries {
+		if e := &summaries[i]; !e.TimeBucket.Before(since) && fn(e) {
+			res = append(res, *e)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].TimeBucket.Equal(res[j].TimeBucket) {
+			return res[i].TimeBucket.Before(res[j].TimeBucket.Time)
+		}
+		if res[i].Section != res[j].Section {
+			return res[i].Section < res[j].Section
+		}
+		return res[i].Method < res[j].Method
+	})
+	return res, nil
+}
+
+// FindMostRecentEventSummary finds most recent event summary for given time interval
+func (s *EventSummaryStore) FindMostRecentEventSummary(interval types.SummaryInterval) (*model.EventSummary, error) {
+	res := s.find(func(e *model.EventSummary) bool {
+		return e.TimeInterval == interval
+	})
+	if len(res) == 0 {
+		return &model.EventSummary{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.After(res[j].TimeBucket.Time) })
+	return &res[0], nil
+}
+
+// DeleteEventSummariesOlderThan deletes event summary records older than given threshold
+func (s *EventSummaryStore) DeleteEventSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		e := row.(*model.EventSummary)
+		return e.TimeInterval == interval && e.TimeBucket.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+func (s EventSummaryStore) find(fn func(*model.EventSummary) bool) []model.EventSummary {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.EventSummary
+	for _, row := range s.rows().rows {
+		if r := row.(*model.EventSummary); fn(r) {
+			res = append(res, *copyOf(r).(*model.EventSummary))
+		}
+	}
+	return res
+}
+
+// lastEventSummaryRun returns most recent time bucket summarized for interval and index version, nil if there is none,
+// and when summaries were last updated
+func lastEventSummaryRun(rows []interface{}, interval types.SummaryInterval, indexVersion int64) (*time.Time, time.Time) {
+	var max *time.Time
+	var updatedAt time.Time
+	for _, row := range rows {
+		e := row.(*model.EventSummary)
+		if e.TimeInterval != interval || e.IndexVersion != indexVersion {
+			continue
+		}
+		if max == nil || e.TimeBucket.After(*max) {
+			t := e.TimeBucket.Time
+			max = &t
+		}
+		if e.UpdatedAt.After(updatedAt) {
+			updatedAt = e.UpdatedAt.Time
+		}
+	}
+	return max, updatedAt
+}
+
+// touchedBuckets returns time buckets before since with syncables processed after lastRun, ie. by backfill
+func touchedBuckets(syncables []interface{}, interval types.SummaryInterval, since *time.Time, lastRun time.Time) (map[time.Time]bool, error) {
+	touched := map[time.Time]bool{}
+	if since == nil {
+		return touched, nil
+	}
+
+	for _, row := range syncables {
+		s := row.(*model.Syncable)
+		if s.ProcessedAt == nil || !s.ProcessedAt.After(lastRun) || !s.Time.Before(*since) {
+			continue
+		}
+
+		bucket, err := truncateTime(interval, s.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		touched[bucket] = true
+	}
+	return touched, nil
+}
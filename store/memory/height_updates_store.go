@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewHeightUpdatesStore(db *db) *HeightUpdatesStore {
+	return &HeightUpdatesStore{scoped(db, model.HeightUpdate{})}
+}
+
+// HeightUpdatesStore handles operations on height updates
+type HeightUpdatesStore struct {
+	baseStore
+}
+
+// PublishHeightUpdate saves height update, listeners are not notified as there is no database to deliver notifications
+func (s HeightUpdatesStore) PublishHeightUpdate(update *model.HeightUpdate) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().upsert(update, func(row interface{}) bool {
+		return row.(*model.HeightUpdate).Height == update.Height
+	}, func(row interface{}) {
+		h := row.(*model.HeightUpdate)
+		h.UpdatedAt = *types.NewTimeFromTime(time.Now())
+		h.Time = update.Time
+		h.Session = update.Session
+		h.Era = update.Era
+		h.LastInSession = update.LastInSession
+		h.LastInEra = update.LastInEra
+		h.RewardsClaimed = *copyOf(&update.RewardsClaimed).(*types.Jsonb)
+	})
+	return nil
+}
+
+// FindHeightUpdatesAfter returns height updates for heights greater than given height
+func (s HeightUpdatesStore) FindHeightUpdatesAfter(height int64, limit int64) ([]model.HeightUpdate, error) {
+	res := s.find(func(h *model.HeightUpdate) bool {
+		return h.Height > height
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Height < res[j].Height })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// FindFirstHeightUpdate returns height update for oldest retained height
+func (s HeightUpdatesStore) FindFirstHeightUpdate() (*model.HeightUpdate, error) {
+	res := s.find(func(*model.HeightUpdate) bool { return true })
+	if len(res) == 0 {
+		return &model.HeightUpdate{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Height < res[j].Height })
+	return &res[0], nil
+}
+
+// FindLastHeightUpdate returns height update for most recent height
+func (s HeightUpdatesStore) FindLastHeightUpdate() (*model.HeightUpdate, error) {
+	res := s.find(func(*model.HeightUpdate) bool { return true })
+	if len(res) == 0 {
+		return &model.HeightUpdate{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Height > res[j].Height })
+	return &res[0], nil
+}
+
+// DeleteHeightUpdatesOlderThan deletes height updates older than given threshold
+func (s HeightUpdatesStore) DeleteHeightUpdatesOlderThan(purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		return row.(*model.HeightUpdate).Time.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+func (s HeightUpdatesStore) find(fn func(*model.HeightUpdate) bool) []model.HeightUpdate {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.HeightUpdate
+	for _, row := range s.rows().rows {
+		if r := row.(*model.HeightUpdate); fn(r) {
+			res = append(res, *copyOf(r).(*model.HeightUpdate))
+		}
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewJobsStore(db *db) *JobsStore {
+	return &JobsStore{scoped(db, model.Job{})}
+}
+
+// JobsStore handles operations on jobs
+type JobsStore struct {
+	baseStore
+}
+
+// FindByID returns job by id
+func (s JobsStore) FindByID(id types.ID) (*model.Job, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	if job := s.findByID(id); job != nil {
+		return copyOf(job).(*model.Job), nil
+	}
+	return &model.Job{}, store.ErrNotFound
+}
+
+// FindRecent returns most recent jobs
+func (s JobsStore) FindRecent(limit int64) ([]model.Job, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []model.Job
+	for _, row := range s.rows().rows {
+		result = append(result, *copyOf(row).(*model.Job))
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if int64(len(result)) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// CreateUnique creates job unless there is already queued or running job with the same unique key
+func (s JobsStore) CreateUnique(job *model.Job, uniqueKey string) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, row := range s.rows().rows {
+		j := row.(*model.Job)
+		if j.UniqueKey != nil && *j.UniqueKey == uniqueKey && (j.Status == model.JobStatusQueued || j.Status == model.JobStatusRunning) {
+			return false, nil
+		}
+	}
+
+	s.rows().insert(&model.Job{
+		Kind:        job.Kind,
+		Params:      *copyOf(&job.Params).(*types.Jsonb),
+		Status:      job.Status,
+		UniqueKey:   &uniqueKey,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+	})
+	return true, nil
+}
+
+// Claim locks the next job of given kinds ready to run, jobs with lock older than lockTimeout are claimed again
+// unless they ran out of attempts, such jobs are marked as failed
+func (s JobsStore) Claim(workerID string, kinds []model.JobKind, lockTimeout time.Duration) (*model.Job, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := time.Now()
+
+	var next *model.Job
+	for _, row := range s.rows().rows {
+		j := row.(*model.Job)
+		ready := j.Status == model.JobStatusQueued && !j.RunAt.After(now)
+		expired := j.Status == model.JobStatusRunning && j.LockedAt != nil && j.LockedAt.Before(now.Add(-lockTimeout))
+		if expired && j.Attempts >= j.MaxAttempts {
+			errMsg := model.JobErrorLockExpired
+			j.Status = model.JobStatusFailed
+			j.Error = &errMsg
+			j.LockedBy = nil
+			j.LockedAt = nil
+			j.CompletedAt = types.NewTimeFromTime(now)
+			j.UpdatedAt = *types.NewTimeFromTime(now)
+			continue
+		}
+		if (!ready && !expired) || j.Attempts >= j.MaxAttempts || !hasJobKind(kinds, j.Kind) {
+			continue
+		}
+		if next == nil || j.RunAt.Before(next.RunAt.Time) || (j.RunAt.Time.Equal(next.RunAt.Time) && j.ID < next.ID) {
+			next = j
+		}
+	}
+	if next == nil {
+		return &model.Job{}, store.ErrNotFound
+	}
+
+	next.Status = model.JobStatusRunning
+	next.LockedBy = &workerID
+	next.LockedAt = types.NewTimeFromTime(now)
+	next.Attempts++
+	next.UpdatedAt = *types.NewTimeFromTime(now)
+	return copyOf(next).(*model.Job), nil
+}
+
+// Heartbeat extends lock of running job and returns its current status
+func (s JobsStore) Heartbeat(job *model.Job) (model.JobStatus, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	j := s.findByID(job.ID)
+	if j == nil || !lockedBy(j.LockedBy, job.LockedBy) {
+		return "", store.ErrNotFound
+	}
+
+	j.LockedAt = types.NewTimeFromTime(time.Now())
+	return j.Status, nil
+}
+
+// Finish releases lock and saves result of running job
+func (s JobsStore) Finish(job *model.Job) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	j := s.findByID(job.ID)
+	if j == nil || j.Status != model.JobStatusRunning || !lockedBy(j.LockedBy, job.LockedBy) {
+		return store.ErrNotFound
+	}
+
+	j.Status = job.Status
+	j.Error = copyOf(job.Error).(*string)
+	j.RunAt = job.RunAt
+	j.CompletedAt = copyOf(job.CompletedAt).(*types.Time)
+	j.LockedBy = nil
+	j.LockedAt = nil
+	j.UpdatedAt = *types.NewTimeFromTime(time.Now())
+	return nil
+}
+
+// Cancel marks queued or running job as cancelled
+func (s JobsStore) Cancel(id types.ID) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	j := s.findByID(id)
+	if j == nil || (j.Status != model.JobStatusQueued && j.Status != model.JobStatusRunning) {
+		return false, nil
+	}
+
+	now := time.Now()
+	j.Status = model.JobStatusCancelled
+	j.CompletedAt = types.NewTimeFromTime(now)
+	j.UpdatedAt = *types.NewTimeFromTime(now)
+	return true, nil
+}
+
+// findByID returns stored job, callers must hold the lock
+func (s JobsStore) findByID(id types.ID) *model.Job {
+	for _, row := range s.rows().rows {
+		if j := row.(*model.Job); j.ID == id {
+			return j
+		}
+	}
+	return nil
+}
+
+func hasJobKind(kinds []model.JobKind, kind model.JobKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedBy reports whether lock is held by given worker, same as comparing nullable columns in SQL
+func lockedBy(lock, worker *string) bool {
+	return lock != nil && worker != nil && *lock == *worker
+}
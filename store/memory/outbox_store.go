@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewOutboxStore(db *db) *OutboxStore {
+	return &OutboxStore{scoped(db, model.OutboxMessage{})}
+}
+
+// OutboxStore handles operations on outbox messages
+type OutboxStore struct {
+	baseStore
+}
+
+// SaveSyncableWithMessage saves syncable and adds message to outbox under one lock
+func (s OutboxStore) SaveSyncableWithMessage(syncable *model.Syncable, message *model.OutboxMessage) error {
+	for _, record := range []interface{}{syncable, message} {
+		if err := checkPtr(record); err != nil {
+			return err
+		}
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.table(syncable).save(syncable)
+	s.rows().insert(message)
+	return nil
+}
+
+// FindOutboxMessages returns oldest outbox messages in order of their heights
+func (s OutboxStore) FindOutboxMessages(limit int64) ([]model.OutboxMessage, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.OutboxMessage
+	for _, row := range s.rows().rows {
+		res = append(res, *copyOf(row).(*model.OutboxMessage))
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].ID < res[j].ID
+	})
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// DeleteOutboxMessages deletes published outbox messages
+func (s OutboxStore) DeleteOutboxMessages(ids []types.ID) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().delete(func(row interface{}) bool {
+		return containsID(ids, row.(*model.OutboxMessage).ID)
+	})
+	return nil
+}
+
+// MarkOutboxMessagesFailed records failed publishing attempt of outbox messages
+func (s OutboxStore) MarkOutboxMessagesFailed(ids []types.ID, lastError string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, row := range s.rows().rows {
+		message := row.(*model.OutboxMessage)
+		if containsID(ids, message.ID) {
+			message.Attempts++
+			message.LastError = &lastError
+			message.UpdatedAt = *types.NewTimeFromTime(time.Now())
+		}
+	}
+	return nil
+}
+
+func containsID(ids []types.ID, id types.ID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
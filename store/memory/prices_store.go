@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewPricesStore(db *db) *PricesStore {
+	return &PricesStore{scoped(db, model.Price{})}
+}
+
+// PricesStore handles operations on prices
+type PricesStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new prices and updates existing ones
+func (s PricesStore) BulkUpsert(records []model.Price) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := *types.NewTimeFromTime(time.Now())
+	for i := range records {
+		r := records[i]
+		r.Model = &model.Model{CreatedAt: t, UpdatedAt: t}
+		r.Date = *types.NewTimeFromTime(priceDate(r.Date.Time))
+		s.rows().upsert(&r, func(row interface{}) bool {
+			p := row.(*model.Price)
+			return p.Currency == r.Currency && p.Date.Equal(r.Date)
+		}, func(row interface{}) {
+			p := row.(*model.Price)
+			p.UpdatedAt = t
+			p.Price = r.Price
+		})
+	}
+	return nil
+}
+
+// FindForPeriod returns prices in currency for days between given dates (inclusive)
+func (s PricesStore) FindForPeriod(currency string, start, end time.Time) ([]model.Price, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	start, end = priceDate(start), priceDate(end)
+
+	var res []model.Price
+	for _, row := range s.rows().rows {
+		p := row.(*model.Price)
+		if p.Currency != currency || p.Date.Before(start) || p.Date.After(end) {
+			continue
+		}
+		res = append(res, *copyOf(p).(*model.Price))
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Date.Before(res[j].Date.Time) })
+	return res, nil
+}
+
+// priceDate truncates time to UTC day, same as casting it to DATE column
+func priceDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
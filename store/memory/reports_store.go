@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+func NewReportsStore(db *db) *ReportsStore {
+	return &ReportsStore{scoped(db, model.Report{})}
+}
+
+// ReportsStore handles operations on reports
+type ReportsStore struct {
+	baseStore
+}
+
+// FindNotCompletedByIndexVersion returns the report by index version and kind
+func (s ReportsStore) FindNotCompletedByIndexVersion(indexVersion int64, kinds ...model.ReportKind) (*model.Report, error) {
+	return s.first(func(r *model.Report) bool {
+		return r.IndexVersion == indexVersion && hasReportKind(kinds, r.Kind) && r.CompletedAt == nil
+	})
+}
+
+// FindNotCompletedByKind returns the first not completed report of given kinds
+func (s ReportsStore) FindNotCompletedByKind(kinds ...model.ReportKind) (*model.Report, error) {
+	return s.first(func(r *model.Report) bool {
+		return hasReportKind(kinds, r.Kind) && r.CompletedAt == nil
+	})
+}
+
+// FindLastCompleted returns the most recently completed report of given kinds
+func (s ReportsStore) FindLastCompleted(kinds ...model.ReportKind) (*model.Report, error) {
+	res := s.find(func(r *model.Report) bool {
+		return hasReportKind(kinds, r.Kind) && r.CompletedAt != nil
+	})
+	if len(res) == 0 {
+		return &model.Report{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].CompletedAt.After(res[j].CompletedAt.Time) })
+	return &res[0], nil
+}
+
+// FindRecent returns most recent reports
+func (s ReportsStore) FindRecent(limit int64) ([]model.Report, error) {
+	res := s.find(func(*model.Report) bool { return true })
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// Last returns the last report
+func (s ReportsStore) Last() (*model.Report, error) {
+	res, _ := s.FindRecent(1)
+	if len(res) == 0 {
+		return &model.Report{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// DeleteByKinds deletes reports with kind reindexing sequential or parallel
+func (s *ReportsStore) DeleteByKinds(kinds []model.ReportKind) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().delete(func(row interface{}) bool {
+		return hasReportKind(kinds, row.(*model.Report).Kind)
+	})
+	return nil
+}
+
+// first returns matching report with the lowest ID
+func (s ReportsStore) first(fn func(*model.Report) bool) (*model.Report, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.Report{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return &res[0], nil
+}
+
+func (s ReportsStore) find(fn func(*model.Report) bool) []model.Report {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.Report
+	for _, row := range s.rows().rows {
+		if r := row.(*model.Report); fn(r) {
+			res = append(res, *copyOf(r).(*model.Report))
+		}
+	}
+	return res
+}
+
+func hasReportKind(kinds []model.ReportKind, kind model.ReportKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/model"
+)
+
+func NewRestoredRangesStore(db *db) *RestoredRangesStore {
+	return &RestoredRangesStore{scoped(db, model.RestoredRange{})}
+}
+
+// RestoredRangesStore handles operations on restored ranges
+type RestoredRangesStore struct {
+	baseStore
+}
+
+// CreateRestoredRange creates restored range
+func (s RestoredRangesStore) CreateRestoredRange(record *model.RestoredRange) error {
+	return s.Create(record)
+}
+
+// isRestored returns true when height is within any restored range, callers must hold the lock
+func isRestored(d *db, height int64) bool {
+	for _, row := range d.table(model.RestoredRange{}).rows {
+		if r := row.(*model.RestoredRange); height >= r.StartHeight && height <= r.EndHeight {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var (
+	errExpectedUpdate = errors.New("no rewards were updated")
+)
+
+func NewRewardEraSeqStore(db *db) *RewardEraSeqStore {
+	return &RewardEraSeqStore{scoped(db, model.RewardEraSeq{})}
+}
+
+// RewardEraSeqStore handles operations on rewardEraSeq
+type RewardEraSeqStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new records, existing ones are left untouched
+func (s RewardEraSeqStore) BulkUpsert(records []model.RewardEraSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			e := row.(*model.RewardEraSeq)
+			return e.Era == r.Era && e.StashAccount == r.StashAccount && e.ValidatorStashAccount == r.ValidatorStashAccount && e.Kind == r.Kind
+		}, nil)
+	}
+	return nil
+}
+
+// MarkAllClaimed updates all rewards for validatorStash and era as claimed. Returns error if nothing updates
+func (s RewardEraSeqStore) MarkAllClaimed(validatorStash string, era int64, txHash string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var updated int64
+	for _, row := range s.rows().rows {
+		r := row.(*model.RewardEraSeq)
+		if r.ValidatorStashAccount == validatorStash && r.Era == era {
+			r.Claimed = true
+			r.TxHash = txHash
+			updated++
+		}
+	}
+
+	if updated == 0 {
+		return errExpectedUpdate
+	}
+	return nil
+}
+
+// GetAll Gets all rewards for given stash
+func (s RewardEraSeqStore) GetAll(stash, validatorStash string, start, end int64) ([]model.RewardEraSeq, error) {
+	res := s.find(func(r *model.RewardEraSeq) bool {
+		if end != 0 && r.Era > end {
+			return false
+		}
+		if start != 0 && r.Era < start {
+			return false
+		}
+		if validatorStash != "" && r.ValidatorStashAccount != validatorStash {
+			return false
+		}
+		return r.StashAccount == stash
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Era < res[j].Era })
+	return res, nil
+}
+
+// GetAllByTime Gets all rewards for given stash
+func (s RewardEraSeqStore) GetAllByTime(stash string, start, end types.Time) ([]model.RewardEraSeq, error) {
+	res := s.find(func(r *model.RewardEraSeq) bool {
+		return r.StashAccount == stash && !r.Time.Before(start.Time) && !r.Time.After(end.Time)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.Before(res[j].Time.Time) })
+	return res, nil
+}
+
+// GetCount returns record count for given validatorStash at era
+func (s RewardEraSeqStore) GetCount(validatorStash string, era int64) (int64, error) {
+	res := s.find(func(r *model.RewardEraSeq) bool {
+		return r.ValidatorStashAccount == validatorStash && r.Era == era
+	})
+	return int64(len(res)), nil
+}
+
+// GetByStashAndEra returns for given stash for validatorStash and era
+func (s *RewardEraSeqStore) GetByStashAndEra(validatorStash, stash string, era int64) (model.RewardEraSeq, error) {
+	res := s.find(func(r *model.RewardEraSeq) bool {
+		return r.ValidatorStashAccount == validatorStash && r.StashAccount == stash && r.Era == era
+	})
+	if len(res) == 0 {
+		return model.RewardEraSeq{}, store.ErrNotFound
+	}
+	return res[0], nil
+}
+
+// FindForEraRange returns rewards between given eras (inclusive), non empty stashAccount matches both nominator and validator stash
+func (s RewardEraSeqStore) FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.RewardEraSeq, error) {
+	res := s.find(func(r *model.RewardEraSeq) bool {
+		if stashAccount != "" && r.StashAccount != stashAccount && r.ValidatorStashAccount != stashAccount {
+			return false
+		}
+		return r.Era >= startEra && r.Era <= endEra
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Era < res[j].Era })
+	return res, nil
+}
+
+// SumNominatorRewardsForEraRange returns rewards of nominators summed by validator and era, for eras between given eras (inclusive)
+func (s RewardEraSeqStore) SumNominatorRewardsForEraRange(startEra, endEra int64) ([]store.ValidatorEraRewardRow, error) {
+	rewards := s.find(func(r *model.RewardEraSeq) bool {
+		if r.Kind != model.RewardReward || r.StashAccount == r.ValidatorStashAccount {
+			return false
+		}
+		return r.Era >= startEra && r.Era <= endEra
+	})
+
+	type key struct {
+		era   int64
+		stash string
+	}
+	sums := map[key]*big.Int{}
+	for _, r := range rewards {
+		amount, err := types.NewQuantityFromString(r.Amount)
+		if err != nil {
+			return nil, err
+		}
+		k := key{r.Era, r.ValidatorStashAccount}
+		if _, ok := sums[k]; !ok {
+			sums[k] = new(big.Int)
+		}
+		sums[k].Add(sums[k], &amount.Int)
+	}
+
+	var res []store.ValidatorEraRewardRow
+	for k, sum := range sums {
+		res = append(res, store.ValidatorEraRewardRow{
+			Era:                   k.era,
+			ValidatorStashAccount: k.stash,
+			Amount:                types.NewQuantity(sum),
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Era != res[j].Era {
+			return res[i].Era < res[j].Era
+		}
+		return res[i].ValidatorStashAccount < res[j].ValidatorStashAccount
+	})
+	return res, nil
+}
+
+func (s RewardEraSeqStore) find(fn func(*model.RewardEraSeq) bool) []model.RewardEraSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.RewardEraSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.RewardEraSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.RewardEraSeq))
+		}
+	}
+	return res
+}
@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+var (
+	errShadowNotSupported = errors.New("shadow tables require postgres store")
+)
+
+func NewShadowStore() *ShadowStore {
+	return &ShadowStore{}
+}
+
+// ShadowStore rejects shadow operations, in-memory store keeps no schemas to swap
+type ShadowStore struct{}
+
+// CreateShadowTables returns error as shadow tables are not supported
+func (s *ShadowStore) CreateShadowTables([]store.ShadowTable) error {
+	return errShadowNotSupported
+}
+
+// OpenShadowStore returns error as shadow tables are not supported
+func (s *ShadowStore) OpenShadowStore() (store.Store, error) {
+	return nil, errShadowNotSupported
+}
+
+// CheckShadowTables returns error as shadow tables are not supported
+func (s *ShadowStore) CheckShadowTables([]store.ShadowTable, int64) ([]store.ShadowTableCheck, error) {
+	return nil, errShadowNotSupported
+}
+
+// SwapShadowTables returns error as shadow tables are not supported
+func (s *ShadowStore) SwapShadowTables([]store.ShadowTable, int64) error {
+	return errShadowNotSupported
+}
+
+// DropShadowTables does nothing as there are no shadow tables
+func (s *ShadowStore) DropShadowTables() error {
+	return nil
+}
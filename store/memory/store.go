@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+var (
+	errNotPointer = errors.New("record must be a pointer to struct")
+)
+
+var (
+	_ store.Store         = (*Store)(nil)
+	_ store.Accounts      = (*accounts)(nil)
+	_ store.Blocks        = (*blocks)(nil)
+	_ store.Database      = (*database)(nil)
+	_ store.Events        = (*events)(nil)
+	_ store.Jobs          = (*jobs)(nil)
+	_ store.Prices        = (*prices)(nil)
+	_ store.Reports       = (*reports)(nil)
+	_ store.Rewards       = (*rewards)(nil)
+	_ store.Subscriptions = (*subscriptions)(nil)
+	_ store.Validators    = (*validators)(nil)
+	_ store.Syncables     = (*syncables)(nil)
+	_ store.SystemEvents  = (*systemEvents)(nil)
+	_ store.Transactions  = (*transactions)(nil)
+)
+
+// Store keeps all data in process memory, it is meant for local development and tests
+type Store struct {
+	db            *db
+	accounts      *accounts
+	blocks        *blocks
+	database      *database
+	events        *events
+	jobs          *jobs
+	prices        *prices
+	reports       *reports
+	rewards       *rewards
+	subscriptions *subscriptions
+	syncables     *syncables
+	systemEvents  *systemEvents
+	transactions  *transactions
+	validators    *validators
+}
+
+type accounts struct {
+	*AccountEraSeqStore
+}
+
+type blocks struct {
+	*BlockSeqStore
+	*BlockSummaryStore
+}
+
+type database struct {
+	*DatabaseStore
+	*RestoredRangesStore
+	*ShadowStore
+}
+
+type events struct {
+	*EventSeqStore
+	*EventSummaryStore
+}
+
+type jobs struct {
+	*JobsStore
+}
+
+type prices struct {
+	*PricesStore
+}
+
+type reports struct {
+	*ReportsStore
+}
+
+type rewards struct {
+	*RewardEraSeqStore
+}
+
+type subscriptions struct {
+	*SubscriptionsStore
+}
+
+type syncables struct {
+	*SyncablesStore
+	*HeightUpdatesStore
+	*OutboxStore
+}
+
+type systemEvents struct {
+	*SystemEventStore
+}
+
+type transactions struct {
+	*TransactionSeqStore
+	*TransactionSummaryStore
+}
+
+type validators struct {
+	*EraSummaryStore
+	*ValidatorAggStore
+	*ValidatorSeqStore
+	*ValidatorEraSeqStore
+	*ValidatorSessionSeqStore
+	*ValidatorSummaryStore
+}
+
+// New returns a new empty in-memory store
+func New() *Store {
+	return &Store{
+		db: newDB(),
+	}
+}
+
+// Close releases all data held by the store
+func (s *Store) Close() error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.db.tables = newTables()
+	return nil
+}
+
+// GetAccounts gets accounts
+func (s *Store) GetAccounts() store.Accounts {
+	if s.accounts == nil {
+		s.accounts = &accounts{
+			NewAccountEraSeqStore(s.db),
+		}
+	}
+	return s.accounts
+}
+
+// GetBlocks gets blocks
+func (s *Store) GetBlocks() store.Blocks {
+	if s.blocks == nil {
+		s.blocks = &blocks{
+			NewBlockSeqStore(s.db),
+			NewBlockSummaryStore(s.db),
+		}
+	}
+	return s.blocks
+}
+
+// GetDatabase gets database
+func (s *Store) GetDatabase() store.Database {
+	if s.database == nil {
+		s.database = &database{
+			NewDatabaseStore(s.db),
+			NewRestoredRangesStore(s.db),
+			NewShadowStore(),
+		}
+	}
+	return s.database
+}
+
+// GetEvents gets events
+func (s *Store) GetEvents() store.Events {
+	if s.events == nil {
+		s.events = &events{
+			NewEventSeqStore(s.db),
+			NewEventSummaryStore(s.db),
+		}
+	}
+	return s.events
+}
+
+// GetJobs gets jobs
+func (s *Store) GetJobs() store.Jobs {
+	if s.jobs == nil {
+		s.jobs = &jobs{
+			NewJobsStore(s.db),
+		}
+	}
+	return s.jobs
+}
+
+// GetPrices gets prices
+func (s *Store) GetPrices() store.Prices {
+	if s.prices == nil {
+		s.prices = &prices{
+			NewPricesStore(s.db),
+		}
+	}
+	return s.prices
+}
+
+// GetReports gets reports
+func (s *Store) GetReports() store.Reports {
+	if s.reports == nil {
+		s.reports = &reports{
+			NewReportsStore(s.db),
+		}
+	}
+	return s.reports
+}
+
+// GetRewards gets rewards
+func (s *Store) GetRewards() store.Rewards {
+	if s.rewards == nil {
+		s.rewards = &rewards{
+			NewRewardEraSeqStore(s.db),
+		}
+	}
+	return s.rewards
+}
+
+// GetSubscriptions gets subscriptions
+func (s *Store) GetSubscriptions() store.Subscriptions {
+	if s.subscriptions == nil {
+		s.subscriptions = &subscriptions{
+			NewSubscriptionsStore(s.db),
+		}
+	}
+	return s.subscriptions
+}
+
+// GetSyncables gets syncables
+func (s *Store) GetSyncables() store.Syncables {
+	if s.syncables == nil {
+		s.syncables = &syncables{
+			NewSyncablesStore(s.db),
+			NewHeightUpdatesStore(s.db),
+			NewOutboxStore(s.db),
+		}
+	}
+	return s.syncables
+}
+
+// GetSystemEvents gets system events
+func (s *Store) GetSystemEvents() store.SystemEvents {
+	if s.systemEvents == nil {
+		s.systemEvents = &systemEvents{
+			NewSystemEventsStore(s.db),
+		}
+	}
+	return s.systemEvents
+}
+
+// GetTransactions gets transactions
+func (s *Store) GetTransactions() store.Transactions {
+	if s.transactions == nil {
+		s.transactions = &transactions{
+			NewTransactionSeqStore(s.db),
+			NewTransactionSummaryStore(s.db),
+		}
+	}
+	return s.transactions
+}
+
+// GetValidators gets validators
+func (s *Store) GetValidators() store.Validators {
+	if s.validators == nil {
+		s.validators = &validators{
+			NewEraSummaryStore(s.db),
+			NewValidatorAggStore(s.db),
+			NewValidatorSeqStore(s.db),
+			NewValidatorEraSeqStore(s.db),
+			NewValidatorSessionSeqStore(s.db),
+			NewValidatorSummaryStore(s.db),
+		}
+	}
+	return s.validators
+}
+
+// Test checks the store status
+func (s *Store) Test() error {
+	return nil
+}
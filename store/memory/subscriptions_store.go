@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewSubscriptionsStore(db *db) *SubscriptionsStore {
+	return &SubscriptionsStore{scoped(db, model.Subscription{})}
+}
+
+// SubscriptionsStore handles operations on subscriptions and their deliveries
+type SubscriptionsStore struct {
+	baseStore
+}
+
+// FindByID returns subscription by id
+func (s SubscriptionsStore) FindByID(id types.ID) (*model.Subscription, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	if subscription := s.findByID(id); subscription != nil {
+		return copyOf(subscription).(*model.Subscription), nil
+	}
+	return &model.Subscription{}, store.ErrNotFound
+}
+
+// FindAll returns all subscriptions
+func (s SubscriptionsStore) FindAll() ([]model.Subscription, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []model.Subscription
+	for _, row := range s.rows().rows {
+		result = append(result, *copyOf(row).(*model.Subscription))
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// SaveSettings saves url, secret, filters and enabled state of subscription without touching its delivery progress
+func (s SubscriptionsStore) SaveSettings(subscription *model.Subscription) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	existing := s.findByID(subscription.ID)
+	if existing == nil {
+		return store.ErrNotFound
+	}
+
+	existing.URL = subscription.URL
+	existing.Secret = subscription.Secret
+	existing.Actors = copyOf(subscription.Actors).(pq.StringArray)
+	existing.Kinds = copyOf(subscription.Kinds).(pq.StringArray)
+	existing.Enabled = subscription.Enabled
+	existing.Failures = subscription.Failures
+	existing.NextAttemptAt = subscription.NextAttemptAt
+	existing.DisabledAt = copyOf(subscription.DisabledAt).(*types.Time)
+	existing.UpdatedAt = *types.NewTimeFromTime(time.Now())
+	return nil
+}
+
+// Delete deletes subscription together with its deliveries
+func (s SubscriptionsStore) Delete(id types.ID) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deleted := s.rows().delete(func(row interface{}) bool {
+		return row.(*model.Subscription).ID == id
+	})
+	if deleted == 0 {
+		return store.ErrNotFound
+	}
+
+	s.db.table(model.SubscriptionDelivery{}).delete(func(row interface{}) bool {
+		return row.(*model.SubscriptionDelivery).SubscriptionID == id
+	})
+	return nil
+}
+
+// Claim locks the next enabled subscription due for delivery before given time, subscriptions with lock older than lockTimeout are claimed again
+func (s SubscriptionsStore) Claim(workerID string, lockTimeout time.Duration, dueBefore time.Time) (*model.Subscription, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := time.Now()
+
+	var next *model.Subscription
+	for _, row := range s.rows().rows {
+		sub := row.(*model.Subscription)
+		if !sub.Enabled || sub.NextAttemptAt.After(dueBefore) {
+			continue
+		}
+		if sub.LockedAt != nil && !sub.LockedAt.Before(now.Add(-lockTimeout)) {
+			continue
+		}
+		if next == nil || sub.NextAttemptAt.Before(next.NextAttemptAt.Time) || (sub.NextAttemptAt.Time.Equal(next.NextAttemptAt.Time) && sub.ID < next.ID) {
+			next = sub
+		}
+	}
+	if next == nil {
+		return &model.Subscription{}, store.ErrNotFound
+	}
+
+	next.LockedBy = &workerID
+	next.LockedAt = types.NewTimeFromTime(now)
+	next.UpdatedAt = *types.NewTimeFromTime(now)
+	return copyOf(next).(*model.Subscription), nil
+}
+
+// Release releases lock and saves delivery progress of claimed subscription
+func (s SubscriptionsStore) Release(subscription *model.Subscription) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	existing := s.findByID(subscription.ID)
+	if existing == nil || !lockedBy(existing.LockedBy, subscription.LockedBy) {
+		return store.ErrNotFound
+	}
+
+	existing.Enabled = subscription.Enabled
+	existing.LastSystemEventHeight = subscription.LastSystemEventHeight
+	existing.LastSystemEventID = subscription.LastSystemEventID
+	existing.Failures = subscription.Failures
+	existing.NextAttemptAt = subscription.NextAttemptAt
+	existing.DisabledAt = copyOf(subscription.DisabledAt).(*types.Time)
+	existing.LockedBy = nil
+	existing.LockedAt = nil
+	existing.UpdatedAt = *types.NewTimeFromTime(time.Now())
+	return nil
+}
+
+// CreateDelivery logs delivery attempt
+func (s SubscriptionsStore) CreateDelivery(delivery *model.SubscriptionDelivery) error {
+	return s.Create(delivery)
+}
+
+// FindDeliveries returns most recent delivery attempts for subscription
+func (s SubscriptionsStore) FindDeliveries(subscriptionID types.ID, limit int64) ([]model.SubscriptionDelivery, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []model.SubscriptionDelivery
+	for _, row := range s.db.table(model.SubscriptionDelivery{}).rows {
+		if d := row.(*model.SubscriptionDelivery); d.SubscriptionID == subscriptionID {
+			result = append(result, *copyOf(d).(*model.SubscriptionDelivery))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if int64(len(result)) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// findByID returns stored subscription, callers must hold the lock
+func (s SubscriptionsStore) findByID(id types.ID) *model.Subscription {
+	for _, row := range s.rows().rows {
+		if sub := row.(*model.Subscription); sub.ID == id {
+			return sub
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewSyncablesStore(db *db) *SyncablesStore {
+	return &SyncablesStore{scoped(db, model.Syncable{})}
+}
+
+// SyncablesStore handles operations on syncables
+type SyncablesStore struct {
+	baseStore
+}
+
+// FindSmallestIndexVersion returns smallest index version
+func (s SyncablesStore) FindSmallestIndexVersion() (*int64, error) {
+	res := s.find(func(syncable *model.Syncable) bool {
+		return syncable.ProcessedAt != nil
+	})
+	if len(res) == 0 {
+		var indexVersion int64
+		return &indexVersion, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].IndexVersion < res[j].IndexVersion })
+	return &res[0].IndexVersion, nil
+}
+
+// FindByHeight returns syncable at given height
+func (s SyncablesStore) FindByHeight(height int64) (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.Height == height
+	}, true)
+}
+
+// FindMostRecent returns the most recent syncable
+func (s SyncablesStore) FindMostRecent() (*model.Syncable, error) {
+	return s.first(func(*model.Syncable) bool { return true }, false)
+}
+
+// FindLastInSessionForHeight finds last_in_session syncable for given height
+func (s SyncablesStore) FindLastInSessionForHeight(height int64) (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.Height >= height && syncable.LastInSession
+	}, true)
+}
+
+// FindLastInSession finds last syncable in given session
+func (s SyncablesStore) FindLastInSession(session int64) (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.Session == session
+	}, false)
+}
+
+// FindLastInEra finds last syncable in given era
+func (s SyncablesStore) FindLastInEra(era int64) (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.Era == era
+	}, false)
+}
+
+// FindLastEndOfSession finds last end of session syncable
+func (s SyncablesStore) FindLastEndOfSession() (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.LastInSession
+	}, false)
+}
+
+// FindLastEndOfEra finds last end of era syncable
+func (s SyncablesStore) FindLastEndOfEra() (syncable *model.Syncable, err error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.LastInEra
+	}, false)
+}
+
+// FindFirstByDifferentIndexVersion returns first syncable with different index version
+func (s SyncablesStore) FindFirstByDifferentIndexVersion(indexVersion int64) (*model.Syncable, error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.IndexVersion != indexVersion
+	}, true)
+}
+
+// FindMostRecentByDifferentIndexVersion returns the most recent syncable with different index version
+func (s SyncablesStore) FindMostRecentByDifferentIndexVersion(indexVersion int64) (*model.Syncable, error) {
+	return s.first(func(syncable *model.Syncable) bool {
+		return syncable.IndexVersion != indexVersion
+	}, false)
+}
+
+// CreateOrUpdate creates a new syncable or updates an existing one
+func (s SyncablesStore) CreateOrUpdate(val *model.Syncable) error {
+	existing, err := s.FindByHeight(val.Height)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return s.Create(val)
+		}
+		return err
+	}
+	return s.Update(existing)
+}
+
+func (s SyncablesStore) SaveSyncable(val *model.Syncable) error {
+	return s.Save(val)
+}
+
+// SetProcessedAtForRange assigns report to syncables in given height range and marks them as not processed
+func (s SyncablesStore) SetProcessedAtForRange(reportID types.ID, startHeight int64, endHeight int64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, row := range s.rows().rows {
+		syncable := row.(*model.Syncable)
+		if syncable.Height >= startHeight && syncable.Height <= endHeight {
+			syncable.ReportID = reportID
+			syncable.ProcessedAt = nil
+		}
+	}
+	return nil
+}
+
+// FindAllByLastInSessionOrEra returns end syncs of sessions and eras
+func (s SyncablesStore) FindAllByLastInSessionOrEra(indexVersion int64, isLastInSession, isLastInEra bool, start, end int64) ([]model.Syncable, error) {
+	res := s.find(func(syncable *model.Syncable) bool {
+		if indexVersion > 0 && syncable.IndexVersion == indexVersion {
+			return false
+		}
+		if isLastInEra && !syncable.LastInEra {
+			return false
+		}
+		if isLastInSession && !syncable.LastInSession {
+			return false
+		}
+		if start > 0 && syncable.Height < start {
+			return false
+		}
+		return end <= 0 || syncable.Height <= end
+	})
+	if res == nil {
+		res = []model.Syncable{}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Height < res[j].Height })
+	return res, nil
+}
+
+// GetReportProgress returns count and last height of syncables processed for report
+func (s SyncablesStore) GetReportProgress(reportID types.ID) (*store.ReportProgressRow, error) {
+	var result store.ReportProgressRow
+	for _, syncable := range s.find(func(syncable *model.Syncable) bool {
+		return syncable.ReportID == reportID && syncable.ProcessedAt != nil
+	}) {
+		result.ProcessedCount++
+		if syncable.Height > result.LastProcessedHeight {
+			result.LastProcessedHeight = syncable.Height
+		}
+	}
+	return &result, nil
+}
+
+// first returns matching syncable with the lowest or the highest height
+func (s SyncablesStore) first(fn func(*model.Syncable) bool, ascending bool) (*model.Syncable, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.Syncable{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if ascending {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].Height > res[j].Height
+	})
+	return &res[0], nil
+}
+
+func (s SyncablesStore) find(fn func(*model.Syncable) bool) []model.Syncable {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.Syncable
+	for _, row := range s.rows().rows {
+		if r := row.(*model.Syncable); fn(r) {
+			res = append(res, *copyOf(r).(*model.Syncable))
+		}
+	}
+	return res
+}
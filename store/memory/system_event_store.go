@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewSystemEventsStore(db *db) *SystemEventStore {
+	return &SystemEventStore{scoped(db, model.SystemEvent{})}
+}
+
+// SystemEventStore handles operations on system events
+type SystemEventStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new records and updates existing ones
+func (s SystemEventStore) BulkUpsert(records []model.SystemEvent) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.upsert(records)
+	return nil
+}
+
+func (s SystemEventStore) upsert(records []model.SystemEvent) {
+	t := *types.NewTimeFromTime(time.Now())
+	for i := range records {
+		r := records[i]
+		r.Model = &model.Model{CreatedAt: t, UpdatedAt: t}
+		s.rows().upsert(&r, func(row interface{}) bool {
+			e := row.(*model.SystemEvent)
+			return e.Height == r.Height && e.Actor == r.Actor && e.Kind == r.Kind
+		}, func(row interface{}) {
+			e := row.(*model.SystemEvent)
+			e.UpdatedAt = t
+			e.Data = *copyOf(&r.Data).(*types.Jsonb)
+		})
+	}
+}
+
+// FindByActor returns system events by actor
+func (s SystemEventStore) FindByActor(actorAddress string, kind *model.SystemEventKind, minHeight *int64) ([]model.SystemEvent, error) {
+	return s.find(func(e *model.SystemEvent) bool {
+		if kind != nil && *kind != "" && e.Kind != *kind {
+			return false
+		}
+		if minHeight != nil && e.Height <= *minHeight {
+			return false
+		}
+		return e.Actor == actorAddress
+	}), nil
+}
+
+// FindByKinds returns first system events of given kinds ordered by id
+func (s SystemEventStore) FindByKinds(kinds []model.SystemEventKind, limit int64) ([]model.SystemEvent, error) {
+	res := s.find(func(e *model.SystemEvent) bool {
+		for _, kind := range kinds {
+			if e.Kind == kind {
+				return true
+			}
+		}
+		return false
+	})
+	return limitSystemEvents(sortSystemEventsByID(res), limit), nil
+}
+
+// FindForSubscription returns system events up to maxHeight ordered by height and id after last delivered one
+// which match subscription actors and kinds
+func (s SystemEventStore) FindForSubscription(subscription *model.Subscription, maxHeight int64, limit int64) ([]model.SystemEvent, error) {
+	res := s.find(func(e *model.SystemEvent) bool {
+		if len(subscription.Actors) > 0 && !hasString(subscription.Actors, e.Actor) {
+			return false
+		}
+		if len(subscription.Kinds) > 0 && !hasString(subscription.Kinds, string(e.Kind)) {
+			return false
+		}
+		if e.Height > maxHeight {
+			return false
+		}
+		return e.Height > subscription.LastSystemEventHeight ||
+			(e.Height == subscription.LastSystemEventHeight && e.ID > subscription.LastSystemEventID)
+	})
+	return limitSystemEvents(sortSystemEventsByHeight(res), limit), nil
+}
+
+// FindForHeightRange returns system events between given heights (inclusive) for given actors and kinds, empty actors or kinds match all of them
+func (s SystemEventStore) FindForHeightRange(startHeight, endHeight int64, actors, kinds []string) ([]model.SystemEvent, error) {
+	res := s.find(func(e *model.SystemEvent) bool {
+		if len(actors) > 0 && !hasString(actors, e.Actor) {
+			return false
+		}
+		if len(kinds) > 0 && !hasString(kinds, string(e.Kind)) {
+			return false
+		}
+		return e.Height >= startHeight && e.Height <= endHeight
+	})
+	return sortSystemEventsByHeight(res), nil
+}
+
+// FindLastID returns id of most recently created system event, it's 0 when there are no system events
+func (s SystemEventStore) FindLastID() (types.ID, error) {
+	var id types.ID
+	for _, e := range s.find(func(*model.SystemEvent) bool { return true }) {
+		if e.ID > id {
+			id = e.ID
+		}
+	}
+	return id, nil
+}
+
+// ReplaceByIDs deletes system events with given ids and imports records in their place
+func (s SystemEventStore) ReplaceByIDs(ids []types.ID, records []model.SystemEvent) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	s.rows().delete(func(row interface{}) bool {
+		id := row.(*model.SystemEvent).ID
+		for _, i := range ids {
+			if i == id {
+				return true
+			}
+		}
+		return false
+	})
+	s.upsert(records)
+	return nil
+}
+
+func (s SystemEventStore) find(fn func(*model.SystemEvent) bool) []model.SystemEvent {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.SystemEvent
+	for _, row := range s.rows().rows {
+		if r := row.(*model.SystemEvent); fn(r) {
+			res = append(res, *copyOf(r).(*model.SystemEvent))
+		}
+	}
+	return res
+}
+
+func sortSystemEventsByID(res []model.SystemEvent) []model.SystemEvent {
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+func sortSystemEventsByHeight(res []model.SystemEvent) []model.SystemEvent {
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+func limitSystemEvents(res []model.SystemEvent, limit int64) []model.SystemEvent {
+	if int64(len(res)) > limit {
+		return res[:limit]
+	}
+	return res
+}
+
+func hasString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
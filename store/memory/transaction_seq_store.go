@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+)
+
+func NewTransactionSeqStore(db *db) *TransactionSeqStore {
+	return &TransactionSeqStore{scoped(db, model.TransactionSeq{})}
+}
+
+// TransactionSeqStore handles operations on transactions
+type TransactionSeqStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new records and updates existing ones
+func (s TransactionSeqStore) BulkUpsert(records []model.TransactionSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		r.Args = ""
+		s.rows().upsert(&r, func(row interface{}) bool {
+			t := row.(*model.TransactionSeq)
+			return t.Height == r.Height && t.Index == r.Index
+		}, func(row interface{}) {
+			t := row.(*model.TransactionSeq)
+			t.Hash = r.Hash
+			t.Method = r.Method
+			t.Section = r.Section
+			t.Signer = r.Signer
+		})
+	}
+	return nil
+}
+
+// GetTransactionsByTransactionKind gets transactions by kind
+func (s TransactionSeqStore) GetTransactionsByTransactionKind(kind model.TransactionKind, start, end int64) ([]model.TransactionSeq, error) {
+	return s.find(func(t *model.TransactionSeq) bool {
+		if start > 0 && t.Height < start {
+			return false
+		}
+		if end > 0 && t.Height > end {
+			return false
+		}
+		return t.Method == kind.Method && t.Section == kind.Section
+	}), nil
+}
+
+// FindForHeightRange returns transaction sequences between given heights (inclusive), empty signer matches all transactions
+func (s TransactionSeqStore) FindForHeightRange(startHeight, endHeight int64, signer string) ([]model.TransactionSeq, error) {
+	res := s.find(func(t *model.TransactionSeq) bool {
+		if signer != "" && t.Signer != signer {
+			return false
+		}
+		return t.Height >= startHeight && t.Height <= endHeight
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].Index < res[j].Index
+	})
+	return res, nil
+}
+
+func (s TransactionSeqStore) find(fn func(*model.TransactionSeq) bool) []model.TransactionSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.TransactionSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.TransactionSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.TransactionSeq))
+		}
+	}
+	return res
+}
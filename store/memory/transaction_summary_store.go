@@ -0,0 +1,226 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewTransactionSummaryStore(db *db) *TransactionSummaryStore {
+	return &TransactionSummaryStore{scoped(db, model.TransactionSummary{})}
+}
+
+// TransactionSummaryStore handles operations on transaction summary
+type TransactionSummaryStore struct {
+	baseStore
+}
+
+// SummarizeTransactionSeqs summarizes transaction sequences starting with most recent time bucket, it returns number of saved summaries.
+// Older buckets with heights indexed since last run, ie. by backfill, are summarized again.
+// Existing summary is never replaced with one of lower count, so summaries outlive purged sequences
+func (s *TransactionSummaryStore) SummarizeTransactionSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var since *time.Time
+	var lastRun time.Time
+	for _, row := range s.rows().rows {
+		t := row.(*model.TransactionSummary)
+		if t.TimeInterval != interval || t.IndexVersion != indexVersion {
+			continue
+		}
+		if since == nil || t.TimeBucket.After(*since) {
+			bucket := t.TimeBucket.Time
+			since = &bucket
+		}
+		if t.UpdatedAt.After(lastRun) {
+			lastRun = t.UpdatedAt.Time
+		}
+	}
+
+	touched, err := touchedBuckets(s.db.table(model.Syncable{}).rows, interval, since, lastRun)
+	if err != nil {
+		return nil, err
+	}
+
+	type failureKey struct {
+		height int64
+		index  int64
+	}
+	failures := map[failureKey]bool{}
+	for _, row := range s.db.table(model.EventSeq{}).rows {
+		e := row.(*model.EventSeq)
+		if e.Section == "system" && e.Method == "ExtrinsicFailed" {
+			failures[failureKey{e.Height, e.ExtrinsicIndex}] = true
+		}
+	}
+
+	counts := map[activityKey]int64{}
+	failedCounts := map[activityKey]int64{}
+	signers := map[activityKey]map[string]bool{}
+	var keys []activityKey
+	for _, row := range s.db.table(model.TransactionSeq{}).rows {
+		t := row.(*model.TransactionSeq)
+
+		bucket, err := truncateTime(interval, t.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		if since != nil && t.Time.Before(*since) && !touched[bucket] {
+			continue
+		}
+
+		for _, key := range []activityKey{{bucket, t.Section, t.Method}, {bucket, "", ""}} {
+			if _, ok := counts[key]; !ok {
+				keys = append(keys, key)
+				signers[key] = map[string]bool{}
+			}
+			counts[key]++
+			if failures[failureKey{t.Height, t.Index}] {
+				failedCounts[key]++
+			}
+			if t.Signer != "" {
+				signers[key][t.Signer] = true
+			}
+		}
+	}
+
+	var rowsAffected int64
+	for _, key := range keys {
+		summary := &model.TransactionSummary{
+			Summary: &model.Summary{
+				TimeInterval: interval,
+				TimeBucket:   *types.NewTimeFromTime(key.bucket),
+				IndexVersion: indexVersion,
+			},
+			Section:      key.section,
+			Method:       key.method,
+			Count:        counts[key],
+			FailedCount:  failedCounts[key],
+			SignersCount: int64(len(signers[key])),
+		}
+
+		var existing *model.TransactionSummary
+		for _, row := range s.rows().rows {
+			t := row.(*model.TransactionSummary)
+			if t.TimeInterval == interval && t.TimeBucket.Time.Equal(key.bucket) && t.IndexVersion == indexVersion && t.Section == key.section && t.Method == key.method {
+				existing = t
+				break
+			}
+		}
+
+		if existing == nil {
+			s.rows().insert(summary)
+			rowsAffected++
+		} else if existing.Count <= summary.Count {
+			existing.UpdatedAt = *types.NewTimeFromTime(time.Now())
+			existing.Count = summary.Count
+			existing.FailedCount = summary.FailedCount
+			existing.SignersCount = summary.SignersCount
+			rowsAffected++
+		}
+	}
+	return &rowsAffected, nil
+}
+
+// FindTransactionSummaries returns transaction summaries of given index version by section and method for period preceding most recent time bucket,
+// empty section or method match all of them
+func (s *TransactionSummaryStore) FindTransactionSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.TransactionSummary, error) {
+	return s.findForPeriod(interval, period, indexVersion, func(t *model.TransactionSummary) bool {
+		if t.Section == "" && t.Method == "" {
+			return false
+		}
+		if section != "" && t.Section != section {
+			return false
+		}
+		return method == "" || t.Method == method
+	})
+}
+
+// FindTransactionTotals returns transaction summaries of given index version which hold totals of all sections and methods
+// for period preceding most recent time bucket
+func (s *TransactionSummaryStore) FindTransactionTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.TransactionSummary, error) {
+	return s.findForPeriod(interval, period, indexVersion, func(t *model.TransactionSummary) bool {
+		return t.Section == "" && t.Method == ""
+	})
+}
+
+// findForPeriod returns matching summaries of index version for period preceding most recent time bucket ordered by time bucket, section and method
+func (s *TransactionSummaryStore) findForPeriod(interval types.SummaryInterval, period string, indexVersion int64, fn func(*model.TransactionSummary) bool) ([]model.TransactionSummary, error) {
+	summaries := s.find(func(t *model.TransactionSummary) bool {
+		return t.TimeInterval == interval && t.IndexVersion == indexVersion
+	})
+	if len(summaries) == 0 {
+		return nil, nil
+	}
+
+	latest := summaries[0].TimeBucket.Time
+	for _, t := range summaries {
+		if t.TimeBucket.After(latest) {
+			latest = t.TimeBucket.Time
+		}
+	}
+
+	since, err := subtractPeriod(latest, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []model.TransactionSummary
+	for i := range summaries {
+		if t := &summaries[i]; !t.TimeBucket.Before(since) && fn(t) {
+			res = append(res, *t)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if !res[i].TimeBucket.Equal(res[j].TimeBucket) {
+			return res[i].TimeBucket.Before(res[j].TimeBucket.Time)
+		}
+		if res[i].Section != res[j].Section {
+			return res[i].Section < res[j].Section
+		}
+		return res[i].Method < res[j].Method
+	})
+	return res, nil
+}
+
+// FindMostRecentTransactionSummary finds most recent transaction summary for given time interval
+func (s *TransactionSummaryStore) FindMostRecentTransactionSummary(interval types.SummaryInterval) (*model.TransactionSummary, error) {
+	res := s.find(func(t *model.TransactionSummary) bool {
+		return t.TimeInterval == interval
+	})
+	if len(res) == 0 {
+		return &model.TransactionSummary{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.After(res[j].TimeBucket.Time) })
+	return &res[0], nil
+}
+
+// DeleteTransactionSummariesOlderThan deletes transaction summary records older than given threshold
+func (s *TransactionSummaryStore) DeleteTransactionSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		t := row.(*model.TransactionSummary)
+		return t.TimeInterval == interval && t.TimeBucket.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+func (s TransactionSummaryStore) find(fn func(*model.TransactionSummary) bool) []model.TransactionSummary {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.TransactionSummary
+	for _, row := range s.rows().rows {
+		if r := row.(*model.TransactionSummary); fn(r) {
+			res = append(res, *copyOf(r).(*model.TransactionSummary))
+		}
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+func NewValidatorAggStore(db *db) *ValidatorAggStore {
+	return &ValidatorAggStore{scoped(db, model.ValidatorAgg{})}
+}
+
+// ValidatorAggStore handles operations on validators
+type ValidatorAggStore struct {
+	baseStore
+}
+
+// CreateAgg creates the validator aggregate
+func (s ValidatorAggStore) CreateAgg(val *model.ValidatorAgg) error {
+	return s.Create(val)
+}
+
+// SaveAgg saves the validator aggregate
+func (s ValidatorAggStore) SaveAgg(val *model.ValidatorAgg) error {
+	return s.Save(val)
+}
+
+// FindBy returns an validator for a matching attribute, only id and stash_account keys are supported
+func (s ValidatorAggStore) FindBy(key string, value interface{}) (*model.ValidatorAgg, error) {
+	var fn func(*model.ValidatorAgg) bool
+	switch key {
+	case "id":
+		fn = func(v *model.ValidatorAgg) bool { return fmt.Sprint(v.ID) == fmt.Sprint(value) }
+	case "stash_account":
+		fn = func(v *model.ValidatorAgg) bool { return v.StashAccount == fmt.Sprint(value) }
+	default:
+		return &model.ValidatorAgg{}, fmt.Errorf("finding validator aggregates by %s is not supported", key)
+	}
+
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.ValidatorAgg{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// FindByID returns an validator for the ID
+func (s ValidatorAggStore) FindByID(id int64) (*model.ValidatorAgg, error) {
+	return s.FindBy("id", id)
+}
+
+// FindAggByStashAccount return validator by stash account
+func (s *ValidatorAggStore) FindAggByStashAccount(key string) (*model.ValidatorAgg, error) {
+	return s.FindBy("stash_account", key)
+}
+
+// GetAllForHeightGreaterThan returns validators who have been validating since given height
+func (s *ValidatorAggStore) GetAllForHeightGreaterThan(height int64) ([]model.ValidatorAgg, error) {
+	return s.find(func(v *model.ValidatorAgg) bool {
+		return v.RecentAsValidatorHeight >= height
+	}), nil
+}
+
+// All returns all validators
+func (s ValidatorAggStore) All() ([]model.ValidatorAgg, error) {
+	res := s.find(func(*model.ValidatorAgg) bool { return true })
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res, nil
+}
+
+func (s ValidatorAggStore) find(fn func(*model.ValidatorAgg) bool) []model.ValidatorAgg {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.ValidatorAgg
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorAgg); fn(r) {
+			res = append(res, *copyOf(r).(*model.ValidatorAgg))
+		}
+	}
+	return res
+}
@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewValidatorEraSeqStore(db *db) *ValidatorEraSeqStore {
+	return &ValidatorEraSeqStore{scoped(db, model.ValidatorEraSeq{})}
+}
+
+// ValidatorEraSeqStore handles operations on validators
+type ValidatorEraSeqStore struct {
+	baseStore
+}
+
+// validatorBucket identifies validator summary group
+type validatorBucket struct {
+	stash  string
+	bucket time.Time
+}
+
+// BulkUpsertEraSeqs imports new records and updates existing ones
+func (s ValidatorEraSeqStore) BulkUpsertEraSeqs(records []model.ValidatorEraSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			v := row.(*model.ValidatorEraSeq)
+			return v.Era == r.Era && v.StashAccount == r.StashAccount
+		}, func(row interface{}) {
+			v := row.(*model.ValidatorEraSeq)
+			v.ControllerAccount = r.ControllerAccount
+			v.SessionAccounts = copyOf(r.SessionAccounts).(pq.StringArray)
+			v.Index = r.Index
+			v.TotalStake = r.TotalStake.Clone()
+			v.OwnStake = r.OwnStake.Clone()
+			v.StakersStake = r.StakersStake.Clone()
+			v.RewardPoints = r.RewardPoints
+			v.Commission = r.Commission
+			v.StakersCount = r.StakersCount
+		})
+	}
+	return nil
+}
+
+// FindByHeightAndStashAccount finds validator by height and stash account
+func (s ValidatorEraSeqStore) FindByHeightAndStashAccount(height int64, stash string) (*model.ValidatorEraSeq, error) {
+	return s.first(func(v *model.ValidatorEraSeq) bool {
+		return v.StashAccount == stash && v.StartHeight <= height && v.EndHeight >= height
+	})
+}
+
+// FindByEraAndStashAccount finds validator by era and stash account
+func (s ValidatorEraSeqStore) FindByEraAndStashAccount(era int64, stash string) (*model.ValidatorEraSeq, error) {
+	return s.first(func(v *model.ValidatorEraSeq) bool {
+		return v.Era == era && v.StashAccount == stash
+	})
+}
+
+// FindEraSeqsByHeight finds validator era sequences by height
+func (s ValidatorEraSeqStore) FindEraSeqsByHeight(h int64) ([]model.ValidatorEraSeq, error) {
+	return s.find(func(v *model.ValidatorEraSeq) bool {
+		return v.StartHeight <= h && v.EndHeight >= h
+	}), nil
+}
+
+// FindByEra finds validator era sequences by era
+func (s ValidatorEraSeqStore) FindByEra(era int64) ([]model.ValidatorEraSeq, error) {
+	return s.find(func(v *model.ValidatorEraSeq) bool {
+		return v.Era == era
+	}), nil
+}
+
+// FindMostRecentEraSeq finds most recent validator era sequence
+func (s *ValidatorEraSeqStore) FindMostRecentEraSeq() (*model.ValidatorEraSeq, error) {
+	res := s.find(func(*model.ValidatorEraSeq) bool { return true })
+	if len(res) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.After(res[j].Time.Time) })
+	return &res[0], nil
+}
+
+// FindLastEraSeqByStashAccount finds last validator era sequences for given stash account
+func (s ValidatorEraSeqStore) FindLastEraSeqByStashAccount(stashAccount string, limit int64) ([]model.ValidatorEraSeq, error) {
+	res := s.find(func(v *model.ValidatorEraSeq) bool {
+		return v.StashAccount == stashAccount
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Era > res[j].Era })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// DeleteEraSeqsOlderThan deletes validator sequence older than given threshold
+func (s *ValidatorEraSeqStore) DeleteEraSeqsOlderThan(purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		return row.(*model.ValidatorEraSeq).Time.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// SummarizeEraSeqs gets the summarized version of validator sequences
+func (s *ValidatorEraSeqStore) SummarizeEraSeqs(interval types.SummaryInterval, activityPeriods []store.ActivityPeriodRow) ([]model.ValidatorEraSeqSummary, error) {
+	var filterErr error
+	seqs := s.find(func(v *model.ValidatorEraSeq) bool {
+		ok, err := outsideActivityPeriods(interval, activityPeriods, v.Time.Time)
+		if err != nil {
+			filterErr = err
+		}
+		return ok
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	groups := map[validatorBucket][]model.ValidatorEraSeq{}
+	var keys []validatorBucket
+	for _, seq := range seqs {
+		bucket, err := truncateTime(interval, seq.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		key := validatorBucket{seq.StashAccount, bucket}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], seq)
+	}
+
+	return summarizeValidatorEraSeqs(keys, groups), nil
+}
+
+// SummarizeEraSeqsByEra gets the summarized version of validator era sequences for eras which started at or after given time
+func (s *ValidatorEraSeqStore) SummarizeEraSeqsByEra(since time.Time) ([]model.ValidatorEraSeqSummary, error) {
+	s.db.mu.RLock()
+	eras := erasSince(s.db.table(model.Syncable{}).rows, since)
+	s.db.mu.RUnlock()
+
+	groups := map[validatorBucket][]model.ValidatorEraSeq{}
+	var keys []validatorBucket
+	for _, era := range eras {
+		for _, seq := range s.find(func(v *model.ValidatorEraSeq) bool { return v.Era == era.era }) {
+			key := validatorBucket{seq.StashAccount, era.startTime}
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], seq)
+		}
+	}
+
+	return summarizeValidatorEraSeqs(keys, groups), nil
+}
+
+// FindEraSeqsForEraRange returns validator era sequences between given eras (inclusive), empty stashAccount matches all validators
+func (s ValidatorEraSeqStore) FindEraSeqsForEraRange(startEra, endEra int64, stashAccount string) ([]model.ValidatorEraSeq, error) {
+	res := s.find(func(v *model.ValidatorEraSeq) bool {
+		if stashAccount != "" && v.StashAccount != stashAccount {
+			return false
+		}
+		return v.Era >= startEra && v.Era <= endEra
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Era != res[j].Era {
+			return res[i].Era < res[j].Era
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func summarizeValidatorEraSeqs(keys []validatorBucket, groups map[validatorBucket][]model.ValidatorEraSeq) []model.ValidatorEraSeqSummary {
+	sortValidatorBuckets(keys)
+
+	var models []model.ValidatorEraSeqSummary
+	for _, key := range keys {
+		seqs := groups[key]
+
+		var totalStakes, ownStakes, stakersStakes []types.Quantity
+		var rewardPoints, commissions, stakersCounts []int64
+		for _, seq := range seqs {
+			totalStakes = append(totalStakes, seq.TotalStake)
+			ownStakes = append(ownStakes, seq.OwnStake)
+			stakersStakes = append(stakersStakes, seq.StakersStake)
+			rewardPoints = append(rewardPoints, seq.RewardPoints)
+			commissions = append(commissions, seq.Commission)
+			stakersCounts = append(stakersCounts, int64(seq.StakersCount))
+		}
+
+		summary := model.ValidatorEraSeqSummary{
+			StashAccount: key.stash,
+			TimeBucket:   *types.NewTimeFromTime(key.bucket),
+		}
+		summary.TotalStakeAvg, summary.TotalStakeMin, summary.TotalStakeMax = quantityStats(totalStakes)
+		summary.OwnStakeAvg, summary.OwnStakeMin, summary.OwnStakeMax = quantityStats(ownStakes)
+		summary.StakersStakeAvg, summary.StakersStakeMin, summary.StakersStakeMax = quantityStats(stakersStakes)
+		summary.RewardPointsAvg, summary.RewardPointsMin, summary.RewardPointsMax = int64Stats(rewardPoints)
+		summary.CommissionAvg, summary.CommissionMin, summary.CommissionMax = int64Stats(commissions)
+		summary.StakersCountAvg, summary.StakersCountMin, summary.StakersCountMax = int64Stats(stakersCounts)
+		models = append(models, summary)
+	}
+	return models
+}
+
+// sortValidatorBuckets orders groups by time bucket, groups within bucket are ordered by stash account
+func sortValidatorBuckets(keys []validatorBucket) {
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].bucket.Equal(keys[j].bucket) {
+			return keys[i].bucket.Before(keys[j].bucket)
+		}
+		return keys[i].stash < keys[j].stash
+	})
+}
+
+func (s ValidatorEraSeqStore) first(fn func(*model.ValidatorEraSeq) bool) (*model.ValidatorEraSeq, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.ValidatorEraSeq{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+func (s ValidatorEraSeqStore) find(fn func(*model.ValidatorEraSeq) bool) []model.ValidatorEraSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.ValidatorEraSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorEraSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.ValidatorEraSeq))
+		}
+	}
+	return res
+}
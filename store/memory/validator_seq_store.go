@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewValidatorSeqStore(db *db) *ValidatorSeqStore {
+	return &ValidatorSeqStore{scoped(db, model.ValidatorSeq{})}
+}
+
+// ValidatorSeqStore handles operations on validators
+type ValidatorSeqStore struct {
+	baseStore
+}
+
+// BulkUpsertSeqs imports new records and updates existing ones
+func (s ValidatorSeqStore) BulkUpsertSeqs(records []model.ValidatorSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			v := row.(*model.ValidatorSeq)
+			return v.Height == r.Height && v.StashAccount == r.StashAccount
+		}, func(row interface{}) {
+			row.(*model.ValidatorSeq).ActiveBalance = r.ActiveBalance.Clone()
+		})
+	}
+	return nil
+}
+
+// FindAllByHeight returns all validators for provided height
+func (s ValidatorSeqStore) FindAllByHeight(height int64) ([]model.ValidatorSeq, error) {
+	return s.find(func(v *model.ValidatorSeq) bool {
+		return v.Height == height
+	}), nil
+}
+
+// FindMostRecentSeq finds most recent validator sequence
+func (s *ValidatorSeqStore) FindMostRecentSeq() (*model.ValidatorSeq, error) {
+	res := s.find(func(*model.ValidatorSeq) bool { return true })
+	if len(res) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.After(res[j].Time.Time) })
+	return &res[0], nil
+}
+
+// DeleteSeqsOlderThan deletes validator sequence older than given threshold
+func (s *ValidatorSeqStore) DeleteSeqsOlderThan(purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		return s.purgeable(row.(*model.ValidatorSeq), purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// ArchiveSeqsOlderThan passes at most limit validator sequences older than given threshold to archive and deletes
+// exactly them, nothing is deleted when archive fails
+func (s *ValidatorSeqStore) ArchiveSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var seqs []model.ValidatorSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorSeq); s.purgeable(r, purgeThreshold) {
+			seqs = append(seqs, *copyOf(r).(*model.ValidatorSeq))
+		}
+	}
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].Height < seqs[j].Height })
+	if int64(len(seqs)) > limit {
+		seqs = seqs[:limit]
+	}
+	if len(seqs) == 0 {
+		return &deletedCount, nil
+	}
+
+	if err := archive(seqs); err != nil {
+		return nil, err
+	}
+
+	ids := map[types.ID]bool{}
+	for _, seq := range seqs {
+		ids[seq.ID] = true
+	}
+	deletedCount = s.rows().delete(func(row interface{}) bool {
+		return ids[row.(*model.ValidatorSeq).ID]
+	})
+	return &deletedCount, nil
+}
+
+// purgeable returns true when validator sequence is older than given threshold and outside of restored ranges, callers must hold the lock
+func (s *ValidatorSeqStore) purgeable(v *model.ValidatorSeq, purgeThreshold time.Time) bool {
+	return v.Time.Before(purgeThreshold) && !isRestored(s.db, v.Height)
+}
+
+// FindSeqsForHeightRange returns validator sequences between given heights (inclusive), empty stashAccount matches all validators
+func (s ValidatorSeqStore) FindSeqsForHeightRange(startHeight, endHeight int64, stashAccount string) ([]model.ValidatorSeq, error) {
+	res := s.find(func(v *model.ValidatorSeq) bool {
+		if stashAccount != "" && v.StashAccount != stashAccount {
+			return false
+		}
+		return v.Height >= startHeight && v.Height <= endHeight
+	})
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Height != res[j].Height {
+			return res[i].Height < res[j].Height
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
+
+func (s ValidatorSeqStore) find(fn func(*model.ValidatorSeq) bool) []model.ValidatorSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.ValidatorSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.ValidatorSeq))
+		}
+	}
+	return res
+}
@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewValidatorSessionSeqStore(db *db) *ValidatorSessionSeqStore {
+	return &ValidatorSessionSeqStore{scoped(db, model.ValidatorSessionSeq{})}
+}
+
+// ValidatorSessionSeqStore handles operations on validators
+type ValidatorSessionSeqStore struct {
+	baseStore
+}
+
+// BulkUpsertSessionSeqs imports new records and updates existing ones
+func (s ValidatorSessionSeqStore) BulkUpsertSessionSeqs(records []model.ValidatorSessionSeq) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for i := range records {
+		r := records[i]
+		s.rows().upsert(&r, func(row interface{}) bool {
+			v := row.(*model.ValidatorSessionSeq)
+			return v.Session == r.Session && v.StashAccount == r.StashAccount
+		}, func(row interface{}) {
+			row.(*model.ValidatorSessionSeq).Online = r.Online
+		})
+	}
+	return nil
+}
+
+// FindByHeightAndStashAccount finds validator by height and stash account
+func (s ValidatorSessionSeqStore) FindByHeightAndStashAccount(height int64, stash string) (*model.ValidatorSessionSeq, error) {
+	return s.first(func(v *model.ValidatorSessionSeq) bool {
+		return v.StashAccount == stash && v.StartHeight <= height && v.EndHeight >= height
+	})
+}
+
+// FindBySessionAndStashAccount finds validator by session and stash account
+func (s ValidatorSessionSeqStore) FindBySessionAndStashAccount(session int64, stash string) (*model.ValidatorSessionSeq, error) {
+	return s.first(func(v *model.ValidatorSessionSeq) bool {
+		return v.Session == session && v.StashAccount == stash
+	})
+}
+
+// FindSessionSeqsByHeight finds validator session sequences by height
+func (s ValidatorSessionSeqStore) FindSessionSeqsByHeight(h int64) ([]model.ValidatorSessionSeq, error) {
+	return s.find(func(v *model.ValidatorSessionSeq) bool {
+		return v.StartHeight <= h && v.EndHeight >= h
+	}), nil
+}
+
+// FindBySession finds validator session sequences by session
+func (s ValidatorSessionSeqStore) FindBySession(session int64) ([]model.ValidatorSessionSeq, error) {
+	return s.find(func(v *model.ValidatorSessionSeq) bool {
+		return v.Session == session
+	}), nil
+}
+
+// FindBySessionRange finds validator session sequences between given sessions (inclusive)
+func (s ValidatorSessionSeqStore) FindBySessionRange(startSession, endSession int64) ([]model.ValidatorSessionSeq, error) {
+	return s.find(func(v *model.ValidatorSessionSeq) bool {
+		return v.Session >= startSession && v.Session <= endSession
+	}), nil
+}
+
+// FindLastSessionSeqByStashAccount finds last validator session sequences for given stash account
+func (s ValidatorSessionSeqStore) FindLastSessionSeqByStashAccount(stashAccount string, limit int64) ([]model.ValidatorSessionSeq, error) {
+	res := s.find(func(v *model.ValidatorSessionSeq) bool {
+		return v.StashAccount == stashAccount
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Session > res[j].Session })
+	if int64(len(res)) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+// FindMostRecentSessionSeq finds most recent validator session sequence
+func (s *ValidatorSessionSeqStore) FindMostRecentSessionSeq() (*model.ValidatorSessionSeq, error) {
+	res := s.find(func(*model.ValidatorSessionSeq) bool { return true })
+	if len(res) == 0 {
+		return nil, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Time.After(res[j].Time.Time) })
+	return &res[0], nil
+}
+
+// DeleteSessionSeqsOlderThan deletes validator session sequences older than given threshold
+func (s *ValidatorSessionSeqStore) DeleteSessionSeqsOlderThan(purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		return s.purgeable(row.(*model.ValidatorSessionSeq), purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// ArchiveSessionSeqsOlderThan passes at most limit validator session sequences older than given threshold to archive
+// and deletes exactly them, nothing is deleted when archive fails
+func (s *ValidatorSessionSeqStore) ArchiveSessionSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSessionSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var seqs []model.ValidatorSessionSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorSessionSeq); s.purgeable(r, purgeThreshold) {
+			seqs = append(seqs, *copyOf(r).(*model.ValidatorSessionSeq))
+		}
+	}
+	sort.SliceStable(seqs, func(i, j int) bool { return seqs[i].StartHeight < seqs[j].StartHeight })
+	if int64(len(seqs)) > limit {
+		seqs = seqs[:limit]
+	}
+	if len(seqs) == 0 {
+		return &deletedCount, nil
+	}
+
+	if err := archive(seqs); err != nil {
+		return nil, err
+	}
+
+	ids := map[types.ID]bool{}
+	for _, seq := range seqs {
+		ids[seq.ID] = true
+	}
+	deletedCount = s.rows().delete(func(row interface{}) bool {
+		return ids[row.(*model.ValidatorSessionSeq).ID]
+	})
+	return &deletedCount, nil
+}
+
+// purgeable returns true when validator session sequence is older than given threshold and does not start within
+// restored ranges, callers must hold the lock
+func (s *ValidatorSessionSeqStore) purgeable(v *model.ValidatorSessionSeq, purgeThreshold time.Time) bool {
+	return v.Time.Before(purgeThreshold) && !isRestored(s.db, v.StartHeight)
+}
+
+// SummarizeSessionSeqs gets the summarized version of validator session sequences
+func (s *ValidatorSessionSeqStore) SummarizeSessionSeqs(interval types.SummaryInterval, activityPeriods []store.ActivityPeriodRow) ([]model.ValidatorSessionSeqSummary, error) {
+	var filterErr error
+	seqs := s.find(func(v *model.ValidatorSessionSeq) bool {
+		ok, err := outsideActivityPeriods(interval, activityPeriods, v.Time.Time)
+		if err != nil {
+			filterErr = err
+		}
+		return ok
+	})
+	if filterErr != nil {
+		return nil, filterErr
+	}
+
+	groups := map[validatorBucket][]model.ValidatorSessionSeq{}
+	var keys []validatorBucket
+	for _, seq := range seqs {
+		bucket, err := truncateTime(interval, seq.Time.Time)
+		if err != nil {
+			return nil, err
+		}
+		key := validatorBucket{seq.StashAccount, bucket}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], seq)
+	}
+
+	return summarizeValidatorSessionSeqs(keys, groups), nil
+}
+
+// SummarizeSessionSeqsByEra gets the summarized version of validator session sequences for eras which started at or after given time,
+// session belongs to era in which it ended
+func (s *ValidatorSessionSeqStore) SummarizeSessionSeqsByEra(since time.Time) ([]model.ValidatorSessionSeqSummary, error) {
+	s.db.mu.RLock()
+	eras := erasSince(s.db.table(model.Syncable{}).rows, since)
+	s.db.mu.RUnlock()
+
+	groups := map[validatorBucket][]model.ValidatorSessionSeq{}
+	var keys []validatorBucket
+	for _, era := range eras {
+		seqs := s.find(func(v *model.ValidatorSessionSeq) bool {
+			return v.EndHeight >= era.startHeight && v.EndHeight <= era.endHeight
+		})
+		for _, seq := range seqs {
+			key := validatorBucket{seq.StashAccount, era.startTime}
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], seq)
+		}
+	}
+
+	return summarizeValidatorSessionSeqs(keys, groups), nil
+}
+
+func summarizeValidatorSessionSeqs(keys []validatorBucket, groups map[validatorBucket][]model.ValidatorSessionSeq) []model.ValidatorSessionSeqSummary {
+	sortValidatorBuckets(keys)
+
+	var models []model.ValidatorSessionSeqSummary
+	for _, key := range keys {
+		var uptimes []int64
+		for _, seq := range groups[key] {
+			if seq.Online {
+				uptimes = append(uptimes, 1)
+			} else {
+				uptimes = append(uptimes, 0)
+			}
+		}
+
+		summary := model.ValidatorSessionSeqSummary{
+			StashAccount: key.stash,
+			TimeBucket:   *types.NewTimeFromTime(key.bucket),
+		}
+		summary.UptimeAvg, summary.UptimeMin, summary.UptimeMax = int64Stats(uptimes)
+		models = append(models, summary)
+	}
+	return models
+}
+
+func (s ValidatorSessionSeqStore) first(fn func(*model.ValidatorSessionSeq) bool) (*model.ValidatorSessionSeq, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.ValidatorSessionSeq{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+func (s ValidatorSessionSeqStore) find(fn func(*model.ValidatorSessionSeq) bool) []model.ValidatorSessionSeq {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.ValidatorSessionSeq
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorSessionSeq); fn(r) {
+			res = append(res, *copyOf(r).(*model.ValidatorSessionSeq))
+		}
+	}
+	return res
+}
@@ -0,0 +1,339 @@
+package memory
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func NewValidatorSummaryStore(db *db) *ValidatorSummaryStore {
+	return &ValidatorSummaryStore{scoped(db, model.ValidatorSummary{})}
+}
+
+// ValidatorSummaryStore handles operations on validators
+type ValidatorSummaryStore struct {
+	baseStore
+}
+
+// CreateSummary creates the validator aggregate
+func (s ValidatorSummaryStore) CreateSummary(val *model.ValidatorSummary) error {
+	return s.Create(val)
+}
+
+// SaveSummary saves the validator summary
+func (s ValidatorSummaryStore) SaveSummary(val *model.ValidatorSummary) error {
+	return s.Save(val)
+}
+
+// FindSummary find validator summary by query
+func (s ValidatorSummaryStore) FindSummary(query *model.ValidatorSummary) (*model.ValidatorSummary, error) {
+	res := s.find(func(v *model.ValidatorSummary) bool {
+		return matchesQuery(v, query)
+	})
+	if len(res) == 0 {
+		return &model.ValidatorSummary{}, store.ErrNotFound
+	}
+	return &res[0], nil
+}
+
+// FindActivityPeriods Finds activity periods
+func (s *ValidatorSummaryStore) FindActivityPeriods(interval types.SummaryInterval, indexVersion int64) ([]store.ActivityPeriodRow, error) {
+	summaries := s.find(func(v *model.ValidatorSummary) bool {
+		return v.TimeInterval == interval && v.IndexVersion == indexVersion
+	})
+
+	buckets := make([]time.Time, len(summaries))
+	for i, summary := range summaries {
+		buckets[i] = summary.TimeBucket.Time
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Before(buckets[j]) })
+
+	return findActivityPeriods(buckets, interval.MaxBucketGap())
+}
+
+// FindSummaries Gets summary of validator sequences for interval
+func (s *ValidatorSummaryStore) FindSummaries(interval types.SummaryInterval, period string) ([]store.ValidatorSummaryRow, error) {
+	summaries, err := s.findForPeriod(interval, period, func(*model.ValidatorSummary) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[time.Time][]model.ValidatorSummary{}
+	var buckets []time.Time
+	for _, summary := range summaries {
+		bucket := summary.TimeBucket.Time
+		if _, ok := groups[bucket]; !ok {
+			buckets = append(buckets, bucket)
+		}
+		groups[bucket] = append(groups[bucket], summary)
+	}
+
+	var res []store.ValidatorSummaryRow
+	for _, bucket := range buckets {
+		res = append(res, aggregateValidatorSummaries(groups[bucket]))
+	}
+	return res, nil
+}
+
+// FindSummaryByStashAccount Gets summary for given validator
+func (s *ValidatorSummaryStore) FindSummaryByStashAccount(stashAccount string, interval types.SummaryInterval, period string) ([]store.ValidatorSummaryRow, error) {
+	summaries, err := s.findForPeriod(interval, period, func(v *model.ValidatorSummary) bool {
+		return v.StashAccount == stashAccount
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res []store.ValidatorSummaryRow
+	for _, summary := range summaries {
+		res = append(res, aggregateValidatorSummaries([]model.ValidatorSummary{summary}))
+	}
+	return res, nil
+}
+
+// FindMostRecentSummary finds most recent validator summary
+func (s *ValidatorSummaryStore) FindMostRecentSummary() (*model.ValidatorSummary, error) {
+	return s.mostRecent(func(*model.ValidatorSummary) bool { return true })
+}
+
+// FindMostRecentByInterval finds most recent validator summary for interval
+func (s *ValidatorSummaryStore) FindMostRecentByInterval(interval types.SummaryInterval) (*model.ValidatorSummary, error) {
+	return s.mostRecent(func(v *model.ValidatorSummary) bool {
+		return v.TimeInterval == interval
+	})
+}
+
+// DeleteSummaryOlderThan deleted validator summary records older than given threshold
+func (s *ValidatorSummaryStore) DeleteSummaryOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	deletedCount := s.rows().delete(func(row interface{}) bool {
+		v := row.(*model.ValidatorSummary)
+		return v.TimeInterval == interval && v.TimeBucket.Before(purgeThreshold)
+	})
+	return &deletedCount, nil
+}
+
+// SummarizeDailySessionSummaries rolls uptime of daily validator summaries with time bucket at or after given time up into buckets of given interval
+func (s *ValidatorSummaryStore) SummarizeDailySessionSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorSessionSeqSummary, error) {
+	keys, groups, err := s.groupDailySummaries(interval, since, indexVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.ValidatorSessionSeqSummary
+	for _, key := range keys {
+		summaries := groups[key]
+
+		rollup := model.ValidatorSessionSeqSummary{
+			StashAccount: key.stash,
+			TimeBucket:   *types.NewTimeFromTime(key.bucket),
+			UptimeMin:    summaries[0].UptimeMin,
+			UptimeMax:    summaries[0].UptimeMax,
+		}
+		for _, v := range summaries {
+			rollup.UptimeAvg += v.UptimeAvg
+			if v.UptimeMin < rollup.UptimeMin {
+				rollup.UptimeMin = v.UptimeMin
+			}
+			if v.UptimeMax > rollup.UptimeMax {
+				rollup.UptimeMax = v.UptimeMax
+			}
+		}
+		rollup.UptimeAvg /= float64(len(summaries))
+		models = append(models, rollup)
+	}
+	return models, nil
+}
+
+// SummarizeDailyEraSummaries rolls stake, points and commission of daily validator summaries with time bucket at or after given time
+// up into buckets of given interval
+func (s *ValidatorSummaryStore) SummarizeDailyEraSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorEraSeqSummary, error) {
+	keys, groups, err := s.groupDailySummaries(interval, since, indexVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var models []model.ValidatorEraSeqSummary
+	for _, key := range keys {
+		row := aggregateValidatorSummaries(groups[key])
+
+		models = append(models, model.ValidatorEraSeqSummary{
+			StashAccount:    key.stash,
+			TimeBucket:      *types.NewTimeFromTime(key.bucket),
+			TotalStakeAvg:   row.TotalStakeAvg,
+			TotalStakeMin:   row.TotalStakeMin,
+			TotalStakeMax:   row.TotalStakeMax,
+			OwnStakeAvg:     row.OwnStakeAvg,
+			OwnStakeMin:     row.OwnStakeMin,
+			OwnStakeMax:     row.OwnStakeMax,
+			StakersStakeAvg: row.StakersStakeAvg,
+			StakersStakeMin: row.StakersStakeMin,
+			StakersStakeMax: row.StakersStakeMax,
+			RewardPointsAvg: row.RewardPointsAvg,
+			RewardPointsMin: row.RewardPointsMin,
+			RewardPointsMax: row.RewardPointsMax,
+			CommissionAvg:   row.CommissionAvg,
+			CommissionMin:   row.CommissionMin,
+			CommissionMax:   row.CommissionMax,
+			StakersCountAvg: row.StakersCountAvg,
+			StakersCountMin: row.StakersCountMin,
+			StakersCountMax: row.StakersCountMax,
+		})
+	}
+	return models, nil
+}
+
+// groupDailySummaries groups daily summaries with time bucket at or after given time by validator and bucket of given interval
+func (s ValidatorSummaryStore) groupDailySummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]validatorBucket, map[validatorBucket][]model.ValidatorSummary, error) {
+	summaries := s.find(func(v *model.ValidatorSummary) bool {
+		return v.TimeInterval == types.IntervalDaily && v.IndexVersion == indexVersion && !v.TimeBucket.Before(since)
+	})
+
+	groups := map[validatorBucket][]model.ValidatorSummary{}
+	var keys []validatorBucket
+	for _, summary := range summaries {
+		bucket, err := truncateTime(interval, summary.TimeBucket.Time)
+		if err != nil {
+			return nil, nil, err
+		}
+		key := validatorBucket{summary.StashAccount, bucket}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], summary)
+	}
+
+	sortValidatorBuckets(keys)
+	return keys, groups, nil
+}
+
+// findForPeriod returns matching summaries for period preceding most recent time bucket of interval ordered by time bucket
+func (s ValidatorSummaryStore) findForPeriod(interval types.SummaryInterval, period string, fn func(*model.ValidatorSummary) bool) ([]model.ValidatorSummary, error) {
+	latest, err := s.FindMostRecentByInterval(interval)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	since, err := subtractPeriod(latest.TimeBucket.Time, period)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.find(func(v *model.ValidatorSummary) bool {
+		return v.TimeInterval == interval && !v.TimeBucket.Before(since) && fn(v)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.Before(res[j].TimeBucket.Time) })
+	return res, nil
+}
+
+// aggregateValidatorSummaries combines summaries of the same time bucket, averages are averaged and extremes are kept
+func aggregateValidatorSummaries(summaries []model.ValidatorSummary) store.ValidatorSummaryRow {
+	first := summaries[0]
+	row := store.ValidatorSummaryRow{
+		TimeBucket:      first.TimeBucket.Format(time.RFC3339Nano),
+		TimeInterval:    string(first.TimeInterval),
+		TotalStakeMin:   first.TotalStakeMin.Clone(),
+		TotalStakeMax:   first.TotalStakeMax.Clone(),
+		OwnStakeMin:     first.OwnStakeMin.Clone(),
+		OwnStakeMax:     first.OwnStakeMax.Clone(),
+		StakersStakeMin: first.StakersStakeMin.Clone(),
+		StakersStakeMax: first.StakersStakeMax.Clone(),
+		RewardPointsMin: first.RewardPointsMin,
+		RewardPointsMax: first.RewardPointsMax,
+		CommissionMin:   first.CommissionMin,
+		CommissionMax:   first.CommissionMax,
+		StakersCountMin: first.StakersCountMin,
+		StakersCountMax: first.StakersCountMax,
+	}
+
+	totalStakeSum, ownStakeSum, stakersStakeSum := new(big.Int), new(big.Int), new(big.Int)
+	for i := range summaries {
+		v := &summaries[i]
+
+		totalStakeSum.Add(totalStakeSum, &v.TotalStakeAvg.Int)
+		ownStakeSum.Add(ownStakeSum, &v.OwnStakeAvg.Int)
+		stakersStakeSum.Add(stakersStakeSum, &v.StakersStakeAvg.Int)
+		row.RewardPointsAvg += v.RewardPointsAvg
+		row.CommissionAvg += v.CommissionAvg
+		row.StakersCountAvg += v.StakersCountAvg
+		row.UptimeAvg += v.UptimeAvg
+
+		minQuantity(&row.TotalStakeMin, v.TotalStakeMin)
+		maxQuantity(&row.TotalStakeMax, v.TotalStakeMax)
+		minQuantity(&row.OwnStakeMin, v.OwnStakeMin)
+		maxQuantity(&row.OwnStakeMax, v.OwnStakeMax)
+		minQuantity(&row.StakersStakeMin, v.StakersStakeMin)
+		maxQuantity(&row.StakersStakeMax, v.StakersStakeMax)
+		if v.RewardPointsMin < row.RewardPointsMin {
+			row.RewardPointsMin = v.RewardPointsMin
+		}
+		if v.RewardPointsMax > row.RewardPointsMax {
+			row.RewardPointsMax = v.RewardPointsMax
+		}
+		if v.CommissionMin < row.CommissionMin {
+			row.CommissionMin = v.CommissionMin
+		}
+		if v.CommissionMax > row.CommissionMax {
+			row.CommissionMax = v.CommissionMax
+		}
+		if v.StakersCountMin < row.StakersCountMin {
+			row.StakersCountMin = v.StakersCountMin
+		}
+		if v.StakersCountMax > row.StakersCountMax {
+			row.StakersCountMax = v.StakersCountMax
+		}
+	}
+
+	count := int64(len(summaries))
+	row.TotalStakeAvg = quantityAvg(totalStakeSum, count)
+	row.OwnStakeAvg = quantityAvg(ownStakeSum, count)
+	row.StakersStakeAvg = quantityAvg(stakersStakeSum, count)
+	row.RewardPointsAvg /= float64(count)
+	row.CommissionAvg /= float64(count)
+	row.StakersCountAvg /= float64(count)
+	row.UptimeAvg /= float64(count)
+	return row
+}
+
+func minQuantity(dst *types.Quantity, q types.Quantity) {
+	if q.Cmp(&dst.Int) < 0 {
+		*dst = q.Clone()
+	}
+}
+
+func maxQuantity(dst *types.Quantity, q types.Quantity) {
+	if q.Cmp(&dst.Int) > 0 {
+		*dst = q.Clone()
+	}
+}
+
+func (s ValidatorSummaryStore) mostRecent(fn func(*model.ValidatorSummary) bool) (*model.ValidatorSummary, error) {
+	res := s.find(fn)
+	if len(res) == 0 {
+		return &model.ValidatorSummary{}, store.ErrNotFound
+	}
+
+	sort.SliceStable(res, func(i, j int) bool { return res[i].TimeBucket.After(res[j].TimeBucket.Time) })
+	return &res[0], nil
+}
+
+func (s ValidatorSummaryStore) find(fn func(*model.ValidatorSummary) bool) []model.ValidatorSummary {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var res []model.ValidatorSummary
+	for _, row := range s.rows().rows {
+		if r := row.(*model.ValidatorSummary); fn(r) {
+			res = append(res, *copyOf(r).(*model.ValidatorSummary))
+		}
+	}
+	return res
+}
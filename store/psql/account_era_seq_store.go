@@ -123,3 +123,22 @@ func (s AccountEraSeqStore) FindLastByValidatorStashAccount(validatorStashAccoun
 	}
 	return res, nil
 }
+
+// FindForEraRange returns account era sequences between given eras (inclusive), non empty stashAccount matches both nominator and validator stash
+func (s AccountEraSeqStore) FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.AccountEraSeq, error) {
+	var result []model.AccountEraSeq
+
+	tx := s.db.
+		Where("era >= ? AND era <= ?", startEra, endEra)
+
+	if stashAccount != "" {
+		tx = tx.Where("stash_account = ? OR validator_stash_account = ?", stashAccount, stashAccount)
+	}
+
+	err := tx.
+		Order("era, id").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
@@ -63,10 +63,11 @@ func findBy(db *gorm.DB, dst interface{}, key string, value interface{}) error {
 }
 
 func findMostRecent(db *gorm.DB, orderField string, record interface{}) error {
-	return db.
+	err := db.
 		Order(fmt.Sprintf("%s DESC", orderField)).
 		Take(record).
 		Error
+	return checkErr(err)
 }
 
 func checkErr(err error) error {
@@ -86,7 +86,69 @@ func (s *BlockSeqStore) FindMostRecentSeq() (*model.BlockSeq, error) {
 
 // DeleteSeqOlderThan deletes block sequence older than given threshold
 func (s *BlockSeqStore) DeleteSeqOlderThan(purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow) (*int64, error) {
-	tx := s.db.
+	var deletedCount int64
+
+	tx, ok := blockSeqPurgeScope(s.db, purgeThreshold, activityPeriods)
+	if !ok {
+		logger.Info("no block sequences to purge")
+		return &deletedCount, nil
+	}
+
+	tx = tx.Delete(&model.BlockSeq{})
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	deletedCount = tx.RowsAffected
+	return &deletedCount, nil
+}
+
+// ArchiveSeqsOlderThan passes at most limit block sequences which DeleteSeqOlderThan deletes for the same arguments
+// to archive and deletes exactly them in the same transaction, nothing is deleted when archive fails
+func (s *BlockSeqStore) ArchiveSeqsOlderThan(purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow, limit int64, archive func([]model.BlockSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		scope, ok := blockSeqPurgeScope(tx, purgeThreshold, activityPeriods)
+		if !ok {
+			return nil
+		}
+
+		var seqs []model.BlockSeq
+		err := scope.
+			Set("gorm:query_option", "FOR UPDATE").
+			Order("height").
+			Limit(limit).
+			Find(&seqs).
+			Error
+		if err != nil || len(seqs) == 0 {
+			return err
+		}
+
+		if err := archive(seqs); err != nil {
+			return err
+		}
+
+		ids := make([]types.ID, len(seqs))
+		for i, seq := range seqs {
+			ids[i] = seq.ID
+		}
+
+		res := tx.Unscoped().Where("id IN (?)", ids).Delete(&model.BlockSeq{})
+		deletedCount = res.RowsAffected
+		return res.Error
+	})
+	if err != nil {
+		return nil, checkErr(err)
+	}
+
+	return &deletedCount, nil
+}
+
+// blockSeqPurgeScope returns scope of block sequences to purge, it returns false when there is nothing to purge.
+// Sequences within restored ranges are never purged
+func blockSeqPurgeScope(db *gorm.DB, purgeThreshold time.Time, activityPeriods []store.ActivityPeriodRow) (*gorm.DB, bool) {
+	tx := db.
 		Unscoped()
 
 	hasIntervals := false
@@ -99,18 +161,9 @@ func (s *BlockSeqStore) DeleteSeqOlderThan(purgeThreshold time.Time, activityPer
 		}
 	}
 
-	if hasIntervals {
-		tx.Where("time < ?", purgeThreshold).
-			Delete(&model.BlockSeq{})
-
-		if tx.Error != nil {
-			return nil, checkErr(tx.Error)
-		}
-	} else {
-		logger.Info("no block sequences to purge")
-	}
-
-	return &tx.RowsAffected, nil
+	return tx.
+		Where("time < ?", purgeThreshold).
+		Where(notRestored(model.BlockSeq{}.TableName(), "height")), hasIntervals
 }
 
 // Summarize gets the summarized version of block sequences
@@ -135,11 +188,11 @@ func (s *BlockSeqStore) Summarize(interval types.SummaryInterval, activityPeriod
 			if isLast {
 				tx = tx.Or("time >= ?", activityPeriod.Max)
 			} else {
-				duration, err := interval.Duration()
+				nextBucket, err := interval.NextBucket(activityPeriod.Max.Time)
 				if err != nil {
 					return nil, err
 				}
-				tx = tx.Or("time >= ? AND time < ?", activityPeriod.Max.Add(*duration), activityPeriods[i+1].Min)
+				tx = tx.Or("time >= ? AND time < ?", nextBucket, activityPeriods[i+1].Min)
 			}
 		}
 	}
@@ -161,3 +214,40 @@ func (s *BlockSeqStore) Summarize(interval types.SummaryInterval, activityPeriod
 	}
 	return models, nil
 }
+
+// SummarizeByEra gets the summarized version of block sequences for eras which started at or after given time
+func (s *BlockSeqStore) SummarizeByEra(since time.Time) ([]model.BlockSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "BlockSeqStore_SummarizeByEra")
+
+	rows, err := s.db.
+		Raw(queries.BlockSeqSummarizeByEra, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.BlockSeqSummary
+	for rows.Next() {
+		var summary model.BlockSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
+
+// FindSeqsForHeightRange returns block sequences between given heights (inclusive)
+func (s *BlockSeqStore) FindSeqsForHeightRange(startHeight, endHeight int64) ([]model.BlockSeq, error) {
+	var result []model.BlockSeq
+
+	err := s.db.
+		Where("height >= ? AND height <= ?", startHeight, endHeight).
+		Order("height").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
@@ -1,7 +1,6 @@
 package psql
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
@@ -71,7 +70,7 @@ func (s *BlockSummaryStore) FindActivityPeriods(interval types.SummaryInterval,
 	defer logQueryDuration(time.Now(), "BlockSummaryStore_FindActivityPeriods")
 
 	rows, err := s.db.
-		Raw(queries.BlockSummaryActivityPeriods, fmt.Sprintf("1%s", interval), interval, indexVersion).
+		Raw(queries.BlockSummaryActivityPeriods, interval.MaxBucketGap(), interval, indexVersion).
 		Rows()
 
 	if err != nil {
@@ -127,3 +126,27 @@ func (s *BlockSummaryStore) DeleteOlderThan(interval types.SummaryInterval, purg
 
 	return &res.RowsAffected, nil
 }
+
+// SummarizeDailySummaries rolls daily block summaries with time bucket at or after given time up into buckets of given interval
+func (s *BlockSummaryStore) SummarizeDailySummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.BlockSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "BlockSummaryStore_SummarizeDailySummaries")
+
+	rows, err := s.db.
+		Raw(queries.BlockSummarySummarizeDaily, interval, types.IntervalDaily, indexVersion, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.BlockSeqSummary
+	for rows.Next() {
+		var summary model.BlockSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
@@ -27,3 +27,20 @@ func (s *DatabaseStore) GetTotalSize() (*store.GetTotalSizeResult, error) {
 	}
 	return &result, nil
 }
+
+// GetMigrationVersion gets the schema version recorded by the migrate tool
+func (s *DatabaseStore) GetMigrationVersion() (*store.GetMigrationVersionResult, error) {
+	query := "SELECT version, dirty FROM schema_migrations LIMIT 1"
+
+	var result store.GetMigrationVersionResult
+	err := s.db.Raw(query).Scan(&result).Error
+	if err != nil {
+		return nil, checkErr(err)
+	}
+	return &result, nil
+}
+
+// Ping checks the database connection
+func (s *DatabaseStore) Ping() error {
+	return s.db.DB().Ping()
+}
@@ -0,0 +1,70 @@
+package psql
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/jinzhu/gorm"
+)
+
+func NewEraSummaryStore(db *gorm.DB) *EraSummaryStore {
+	return &EraSummaryStore{scoped(db, model.EraSummary{})}
+}
+
+// EraSummaryStore handles operations on era summaries
+type EraSummaryStore struct {
+	baseStore
+}
+
+// UpsertEraSummary creates era summary or updates existing one of the same era
+func (s EraSummaryStore) UpsertEraSummary(r *model.EraSummary) error {
+	err := s.db.
+		Exec(queries.EraSummaryUpsert,
+			r.Era,
+			r.StartHeight,
+			r.EndHeight,
+			r.Time,
+			r.TotalStake.String(),
+			r.ActiveValidatorsCount,
+			r.NominatorsCount,
+			r.ValidatorStakeMin.String(),
+			r.ValidatorStakeMax.String(),
+			r.ValidatorStakeMedian.String(),
+			r.TotalRewardPayout.String(),
+			r.TotalRewardPoints,
+			r.CommissionAvg,
+		).
+		Error
+
+	return checkErr(err)
+}
+
+// FindEraSummaries returns most recent era summaries
+func (s EraSummaryStore) FindEraSummaries(limit int64) ([]store.EraSummaryRow, error) {
+	var result []store.EraSummaryRow
+
+	err := s.db.
+		Raw(queries.EraSummaryFindRecent, limit).
+		Scan(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindEraSummaryByEra returns summary of given era
+func (s EraSummaryStore) FindEraSummaryByEra(era int64) (*store.EraSummaryRow, error) {
+	var result []store.EraSummaryRow
+
+	err := s.db.
+		Raw(queries.EraSummaryFindByEra, era).
+		Scan(&result).
+		Error
+	if err != nil {
+		return nil, checkErr(err)
+	}
+
+	if len(result) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return &result[0], nil
+}
@@ -1,6 +1,7 @@
 package psql
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
@@ -149,6 +150,20 @@ func (s *EventSeqStore) FindRewardsForTimePeriod(address string, start, end time
 	return results, checkErr(err)
 }
 
+// FindSlashesForHeightRange returns staking slash event sequences between given heights (inclusive), slashed stash is first item of data
+func (s EventSeqStore) FindSlashesForHeightRange(startHeight, endHeight int64) ([]model.EventSeq, error) {
+	var result []model.EventSeq
+
+	err := s.db.
+		Where("section = 'staking' AND method IN (?)", []string{"Slash", "Slashed"}).
+		Where("height >= ? AND height <= ?", startHeight, endHeight).
+		Order("height, index").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
 func (s EventSeqStore) findForEventSeqWithTxHashQuery(section, method, address string) ([]model.EventSeqWithTxHash, error) {
 	var result []model.EventSeqWithTxHash
 
@@ -178,3 +193,26 @@ func (s EventSeqStore) findForEventSeqWithTxHashQuery(section, method, address s
 
 	return result, nil
 }
+
+// FindForHeightRange returns event sequences between given heights (inclusive), non empty address matches events with address in data
+func (s EventSeqStore) FindForHeightRange(startHeight, endHeight int64, address string) ([]model.EventSeq, error) {
+	var result []model.EventSeq
+
+	tx := s.db.
+		Where("height >= ? AND height <= ?", startHeight, endHeight)
+
+	if address != "" {
+		data, err := json.Marshal([]map[string]string{{"value": address}})
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Where("data @> ?::jsonb", string(data))
+	}
+
+	err := tx.
+		Order("height, index").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
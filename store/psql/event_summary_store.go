@@ -0,0 +1,109 @@
+package psql
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/jinzhu/gorm"
+)
+
+func NewEventSummaryStore(db *gorm.DB) *EventSummaryStore {
+	return &EventSummaryStore{scoped(db, model.EventSummary{})}
+}
+
+// EventSummaryStore handles operations on event summary
+type EventSummaryStore struct {
+	baseStore
+}
+
+// SummarizeEventSeqs summarizes event sequences starting with most recent time bucket, it returns number of saved summaries.
+// Older buckets with heights indexed since last run, ie. by backfill, are summarized again.
+// Existing summary is never replaced with one of lower count, so summaries outlive purged sequences
+func (s *EventSummaryStore) SummarizeEventSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error) {
+	defer logQueryDuration(time.Now(), "EventSummaryStore_SummarizeEventSeqs")
+
+	tx := s.db.
+		Exec(queries.EventSummarySummarize, interval, indexVersion, interval, interval.MaxBucketGap(), interval, interval, indexVersion)
+
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	return &tx.RowsAffected, nil
+}
+
+// FindEventSummaries returns event summaries of given index version by section and method for period preceding most recent time bucket,
+// empty section or method match all of them
+func (s *EventSummaryStore) FindEventSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.EventSummary, error) {
+	defer logQueryDuration(time.Now(), "EventSummaryStore_FindEventSummaries")
+
+	var result []model.EventSummary
+
+	tx := s.forPeriod(interval, period, indexVersion).
+		Where("NOT (section = '' AND method = '')")
+
+	if section != "" {
+		tx = tx.Where("section = ?", section)
+	}
+	if method != "" {
+		tx = tx.Where("method = ?", method)
+	}
+
+	err := tx.
+		Order("time_bucket, section, method").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindEventTotals returns event summaries of given index version which hold totals of all sections and methods
+// for period preceding most recent time bucket
+func (s *EventSummaryStore) FindEventTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.EventSummary, error) {
+	defer logQueryDuration(time.Now(), "EventSummaryStore_FindEventTotals")
+
+	var result []model.EventSummary
+
+	err := s.forPeriod(interval, period, indexVersion).
+		Where("section = '' AND method = ''").
+		Order("time_bucket").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+func (s *EventSummaryStore) forPeriod(interval types.SummaryInterval, period string, indexVersion int64) *gorm.DB {
+	return s.db.
+		Where("time_interval = ? AND index_version = ?", interval, indexVersion).
+		Where("time_bucket >= (SELECT MAX(time_bucket) FROM event_summary WHERE time_interval = ? AND index_version = ?) - ?::INTERVAL", interval, indexVersion, period)
+}
+
+// FindMostRecentEventSummary finds most recent event summary for given time interval
+func (s *EventSummaryStore) FindMostRecentEventSummary(interval types.SummaryInterval) (*model.EventSummary, error) {
+	result := &model.EventSummary{}
+
+	err := s.db.
+		Where("time_interval = ?", interval).
+		Order("time_bucket DESC").
+		Take(result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// DeleteEventSummariesOlderThan deletes event summary records older than given threshold
+func (s *EventSummaryStore) DeleteEventSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	tx := s.db.
+		Unscoped().
+		Where("time_interval = ? AND time_bucket < ?", interval, purgeThreshold).
+		Delete(&model.EventSummary{})
+
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	return &tx.RowsAffected, nil
+}
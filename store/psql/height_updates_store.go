@@ -0,0 +1,75 @@
+package psql
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/jinzhu/gorm"
+)
+
+func NewHeightUpdatesStore(db *gorm.DB) *HeightUpdatesStore {
+	return &HeightUpdatesStore{scoped(db, model.HeightUpdate{})}
+}
+
+// HeightUpdatesStore handles operations on height updates
+type HeightUpdatesStore struct {
+	baseStore
+}
+
+// PublishHeightUpdate saves height update and notifies listeners of height updates channel in the same statement
+func (s HeightUpdatesStore) PublishHeightUpdate(update *model.HeightUpdate) error {
+	err := s.db.
+		Exec(queries.HeightUpdateInsert, update.Height, update.Time, update.Session, update.Era, update.LastInSession, update.LastInEra, update.RewardsClaimed, model.HeightUpdatesChannel).
+		Error
+
+	return checkErr(err)
+}
+
+// FindHeightUpdatesAfter returns height updates for heights greater than given height
+func (s HeightUpdatesStore) FindHeightUpdatesAfter(height int64, limit int64) ([]model.HeightUpdate, error) {
+	var result []model.HeightUpdate
+
+	err := s.db.
+		Where("height > ?", height).
+		Order("height").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindFirstHeightUpdate returns height update for oldest retained height
+func (s HeightUpdatesStore) FindFirstHeightUpdate() (*model.HeightUpdate, error) {
+	result := &model.HeightUpdate{}
+
+	err := s.db.
+		Order("height").
+		First(result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindLastHeightUpdate returns height update for most recent height
+func (s HeightUpdatesStore) FindLastHeightUpdate() (*model.HeightUpdate, error) {
+	result := &model.HeightUpdate{}
+
+	err := findMostRecent(s.db, "height", result)
+	return result, checkErr(err)
+}
+
+// DeleteHeightUpdatesOlderThan deletes height updates older than given threshold
+func (s HeightUpdatesStore) DeleteHeightUpdatesOlderThan(purgeThreshold time.Time) (*int64, error) {
+	tx := s.db.
+		Unscoped().
+		Where("time < ?", purgeThreshold).
+		Delete(&model.HeightUpdate{})
+
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	return &tx.RowsAffected, nil
+}
@@ -0,0 +1,121 @@
+package psql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/jinzhu/gorm"
+)
+
+func NewJobsStore(db *gorm.DB) *JobsStore {
+	return &JobsStore{scoped(db, model.Job{})}
+}
+
+// JobsStore handles operations on jobs
+type JobsStore struct {
+	baseStore
+}
+
+// FindByID returns job by id
+func (s JobsStore) FindByID(id types.ID) (*model.Job, error) {
+	result := &model.Job{}
+
+	err := findBy(s.db, result, "id", id)
+	return result, checkErr(err)
+}
+
+// FindRecent returns most recent jobs
+func (s JobsStore) FindRecent(limit int64) ([]model.Job, error) {
+	var result []model.Job
+
+	err := s.db.
+		Order("id DESC").
+		Limit(limit).
+		Find(&result).Error
+
+	return result, checkErr(err)
+}
+
+// CreateUnique creates job unless there is already queued or running job with the same unique key
+func (s JobsStore) CreateUnique(job *model.Job, uniqueKey string) (bool, error) {
+	res := s.db.Exec(queries.JobInsertUnique, job.Kind, job.Params, job.Status, uniqueKey, job.MaxAttempts, job.RunAt)
+	if res.Error != nil {
+		return false, checkErr(res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+
+// Claim locks the next job of given kinds ready to run, jobs with lock older than lockTimeout are claimed again
+// unless they ran out of attempts, such jobs are marked as failed
+func (s JobsStore) Claim(workerID string, kinds []model.JobKind, lockTimeout time.Duration) (*model.Job, error) {
+	result := &model.Job{}
+
+	err := s.db.Exec(queries.JobFailExhausted, model.JobErrorLockExpired, lockTimeout.Seconds()).Error
+	if err != nil {
+		return result, checkErr(err)
+	}
+
+	err = s.db.
+		Raw(queries.JobClaim, workerID, kinds, lockTimeout.Seconds()).
+		Scan(result).Error
+
+	return result, checkErr(err)
+}
+
+// Heartbeat extends lock of running job and returns its current status
+func (s JobsStore) Heartbeat(job *model.Job) (model.JobStatus, error) {
+	var status model.JobStatus
+
+	err := s.db.
+		Raw("UPDATE jobs SET locked_at = NOW() WHERE id = ? AND locked_by = ? RETURNING status", job.ID, job.LockedBy).
+		Row().
+		Scan(&status)
+
+	if err == sql.ErrNoRows {
+		return status, store.ErrNotFound
+	}
+	return status, checkErr(err)
+}
+
+// Finish releases lock and saves result of running job
+func (s JobsStore) Finish(job *model.Job) error {
+	res := s.db.
+		Model(&model.Job{}).
+		Where("id = ? AND status = ? AND locked_by = ?", job.ID, model.JobStatusRunning, job.LockedBy).
+		Updates(map[string]interface{}{
+			"status":       job.Status,
+			"error":        job.Error,
+			"run_at":       job.RunAt,
+			"completed_at": job.CompletedAt,
+			"locked_by":    nil,
+			"locked_at":    nil,
+		})
+
+	if res.Error != nil {
+		return checkErr(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// Cancel marks queued or running job as cancelled
+func (s JobsStore) Cancel(id types.ID) (bool, error) {
+	res := s.db.
+		Model(&model.Job{}).
+		Where("id = ? AND status IN (?)", id, []model.JobStatus{model.JobStatusQueued, model.JobStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":       model.JobStatusCancelled,
+			"completed_at": time.Now(),
+		})
+
+	if res.Error != nil {
+		return false, checkErr(res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -0,0 +1,61 @@
+package psql
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/jinzhu/gorm"
+)
+
+func NewOutboxStore(db *gorm.DB) *OutboxStore {
+	return &OutboxStore{scoped(db, model.OutboxMessage{})}
+}
+
+// OutboxStore handles operations on outbox messages
+type OutboxStore struct {
+	baseStore
+}
+
+// SaveSyncableWithMessage saves syncable and adds message to outbox in one transaction, so that message is never lost
+// for processed height
+func (s OutboxStore) SaveSyncableWithMessage(syncable *model.Syncable, message *model.OutboxMessage) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(syncable).Error; err != nil {
+			return err
+		}
+		return tx.Create(message).Error
+	})
+
+	return checkErr(err)
+}
+
+// FindOutboxMessages returns oldest outbox messages in order of their heights
+func (s OutboxStore) FindOutboxMessages(limit int64) ([]model.OutboxMessage, error) {
+	var result []model.OutboxMessage
+
+	err := s.db.
+		Order("height, id").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// DeleteOutboxMessages deletes published outbox messages
+func (s OutboxStore) DeleteOutboxMessages(ids []types.ID) error {
+	err := s.db.
+		Where("id IN (?)", ids).
+		Delete(&model.OutboxMessage{}).
+		Error
+
+	return checkErr(err)
+}
+
+// MarkOutboxMessagesFailed records failed publishing attempt of outbox messages
+func (s OutboxStore) MarkOutboxMessagesFailed(ids []types.ID, lastError string) error {
+	err := s.db.
+		Exec("UPDATE outbox_messages SET attempts = attempts + 1, last_error = ?, updated_at = NOW() WHERE id IN (?)", lastError, ids).
+		Error
+
+	return checkErr(err)
+}
@@ -0,0 +1,60 @@
+package psql
+
+import (
+	"time"
+
+	"github.com/figment-networks/indexing-engine/store/bulk"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+
+	"github.com/jinzhu/gorm"
+)
+
+func NewPricesStore(db *gorm.DB) *PricesStore {
+	return &PricesStore{scoped(db, model.Price{})}
+}
+
+// PricesStore handles operations on prices
+type PricesStore struct {
+	baseStore
+}
+
+// BulkUpsert imports new prices and updates existing ones
+func (s PricesStore) BulkUpsert(records []model.Price) error {
+	var err error
+	t := time.Now()
+
+	for i := 0; i < len(records); i += batchSize {
+		j := i + batchSize
+		if j > len(records) {
+			j = len(records)
+		}
+		err = s.Import(queries.PriceInsert, j-i, func(k int) bulk.Row {
+			r := records[i+k]
+			return bulk.Row{
+				t,
+				t,
+				r.Date.Format(model.PriceDateFormat),
+				r.Currency,
+				r.Price,
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindForPeriod returns prices in currency for days between given dates (inclusive)
+func (s PricesStore) FindForPeriod(currency string, start, end time.Time) ([]model.Price, error) {
+	var result []model.Price
+
+	err := s.db.
+		Where("currency = ? AND date >= ? AND date <= ?", currency, start.Format(model.PriceDateFormat), end.Format(model.PriceDateFormat)).
+		Order("date").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
@@ -0,0 +1,19 @@
+WITH eras AS (
+	SELECT
+		era,
+		MIN(height) AS start_height,
+		MAX(height) AS end_height,
+		MIN(time) AS start_time
+	FROM syncables
+	WHERE time >= ?
+	GROUP BY era
+)
+SELECT
+	eras.start_time AS time_bucket,
+	COUNT(*) AS count,
+	EXTRACT(EPOCH FROM (MAX(b.time) - MIN(b.time)) / COUNT(*)) AS block_time_avg
+FROM block_sequences AS b
+	INNER JOIN eras ON b.height >= eras.start_height AND b.height <= eras.end_height
+WHERE b.height >= (SELECT MIN(start_height) FROM eras)
+GROUP BY eras.start_time
+ORDER BY time_bucket
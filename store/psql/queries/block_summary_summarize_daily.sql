@@ -0,0 +1,8 @@
+SELECT
+	DATE_TRUNC(?, time_bucket) AS time_bucket,
+	SUM(count)::BIGINT AS count,
+	SUM(block_time_avg * count) / NULLIF(SUM(count), 0) AS block_time_avg
+FROM block_summary
+WHERE time_interval = ? AND index_version = ? AND time_bucket >= ?
+GROUP BY 1
+ORDER BY 1
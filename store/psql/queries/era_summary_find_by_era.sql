@@ -0,0 +1,6 @@
+SELECT
+  e.*,
+  s.time AS start_time
+FROM era_summaries AS e
+  LEFT JOIN syncables AS s ON s.height = e.start_height
+WHERE e.era = ?
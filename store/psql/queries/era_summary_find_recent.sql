@@ -0,0 +1,7 @@
+SELECT
+  e.*,
+  s.time AS start_time
+FROM era_summaries AS e
+  LEFT JOIN syncables AS s ON s.height = e.start_height
+ORDER BY e.era DESC
+LIMIT ?
@@ -0,0 +1,31 @@
+INSERT INTO era_summaries (
+  era,
+  start_height,
+  end_height,
+  time,
+  total_stake,
+  active_validators_count,
+  nominators_count,
+  validator_stake_min,
+  validator_stake_max,
+  validator_stake_median,
+  total_reward_payout,
+  total_reward_points,
+  commission_avg
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+
+ON CONFLICT (era) DO UPDATE
+SET
+  start_height            = excluded.start_height,
+  end_height              = excluded.end_height,
+  time                    = excluded.time,
+  total_stake             = excluded.total_stake,
+  active_validators_count = excluded.active_validators_count,
+  nominators_count        = excluded.nominators_count,
+  validator_stake_min     = excluded.validator_stake_min,
+  validator_stake_max     = excluded.validator_stake_max,
+  validator_stake_median  = excluded.validator_stake_median,
+  total_reward_payout     = excluded.total_reward_payout,
+  total_reward_points     = excluded.total_reward_points,
+  commission_avg          = excluded.commission_avg
@@ -0,0 +1,65 @@
+WITH last_summary AS (
+	SELECT
+		MAX(time_bucket) AS time_bucket,
+		MAX(updated_at) AS updated_at
+	FROM event_summary
+	WHERE time_interval = ? AND index_version = ?
+),
+touched_buckets AS (
+	SELECT DISTINCT DATE_TRUNC(?, s.time) AS time_bucket
+	FROM syncables AS s, last_summary AS l
+	WHERE s.processed_at > l.updated_at AND s.time < l.time_bucket
+),
+ranges AS (
+	SELECT
+		COALESCE(time_bucket, '-infinity') AS start_time,
+		'infinity'::TIMESTAMP WITH TIME ZONE AS end_time
+	FROM last_summary
+	UNION ALL
+	SELECT
+		time_bucket,
+		time_bucket + ?::INTERVAL
+	FROM touched_buckets
+),
+seqs AS (
+	SELECT
+		DATE_TRUNC(?, e.time) AS time_bucket,
+		e.section,
+		e.method,
+		CASE WHEN e.section = 'balances' AND e.method = 'Transfer'
+			THEN (e.data->2->>'value')::DECIMAL(65, 0)
+			ELSE 0 END AS transfer_amount
+	FROM ranges AS r
+		INNER JOIN event_sequences AS e
+			ON e.time >= r.start_time AND e.time < r.end_time
+)
+INSERT INTO event_summary (
+	created_at,
+	updated_at,
+	time_interval,
+	time_bucket,
+	index_version,
+	section,
+	method,
+	count,
+	transfer_volume
+)
+SELECT
+	NOW(),
+	NOW(),
+	?,
+	time_bucket,
+	?,
+	COALESCE(section, ''),
+	COALESCE(method, ''),
+	COUNT(*),
+	SUM(transfer_amount)
+FROM seqs
+GROUP BY GROUPING SETS ((time_bucket, section, method), (time_bucket))
+
+ON CONFLICT (time_interval, time_bucket, index_version, section, method) DO UPDATE
+SET
+	updated_at      = excluded.updated_at,
+	count           = excluded.count,
+	transfer_volume = excluded.transfer_volume
+WHERE event_summary.count <= excluded.count
\ No newline at end of file
@@ -0,0 +1,26 @@
+WITH upserted AS (
+  INSERT INTO height_updates (
+    created_at,
+    updated_at,
+    height,
+    time,
+    session,
+    era,
+    last_in_session,
+    last_in_era,
+    rewards_claimed
+  )
+  VALUES (NOW(), NOW(), ?, ?, ?, ?, ?, ?, ?)
+
+  ON CONFLICT (height) DO UPDATE
+  SET
+    updated_at      = excluded.updated_at,
+    time            = excluded.time,
+    session         = excluded.session,
+    era             = excluded.era,
+    last_in_session = excluded.last_in_session,
+    last_in_era     = excluded.last_in_era,
+    rewards_claimed = excluded.rewards_claimed
+  RETURNING height
+)
+SELECT pg_notify(?, height::TEXT) FROM upserted
@@ -0,0 +1,18 @@
+UPDATE jobs
+SET status     = 'running',
+    locked_by  = ?,
+    locked_at  = NOW(),
+    attempts   = attempts + 1,
+    updated_at = NOW()
+WHERE id = (
+    SELECT id
+    FROM jobs
+    WHERE kind IN (?)
+      AND attempts < max_attempts
+      AND ((status = 'queued' AND run_at <= NOW())
+        OR (status = 'running' AND locked_at < NOW() - ? * INTERVAL '1 second'))
+    ORDER BY run_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *
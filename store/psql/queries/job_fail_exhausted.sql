@@ -0,0 +1,10 @@
+UPDATE jobs
+SET status       = 'failed',
+    error        = ?,
+    locked_by    = NULL,
+    locked_at    = NULL,
+    completed_at = NOW(),
+    updated_at   = NOW()
+WHERE status = 'running'
+  AND attempts >= max_attempts
+  AND locked_at < NOW() - ? * INTERVAL '1 second'
@@ -0,0 +1,3 @@
+INSERT INTO jobs (created_at, updated_at, kind, params, status, unique_key, attempts, max_attempts, run_at)
+VALUES (NOW(), NOW(), ?, ?, ?, ?, 0, ?, ?)
+ON CONFLICT (unique_key) WHERE status IN ('queued', 'running') DO NOTHING
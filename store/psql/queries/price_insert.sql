@@ -0,0 +1,13 @@
+INSERT INTO prices (
+  created_at,
+  updated_at,
+  date,
+  currency,
+  price
+)
+VALUES @values
+
+ON CONFLICT (currency, date) DO UPDATE
+SET
+  updated_at = excluded.updated_at,
+  price      = excluded.price
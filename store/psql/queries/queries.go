@@ -15,6 +15,9 @@ const (
 	// store/psql/queries/block_seq_summarize.sql
 	BlockSeqSummarize = `DATE_TRUNC(?, time) AS time_bucket, COUNT(*) AS count, EXTRACT(EPOCH FROM (MAX(time) - MIN(time)) / COUNT(*)) AS block_time_avg`
 	
+	// store/psql/queries/block_seq_summarize_by_era.sql
+	BlockSeqSummarizeByEra = `WITH eras AS ( 	SELECT 		era, 		MIN(height) AS start_height, 		MAX(height) AS end_height, 		MIN(time) AS start_time 	FROM syncables 	WHERE time >= ? 	GROUP BY era ) SELECT 	eras.start_time AS time_bucket, 	COUNT(*) AS count, 	EXTRACT(EPOCH FROM (MAX(b.time) - MIN(b.time)) / COUNT(*)) AS block_time_avg FROM block_sequences AS b 	INNER JOIN eras ON b.height >= eras.start_height AND b.height <= eras.end_height WHERE b.height >= (SELECT MIN(start_height) FROM eras) GROUP BY eras.start_time ORDER BY time_bucket `
+	
 	// store/psql/queries/block_seq_times.sql
 	BlockSeqTimes = `SELECT    MIN(height) start_height,    MAX(height) end_height,    MIN(time) start_time,   MAX(time) end_time,   COUNT(*) count,    EXTRACT(EPOCH FROM MAX(time) - MIN(time)) AS diff,    EXTRACT(EPOCH FROM ((MAX(time) - MIN(time)) / COUNT(*))) AS avg   FROM (      SELECT * FROM block_sequences     ORDER BY height DESC     LIMIT ?   ) t;`
 	
@@ -24,6 +27,18 @@ const (
 	// store/psql/queries/block_summary_for_interval.sql
 	BlockSummaryForInterval = `SELECT *  FROM block_summary  WHERE time_bucket >= ( 	SELECT time_bucket  	FROM block_summary  	WHERE time_interval = ? 	ORDER BY time_bucket DESC 	LIMIT 1 ) - ?::INTERVAL AND time_interval = ? ORDER BY time_bucket`
 	
+	// store/psql/queries/block_summary_summarize_daily.sql
+	BlockSummarySummarizeDaily = `SELECT 	DATE_TRUNC(?, time_bucket) AS time_bucket, 	SUM(count)::BIGINT AS count, 	SUM(block_time_avg * count) / NULLIF(SUM(count), 0) AS block_time_avg FROM block_summary WHERE time_interval = ? AND index_version = ? AND time_bucket >= ? GROUP BY 1 ORDER BY 1 `
+	
+	// store/psql/queries/era_summary_find_by_era.sql
+	EraSummaryFindByEra = `SELECT   e.*,   s.time AS start_time FROM era_summaries AS e   LEFT JOIN syncables AS s ON s.height = e.start_height WHERE e.era = ? `
+	
+	// store/psql/queries/era_summary_find_recent.sql
+	EraSummaryFindRecent = `SELECT   e.*,   s.time AS start_time FROM era_summaries AS e   LEFT JOIN syncables AS s ON s.height = e.start_height ORDER BY e.era DESC LIMIT ? `
+	
+	// store/psql/queries/era_summary_upsert.sql
+	EraSummaryUpsert = `INSERT INTO era_summaries (   era,   start_height,   end_height,   time,   total_stake,   active_validators_count,   nominators_count,   validator_stake_min,   validator_stake_max,   validator_stake_median,   total_reward_payout,   total_reward_points,   commission_avg ) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)  ON CONFLICT (era) DO UPDATE SET   start_height            = excluded.start_height,   end_height              = excluded.end_height,   time                    = excluded.time,   total_stake             = excluded.total_stake,   active_validators_count = excluded.active_validators_count,   nominators_count        = excluded.nominators_count,   validator_stake_min     = excluded.validator_stake_min,   validator_stake_max     = excluded.validator_stake_max,   validator_stake_median  = excluded.validator_stake_median,   total_reward_payout     = excluded.total_reward_payout,   total_reward_points     = excluded.total_reward_points,   commission_avg          = excluded.commission_avg `
+	
 	// store/psql/queries/event_seq_insert.sql
 	EventSeqInsert = `INSERT INTO event_sequences (   height,   time,   index,   extrinsic_index,   data,   phase,   method,   section ) VALUES @values  ON CONFLICT (height, index) DO UPDATE SET   extrinsic_index    = excluded.extrinsic_index,   data               = excluded.data,   phase              = excluded.phase,   method             = excluded.method,   section            = excluded.section `
 	
@@ -33,18 +48,45 @@ const (
 	// store/psql/queries/event_seq_with_tx_hash_for_src_and_target.sql
 	EventSeqWithTxHashForSrcAndTarget = `	SELECT 		e.height, 		e.method, 		e.section, 		e.data, 		t.hash 	FROM event_sequences AS e 	INNER JOIN transaction_sequences as t 		ON t.height = e.height AND t.index = e.extrinsic_index 	WHERE e.section = ? AND e.method = ? AND (e.data->0->>'value' = ? OR e.data->1->>'value' = ?)`
 	
+	// store/psql/queries/event_summary_summarize.sql
+	EventSummarySummarize = `WITH last_summary AS ( 	SELECT 		MAX(time_bucket) AS time_bucket, 		MAX(updated_at) AS updated_at 	FROM event_summary 	WHERE time_interval = ? AND index_version = ? ), touched_buckets AS ( 	SELECT DISTINCT DATE_TRUNC(?, s.time) AS time_bucket 	FROM syncables AS s, last_summary AS l 	WHERE s.processed_at > l.updated_at AND s.time < l.time_bucket ), ranges AS ( 	SELECT 		COALESCE(time_bucket, '-infinity') AS start_time, 		'infinity'::TIMESTAMP WITH TIME ZONE AS end_time 	FROM last_summary 	UNION ALL 	SELECT 		time_bucket, 		time_bucket + ?::INTERVAL 	FROM touched_buckets ), seqs AS ( 	SELECT 		DATE_TRUNC(?, e.time) AS time_bucket, 		e.section, 		e.method, 		CASE WHEN e.section = 'balances' AND e.method = 'Transfer' 			THEN (e.data->2->>'value')::DECIMAL(65, 0) 			ELSE 0 END AS transfer_amount 	FROM ranges AS r 		INNER JOIN event_sequences AS e 			ON e.time >= r.start_time AND e.time < r.end_time ) INSERT INTO event_summary ( 	created_at, 	updated_at, 	time_interval, 	time_bucket, 	index_version, 	section, 	method, 	count, 	transfer_volume ) SELECT 	NOW(), 	NOW(), 	?, 	time_bucket, 	?, 	COALESCE(section, ''), 	COALESCE(method, ''), 	COUNT(*), 	SUM(transfer_amount) FROM seqs GROUP BY GROUPING SETS ((time_bucket, section, method), (time_bucket))  ON CONFLICT (time_interval, time_bucket, index_version, section, method) DO UPDATE SET 	updated_at      = excluded.updated_at, 	count           = excluded.count, 	transfer_volume = excluded.transfer_volume WHERE event_summary.count <= excluded.count`
+	
+	// store/psql/queries/height_update_insert.sql
+	HeightUpdateInsert = `WITH upserted AS (   INSERT INTO height_updates (     created_at,     updated_at,     height,     time,     session,     era,     last_in_session,     last_in_era,     rewards_claimed   )   VALUES (NOW(), NOW(), ?, ?, ?, ?, ?, ?, ?)    ON CONFLICT (height) DO UPDATE   SET     updated_at      = excluded.updated_at,     time            = excluded.time,     session         = excluded.session,     era             = excluded.era,     last_in_session = excluded.last_in_session,     last_in_era     = excluded.last_in_era,     rewards_claimed = excluded.rewards_claimed   RETURNING height ) SELECT pg_notify(?, height::TEXT) FROM upserted `
+	
+	// store/psql/queries/job_claim.sql
+	JobClaim = `UPDATE jobs SET status     = 'running',     locked_by  = ?,     locked_at  = NOW(),     attempts   = attempts + 1,     updated_at = NOW() WHERE id = (     SELECT id     FROM jobs     WHERE kind IN (?)       AND attempts < max_attempts       AND ((status = 'queued' AND run_at <= NOW())         OR (status = 'running' AND locked_at < NOW() - ? * INTERVAL '1 second'))     ORDER BY run_at, id     LIMIT 1     FOR UPDATE SKIP LOCKED ) RETURNING * `
+	
+	// store/psql/queries/job_fail_exhausted.sql
+	JobFailExhausted = `UPDATE jobs SET status       = 'failed',     error        = ?,     locked_by    = NULL,     locked_at    = NULL,     completed_at = NOW(),     updated_at   = NOW() WHERE status = 'running'   AND attempts >= max_attempts   AND locked_at < NOW() - ? * INTERVAL '1 second' `
+	
+	// store/psql/queries/job_insert_unique.sql
+	JobInsertUnique = `INSERT INTO jobs (created_at, updated_at, kind, params, status, unique_key, attempts, max_attempts, run_at) VALUES (NOW(), NOW(), ?, ?, ?, ?, 0, ?, ?) ON CONFLICT (unique_key) WHERE status IN ('queued', 'running') DO NOTHING `
+	
+	// store/psql/queries/price_insert.sql
+	PriceInsert = `INSERT INTO prices (   created_at,   updated_at,   date,   currency,   price ) VALUES @values  ON CONFLICT (currency, date) DO UPDATE SET   updated_at = excluded.updated_at,   price      = excluded.price `
+	
 	// store/psql/queries/reward_era_seq_insert.sql
 	RewardEraSeqInsert = `INSERT INTO reward_era_sequences (   era,   start_height,   end_height,   time,   stash_account,   validator_stash_account,   amount,   kind,   claimed,   tx_hash ) VALUES @values  ON CONFLICT (era, stash_account, validator_stash_account, kind) DO NOTHING; `
 	
+	// store/psql/queries/subscription_claim.sql
+	SubscriptionClaim = `UPDATE subscriptions SET locked_by  = ?,     locked_at  = NOW(),     updated_at = NOW() WHERE id = (     SELECT id     FROM subscriptions     WHERE enabled       AND next_attempt_at <= ?       AND (locked_at IS NULL OR locked_at < NOW() - ? * INTERVAL '1 second')     ORDER BY next_attempt_at, id     LIMIT 1     FOR UPDATE SKIP LOCKED ) RETURNING * `
+	
 	// store/psql/queries/system_event_insert.sql
 	SystemEventInsert = `INSERT INTO system_events (   created_at,   updated_at,   height,   time,   actor,   kind,   data ) VALUES @values  ON CONFLICT (height, actor, kind) DO UPDATE SET   updated_at   = excluded.updated_at,   data         = excluded.data `
 	
 	// store/psql/queries/transaction_seq_insert.sql
-	TransactionSeqInsert = `INSERT INTO transaction_sequences (   height,   time,   index,   hash,   method,   section ) VALUES @values  ON CONFLICT (height, index) DO UPDATE SET   hash     = excluded.hash,   method   = excluded.method,   section  = excluded.section `
+	TransactionSeqInsert = `INSERT INTO transaction_sequences (   height,   time,   index,   hash,   method,   section,   signer ) VALUES @values  ON CONFLICT (height, index) DO UPDATE SET   hash     = excluded.hash,   method   = excluded.method,   section  = excluded.section,   signer   = excluded.signer`
+	
+	// store/psql/queries/transaction_summary_summarize.sql
+	TransactionSummarySummarize = `WITH last_summary AS ( 	SELECT 		MAX(time_bucket) AS time_bucket, 		MAX(updated_at) AS updated_at 	FROM transaction_summary 	WHERE time_interval = ? AND index_version = ? ), touched_buckets AS ( 	SELECT DISTINCT DATE_TRUNC(?, s.time) AS time_bucket 	FROM syncables AS s, last_summary AS l 	WHERE s.processed_at > l.updated_at AND s.time < l.time_bucket ), ranges AS ( 	SELECT 		COALESCE(time_bucket, '-infinity') AS start_time, 		'infinity'::TIMESTAMP WITH TIME ZONE AS end_time 	FROM last_summary 	UNION ALL 	SELECT 		time_bucket, 		time_bucket + ?::INTERVAL 	FROM touched_buckets ), seqs AS ( 	SELECT 		DATE_TRUNC(?, t.time) AS time_bucket, 		t.section, 		t.method, 		NULLIF(t.signer, '') AS signer, 		f.id IS NOT NULL AS failed 	FROM ranges AS r 		INNER JOIN transaction_sequences AS t 			ON t.time >= r.start_time AND t.time < r.end_time 		LEFT JOIN event_sequences AS f 			ON f.height = t.height AND f.extrinsic_index = t.index AND f.section = 'system' AND f.method = 'ExtrinsicFailed' ) INSERT INTO transaction_summary ( 	created_at, 	updated_at, 	time_interval, 	time_bucket, 	index_version, 	section, 	method, 	count, 	failed_count, 	signers_count ) SELECT 	NOW(), 	NOW(), 	?, 	time_bucket, 	?, 	COALESCE(section, ''), 	COALESCE(method, ''), 	COUNT(*), 	COUNT(*) FILTER (WHERE failed), 	COUNT(DISTINCT signer) FROM seqs GROUP BY GROUPING SETS ((time_bucket, section, method), (time_bucket))  ON CONFLICT (time_interval, time_bucket, index_version, section, method) DO UPDATE SET 	updated_at    = excluded.updated_at, 	count         = excluded.count, 	failed_count  = excluded.failed_count, 	signers_count = excluded.signers_count WHERE transaction_summary.count <= excluded.count`
 	
 	// store/psql/queries/validator_era_seq_insert.sql
 	ValidatorEraSeqInsert = `INSERT INTO validator_era_sequences (   era,   start_height,   end_height,   time,   stash_account,   controller_account,   session_accounts,   index,   total_stake,   own_stake,   stakers_stake,   reward_points,   commission,   stakers_count ) VALUES @values  ON CONFLICT (era, stash_account) DO UPDATE SET   controller_account = excluded.controller_account,   session_accounts = excluded.session_accounts,   index = excluded.index,   total_stake = excluded.total_stake,   own_stake = excluded.own_stake,   stakers_stake = excluded.stakers_stake,   reward_points = excluded.reward_points,   commission = excluded.commission,   stakers_count = excluded.stakers_count `
 	
+	// store/psql/queries/validator_era_seq_summarize_by_era.sql
+	ValidatorEraSeqSummarizeByEra = `WITH eras AS ( 	SELECT 		era, 		MIN(time) AS start_time 	FROM syncables 	WHERE time >= ? 	GROUP BY era ) SELECT 	v.stash_account, 	eras.start_time AS time_bucket, 	AVG(v.total_stake) AS total_stake_avg, 	MAX(v.total_stake) AS total_stake_max, 	MIN(v.total_stake) AS total_stake_min, 	AVG(v.own_stake) AS own_stake_avg, 	MAX(v.own_stake) AS own_stake_max, 	MIN(v.own_stake) AS own_stake_min, 	AVG(v.stakers_stake) AS stakers_stake_avg, 	MAX(v.stakers_stake) AS stakers_stake_max, 	MIN(v.stakers_stake) AS stakers_stake_min, 	AVG(v.reward_points) AS reward_points_avg, 	MAX(v.reward_points) AS reward_points_max, 	MIN(v.reward_points) AS reward_points_min, 	AVG(v.commission) AS commission_avg, 	MAX(v.commission) AS commission_max, 	MIN(v.commission) AS commission_min, 	AVG(v.stakers_count) AS stakers_count_avg, 	MAX(v.stakers_count) AS stakers_count_max, 	MIN(v.stakers_count) AS stakers_count_min FROM validator_era_sequences AS v 	INNER JOIN eras ON v.era = eras.era WHERE v.era >= (SELECT MIN(era) FROM eras) GROUP BY v.stash_account, eras.start_time ORDER BY time_bucket `
+	
 	// store/psql/queries/validator_era_seq_summarize_select.sql
 	ValidatorEraSeqSummarizeSelect = `	stash_account, 	DATE_TRUNC(?, time) AS time_bucket,    	AVG(total_stake) AS total_stake_avg,    	MAX(total_stake) AS total_stake_max,    	MIN(total_stake) AS total_stake_min, 	AVG(own_stake) AS own_stake_avg,    	MAX(own_stake) AS own_stake_max,    	MIN(own_stake) AS own_stake_min, 	AVG(stakers_stake) AS stakers_stake_avg,    	MAX(stakers_stake) AS stakers_stake_max,    	MIN(stakers_stake) AS stakers_stake_min, 	AVG(reward_points) AS reward_points_avg,    	MAX(reward_points) AS reward_points_max,    	MIN(reward_points) AS reward_points_min, 	AVG(commission) AS commission_avg,    	MAX(commission) AS commission_max,    	MIN(commission) AS commission_min, 	AVG(stakers_count) AS stakers_count_avg,    	MAX(stakers_count) AS stakers_count_max,    	MIN(stakers_count) AS stakers_count_min`
 	
@@ -57,6 +99,9 @@ const (
 	// store/psql/queries/validator_session_seq_insert.sql
 	ValidatorSessionSeqInsert = `INSERT INTO validator_session_sequences (   session,   start_height,   end_height,   time,   stash_account,   online ) VALUES @values  ON CONFLICT (session, stash_account) DO UPDATE SET   online = excluded.online `
 	
+	// store/psql/queries/validator_session_seq_summarize_by_era.sql
+	ValidatorSessionSeqSummarizeByEra = `WITH eras AS ( 	SELECT 		era, 		MIN(height) AS start_height, 		MAX(height) AS end_height, 		MIN(time) AS start_time 	FROM syncables 	WHERE time >= ? 	GROUP BY era ) SELECT 	v.stash_account, 	eras.start_time AS time_bucket, 	AVG(v.online::INT) AS uptime_avg, 	MAX(v.online::INT) AS uptime_max, 	MIN(v.online::INT) AS uptime_min FROM validator_session_sequences AS v 	INNER JOIN eras ON v.end_height >= eras.start_height AND v.end_height <= eras.end_height WHERE v.end_height >= (SELECT MIN(start_height) FROM eras) GROUP BY v.stash_account, eras.start_time ORDER BY time_bucket `
+	
 	// store/psql/queries/validator_session_seq_summarize_select.sql
 	ValidatorSessionSeqSummarizeSelect = `	stash_account, 	DATE_TRUNC(?, time) AS time_bucket,    	AVG(online::INT) AS uptime_avg,    	MAX(online::INT) AS uptime_max,    	MIN(online::INT) AS uptime_min`
 	
@@ -69,5 +114,11 @@ const (
 	// store/psql/queries/validator_summary_for_interval_and_stash.sql
 	ValidatorSummaryForIntervalAndStash = `SELECT *  FROM validator_summary  WHERE time_bucket >= ( 	SELECT time_bucket  	FROM validator_summary  	WHERE time_interval = ? 	ORDER BY time_bucket DESC 	LIMIT 1 ) - ?::INTERVAL 	AND stash_account = ? AND time_interval = ? ORDER BY time_bucket`
 	
+	// store/psql/queries/validator_summary_summarize_daily_eras.sql
+	ValidatorSummarySummarizeDailyEras = `SELECT 	stash_account, 	DATE_TRUNC(?, time_bucket) AS time_bucket, 	ROUND(AVG(total_stake_avg)) AS total_stake_avg, 	MAX(total_stake_max) AS total_stake_max, 	MIN(total_stake_min) AS total_stake_min, 	ROUND(AVG(own_stake_avg)) AS own_stake_avg, 	MAX(own_stake_max) AS own_stake_max, 	MIN(own_stake_min) AS own_stake_min, 	ROUND(AVG(stakers_stake_avg)) AS stakers_stake_avg, 	MAX(stakers_stake_max) AS stakers_stake_max, 	MIN(stakers_stake_min) AS stakers_stake_min, 	AVG(reward_points_avg) AS reward_points_avg, 	MAX(reward_points_max) AS reward_points_max, 	MIN(reward_points_min) AS reward_points_min, 	AVG(commission_avg) AS commission_avg, 	MAX(commission_max) AS commission_max, 	MIN(commission_min) AS commission_min, 	AVG(stakers_count_avg) AS stakers_count_avg, 	MAX(stakers_count_max) AS stakers_count_max, 	MIN(stakers_count_min) AS stakers_count_min FROM validator_summary WHERE time_interval = ? AND index_version = ? AND time_bucket >= ? GROUP BY 1, 2 ORDER BY 2 `
+	
+	// store/psql/queries/validator_summary_summarize_daily_sessions.sql
+	ValidatorSummarySummarizeDailySessions = `SELECT 	stash_account, 	DATE_TRUNC(?, time_bucket) AS time_bucket, 	AVG(uptime_avg) AS uptime_avg, 	MAX(uptime_max) AS uptime_max, 	MIN(uptime_min) AS uptime_min FROM validator_summary WHERE time_interval = ? AND index_version = ? AND time_bucket >= ? GROUP BY 1, 2 ORDER BY 2 `
+	
 )
 	
\ No newline at end of file
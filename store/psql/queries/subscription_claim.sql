@@ -0,0 +1,15 @@
+UPDATE subscriptions
+SET locked_by  = ?,
+    locked_at  = NOW(),
+    updated_at = NOW()
+WHERE id = (
+    SELECT id
+    FROM subscriptions
+    WHERE enabled
+      AND next_attempt_at <= ?
+      AND (locked_at IS NULL OR locked_at < NOW() - ? * INTERVAL '1 second')
+    ORDER BY next_attempt_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *
@@ -4,7 +4,8 @@ INSERT INTO transaction_sequences (
   index,
   hash,
   method,
-  section
+  section,
+  signer
 )
 VALUES @values
 
@@ -12,4 +13,5 @@ ON CONFLICT (height, index) DO UPDATE
 SET
   hash     = excluded.hash,
   method   = excluded.method,
-  section  = excluded.section
+  section  = excluded.section,
+  signer   = excluded.signer
\ No newline at end of file
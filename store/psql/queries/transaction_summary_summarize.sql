@@ -0,0 +1,69 @@
+WITH last_summary AS (
+	SELECT
+		MAX(time_bucket) AS time_bucket,
+		MAX(updated_at) AS updated_at
+	FROM transaction_summary
+	WHERE time_interval = ? AND index_version = ?
+),
+touched_buckets AS (
+	SELECT DISTINCT DATE_TRUNC(?, s.time) AS time_bucket
+	FROM syncables AS s, last_summary AS l
+	WHERE s.processed_at > l.updated_at AND s.time < l.time_bucket
+),
+ranges AS (
+	SELECT
+		COALESCE(time_bucket, '-infinity') AS start_time,
+		'infinity'::TIMESTAMP WITH TIME ZONE AS end_time
+	FROM last_summary
+	UNION ALL
+	SELECT
+		time_bucket,
+		time_bucket + ?::INTERVAL
+	FROM touched_buckets
+),
+seqs AS (
+	SELECT
+		DATE_TRUNC(?, t.time) AS time_bucket,
+		t.section,
+		t.method,
+		NULLIF(t.signer, '') AS signer,
+		f.id IS NOT NULL AS failed
+	FROM ranges AS r
+		INNER JOIN transaction_sequences AS t
+			ON t.time >= r.start_time AND t.time < r.end_time
+		LEFT JOIN event_sequences AS f
+			ON f.height = t.height AND f.extrinsic_index = t.index AND f.section = 'system' AND f.method = 'ExtrinsicFailed'
+)
+INSERT INTO transaction_summary (
+	created_at,
+	updated_at,
+	time_interval,
+	time_bucket,
+	index_version,
+	section,
+	method,
+	count,
+	failed_count,
+	signers_count
+)
+SELECT
+	NOW(),
+	NOW(),
+	?,
+	time_bucket,
+	?,
+	COALESCE(section, ''),
+	COALESCE(method, ''),
+	COUNT(*),
+	COUNT(*) FILTER (WHERE failed),
+	COUNT(DISTINCT signer)
+FROM seqs
+GROUP BY GROUPING SETS ((time_bucket, section, method), (time_bucket))
+
+ON CONFLICT (time_interval, time_bucket, index_version, section, method) DO UPDATE
+SET
+	updated_at    = excluded.updated_at,
+	count         = excluded.count,
+	failed_count  = excluded.failed_count,
+	signers_count = excluded.signers_count
+WHERE transaction_summary.count <= excluded.count
\ No newline at end of file
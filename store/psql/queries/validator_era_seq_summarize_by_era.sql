@@ -0,0 +1,34 @@
+WITH eras AS (
+	SELECT
+		era,
+		MIN(time) AS start_time
+	FROM syncables
+	WHERE time >= ?
+	GROUP BY era
+)
+SELECT
+	v.stash_account,
+	eras.start_time AS time_bucket,
+	AVG(v.total_stake) AS total_stake_avg,
+	MAX(v.total_stake) AS total_stake_max,
+	MIN(v.total_stake) AS total_stake_min,
+	AVG(v.own_stake) AS own_stake_avg,
+	MAX(v.own_stake) AS own_stake_max,
+	MIN(v.own_stake) AS own_stake_min,
+	AVG(v.stakers_stake) AS stakers_stake_avg,
+	MAX(v.stakers_stake) AS stakers_stake_max,
+	MIN(v.stakers_stake) AS stakers_stake_min,
+	AVG(v.reward_points) AS reward_points_avg,
+	MAX(v.reward_points) AS reward_points_max,
+	MIN(v.reward_points) AS reward_points_min,
+	AVG(v.commission) AS commission_avg,
+	MAX(v.commission) AS commission_max,
+	MIN(v.commission) AS commission_min,
+	AVG(v.stakers_count) AS stakers_count_avg,
+	MAX(v.stakers_count) AS stakers_count_max,
+	MIN(v.stakers_count) AS stakers_count_min
+FROM validator_era_sequences AS v
+	INNER JOIN eras ON v.era = eras.era
+WHERE v.era >= (SELECT MIN(era) FROM eras)
+GROUP BY v.stash_account, eras.start_time
+ORDER BY time_bucket
@@ -0,0 +1,21 @@
+WITH eras AS (
+	SELECT
+		era,
+		MIN(height) AS start_height,
+		MAX(height) AS end_height,
+		MIN(time) AS start_time
+	FROM syncables
+	WHERE time >= ?
+	GROUP BY era
+)
+SELECT
+	v.stash_account,
+	eras.start_time AS time_bucket,
+	AVG(v.online::INT) AS uptime_avg,
+	MAX(v.online::INT) AS uptime_max,
+	MIN(v.online::INT) AS uptime_min
+FROM validator_session_sequences AS v
+	INNER JOIN eras ON v.end_height >= eras.start_height AND v.end_height <= eras.end_height
+WHERE v.end_height >= (SELECT MIN(start_height) FROM eras)
+GROUP BY v.stash_account, eras.start_time
+ORDER BY time_bucket
@@ -0,0 +1,25 @@
+SELECT
+	stash_account,
+	DATE_TRUNC(?, time_bucket) AS time_bucket,
+	ROUND(AVG(total_stake_avg)) AS total_stake_avg,
+	MAX(total_stake_max) AS total_stake_max,
+	MIN(total_stake_min) AS total_stake_min,
+	ROUND(AVG(own_stake_avg)) AS own_stake_avg,
+	MAX(own_stake_max) AS own_stake_max,
+	MIN(own_stake_min) AS own_stake_min,
+	ROUND(AVG(stakers_stake_avg)) AS stakers_stake_avg,
+	MAX(stakers_stake_max) AS stakers_stake_max,
+	MIN(stakers_stake_min) AS stakers_stake_min,
+	AVG(reward_points_avg) AS reward_points_avg,
+	MAX(reward_points_max) AS reward_points_max,
+	MIN(reward_points_min) AS reward_points_min,
+	AVG(commission_avg) AS commission_avg,
+	MAX(commission_max) AS commission_max,
+	MIN(commission_min) AS commission_min,
+	AVG(stakers_count_avg) AS stakers_count_avg,
+	MAX(stakers_count_max) AS stakers_count_max,
+	MIN(stakers_count_min) AS stakers_count_min
+FROM validator_summary
+WHERE time_interval = ? AND index_version = ? AND time_bucket >= ?
+GROUP BY 1, 2
+ORDER BY 2
@@ -0,0 +1,10 @@
+SELECT
+	stash_account,
+	DATE_TRUNC(?, time_bucket) AS time_bucket,
+	AVG(uptime_avg) AS uptime_avg,
+	MAX(uptime_max) AS uptime_max,
+	MIN(uptime_min) AS uptime_min
+FROM validator_summary
+WHERE time_interval = ? AND index_version = ? AND time_bucket >= ?
+GROUP BY 1, 2
+ORDER BY 2
@@ -42,6 +42,31 @@ func (s ReportsStore) FindNotCompletedByKind(kinds ...model.ReportKind) (*model.
 	return result, checkErr(err)
 }
 
+// FindLastCompleted returns the most recently completed report of given kinds
+func (s ReportsStore) FindLastCompleted(kinds ...model.ReportKind) (*model.Report, error) {
+	result := &model.Report{}
+
+	err := s.db.
+		Where("kind IN(?)", kinds).
+		Where("completed_at IS NOT NULL").
+		Order("completed_at DESC").
+		First(result).Error
+
+	return result, checkErr(err)
+}
+
+// FindRecent returns most recent reports
+func (s ReportsStore) FindRecent(limit int64) ([]model.Report, error) {
+	var result []model.Report
+
+	err := s.db.
+		Order("id DESC").
+		Limit(limit).
+		Find(&result).Error
+
+	return result, checkErr(err)
+}
+
 // Last returns the last report
 func (s ReportsStore) Last() (*model.Report, error) {
 	result := &model.Report{}
@@ -0,0 +1,28 @@
+package psql
+
+import (
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/jinzhu/gorm"
+)
+
+func NewRestoredRangesStore(db *gorm.DB) *RestoredRangesStore {
+	return &RestoredRangesStore{scoped(db, model.RestoredRange{})}
+}
+
+// RestoredRangesStore handles operations on restored ranges
+type RestoredRangesStore struct {
+	baseStore
+}
+
+// CreateRestoredRange creates restored range
+func (s RestoredRangesStore) CreateRestoredRange(record *model.RestoredRange) error {
+	return s.Create(record)
+}
+
+// notRestored returns condition matching rows whose height column is outside of all restored ranges
+func notRestored(table, heightColumn string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s AS r WHERE %s.%s BETWEEN r.start_height AND r.end_height)",
+		model.RestoredRange{}.TableName(), table, heightColumn)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
 	"github.com/figment-networks/polkadothub-indexer/types"
 
@@ -138,3 +139,40 @@ func (s *RewardEraSeqStore) GetByStashAndEra(validatorStash, stash string, era i
 
 	return result, checkErr(err)
 }
+
+// FindForEraRange returns rewards between given eras (inclusive), non empty stashAccount matches both nominator and validator stash
+func (s RewardEraSeqStore) FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.RewardEraSeq, error) {
+	tx := s.db.
+		Table(model.RewardEraSeq{}.TableName()).
+		Select("*").
+		Where("era >= ? AND era <= ?", startEra, endEra)
+
+	if stashAccount != "" {
+		tx = tx.Where("stash_account = ? OR validator_stash_account = ?", stashAccount, stashAccount)
+	}
+
+	var res []model.RewardEraSeq
+	err := tx.
+		Order("era, id").
+		Find(&res).
+		Error
+
+	return res, checkErr(err)
+}
+
+// SumNominatorRewardsForEraRange returns rewards of nominators summed by validator and era, for eras between given eras (inclusive)
+func (s RewardEraSeqStore) SumNominatorRewardsForEraRange(startEra, endEra int64) ([]store.ValidatorEraRewardRow, error) {
+	var res []store.ValidatorEraRewardRow
+
+	err := s.db.
+		Table(model.RewardEraSeq{}.TableName()).
+		Select("era, validator_stash_account, SUM(amount) AS amount").
+		Where("era >= ? AND era <= ?", startEra, endEra).
+		Where("kind = ? AND stash_account <> validator_stash_account", model.RewardReward).
+		Group("era, validator_stash_account").
+		Order("era, validator_stash_account").
+		Scan(&res).
+		Error
+
+	return res, checkErr(err)
+}
@@ -0,0 +1,274 @@
+package psql
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	liveSchema    = "public"
+	shadowSchema  = "shadow"
+	retiredSchema = "shadow_retired"
+)
+
+// indexNamePattern matches name and table of index in definition returned by pg_indexes
+var indexNamePattern = regexp.MustCompile(`INDEX \S+ ON \S+`)
+
+func NewShadowStore(db *gorm.DB, connStr string) *ShadowStore {
+	return &ShadowStore{
+		db:      db,
+		connStr: connStr,
+	}
+}
+
+// ShadowStore handles shadow copies of tables in shadow schema. Backfill writes into shadow tables through store
+// which finds shadow schema first on search path, so other tables are read and written live
+type ShadowStore struct {
+	db      *gorm.DB
+	connStr string
+}
+
+// CreateShadowTables recreates shadow schema with empty copies of given tables, only seeded ones are filled with live rows
+func (s *ShadowStore) CreateShadowTables(tables []store.ShadowTable) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", shadowSchema),
+			fmt.Sprintf("CREATE SCHEMA %s", shadowSchema),
+		}
+
+		for _, t := range tables {
+			live, shadow := qualified(liveSchema, t.Name), qualified(shadowSchema, t.Name)
+			sequence := qualified(shadowSchema, t.Name+"_id_seq")
+
+			// Shadow table gets its own id sequence, otherwise it would keep using sequence of live table
+			statements = append(statements,
+				fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING ALL)", shadow, live),
+				fmt.Sprintf("CREATE SEQUENCE %s OWNED BY %s.id", sequence, shadow),
+				fmt.Sprintf("ALTER TABLE %s ALTER COLUMN id SET DEFAULT nextval('%s')", shadow, sequence),
+			)
+
+			if t.Seeded {
+				statements = append(statements,
+					fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", shadow, live),
+					fmt.Sprintf("SELECT setval('%s', COALESCE((SELECT MAX(id) FROM %s), 0) + 1, false)", sequence, shadow),
+				)
+			}
+		}
+
+		return execAll(tx, statements)
+	})
+
+	return checkErr(err)
+}
+
+// OpenShadowStore returns store which reads and writes shadow tables instead of live ones
+func (s *ShadowStore) OpenShadowStore() (store.Store, error) {
+	connStr, err := withSearchPath(s.connStr, shadowSchema+","+liveSchema)
+	if err != nil {
+		return nil, err
+	}
+	return New(connStr)
+}
+
+// CheckShadowTables counts rows of shadow and live tables up to end height and heights of live rows missing in shadow table
+func (s *ShadowStore) CheckShadowTables(tables []store.ShadowTable, endHeight int64) ([]store.ShadowTableCheck, error) {
+	checks := make([]store.ShadowTableCheck, len(tables))
+	for i, t := range tables {
+		live, shadow := qualified(liveSchema, t.Name), qualified(shadowSchema, t.Name)
+
+		query := fmt.Sprintf(`SELECT
+			(SELECT COUNT(*) FROM %[1]s WHERE %[3]s <= @end) AS live_count,
+			(SELECT COUNT(*) FROM %[2]s WHERE %[3]s <= @end) AS shadow_count,
+			(SELECT COUNT(*) FROM (
+				SELECT DISTINCT %[3]s FROM %[1]s WHERE %[3]s <= @end
+				EXCEPT
+				SELECT DISTINCT %[3]s FROM %[2]s WHERE %[3]s <= @end
+			) missing) AS missing_heights`, live, shadow, t.HeightColumn)
+
+		var check store.ShadowTableCheck
+		err := s.db.Raw(strings.Replace(query, "@end", "?", -1), endHeight, endHeight, endHeight, endHeight).Scan(&check).Error
+		if err != nil {
+			return nil, checkErr(err)
+		}
+
+		check.Table = t.Name
+		checks[i] = check
+	}
+	return checks, nil
+}
+
+// SwapShadowTables replaces live tables with shadow tables in one transaction. Rows indexed into live tables after
+// end height are carried over first, while live tables are locked against writes. Replaced tables are kept
+// in retired schema until next swap
+func (s *ShadowStore) SwapShadowTables(tables []store.ShadowTable, endHeight int64) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		names := make([]string, len(tables))
+		for i, t := range tables {
+			names[i] = qualified(liveSchema, t.Name)
+		}
+
+		if err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN EXCLUSIVE MODE", strings.Join(names, ", "))).Error; err != nil {
+			return err
+		}
+
+		for _, t := range tables {
+			if err := carryOver(tx, t, endHeight); err != nil {
+				return err
+			}
+		}
+
+		statements := []string{
+			fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", retiredSchema),
+			fmt.Sprintf("CREATE SCHEMA %s", retiredSchema),
+		}
+		if err := execAll(tx, statements); err != nil {
+			return err
+		}
+
+		for _, t := range tables {
+			if err := swapTable(tx, t); err != nil {
+				return err
+			}
+		}
+
+		return tx.Exec(fmt.Sprintf("DROP SCHEMA %s", shadowSchema)).Error
+	})
+
+	return checkErr(err)
+}
+
+// DropShadowTables drops shadow schema with all its tables
+func (s *ShadowStore) DropShadowTables() error {
+	return checkErr(s.db.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", shadowSchema)).Error)
+}
+
+// carryOver copies rows indexed into live table after end height to shadow table, ids are assigned by shadow table
+func carryOver(tx *gorm.DB, t store.ShadowTable, endHeight int64) error {
+	live, shadow := qualified(liveSchema, t.Name), qualified(shadowSchema, t.Name)
+
+	var rows []columnRow
+	err := tx.
+		Raw("SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name <> 'id' ORDER BY ordinal_position", liveSchema, t.Name).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return err
+	}
+
+	columns := make([]string, len(rows))
+	for i, row := range rows {
+		columns[i] = row.ColumnName
+	}
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE %s > ?", shadow, t.HeightColumn)
+	if len(t.KeyColumns) > 0 {
+		conditions := make([]string, len(t.KeyColumns))
+		for i, key := range t.KeyColumns {
+			conditions[i] = fmt.Sprintf("s.%[1]s = l.%[1]s", key)
+		}
+		deleteQuery = fmt.Sprintf("DELETE FROM %s s USING %s l WHERE l.%s > ? AND %s", shadow, live, t.HeightColumn, strings.Join(conditions, " AND "))
+	}
+
+	if err = tx.Exec(deleteQuery, endHeight).Error; err != nil {
+		return err
+	}
+
+	columnList := strings.Join(columns, ", ")
+	return tx.
+		Exec(fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s WHERE %s > ?", shadow, columnList, columnList, live, t.HeightColumn), endHeight).
+		Error
+}
+
+// swapTable moves live table to retired schema and shadow table in its place. Indexes of shadow table get names
+// of matching live indexes, so that migrations can refer to them
+func swapTable(tx *gorm.DB, t store.ShadowTable) error {
+	liveIndexes, err := findIndexes(tx, liveSchema, t.Name)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", qualified(liveSchema, t.Name), retiredSchema),
+		fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", qualified(shadowSchema, t.Name), liveSchema),
+	}
+	if err = execAll(tx, statements); err != nil {
+		return err
+	}
+
+	shadowIndexes, err := findIndexes(tx, liveSchema, t.Name)
+	if err != nil {
+		return err
+	}
+
+	for definition, name := range shadowIndexes {
+		if liveName, ok := liveIndexes[definition]; ok && liveName != name {
+			if err = tx.Exec(fmt.Sprintf("ALTER INDEX %s RENAME TO %s", qualified(liveSchema, name), liveName)).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type columnRow struct {
+	ColumnName string
+}
+
+type indexRow struct {
+	IndexName string
+	IndexDef  string
+}
+
+// findIndexes returns names of table indexes by their definition without index name and table
+func findIndexes(tx *gorm.DB, schema, table string) (map[string]string, error) {
+	var rows []indexRow
+	err := tx.
+		Raw("SELECT indexname AS index_name, indexdef AS index_def FROM pg_indexes WHERE schemaname = ? AND tablename = ?", schema, table).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := map[string]string{}
+	for _, row := range rows {
+		indexes[indexNamePattern.ReplaceAllString(row.IndexDef, "INDEX ON")] = row.IndexName
+	}
+	return indexes, nil
+}
+
+func execAll(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func qualified(schema, name string) string {
+	return schema + "." + name
+}
+
+// withSearchPath sets search_path run-time parameter of connections opened with connection string,
+// it can be given as URL or as key=value pairs
+func withSearchPath(connStr, searchPath string) (string, error) {
+	if !strings.HasPrefix(connStr, "postgres://") && !strings.HasPrefix(connStr, "postgresql://") {
+		return fmt.Sprintf("%s search_path=%s", connStr, searchPath), nil
+	}
+
+	u, err := url.Parse(connStr)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Set("search_path", searchPath)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
@@ -19,30 +19,38 @@ const (
 )
 
 var (
-	_ store.Accounts     = (*accounts)(nil)
-	_ store.Blocks       = (*blocks)(nil)
-	_ store.Database     = (*database)(nil)
-	_ store.Events       = (*events)(nil)
-	_ store.Reports      = (*reports)(nil)
-	_ store.Rewards      = (*rewards)(nil)
-	_ store.Validators   = (*validators)(nil)
-	_ store.Syncables    = (*syncables)(nil)
-	_ store.SystemEvents = (*systemEvents)(nil)
-	_ store.Transactions = (*transactions)(nil)
+	_ store.Store         = (*Store)(nil)
+	_ store.Accounts      = (*accounts)(nil)
+	_ store.Blocks        = (*blocks)(nil)
+	_ store.Database      = (*database)(nil)
+	_ store.Events        = (*events)(nil)
+	_ store.Jobs          = (*jobs)(nil)
+	_ store.Prices        = (*prices)(nil)
+	_ store.Reports       = (*reports)(nil)
+	_ store.Rewards       = (*rewards)(nil)
+	_ store.Subscriptions = (*subscriptions)(nil)
+	_ store.Validators    = (*validators)(nil)
+	_ store.Syncables     = (*syncables)(nil)
+	_ store.SystemEvents  = (*systemEvents)(nil)
+	_ store.Transactions  = (*transactions)(nil)
 )
 
 type Store struct {
-	db           *gorm.DB
-	accounts     *accounts
-	blocks       *blocks
-	database     *database
-	events       *events
-	reports      *reports
-	rewards      *rewards
-	syncables    *syncables
-	systemEvents *systemEvents
-	transactions *transactions
-	validators   *validators
+	db            *gorm.DB
+	connStr       string
+	accounts      *accounts
+	blocks        *blocks
+	database      *database
+	events        *events
+	jobs          *jobs
+	prices        *prices
+	reports       *reports
+	rewards       *rewards
+	subscriptions *subscriptions
+	syncables     *syncables
+	systemEvents  *systemEvents
+	transactions  *transactions
+	validators    *validators
 }
 
 type accounts struct {
@@ -56,10 +64,21 @@ type blocks struct {
 
 type database struct {
 	*DatabaseStore
+	*RestoredRangesStore
+	*ShadowStore
 }
 
 type events struct {
 	*EventSeqStore
+	*EventSummaryStore
+}
+
+type jobs struct {
+	*JobsStore
+}
+
+type prices struct {
+	*PricesStore
 }
 
 type reports struct {
@@ -69,8 +88,15 @@ type reports struct {
 type rewards struct {
 	*RewardEraSeqStore
 }
+
+type subscriptions struct {
+	*SubscriptionsStore
+}
+
 type syncables struct {
 	*SyncablesStore
+	*HeightUpdatesStore
+	*OutboxStore
 }
 
 type systemEvents struct {
@@ -78,9 +104,11 @@ type systemEvents struct {
 }
 type transactions struct {
 	*TransactionSeqStore
+	*TransactionSummaryStore
 }
 
 type validators struct {
+	*EraSummaryStore
 	*ValidatorAggStore
 	*ValidatorSeqStore
 	*ValidatorEraSeqStore
@@ -102,7 +130,8 @@ func New(connStr string) (*Store, error) {
 	registerPlugins(conn)
 
 	return &Store{
-		db: conn,
+		db:      conn,
+		connStr: connStr,
 	}, nil
 }
 
@@ -112,7 +141,7 @@ func (s *Store) Close() error {
 }
 
 // GetAccounts gets accounts
-func (s *Store) GetAccounts() *accounts {
+func (s *Store) GetAccounts() store.Accounts {
 	if s.accounts == nil {
 		s.accounts = &accounts{
 			NewAccountEraSeqStore(s.db),
@@ -122,7 +151,7 @@ func (s *Store) GetAccounts() *accounts {
 }
 
 // GetBlocks gets blocks
-func (s *Store) GetBlocks() *blocks {
+func (s *Store) GetBlocks() store.Blocks {
 	if s.blocks == nil {
 		s.blocks = &blocks{
 			NewBlockSeqStore(s.db),
@@ -133,27 +162,50 @@ func (s *Store) GetBlocks() *blocks {
 }
 
 // GetDatabase gets database
-func (s *Store) GetDatabase() *database {
+func (s *Store) GetDatabase() store.Database {
 	if s.database == nil {
 		s.database = &database{
 			NewDatabaseStore(s.db),
+			NewRestoredRangesStore(s.db),
+			NewShadowStore(s.db, s.connStr),
 		}
 	}
 	return s.database
 }
 
 // GetEvents gets events
-func (s *Store) GetEvents() *events {
+func (s *Store) GetEvents() store.Events {
 	if s.events == nil {
 		s.events = &events{
 			NewEventSeqStore(s.db),
+			NewEventSummaryStore(s.db),
 		}
 	}
 	return s.events
 }
 
+// GetJobs gets jobs
+func (s *Store) GetJobs() store.Jobs {
+	if s.jobs == nil {
+		s.jobs = &jobs{
+			NewJobsStore(s.db),
+		}
+	}
+	return s.jobs
+}
+
+// GetPrices gets prices
+func (s *Store) GetPrices() store.Prices {
+	if s.prices == nil {
+		s.prices = &prices{
+			NewPricesStore(s.db),
+		}
+	}
+	return s.prices
+}
+
 // GetReports gets reports
-func (s *Store) GetReports() *reports {
+func (s *Store) GetReports() store.Reports {
 	if s.reports == nil {
 		s.reports = &reports{
 			NewReportsStore(s.db),
@@ -163,7 +215,7 @@ func (s *Store) GetReports() *reports {
 }
 
 // GetRewards gets rewards
-func (s *Store) GetRewards() *rewards {
+func (s *Store) GetRewards() store.Rewards {
 	if s.rewards == nil {
 		s.rewards = &rewards{
 			NewRewardEraSeqStore(s.db),
@@ -172,18 +224,30 @@ func (s *Store) GetRewards() *rewards {
 	return s.rewards
 }
 
+// GetSubscriptions gets subscriptions
+func (s *Store) GetSubscriptions() store.Subscriptions {
+	if s.subscriptions == nil {
+		s.subscriptions = &subscriptions{
+			NewSubscriptionsStore(s.db),
+		}
+	}
+	return s.subscriptions
+}
+
 // GetSyncables gets syncables
-func (s *Store) GetSyncables() *syncables {
+func (s *Store) GetSyncables() store.Syncables {
 	if s.syncables == nil {
 		s.syncables = &syncables{
 			NewSyncablesStore(s.db),
+			NewHeightUpdatesStore(s.db),
+			NewOutboxStore(s.db),
 		}
 	}
 	return s.syncables
 }
 
 // GetSystemEvents gets syncables
-func (s *Store) GetSystemEvents() *systemEvents {
+func (s *Store) GetSystemEvents() store.SystemEvents {
 	if s.systemEvents == nil {
 		s.systemEvents = &systemEvents{
 			NewSystemEventsStore(s.db),
@@ -193,19 +257,21 @@ func (s *Store) GetSystemEvents() *systemEvents {
 }
 
 // GetTransactions gets transactions
-func (s *Store) GetTransactions() *transactions {
+func (s *Store) GetTransactions() store.Transactions {
 	if s.transactions == nil {
 		s.transactions = &transactions{
 			NewTransactionSeqStore(s.db),
+			NewTransactionSummaryStore(s.db),
 		}
 	}
 	return s.transactions
 }
 
 // GetValidators gets validators
-func (s *Store) GetValidators() *validators {
+func (s *Store) GetValidators() store.Validators {
 	if s.validators == nil {
 		s.validators = &validators{
+			NewEraSummaryStore(s.db),
 			NewValidatorAggStore(s.db),
 			NewValidatorSeqStore(s.db),
 			NewValidatorEraSeqStore(s.db),
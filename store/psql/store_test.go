@@ -0,0 +1,64 @@
+package psql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/storetest"
+	"github.com/figment-networks/polkadothub-indexer/utils/projectpath"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jinzhu/gorm"
+
+	// Migrate configuration
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// testDatabaseEnv holds connection string of database used by tests, all of its tables are truncated
+const testDatabaseEnv = "TEST_DATABASE_DSN"
+
+func TestStore(t *testing.T) {
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	migrations, err := migrate.New(fmt.Sprintf("file://%s", filepath.Join(projectpath.Root, "migrations")), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrations.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatal(err)
+	}
+
+	s, err := New(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	storetest.Run(t, func(t *testing.T) store.Store {
+		truncateTables(t, s.db)
+		return s
+	})
+}
+
+// truncateTables removes rows of all tables except migrations version
+func truncateTables(t *testing.T, db *gorm.DB) {
+	var tables []string
+	err := db.
+		Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'").
+		Pluck("tablename", &tables).
+		Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Exec(fmt.Sprintf("TRUNCATE %s RESTART IDENTITY", strings.Join(tables, ", "))).Error; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+package psql
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/jinzhu/gorm"
+)
+
+func NewSubscriptionsStore(db *gorm.DB) *SubscriptionsStore {
+	return &SubscriptionsStore{scoped(db, model.Subscription{})}
+}
+
+// SubscriptionsStore handles operations on subscriptions and their deliveries
+type SubscriptionsStore struct {
+	baseStore
+}
+
+// FindByID returns subscription by id
+func (s SubscriptionsStore) FindByID(id types.ID) (*model.Subscription, error) {
+	result := &model.Subscription{}
+
+	err := findBy(s.db, result, "id", id)
+	return result, checkErr(err)
+}
+
+// FindAll returns all subscriptions
+func (s SubscriptionsStore) FindAll() ([]model.Subscription, error) {
+	var result []model.Subscription
+
+	err := s.db.
+		Order("id").
+		Find(&result).Error
+
+	return result, checkErr(err)
+}
+
+// SaveSettings saves url, secret, filters and enabled state of subscription without touching its delivery progress
+func (s SubscriptionsStore) SaveSettings(subscription *model.Subscription) error {
+	res := s.db.
+		Model(&model.Subscription{}).
+		Where("id = ?", subscription.ID).
+		Updates(map[string]interface{}{
+			"url":             subscription.URL,
+			"secret":          subscription.Secret,
+			"actors":          subscription.Actors,
+			"kinds":           subscription.Kinds,
+			"enabled":         subscription.Enabled,
+			"failures":        subscription.Failures,
+			"next_attempt_at": subscription.NextAttemptAt,
+			"disabled_at":     subscription.DisabledAt,
+		})
+
+	if res.Error != nil {
+		return checkErr(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// Delete deletes subscription together with its deliveries
+func (s SubscriptionsStore) Delete(id types.ID) error {
+	res := s.db.
+		Where("id = ?", id).
+		Delete(&model.Subscription{})
+
+	if res.Error != nil {
+		return checkErr(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// Claim locks the next enabled subscription due for delivery before given time, subscriptions with lock older than lockTimeout are claimed again
+func (s SubscriptionsStore) Claim(workerID string, lockTimeout time.Duration, dueBefore time.Time) (*model.Subscription, error) {
+	result := &model.Subscription{}
+
+	err := s.db.
+		Raw(queries.SubscriptionClaim, workerID, dueBefore, lockTimeout.Seconds()).
+		Scan(result).Error
+
+	return result, checkErr(err)
+}
+
+// Release releases lock and saves delivery progress of claimed subscription
+func (s SubscriptionsStore) Release(subscription *model.Subscription) error {
+	res := s.db.
+		Model(&model.Subscription{}).
+		Where("id = ? AND locked_by = ?", subscription.ID, subscription.LockedBy).
+		Updates(map[string]interface{}{
+			"enabled":                  subscription.Enabled,
+			"last_system_event_height": subscription.LastSystemEventHeight,
+			"last_system_event_id":     subscription.LastSystemEventID,
+			"failures":                 subscription.Failures,
+			"next_attempt_at":          subscription.NextAttemptAt,
+			"disabled_at":              subscription.DisabledAt,
+			"locked_by":                nil,
+			"locked_at":                nil,
+		})
+
+	if res.Error != nil {
+		return checkErr(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// CreateDelivery logs delivery attempt
+func (s SubscriptionsStore) CreateDelivery(delivery *model.SubscriptionDelivery) error {
+	err := s.db.Create(delivery).Error
+	return checkErr(err)
+}
+
+// FindDeliveries returns most recent delivery attempts for subscription
+func (s SubscriptionsStore) FindDeliveries(subscriptionID types.ID, limit int64) ([]model.SubscriptionDelivery, error) {
+	var result []model.SubscriptionDelivery
+
+	err := s.db.
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&result).Error
+
+	return result, checkErr(err)
+}
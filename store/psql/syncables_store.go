@@ -190,5 +190,17 @@ func (s SyncablesStore) FindAllByLastInSessionOrEra(indexVersion int64, isLastIn
 		tx = tx.Where("height <= ?", end)
 	}
 
-	return result, checkErr(tx.Find(&result).Error)
+	return result, checkErr(tx.Order("height").Find(&result).Error)
+}
+
+// GetReportProgress returns count and last height of syncables processed for report
+func (s SyncablesStore) GetReportProgress(reportID types.ID) (*store.ReportProgressRow, error) {
+	var result store.ReportProgressRow
+
+	err := s.db.
+		Raw("SELECT COUNT(*) AS processed_count, COALESCE(MAX(height), 0) AS last_processed_height FROM syncables WHERE report_id = ? AND processed_at IS NOT NULL", reportID).
+		Scan(&result).
+		Error
+
+	return &result, checkErr(err)
 }
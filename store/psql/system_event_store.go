@@ -6,6 +6,7 @@ import (
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
 	"github.com/jinzhu/gorm"
 )
 
@@ -70,6 +71,100 @@ func (s SystemEventStore) FindByActor(actorAddress string, kind *model.SystemEve
 	return result, checkErr(err)
 }
 
+// FindByKinds returns first system events of given kinds ordered by id
+func (s SystemEventStore) FindByKinds(kinds []model.SystemEventKind, limit int64) ([]model.SystemEvent, error) {
+	var result []model.SystemEvent
+
+	err := s.db.
+		Where("kind IN (?)", kinds).
+		Order("id").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindForSubscription returns system events up to maxHeight ordered by height and id after last delivered one
+// which match subscription actors and kinds
+func (s SystemEventStore) FindForSubscription(subscription *model.Subscription, maxHeight int64, limit int64) ([]model.SystemEvent, error) {
+	var result []model.SystemEvent
+
+	statement := s.db.
+		Where("(height, id) > (?, ?)", subscription.LastSystemEventHeight, subscription.LastSystemEventID).
+		Where("height <= ?", maxHeight)
+
+	if len(subscription.Actors) > 0 {
+		statement = statement.Where("actor IN (?)", []string(subscription.Actors))
+	}
+	if len(subscription.Kinds) > 0 {
+		statement = statement.Where("kind IN (?)", []string(subscription.Kinds))
+	}
+
+	err := statement.
+		Order("height, id").
+		Limit(limit).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindForHeightRange returns system events between given heights (inclusive) for given actors and kinds, empty actors or kinds match all of them
+func (s SystemEventStore) FindForHeightRange(startHeight, endHeight int64, actors, kinds []string) ([]model.SystemEvent, error) {
+	var result []model.SystemEvent
+
+	statement := s.db.
+		Where("height >= ? AND height <= ?", startHeight, endHeight)
+
+	if len(actors) > 0 {
+		statement = statement.Where("actor IN (?)", actors)
+	}
+	if len(kinds) > 0 {
+		statement = statement.Where("kind IN (?)", kinds)
+	}
+
+	err := statement.
+		Order("height, id").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindLastID returns id of most recently created system event, it's 0 when there are no system events
+func (s SystemEventStore) FindLastID() (types.ID, error) {
+	var id types.ID
+
+	err := s.db.
+		Table("system_events").
+		Select("COALESCE(MAX(id), 0)").
+		Row().
+		Scan(&id)
+
+	return id, checkErr(err)
+}
+
+// ReplaceByIDs deletes system events with given ids and imports records in their place in one transaction,
+// so that either old or new events are delivered to subscribers but never both
+func (s SystemEventStore) ReplaceByIDs(ids []types.ID, records []model.SystemEvent) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if len(ids) > 0 {
+			err := tx.
+				Unscoped().
+				Where("id IN (?)", ids).
+				Delete(&model.SystemEvent{}).
+				Error
+			if err != nil {
+				return err
+			}
+		}
+		return NewSystemEventsStore(tx).BulkUpsert(records)
+	})
+
+	return checkErr(err)
+}
+
 func (s SystemEventStore) findUnique(height int64, address string, kind model.SystemEventKind) (*model.SystemEvent, error) {
 	q := model.SystemEvent{
 		Height: height,
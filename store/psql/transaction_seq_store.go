@@ -28,6 +28,7 @@ func (s TransactionSeqStore) BulkUpsert(records []model.TransactionSeq) error {
 			r.Hash,
 			r.Method,
 			r.Section,
+			r.Signer,
 		}
 	})
 }
@@ -52,3 +53,22 @@ func (s TransactionSeqStore) GetTransactionsByTransactionKind(kind model.Transac
 
 	return results, checkErr(err)
 }
+
+// FindForHeightRange returns transaction sequences between given heights (inclusive), empty signer matches all transactions
+func (s TransactionSeqStore) FindForHeightRange(startHeight, endHeight int64, signer string) ([]model.TransactionSeq, error) {
+	var result []model.TransactionSeq
+
+	tx := s.db.
+		Where("height >= ? AND height <= ?", startHeight, endHeight)
+
+	if signer != "" {
+		tx = tx.Where("signer = ?", signer)
+	}
+
+	err := tx.
+		Order("height, index").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
@@ -0,0 +1,109 @@
+package psql
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/jinzhu/gorm"
+)
+
+func NewTransactionSummaryStore(db *gorm.DB) *TransactionSummaryStore {
+	return &TransactionSummaryStore{scoped(db, model.TransactionSummary{})}
+}
+
+// TransactionSummaryStore handles operations on transaction summary
+type TransactionSummaryStore struct {
+	baseStore
+}
+
+// SummarizeTransactionSeqs summarizes transaction sequences starting with most recent time bucket, it returns number of saved summaries.
+// Older buckets with heights indexed since last run, ie. by backfill, are summarized again.
+// Existing summary is never replaced with one of lower count, so summaries outlive purged sequences
+func (s *TransactionSummaryStore) SummarizeTransactionSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error) {
+	defer logQueryDuration(time.Now(), "TransactionSummaryStore_SummarizeTransactionSeqs")
+
+	tx := s.db.
+		Exec(queries.TransactionSummarySummarize, interval, indexVersion, interval, interval.MaxBucketGap(), interval, interval, indexVersion)
+
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	return &tx.RowsAffected, nil
+}
+
+// FindTransactionSummaries returns transaction summaries of given index version by section and method for period preceding most recent time bucket,
+// empty section or method match all of them
+func (s *TransactionSummaryStore) FindTransactionSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.TransactionSummary, error) {
+	defer logQueryDuration(time.Now(), "TransactionSummaryStore_FindTransactionSummaries")
+
+	var result []model.TransactionSummary
+
+	tx := s.forPeriod(interval, period, indexVersion).
+		Where("NOT (section = '' AND method = '')")
+
+	if section != "" {
+		tx = tx.Where("section = ?", section)
+	}
+	if method != "" {
+		tx = tx.Where("method = ?", method)
+	}
+
+	err := tx.
+		Order("time_bucket, section, method").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// FindTransactionTotals returns transaction summaries of given index version which hold totals of all sections and methods
+// for period preceding most recent time bucket
+func (s *TransactionSummaryStore) FindTransactionTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.TransactionSummary, error) {
+	defer logQueryDuration(time.Now(), "TransactionSummaryStore_FindTransactionTotals")
+
+	var result []model.TransactionSummary
+
+	err := s.forPeriod(interval, period, indexVersion).
+		Where("section = '' AND method = ''").
+		Order("time_bucket").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
+func (s *TransactionSummaryStore) forPeriod(interval types.SummaryInterval, period string, indexVersion int64) *gorm.DB {
+	return s.db.
+		Where("time_interval = ? AND index_version = ?", interval, indexVersion).
+		Where("time_bucket >= (SELECT MAX(time_bucket) FROM transaction_summary WHERE time_interval = ? AND index_version = ?) - ?::INTERVAL", interval, indexVersion, period)
+}
+
+// FindMostRecentTransactionSummary finds most recent transaction summary for given time interval
+func (s *TransactionSummaryStore) FindMostRecentTransactionSummary(interval types.SummaryInterval) (*model.TransactionSummary, error) {
+	result := &model.TransactionSummary{}
+
+	err := s.db.
+		Where("time_interval = ?", interval).
+		Order("time_bucket DESC").
+		Take(result).
+		Error
+
+	return result, checkErr(err)
+}
+
+// DeleteTransactionSummariesOlderThan deletes transaction summary records older than given threshold
+func (s *TransactionSummaryStore) DeleteTransactionSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error) {
+	tx := s.db.
+		Unscoped().
+		Where("time_interval = ? AND time_bucket < ?", interval, purgeThreshold).
+		Delete(&model.TransactionSummary{})
+
+	if tx.Error != nil {
+		return nil, checkErr(tx.Error)
+	}
+
+	return &tx.RowsAffected, nil
+}
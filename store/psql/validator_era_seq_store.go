@@ -180,11 +180,11 @@ func (s *ValidatorEraSeqStore) SummarizeEraSeqs(interval types.SummaryInterval,
 			if isLast {
 				tx = tx.Or("time >= ?", activityPeriod.Max)
 			} else {
-				duration, err := interval.Duration()
+				nextBucket, err := interval.NextBucket(activityPeriod.Max.Time)
 				if err != nil {
 					return nil, err
 				}
-				tx = tx.Or("time >= ? AND time < ?", activityPeriod.Max.Add(*duration), activityPeriods[i+1].Min)
+				tx = tx.Or("time >= ? AND time < ?", nextBucket, activityPeriods[i+1].Min)
 			}
 		}
 	}
@@ -206,3 +206,46 @@ func (s *ValidatorEraSeqStore) SummarizeEraSeqs(interval types.SummaryInterval,
 	}
 	return models, nil
 }
+
+// SummarizeEraSeqsByEra gets the summarized version of validator era sequences for eras which started at or after given time
+func (s *ValidatorEraSeqStore) SummarizeEraSeqsByEra(since time.Time) ([]model.ValidatorEraSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "ValidatorEraSeqStore_SummarizeEraSeqsByEra")
+
+	rows, err := s.db.
+		Raw(queries.ValidatorEraSeqSummarizeByEra, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.ValidatorEraSeqSummary
+	for rows.Next() {
+		var summary model.ValidatorEraSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
+
+// FindEraSeqsForEraRange returns validator era sequences between given eras (inclusive), empty stashAccount matches all validators
+func (s ValidatorEraSeqStore) FindEraSeqsForEraRange(startEra, endEra int64, stashAccount string) ([]model.ValidatorEraSeq, error) {
+	var result []model.ValidatorEraSeq
+
+	tx := s.db.
+		Where("era >= ? AND era <= ?", startEra, endEra)
+
+	if stashAccount != "" {
+		tx = tx.Where("stash_account = ?", stashAccount)
+	}
+
+	err := tx.
+		Order("era, id").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
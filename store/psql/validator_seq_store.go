@@ -6,6 +6,7 @@ import (
 	"github.com/figment-networks/indexing-engine/store/bulk"
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
+	"github.com/figment-networks/polkadothub-indexer/types"
 	"github.com/jinzhu/gorm"
 )
 
@@ -67,9 +68,7 @@ func (s *ValidatorSeqStore) FindMostRecentSeq() (*model.ValidatorSeq, error) {
 
 // DeleteSeqsOlderThan deletes validator sequences older than given threshold
 func (s *ValidatorSeqStore) DeleteSeqsOlderThan(purgeThreshold time.Time) (*int64, error) {
-	tx := s.db.
-		Unscoped().
-		Where("time < ?", purgeThreshold).
+	tx := validatorSeqPurgeScope(s.db, purgeThreshold).
 		Delete(&model.ValidatorSeq{})
 
 	if tx.Error != nil {
@@ -78,3 +77,67 @@ func (s *ValidatorSeqStore) DeleteSeqsOlderThan(purgeThreshold time.Time) (*int6
 
 	return &tx.RowsAffected, nil
 }
+
+// ArchiveSeqsOlderThan passes at most limit validator sequences older than given threshold to archive and deletes
+// exactly them in the same transaction, nothing is deleted when archive fails
+func (s *ValidatorSeqStore) ArchiveSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var seqs []model.ValidatorSeq
+		err := validatorSeqPurgeScope(tx, purgeThreshold).
+			Set("gorm:query_option", "FOR UPDATE").
+			Order("height, id").
+			Limit(limit).
+			Find(&seqs).
+			Error
+		if err != nil || len(seqs) == 0 {
+			return err
+		}
+
+		if err := archive(seqs); err != nil {
+			return err
+		}
+
+		ids := make([]types.ID, len(seqs))
+		for i, seq := range seqs {
+			ids[i] = seq.ID
+		}
+
+		res := tx.Unscoped().Where("id IN (?)", ids).Delete(&model.ValidatorSeq{})
+		deletedCount = res.RowsAffected
+		return res.Error
+	})
+	if err != nil {
+		return nil, checkErr(err)
+	}
+
+	return &deletedCount, nil
+}
+
+// validatorSeqPurgeScope returns scope of validator sequences to purge, sequences within restored ranges are never purged
+func validatorSeqPurgeScope(db *gorm.DB, purgeThreshold time.Time) *gorm.DB {
+	return db.
+		Unscoped().
+		Where("time < ?", purgeThreshold).
+		Where(notRestored(model.ValidatorSeq{}.TableName(), "height"))
+}
+
+// FindSeqsForHeightRange returns validator sequences between given heights (inclusive), empty stashAccount matches all validators
+func (s ValidatorSeqStore) FindSeqsForHeightRange(startHeight, endHeight int64, stashAccount string) ([]model.ValidatorSeq, error) {
+	var result []model.ValidatorSeq
+
+	tx := s.db.
+		Where("height >= ? AND height <= ?", startHeight, endHeight)
+
+	if stashAccount != "" {
+		tx = tx.Where("stash_account = ?", stashAccount)
+	}
+
+	err := tx.
+		Order("height, id").
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
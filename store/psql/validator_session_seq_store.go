@@ -112,6 +112,18 @@ func (s ValidatorSessionSeqStore) FindBySession(session int64) ([]model.Validato
 	return result, checkErr(err)
 }
 
+// FindBySessionRange finds validator session sequences for sessions between start and end session inclusive
+func (s ValidatorSessionSeqStore) FindBySessionRange(startSession, endSession int64) ([]model.ValidatorSessionSeq, error) {
+	var result []model.ValidatorSessionSeq
+
+	err := s.db.
+		Where("session >= ? AND session <= ?", startSession, endSession).
+		Find(&result).
+		Error
+
+	return result, checkErr(err)
+}
+
 // FindLastSessionSeqByStashAccount finds last validator session sequences for given stash account
 func (s ValidatorSessionSeqStore) FindLastSessionSeqByStashAccount(stashAccount string, limit int64) ([]model.ValidatorSessionSeq, error) {
 	q := model.ValidatorSessionSeq{
@@ -140,9 +152,7 @@ func (s *ValidatorSessionSeqStore) FindMostRecentSessionSeq() (*model.ValidatorS
 
 // DeleteSessionSeqsOlderThan deletes validator sequence older than given threshold
 func (s *ValidatorSessionSeqStore) DeleteSessionSeqsOlderThan(purgeThreshold time.Time) (*int64, error) {
-	tx := s.db.
-		Unscoped().
-		Where("time < ?", purgeThreshold).
+	tx := validatorSessionSeqPurgeScope(s.db, purgeThreshold).
 		Delete(&model.ValidatorSessionSeq{})
 
 	if tx.Error != nil {
@@ -152,6 +162,52 @@ func (s *ValidatorSessionSeqStore) DeleteSessionSeqsOlderThan(purgeThreshold tim
 	return &tx.RowsAffected, nil
 }
 
+// ArchiveSessionSeqsOlderThan passes at most limit validator session sequences older than given threshold to archive
+// and deletes exactly them in the same transaction, nothing is deleted when archive fails
+func (s *ValidatorSessionSeqStore) ArchiveSessionSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSessionSeq) error) (*int64, error) {
+	var deletedCount int64
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var seqs []model.ValidatorSessionSeq
+		err := validatorSessionSeqPurgeScope(tx, purgeThreshold).
+			Set("gorm:query_option", "FOR UPDATE").
+			Order("start_height, id").
+			Limit(limit).
+			Find(&seqs).
+			Error
+		if err != nil || len(seqs) == 0 {
+			return err
+		}
+
+		if err := archive(seqs); err != nil {
+			return err
+		}
+
+		ids := make([]types.ID, len(seqs))
+		for i, seq := range seqs {
+			ids[i] = seq.ID
+		}
+
+		res := tx.Unscoped().Where("id IN (?)", ids).Delete(&model.ValidatorSessionSeq{})
+		deletedCount = res.RowsAffected
+		return res.Error
+	})
+	if err != nil {
+		return nil, checkErr(err)
+	}
+
+	return &deletedCount, nil
+}
+
+// validatorSessionSeqPurgeScope returns scope of validator session sequences to purge, sequences starting within
+// restored ranges are never purged
+func validatorSessionSeqPurgeScope(db *gorm.DB, purgeThreshold time.Time) *gorm.DB {
+	return db.
+		Unscoped().
+		Where("time < ?", purgeThreshold).
+		Where(notRestored(model.ValidatorSessionSeq{}.TableName(), "start_height"))
+}
+
 // SummarizeSessionSeqs gets the summarized version of validator sequences
 func (s *ValidatorSessionSeqStore) SummarizeSessionSeqs(interval types.SummaryInterval, activityPeriods []store.ActivityPeriodRow) ([]model.ValidatorSessionSeqSummary, error) {
 	defer logQueryDuration(time.Now(), "ValidatorSessionSeqStore_Summarize")
@@ -172,11 +228,11 @@ func (s *ValidatorSessionSeqStore) SummarizeSessionSeqs(interval types.SummaryIn
 			if isLast {
 				tx = tx.Or("time >= ?", activityPeriod.Max)
 			} else {
-				duration, err := interval.Duration()
+				nextBucket, err := interval.NextBucket(activityPeriod.Max.Time)
 				if err != nil {
 					return nil, err
 				}
-				tx = tx.Or("time >= ? AND time < ?", activityPeriod.Max.Add(*duration), activityPeriods[i+1].Min)
+				tx = tx.Or("time >= ? AND time < ?", nextBucket, activityPeriods[i+1].Min)
 			}
 		}
 	}
@@ -198,3 +254,27 @@ func (s *ValidatorSessionSeqStore) SummarizeSessionSeqs(interval types.SummaryIn
 	}
 	return models, nil
 }
+
+// SummarizeSessionSeqsByEra gets the summarized version of validator session sequences for eras which started at or after given time
+func (s *ValidatorSessionSeqStore) SummarizeSessionSeqsByEra(since time.Time) ([]model.ValidatorSessionSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "ValidatorSessionSeqStore_SummarizeSessionSeqsByEra")
+
+	rows, err := s.db.
+		Raw(queries.ValidatorSessionSeqSummarizeByEra, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.ValidatorSessionSeqSummary
+	for rows.Next() {
+		var summary model.ValidatorSessionSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
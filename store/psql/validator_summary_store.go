@@ -1,7 +1,6 @@
 package psql
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/store/psql/queries"
@@ -48,7 +47,7 @@ func (s *ValidatorSummaryStore) FindActivityPeriods(interval types.SummaryInterv
 	defer logQueryDuration(time.Now(), "ValidatorSummaryStore_FindActivityPeriods")
 
 	rows, err := s.db.
-		Raw(queries.ValidatorSummaryActivityPeriods, fmt.Sprintf("1%s", interval), interval, indexVersion).
+		Raw(queries.ValidatorSummaryActivityPeriods, interval.MaxBucketGap(), interval, indexVersion).
 		Rows()
 
 	if err != nil {
@@ -125,3 +124,52 @@ func (s *ValidatorSummaryStore) DeleteSummaryOlderThan(interval types.SummaryInt
 
 	return &statement.RowsAffected, nil
 }
+
+// SummarizeDailySessionSummaries rolls uptime of daily validator summaries with time bucket at or after given time up into buckets of given interval
+func (s *ValidatorSummaryStore) SummarizeDailySessionSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorSessionSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "ValidatorSummaryStore_SummarizeDailySessionSummaries")
+
+	rows, err := s.db.
+		Raw(queries.ValidatorSummarySummarizeDailySessions, interval, types.IntervalDaily, indexVersion, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.ValidatorSessionSeqSummary
+	for rows.Next() {
+		var summary model.ValidatorSessionSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
+
+// SummarizeDailyEraSummaries rolls stake, points and commission of daily validator summaries with time bucket at or after given time
+// up into buckets of given interval
+func (s *ValidatorSummaryStore) SummarizeDailyEraSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorEraSeqSummary, error) {
+	defer logQueryDuration(time.Now(), "ValidatorSummaryStore_SummarizeDailyEraSummaries")
+
+	rows, err := s.db.
+		Raw(queries.ValidatorSummarySummarizeDailyEras, interval, types.IntervalDaily, indexVersion, since).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []model.ValidatorEraSeqSummary
+	for rows.Next() {
+		var summary model.ValidatorEraSeqSummary
+		if err := s.db.ScanRows(rows, &summary); err != nil {
+			return nil, err
+		}
+
+		models = append(models, summary)
+	}
+	return models, nil
+}
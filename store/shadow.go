@@ -0,0 +1,30 @@
+package store
+
+// ShadowTable is table which backfill writes into shadow copy while live table keeps serving
+type ShadowTable struct {
+	Name string
+	// HeightColumn holds height row was indexed at, rows indexed into live table after backfill are carried over on swap
+	HeightColumn string
+	// KeyColumns identify rows updated in place across heights, i.e. validator aggregates; rows of tables without
+	// key columns are carried over by height
+	KeyColumns []string
+	// Seeded table is filled with live rows when shadow tables are created, i.e. syncables which track backfill progress.
+	// Other tables are created empty and filled by backfill
+	Seeded bool
+}
+
+// ShadowTableCheck compares rows of shadow table with live table up to backfill end height
+type ShadowTableCheck struct {
+	Table          string `json:"table"`
+	LiveCount      int64  `json:"live_count"`
+	ShadowCount    int64  `json:"shadow_count"`
+	MissingHeights int64  `json:"missing_heights"`
+}
+
+type Shadow interface {
+	CreateShadowTables(tables []ShadowTable) error
+	OpenShadowStore() (Store, error)
+	CheckShadowTables(tables []ShadowTable, endHeight int64) ([]ShadowTableCheck, error)
+	SwapShadowTables(tables []ShadowTable, endHeight int64) error
+	DropShadowTables() error
+}
@@ -1,10 +1,31 @@
 package store
 
 import (
+	"time"
+
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/types"
 )
 
+// Store gives access to all stores of a storage backend
+type Store interface {
+	Close() error
+	GetAccounts() Accounts
+	GetBlocks() Blocks
+	GetDatabase() Database
+	GetEvents() Events
+	GetJobs() Jobs
+	GetPrices() Prices
+	GetReports() Reports
+	GetRewards() Rewards
+	GetSubscriptions() Subscriptions
+	GetSyncables() Syncables
+	GetSystemEvents() SystemEvents
+	GetTransactions() Transactions
+	GetValidators() Validators
+	Test() error
+}
+
 type Accounts interface {
 	AccountEraSeq
 }
@@ -16,10 +37,36 @@ type Blocks interface {
 
 type Database interface {
 	GetTotalSize() (*GetTotalSizeResult, error)
+	GetMigrationVersion() (*GetMigrationVersionResult, error)
+	Ping() error
+	RestoredRanges
+	Shadow
+}
+
+// RestoredRanges keeps height ranges restored from purge archive, sequences within them are not purged again
+type RestoredRanges interface {
+	CreateRestoredRange(*model.RestoredRange) error
 }
 
 type Events interface {
 	EventSeq
+	EventSummary
+}
+
+type Jobs interface {
+	baseStore
+	FindByID(id types.ID) (*model.Job, error)
+	FindRecent(limit int64) ([]model.Job, error)
+	CreateUnique(job *model.Job, uniqueKey string) (bool, error)
+	Claim(workerID string, kinds []model.JobKind, lockTimeout time.Duration) (*model.Job, error)
+	Heartbeat(job *model.Job) (model.JobStatus, error)
+	Finish(job *model.Job) error
+	Cancel(id types.ID) (bool, error)
+}
+
+type Prices interface {
+	BulkUpsert(records []model.Price) error
+	FindForPeriod(currency string, start, end time.Time) ([]model.Price, error)
 }
 
 type Reports interface {
@@ -27,6 +74,8 @@ type Reports interface {
 	DeleteByKinds(kinds []model.ReportKind) error
 	FindNotCompletedByIndexVersion(indexVersion int64, kinds ...model.ReportKind) (*model.Report, error)
 	FindNotCompletedByKind(kinds ...model.ReportKind) (*model.Report, error)
+	FindLastCompleted(kinds ...model.ReportKind) (*model.Report, error)
+	FindRecent(limit int64) ([]model.Report, error)
 	Last() (*model.Report, error)
 }
 
@@ -37,23 +86,53 @@ type Rewards interface {
 	GetAllByTime(stash string, start, end types.Time) ([]model.RewardEraSeq, error)
 	GetCount(validatorStash string, era int64) (int64, error)
 	GetByStashAndEra(validatorStash, stash string, era int64) (model.RewardEraSeq, error)
+	FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.RewardEraSeq, error)
+	SumNominatorRewardsForEraRange(startEra, endEra int64) ([]ValidatorEraRewardRow, error)
+}
+
+// ValidatorEraRewardRow is sum of rewards paid to nominators of validator in era, validator own reward is not included
+type ValidatorEraRewardRow struct {
+	Era                   int64          `json:"era"`
+	ValidatorStashAccount string         `json:"validator_stash_account"`
+	Amount                types.Quantity `json:"amount"`
 }
 
 type Syncables interface {
 	syncables
 	FindMostRecenter
+	HeightUpdates
+	Outbox
 }
 
 type SystemEvents interface {
 	BulkUpsert(records []model.SystemEvent) error
 	FindByActor(actorAddress string, kind *model.SystemEventKind, minHeight *int64) ([]model.SystemEvent, error)
+	FindByKinds(kinds []model.SystemEventKind, limit int64) ([]model.SystemEvent, error)
+	FindForSubscription(subscription *model.Subscription, maxHeight int64, limit int64) ([]model.SystemEvent, error)
+	FindLastID() (types.ID, error)
+	FindForHeightRange(startHeight, endHeight int64, actors, kinds []string) ([]model.SystemEvent, error)
+	ReplaceByIDs(ids []types.ID, records []model.SystemEvent) error
+}
+
+type Subscriptions interface {
+	baseStore
+	FindByID(id types.ID) (*model.Subscription, error)
+	FindAll() ([]model.Subscription, error)
+	SaveSettings(subscription *model.Subscription) error
+	Delete(id types.ID) error
+	Claim(workerID string, lockTimeout time.Duration, dueBefore time.Time) (*model.Subscription, error)
+	Release(subscription *model.Subscription) error
+	CreateDelivery(delivery *model.SubscriptionDelivery) error
+	FindDeliveries(subscriptionID types.ID, limit int64) ([]model.SubscriptionDelivery, error)
 }
 
 type Transactions interface {
 	TransactionSeq
+	TransactionSummary
 }
 
 type Validators interface {
+	EraSummary
 	ValidatorAgg
 	ValidatorSeq
 	ValidatorEraSeq
@@ -65,6 +144,11 @@ type GetTotalSizeResult struct {
 	Size float64 `json:"size"`
 }
 
+type GetMigrationVersionResult struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+}
+
 type baseStore interface {
 	Create(record interface{}) error
 	Update(record interface{}) error
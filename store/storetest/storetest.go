@@ -0,0 +1,692 @@
+// Package storetest holds contract tests which every store backend has to pass
+package storetest
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/lib/pq"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Run runs contract tests of queries used by indexing pipeline and http handlers, newStore must return empty store
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.Store)
+	}{
+		{"Syncables", testSyncables},
+		{"HeightUpdates", testHeightUpdates},
+		{"BlockSeqs", testBlockSeqs},
+		{"BlockSeqsPurge", testBlockSeqsPurge},
+		{"ValidatorSeqs", testValidatorSeqs},
+		{"ValidatorSeqsPurge", testValidatorSeqsPurge},
+		{"ValidatorEraSeqs", testValidatorEraSeqs},
+		{"ValidatorSessionSeqs", testValidatorSessionSeqs},
+		{"Rewards", testRewards},
+		{"Prices", testPrices},
+		{"Jobs", testJobs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+func heightTime(height int64) types.Time {
+	return *types.NewTimeFromTime(start.Add(time.Duration(height) * time.Minute))
+}
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func checkNotFound(t *testing.T, err error) {
+	t.Helper()
+	if err != store.ErrNotFound {
+		t.Errorf("want error %v; got %v", store.ErrNotFound, err)
+	}
+}
+
+func checkHeights(t *testing.T, want []int64, got []int64) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want heights %v; got %v", want, got)
+	}
+}
+
+// testSyncables creates heights 1 to 6 in sessions of 2 heights and eras of 3 heights, heights 1 to 3 are indexed by version 1
+func testSyncables(t *testing.T, s store.Store) {
+	db := s.GetSyncables()
+
+	processedAt := heightTime(100)
+	for h := int64(1); h <= 6; h++ {
+		syncable := &model.Syncable{
+			Height:        h,
+			Time:          heightTime(h),
+			Session:       (h - 1) / 2,
+			Era:           (h - 1) / 3,
+			LastInSession: h%2 == 0,
+			LastInEra:     h%3 == 0,
+			IndexVersion:  2,
+			Status:        model.SyncableStatusCompleted,
+			ProcessedAt:   &processedAt,
+		}
+		if h <= 3 {
+			syncable.IndexVersion = 1
+		}
+		check(t, db.CreateOrUpdate(syncable))
+	}
+
+	t.Run("FindByHeight", func(t *testing.T) {
+		syncable, err := db.FindByHeight(4)
+		check(t, err)
+		if syncable.Height != 4 || syncable.Session != 1 || syncable.Era != 1 || !syncable.Time.Equal(heightTime(4)) {
+			t.Errorf("want syncable of height 4 in session 1 and era 1; got %+v", syncable)
+		}
+
+		_, err = db.FindByHeight(7)
+		checkNotFound(t, err)
+	})
+
+	t.Run("FindMostRecent", func(t *testing.T) {
+		syncable, err := db.FindMostRecent()
+		check(t, err)
+		if syncable.Height != 6 {
+			t.Errorf("want height 6; got %d", syncable.Height)
+		}
+	})
+
+	t.Run("FindLastInSessionAndEra", func(t *testing.T) {
+		for _, tt := range []struct {
+			description string
+			find        func() (*model.Syncable, error)
+			want        int64
+		}{
+			{"last in era", func() (*model.Syncable, error) { return db.FindLastInEra(0) }, 3},
+			{"last in session", func() (*model.Syncable, error) { return db.FindLastInSession(1) }, 4},
+			{"last end of era", db.FindLastEndOfEra, 6},
+			{"last end of session", db.FindLastEndOfSession, 6},
+			{"last in session for height", func() (*model.Syncable, error) { return db.FindLastInSessionForHeight(3) }, 4},
+		} {
+			syncable, err := tt.find()
+			check(t, err)
+			if syncable.Height != tt.want {
+				t.Errorf("%s: want height %d; got %d", tt.description, tt.want, syncable.Height)
+			}
+		}
+
+		_, err := db.FindLastInEra(5)
+		checkNotFound(t, err)
+	})
+
+	t.Run("FindByDifferentIndexVersion", func(t *testing.T) {
+		first, err := db.FindFirstByDifferentIndexVersion(2)
+		check(t, err)
+		last, err := db.FindMostRecentByDifferentIndexVersion(2)
+		check(t, err)
+		if first.Height != 1 || last.Height != 3 {
+			t.Errorf("want heights 1 and 3 of other index version; got %d and %d", first.Height, last.Height)
+		}
+
+		_, err = db.FindFirstByDifferentIndexVersion(0)
+		check(t, err)
+
+		smallest, err := db.FindSmallestIndexVersion()
+		check(t, err)
+		if *smallest != 1 {
+			t.Errorf("want smallest index version 1; got %d", *smallest)
+		}
+	})
+
+	t.Run("FindAllByLastInSessionOrEra", func(t *testing.T) {
+		syncables, err := db.FindAllByLastInSessionOrEra(0, true, false, 0, 0)
+		check(t, err)
+		checkHeights(t, []int64{2, 4, 6}, syncableHeights(syncables))
+
+		syncables, err = db.FindAllByLastInSessionOrEra(2, false, true, 0, 0)
+		check(t, err)
+		checkHeights(t, []int64{3}, syncableHeights(syncables))
+
+		syncables, err = db.FindAllByLastInSessionOrEra(0, true, false, 3, 5)
+		check(t, err)
+		checkHeights(t, []int64{4}, syncableHeights(syncables))
+	})
+
+	t.Run("ReportProgress", func(t *testing.T) {
+		reportID := types.ID(7)
+		check(t, db.SetProcessedAtForRange(reportID, 2, 4))
+
+		progress, err := db.GetReportProgress(reportID)
+		check(t, err)
+		if progress.ProcessedCount != 0 {
+			t.Errorf("want no heights processed for report; got %d", progress.ProcessedCount)
+		}
+
+		syncable, err := db.FindByHeight(3)
+		check(t, err)
+		if syncable.ProcessedAt != nil || syncable.ReportID != reportID {
+			t.Fatalf("want height 3 assigned to report and not processed; got %+v", syncable)
+		}
+
+		syncable.ProcessedAt = &processedAt
+		check(t, db.SaveSyncable(syncable))
+
+		progress, err = db.GetReportProgress(reportID)
+		check(t, err)
+		if progress.ProcessedCount != 1 || progress.LastProcessedHeight != 3 {
+			t.Errorf("want height 3 processed for report; got %+v", progress)
+		}
+	})
+}
+
+func syncableHeights(syncables []model.Syncable) []int64 {
+	var heights []int64
+	for _, s := range syncables {
+		heights = append(heights, s.Height)
+	}
+	return heights
+}
+
+func testHeightUpdates(t *testing.T, s store.Store) {
+	db := s.GetSyncables()
+
+	_, err := db.FindFirstHeightUpdate()
+	checkNotFound(t, err)
+	_, err = db.FindLastHeightUpdate()
+	checkNotFound(t, err)
+
+	for h := int64(1); h <= 5; h++ {
+		update, err := model.NewHeightUpdate(&model.Syncable{Height: h, Time: heightTime(h), Era: 1}, nil)
+		check(t, err)
+		check(t, db.PublishHeightUpdate(update))
+	}
+
+	first, err := db.FindFirstHeightUpdate()
+	check(t, err)
+	last, err := db.FindLastHeightUpdate()
+	check(t, err)
+	if first.Height != 1 || last.Height != 5 {
+		t.Errorf("want first height 1 and last height 5; got %d and %d", first.Height, last.Height)
+	}
+
+	updates, err := db.FindHeightUpdatesAfter(2, 2)
+	check(t, err)
+	var heights []int64
+	for _, u := range updates {
+		heights = append(heights, u.Height)
+	}
+	checkHeights(t, []int64{3, 4}, heights)
+
+	deleted, err := db.DeleteHeightUpdatesOlderThan(heightTime(3).Time)
+	check(t, err)
+	if *deleted != 2 {
+		t.Errorf("want 2 height updates deleted; got %d", *deleted)
+	}
+
+	first, err = db.FindFirstHeightUpdate()
+	check(t, err)
+	if first.Height != 3 {
+		t.Errorf("want first height 3 after purge; got %d", first.Height)
+	}
+}
+
+func createBlockSeqs(t *testing.T, db store.Blocks, startHeight, endHeight int64) {
+	for h := startHeight; h <= endHeight; h++ {
+		check(t, db.CreateSeq(&model.BlockSeq{
+			Sequence:        &model.Sequence{Height: h, Time: heightTime(h)},
+			ExtrinsicsCount: h,
+		}))
+	}
+}
+
+func blockSeqHeights(t *testing.T, db store.Blocks) []int64 {
+	seqs, err := db.FindSeqsForHeightRange(0, 1000)
+	check(t, err)
+
+	var heights []int64
+	for _, seq := range seqs {
+		heights = append(heights, seq.Height)
+	}
+	return heights
+}
+
+func testBlockSeqs(t *testing.T, s store.Store) {
+	db := s.GetBlocks()
+
+	_, err := db.FindMostRecentSeq()
+	checkNotFound(t, err)
+
+	createBlockSeqs(t, db, 1, 5)
+
+	seq, err := db.FindSeqByHeight(3)
+	check(t, err)
+	if seq.Height != 3 || seq.ExtrinsicsCount != 3 || !seq.Time.Equal(heightTime(3)) {
+		t.Errorf("want block sequence of height 3; got %+v", seq)
+	}
+
+	_, err = db.FindSeqByHeight(6)
+	checkNotFound(t, err)
+
+	seq, err = db.FindMostRecentSeq()
+	check(t, err)
+	if seq.Height != 5 {
+		t.Errorf("want most recent height 5; got %d", seq.Height)
+	}
+
+	check(t, db.CreateIfNotExists(&model.BlockSeq{Sequence: &model.Sequence{Height: 3, Time: heightTime(3)}, ExtrinsicsCount: 100}))
+	check(t, db.CreateIfNotExists(&model.BlockSeq{Sequence: &model.Sequence{Height: 6, Time: heightTime(6)}, ExtrinsicsCount: 6}))
+
+	seq, err = db.FindSeqByHeight(3)
+	check(t, err)
+	if seq.ExtrinsicsCount != 3 {
+		t.Errorf("want existing block sequence kept; got %d extrinsics", seq.ExtrinsicsCount)
+	}
+
+	seqs, err := db.FindSeqsForHeightRange(2, 4)
+	check(t, err)
+	var heights []int64
+	for _, seq := range seqs {
+		heights = append(heights, seq.Height)
+	}
+	checkHeights(t, []int64{2, 3, 4}, heights)
+}
+
+func testBlockSeqsPurge(t *testing.T, s store.Store) {
+	db := s.GetBlocks()
+	createBlockSeqs(t, db, 1, 10)
+
+	activityPeriods := []store.ActivityPeriodRow{{Period: 1, Min: heightTime(0), Max: heightTime(100)}}
+	check(t, s.GetDatabase().CreateRestoredRange(&model.RestoredRange{StartHeight: 3, EndHeight: 4}))
+
+	var archived []int64
+	deleted, err := db.ArchiveSeqsOlderThan(heightTime(8).Time, activityPeriods, 3, func(seqs []model.BlockSeq) error {
+		for _, seq := range seqs {
+			archived = append(archived, seq.Height)
+		}
+		return nil
+	})
+	check(t, err)
+	if *deleted != 3 {
+		t.Errorf("want 3 block sequences deleted; got %d", *deleted)
+	}
+	checkHeights(t, []int64{1, 2, 5}, archived)
+
+	deleted, err = db.DeleteSeqOlderThan(heightTime(8).Time, activityPeriods)
+	check(t, err)
+	if *deleted != 2 {
+		t.Errorf("want 2 block sequences deleted; got %d", *deleted)
+	}
+
+	checkHeights(t, []int64{3, 4, 8, 9, 10}, blockSeqHeights(t, db))
+
+	deleted, err = db.DeleteSeqOlderThan(heightTime(10).Time, nil)
+	check(t, err)
+	if *deleted != 0 {
+		t.Errorf("want nothing deleted without activity periods; got %d", *deleted)
+	}
+}
+
+func newValidatorSeq(height int64, stash string, balance int64) model.ValidatorSeq {
+	return model.ValidatorSeq{
+		Sequence:      &model.Sequence{Height: height, Time: heightTime(height)},
+		StashAccount:  stash,
+		ActiveBalance: types.NewQuantityFromInt64(balance),
+	}
+}
+
+func testValidatorSeqs(t *testing.T, s store.Store) {
+	db := s.GetValidators()
+
+	_, err := db.FindMostRecentSeq()
+	checkNotFound(t, err)
+
+	check(t, db.BulkUpsertSeqs([]model.ValidatorSeq{
+		newValidatorSeq(1, "stash1", 10),
+		newValidatorSeq(1, "stash2", 20),
+		newValidatorSeq(2, "stash1", 11),
+		newValidatorSeq(2, "stash2", 21),
+	}))
+	check(t, db.BulkUpsertSeqs([]model.ValidatorSeq{
+		newValidatorSeq(2, "stash2", 22),
+		newValidatorSeq(3, "stash1", 12),
+	}))
+
+	seqs, err := db.FindAllByHeight(2)
+	check(t, err)
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i].StashAccount < seqs[j].StashAccount })
+	if len(seqs) != 2 || seqs[0].ActiveBalance.String() != "11" || seqs[1].ActiveBalance.String() != "22" {
+		t.Errorf("want balances 11 and updated 22 at height 2; got %+v", seqs)
+	}
+
+	seqs, err = db.FindSeqsForHeightRange(2, 3, "stash1")
+	check(t, err)
+	if len(seqs) != 2 || seqs[0].Height != 2 || seqs[1].Height != 3 {
+		t.Errorf("want sequences of stash1 at heights 2 and 3; got %+v", seqs)
+	}
+
+	seqs, err = db.FindSeqsForHeightRange(1, 3, "")
+	check(t, err)
+	if len(seqs) != 5 {
+		t.Errorf("want 5 sequences of all validators; got %d", len(seqs))
+	}
+
+	seq, err := db.FindMostRecentSeq()
+	check(t, err)
+	if seq.Height != 3 {
+		t.Errorf("want most recent height 3; got %d", seq.Height)
+	}
+}
+
+func testValidatorSeqsPurge(t *testing.T, s store.Store) {
+	db := s.GetValidators()
+
+	var seqs []model.ValidatorSeq
+	var sessionSeqs []model.ValidatorSessionSeq
+	for h := int64(1); h <= 6; h++ {
+		seqs = append(seqs, newValidatorSeq(h, "stash1", h), newValidatorSeq(h, "stash2", h))
+		sessionSeqs = append(sessionSeqs, newValidatorSessionSeq(h, "stash1", true))
+	}
+	check(t, db.BulkUpsertSeqs(seqs))
+	check(t, db.BulkUpsertSessionSeqs(sessionSeqs))
+	check(t, s.GetDatabase().CreateRestoredRange(&model.RestoredRange{StartHeight: 2, EndHeight: 2}))
+
+	var archived int
+	deleted, err := db.ArchiveSeqsOlderThan(heightTime(5).Time, 3, func(seqs []model.ValidatorSeq) error {
+		archived += len(seqs)
+		return nil
+	})
+	check(t, err)
+	if *deleted != 3 || archived != 3 {
+		t.Errorf("want 3 validator sequences archived and deleted; got %d and %d", archived, *deleted)
+	}
+
+	deleted, err = db.DeleteSeqsOlderThan(heightTime(5).Time)
+	check(t, err)
+	if *deleted != 3 {
+		t.Errorf("want 3 validator sequences deleted; got %d", *deleted)
+	}
+
+	left, err := db.FindSeqsForHeightRange(0, 10, "stash1")
+	check(t, err)
+	var heights []int64
+	for _, seq := range left {
+		heights = append(heights, seq.Height)
+	}
+	checkHeights(t, []int64{2, 5, 6}, heights)
+
+	deleted, err = db.ArchiveSessionSeqsOlderThan(heightTime(5).Time, 10, func(seqs []model.ValidatorSessionSeq) error {
+		return nil
+	})
+	check(t, err)
+	if *deleted != 3 {
+		t.Errorf("want 3 validator session sequences deleted; got %d", *deleted)
+	}
+
+	_, err = db.FindBySessionAndStashAccount(2, "stash1")
+	check(t, err)
+}
+
+func newValidatorSessionSeq(session int64, stash string, online bool) model.ValidatorSessionSeq {
+	return model.ValidatorSessionSeq{
+		SessionSequence: &model.SessionSequence{
+			Session:     session,
+			StartHeight: session,
+			EndHeight:   session,
+			Time:        heightTime(session),
+		},
+		StashAccount: stash,
+		Online:       online,
+	}
+}
+
+func testValidatorSessionSeqs(t *testing.T, s store.Store) {
+	db := s.GetValidators()
+
+	check(t, db.BulkUpsertSessionSeqs([]model.ValidatorSessionSeq{
+		newValidatorSessionSeq(1, "stash1", true),
+		newValidatorSessionSeq(1, "stash2", true),
+		newValidatorSessionSeq(2, "stash1", true),
+	}))
+	check(t, db.BulkUpsertSessionSeqs([]model.ValidatorSessionSeq{
+		newValidatorSessionSeq(1, "stash2", false),
+	}))
+
+	seq, err := db.FindBySessionAndStashAccount(1, "stash2")
+	check(t, err)
+	if seq.Online {
+		t.Errorf("want updated session sequence offline; got %+v", seq)
+	}
+
+	seqs, err := db.FindSessionSeqsByHeight(2)
+	check(t, err)
+	if len(seqs) != 1 || seqs[0].Session != 2 {
+		t.Errorf("want session 2 of stash1 at height 2; got %+v", seqs)
+	}
+
+	_, err = db.FindBySessionAndStashAccount(2, "stash2")
+	checkNotFound(t, err)
+
+	seqs, err = db.FindBySession(1)
+	check(t, err)
+	if len(seqs) != 2 {
+		t.Errorf("want 2 session sequences in session 1; got %d", len(seqs))
+	}
+
+	seqs, err = db.FindLastSessionSeqByStashAccount("stash1", 1)
+	check(t, err)
+	if len(seqs) != 1 || seqs[0].Session != 2 {
+		t.Errorf("want last session 2 of stash1; got %+v", seqs)
+	}
+}
+
+func newValidatorEraSeq(era int64, stash string, totalStake int64) model.ValidatorEraSeq {
+	return model.ValidatorEraSeq{
+		EraSequence: &model.EraSequence{
+			Era:         era,
+			StartHeight: era*10 + 1,
+			EndHeight:   era*10 + 10,
+			Time:        heightTime(era*10 + 1),
+		},
+		StashAccount:      stash,
+		ControllerAccount: "controller",
+		SessionAccounts:   pq.StringArray{"session"},
+		TotalStake:        types.NewQuantityFromInt64(totalStake),
+		OwnStake:          types.NewQuantityFromInt64(1),
+		StakersStake:      types.NewQuantityFromInt64(totalStake - 1),
+		RewardPoints:      10,
+		Commission:        100,
+		StakersCount:      2,
+	}
+}
+
+func testValidatorEraSeqs(t *testing.T, s store.Store) {
+	db := s.GetValidators()
+
+	check(t, db.BulkUpsertEraSeqs([]model.ValidatorEraSeq{
+		newValidatorEraSeq(1, "stash1", 100),
+		newValidatorEraSeq(1, "stash2", 200),
+		newValidatorEraSeq(2, "stash1", 110),
+	}))
+	check(t, db.BulkUpsertEraSeqs([]model.ValidatorEraSeq{
+		newValidatorEraSeq(2, "stash1", 120),
+		newValidatorEraSeq(3, "stash1", 130),
+	}))
+
+	seq, err := db.FindByEraAndStashAccount(2, "stash1")
+	check(t, err)
+	if seq.TotalStake.String() != "120" || seq.StakersCount != 2 || !reflect.DeepEqual([]string(seq.SessionAccounts), []string{"session"}) {
+		t.Errorf("want updated era sequence with total stake 120; got %+v", seq)
+	}
+
+	_, err = db.FindByEraAndStashAccount(3, "stash2")
+	checkNotFound(t, err)
+
+	seqs, err := db.FindEraSeqsForEraRange(1, 2, "")
+	check(t, err)
+	if len(seqs) != 3 || seqs[0].Era != 1 || seqs[2].Era != 2 {
+		t.Errorf("want 3 era sequences ordered by era; got %+v", seqs)
+	}
+
+	seqs, err = db.FindLastEraSeqByStashAccount("stash1", 2)
+	check(t, err)
+	if len(seqs) != 2 || seqs[0].Era != 3 || seqs[1].Era != 2 {
+		t.Errorf("want eras 3 and 2 of stash1; got %+v", seqs)
+	}
+
+	seqs, err = db.FindEraSeqsByHeight(15)
+	check(t, err)
+	if len(seqs) != 2 {
+		t.Errorf("want 2 era sequences at height 15; got %d", len(seqs))
+	}
+}
+
+func newReward(era int64, stash, validatorStash string, kind model.RewardKind) model.RewardEraSeq {
+	return model.RewardEraSeq{
+		EraSequence:           &model.EraSequence{Era: era, StartHeight: era*10 + 1, EndHeight: era*10 + 10, Time: heightTime(era*10 + 1)},
+		StashAccount:          stash,
+		ValidatorStashAccount: validatorStash,
+		Amount:                "100",
+		Kind:                  kind,
+	}
+}
+
+func testRewards(t *testing.T, s store.Store) {
+	db := s.GetRewards()
+
+	check(t, db.BulkUpsert([]model.RewardEraSeq{
+		newReward(1, "validator", "validator", model.RewardCommission),
+		newReward(1, "nominator", "validator", model.RewardReward),
+		newReward(2, "nominator", "validator", model.RewardReward),
+		newReward(2, "nominator", "other", model.RewardReward),
+	}))
+	// existing rewards are kept
+	duplicate := newReward(1, "nominator", "validator", model.RewardReward)
+	duplicate.Amount = "1"
+	check(t, db.BulkUpsert([]model.RewardEraSeq{duplicate}))
+
+	reward, err := db.GetByStashAndEra("validator", "nominator", 1)
+	check(t, err)
+	if reward.Amount != "100" || reward.Claimed {
+		t.Errorf("want unclaimed reward of 100; got %+v", reward)
+	}
+
+	_, err = db.GetByStashAndEra("validator", "nominator", 3)
+	checkNotFound(t, err)
+
+	count, err := db.GetCount("validator", 1)
+	check(t, err)
+	if count != 2 {
+		t.Errorf("want 2 rewards of validator in era 1; got %d", count)
+	}
+
+	check(t, db.MarkAllClaimed("validator", 1, "0xhash"))
+	reward, err = db.GetByStashAndEra("validator", "validator", 1)
+	check(t, err)
+	if !reward.Claimed || reward.TxHash != "0xhash" {
+		t.Errorf("want reward claimed by 0xhash; got %+v", reward)
+	}
+	if err := db.MarkAllClaimed("validator", 5, "0xhash"); err == nil {
+		t.Error("want error when no reward is claimed")
+	}
+
+	rewards, err := db.GetAll("nominator", "validator", 0, 0)
+	check(t, err)
+	if len(rewards) != 2 || rewards[0].Era != 1 || rewards[1].Era != 2 {
+		t.Errorf("want rewards of nominator from validator in eras 1 and 2; got %+v", rewards)
+	}
+
+	rewards, err = db.FindForEraRange(1, 2, "validator")
+	check(t, err)
+	if len(rewards) != 3 {
+		t.Errorf("want 3 rewards matching validator stash; got %d", len(rewards))
+	}
+
+	rewards, err = db.FindForEraRange(2, 2, "")
+	check(t, err)
+	if len(rewards) != 2 {
+		t.Errorf("want 2 rewards in era 2; got %d", len(rewards))
+	}
+}
+
+func testPrices(t *testing.T, s store.Store) {
+	db := s.GetPrices()
+
+	day := func(d int) types.Time {
+		return *types.NewTimeFromTime(start.AddDate(0, 0, d))
+	}
+
+	check(t, db.BulkUpsert([]model.Price{
+		{Date: day(0), Currency: "USD", Price: "4.1"},
+		{Date: day(1), Currency: "USD", Price: "4.2"},
+		{Date: day(2), Currency: "USD", Price: "4.3"},
+		{Date: day(1), Currency: "EUR", Price: "3.5"},
+	}))
+	check(t, db.BulkUpsert([]model.Price{
+		{Date: day(1), Currency: "USD", Price: "5.2"},
+	}))
+
+	prices, err := db.FindForPeriod("USD", start.AddDate(0, 0, 1).Add(12*time.Hour), start.AddDate(0, 0, 2).Add(time.Hour))
+	check(t, err)
+
+	var got []string
+	for _, p := range prices {
+		got = append(got, p.Date.UTC().Format(model.PriceDateFormat)+"="+p.Price)
+	}
+	want := []string{"2020-01-02=5.2", "2020-01-03=4.3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want prices %v; got %v", want, got)
+	}
+}
+
+func testJobs(t *testing.T, s store.Store) {
+	db := s.GetJobs()
+
+	for _, kind := range []model.JobKind{model.JobKindBackfill, model.JobKindIndex} {
+		job, err := model.NewJob(kind, model.JobParams{}, 3)
+		check(t, err)
+		job.RunAt = *types.NewTimeFromTime(time.Now().Add(-time.Minute))
+
+		created, err := db.CreateUnique(job, kind.String())
+		check(t, err)
+		if !created {
+			t.Fatalf("want %s job to be created", kind)
+		}
+	}
+
+	adminKinds := []model.JobKind{model.JobKindBackfill, model.JobKindReindex}
+	backfill, err := db.Claim("worker", adminKinds, time.Hour)
+	check(t, err)
+	if backfill.Kind != model.JobKindBackfill || backfill.Status != model.JobStatusRunning {
+		t.Fatalf("want running backfill job; got %s job %s", backfill.Kind, backfill.Status)
+	}
+
+	_, err = db.Claim("worker", adminKinds, time.Hour)
+	checkNotFound(t, err)
+
+	index, err := db.Claim("worker", []model.JobKind{model.JobKindIndex}, time.Hour)
+	check(t, err)
+	if index.Kind != model.JobKindIndex || index.Status != model.JobStatusRunning {
+		t.Errorf("want index job to be claimed while backfill is running; got %s job %s", index.Kind, index.Status)
+	}
+
+	_, err = db.Claim("worker", []model.JobKind{model.JobKindSummarize}, time.Hour)
+	checkNotFound(t, err)
+
+	status, err := db.Heartbeat(backfill)
+	check(t, err)
+	if status != model.JobStatusRunning {
+		t.Errorf("want backfill job to be still running; got %s", status)
+	}
+}
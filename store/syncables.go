@@ -1,6 +1,8 @@
 package store
 
 import (
+	"time"
+
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/types"
 )
@@ -19,8 +21,29 @@ type syncables interface {
 	SaveSyncable(*model.Syncable) error
 	SetProcessedAtForRange(reportID types.ID, startHeight int64, endHeight int64) error
 	FindAllByLastInSessionOrEra(indexVersion int64, isLastInSession, isLastInEra bool, start, end int64) ([]model.Syncable, error)
+	GetReportProgress(reportID types.ID) (*ReportProgressRow, error)
+}
+
+type HeightUpdates interface {
+	PublishHeightUpdate(update *model.HeightUpdate) error
+	FindHeightUpdatesAfter(height int64, limit int64) ([]model.HeightUpdate, error)
+	FindFirstHeightUpdate() (*model.HeightUpdate, error)
+	FindLastHeightUpdate() (*model.HeightUpdate, error)
+	DeleteHeightUpdatesOlderThan(purgeThreshold time.Time) (*int64, error)
+}
+
+type Outbox interface {
+	SaveSyncableWithMessage(syncable *model.Syncable, message *model.OutboxMessage) error
+	FindOutboxMessages(limit int64) ([]model.OutboxMessage, error)
+	DeleteOutboxMessages(ids []types.ID) error
+	MarkOutboxMessagesFailed(ids []types.ID, lastError string) error
 }
 
 type FindMostRecenter interface {
 	FindMostRecent() (*model.Syncable, error)
 }
+
+type ReportProgressRow struct {
+	ProcessedCount      int64
+	LastProcessedHeight int64
+}
@@ -1,10 +1,22 @@
 package store
 
 import (
+	"time"
+
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
 )
 
 type TransactionSeq interface {
 	BulkUpsert(records []model.TransactionSeq) error
 	GetTransactionsByTransactionKind(kind model.TransactionKind, start, end int64) ([]model.TransactionSeq, error)
+	FindForHeightRange(startHeight, endHeight int64, signer string) ([]model.TransactionSeq, error)
+}
+
+type TransactionSummary interface {
+	SummarizeTransactionSeqs(interval types.SummaryInterval, indexVersion int64) (*int64, error)
+	FindTransactionSummaries(interval types.SummaryInterval, period string, indexVersion int64, section, method string) ([]model.TransactionSummary, error)
+	FindTransactionTotals(interval types.SummaryInterval, period string, indexVersion int64) ([]model.TransactionSummary, error)
+	FindMostRecentTransactionSummary(interval types.SummaryInterval) (*model.TransactionSummary, error)
+	DeleteTransactionSummariesOlderThan(interval types.SummaryInterval, purgeThreshold time.Time) (*int64, error)
 }
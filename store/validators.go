@@ -19,7 +19,9 @@ type ValidatorAgg interface {
 type ValidatorSeq interface {
 	BulkUpsertSeqs(records []model.ValidatorSeq) error
 	DeleteSeqsOlderThan(purgeThreshold time.Time) (*int64, error)
+	ArchiveSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSeq) error) (*int64, error)
 	FindAllByHeight(height int64) ([]model.ValidatorSeq, error)
+	FindSeqsForHeightRange(startHeight, endHeight int64, stashAccount string) ([]model.ValidatorSeq, error)
 	FindMostRecentSeq() (*model.ValidatorSeq, error)
 }
 
@@ -29,20 +31,38 @@ type ValidatorEraSeq interface {
 	FindByEraAndStashAccount(era int64, stash string) (*model.ValidatorEraSeq, error)
 	FindEraSeqsByHeight(h int64) ([]model.ValidatorEraSeq, error)
 	FindByEra(era int64) ([]model.ValidatorEraSeq, error)
+	FindEraSeqsForEraRange(startEra, endEra int64, stashAccount string) ([]model.ValidatorEraSeq, error)
 	FindMostRecentEraSeq() (*model.ValidatorEraSeq, error)
 	FindLastEraSeqByStashAccount(stashAccount string, limit int64) ([]model.ValidatorEraSeq, error)
 	SummarizeEraSeqs(interval types.SummaryInterval, activityPeriods []ActivityPeriodRow) ([]model.ValidatorEraSeqSummary, error)
+	SummarizeEraSeqsByEra(since time.Time) ([]model.ValidatorEraSeqSummary, error)
+}
+
+type EraSummary interface {
+	UpsertEraSummary(record *model.EraSummary) error
+	FindEraSummaries(limit int64) ([]EraSummaryRow, error)
+	FindEraSummaryByEra(era int64) (*EraSummaryRow, error)
+}
+
+// EraSummaryRow is era summary with time of first height in era, it's nil when syncable of that height is missing
+type EraSummaryRow struct {
+	model.EraSummary
+
+	StartTime *types.Time `json:"start_time"`
 }
 
 type ValidatorSessionSeq interface {
 	BulkUpsertSessionSeqs(records []model.ValidatorSessionSeq) error
 	DeleteSessionSeqsOlderThan(purgeThreshold time.Time) (*int64, error)
+	ArchiveSessionSeqsOlderThan(purgeThreshold time.Time, limit int64, archive func([]model.ValidatorSessionSeq) error) (*int64, error)
 	FindSessionSeqsByHeight(h int64) ([]model.ValidatorSessionSeq, error)
 	FindBySession(h int64) ([]model.ValidatorSessionSeq, error)
 	FindBySessionAndStashAccount(session int64, stash string) (*model.ValidatorSessionSeq, error)
+	FindBySessionRange(startSession, endSession int64) ([]model.ValidatorSessionSeq, error)
 	FindLastSessionSeqByStashAccount(stashAccount string, limit int64) ([]model.ValidatorSessionSeq, error)
 	FindMostRecentSessionSeq() (*model.ValidatorSessionSeq, error)
 	SummarizeSessionSeqs(interval types.SummaryInterval, activityPeriods []ActivityPeriodRow) ([]model.ValidatorSessionSeqSummary, error)
+	SummarizeSessionSeqsByEra(since time.Time) ([]model.ValidatorSessionSeqSummary, error)
 }
 
 type ValidatorSummary interface {
@@ -55,6 +75,8 @@ type ValidatorSummary interface {
 	FindSummaries(interval types.SummaryInterval, period string) ([]ValidatorSummaryRow, error)
 	FindSummaryByStashAccount(stashAccount string, interval types.SummaryInterval, period string) ([]ValidatorSummaryRow, error)
 	SaveSummary(*model.ValidatorSummary) error
+	SummarizeDailySessionSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorSessionSeqSummary, error)
+	SummarizeDailyEraSummaries(interval types.SummaryInterval, since time.Time, indexVersion int64) ([]model.ValidatorEraSeqSummary, error)
 }
 
 type ValidatorSummaryRow struct {
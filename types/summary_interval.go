@@ -7,33 +7,71 @@ import (
 )
 
 const (
-	IntervalHourly SummaryInterval = "hour"
-	IntervalDaily  SummaryInterval = "day"
+	IntervalHourly  SummaryInterval = "hour"
+	IntervalDaily   SummaryInterval = "day"
+	IntervalWeekly  SummaryInterval = "week"
+	IntervalMonthly SummaryInterval = "month"
+	// IntervalEra buckets are aligned to staking eras, time bucket is time of first block of era
+	IntervalEra SummaryInterval = "era"
 )
 
 // SummaryInterval type represents summary interval
 type SummaryInterval string
 
 func (s SummaryInterval) Valid() bool {
-	return s == IntervalHourly || s == IntervalDaily
+	return s == IntervalHourly || s == IntervalDaily || s == IntervalWeekly || s == IntervalMonthly || s == IntervalEra
 }
 
 func (s SummaryInterval) Equal(o SummaryInterval) bool {
 	return s == o
 }
 
+// IsEra returns true if buckets are aligned to eras instead of wall-clock time
+func (s SummaryInterval) IsEra() bool {
+	return s == IntervalEra
+}
+
+// IsRollup returns true if summaries are built from daily summaries instead of sequences
+func (s SummaryInterval) IsRollup() bool {
+	return s == IntervalWeekly || s == IntervalMonthly
+}
+
 func (s SummaryInterval) Duration() (*time.Duration, error) {
 	var durationInterval string
 	if s == IntervalHourly {
 		durationInterval = "1h"
 	} else if s == IntervalDaily {
 		durationInterval = "24h"
+	} else if s == IntervalWeekly {
+		durationInterval = "168h"
 	} else {
-		return nil, errors.New(fmt.Sprintf("unknown summary interval %s", s))
+		return nil, errors.New(fmt.Sprintf("summary interval %s has no fixed duration", s))
 	}
 	duration, err := time.ParseDuration(durationInterval)
 	if err != nil {
 		return nil, err
 	}
 	return &duration, nil
-}
\ No newline at end of file
+}
+
+// NextBucket returns start of time bucket following the one starting at given time
+func (s SummaryInterval) NextBucket(t time.Time) (time.Time, error) {
+	if s == IntervalMonthly {
+		return t.AddDate(0, 1, 0), nil
+	}
+
+	duration, err := s.Duration()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(*duration), nil
+}
+
+// MaxBucketGap returns Postgres interval which consecutive time buckets are at most apart
+func (s SummaryInterval) MaxBucketGap() string {
+	if s == IntervalMonthly {
+		// months differ in length
+		return "31 days"
+	}
+	return fmt.Sprintf("1 %s", s)
+}
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"errors"
 
 	"github.com/figment-networks/polkadothub-indexer/client"
@@ -21,7 +22,7 @@ func NewGetByHeightUseCase(c *client.Client, syncablesDb store.FindMostRecenter)
 	}
 }
 
-func (uc *getByHeightUseCase) Execute(address string, height *int64) (*HeightDetailsView, error) {
+func (uc *getByHeightUseCase) Execute(ctx context.Context, address string, height *int64) (*HeightDetailsView, error) {
 	// Get last indexed height
 	mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
 	if err != nil {
@@ -38,7 +39,7 @@ func (uc *getByHeightUseCase) Execute(address string, height *int64) (*HeightDet
 		return nil, errors.New("height is not indexed yet")
 	}
 
-	res, err := uc.client.Account.GetByHeight(address, *height)
+	res, err := uc.client.Account.GetByHeight(ctx, address, *height)
 	if err != nil {
 		return nil, err
 	}
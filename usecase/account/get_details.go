@@ -1,6 +1,8 @@
 package account
 
 import (
+	"context"
+
 	"github.com/figment-networks/polkadothub-indexer/client"
 	"github.com/figment-networks/polkadothub-indexer/store"
 )
@@ -23,7 +25,7 @@ func NewGetDetailsUseCase(c *client.Client, accountEraSeqDb store.AccountEraSeq,
 	}
 }
 
-func (uc *getDetailsUseCase) Execute(address string) (DetailsView, error) {
+func (uc *getDetailsUseCase) Execute(ctx context.Context, address string) (DetailsView, error) {
 	// Get last indexed height
 	mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
 	if err != nil {
@@ -31,12 +33,12 @@ func (uc *getDetailsUseCase) Execute(address string) (DetailsView, error) {
 	}
 	lastH := mostRecentSynced.Height
 
-	account, err := uc.client.Account.GetByHeight(address, lastH)
+	account, err := uc.client.Account.GetByHeight(ctx, address, lastH)
 	if err != nil {
 		return DetailsView{}, err
 	}
 
-	identity, err := uc.client.Account.GetIdentity(address)
+	identity, err := uc.client.Account.GetIdentity(ctx, address)
 	if err != nil {
 		return DetailsView{}, err
 	}
@@ -53,7 +53,7 @@ func (h *getDetailsHttpHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	ds, err := h.getUseCase().Execute(req.StashAccount)
+	ds, err := h.getUseCase().Execute(c.Request.Context(), req.StashAccount)
 	if err != nil {
 		logger.Error(err)
 		http.ServerError(c, err)
@@ -1,6 +1,7 @@
 package apr
 
 import (
+	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
 )
@@ -19,7 +20,7 @@ func NewGetAprByAddressUseCase(accountDb store.AccountEraSeq, rewardDb store.Rew
 	}
 }
 
-func (uc *getAprByAddressUseCase) Execute(stash string, start, end types.Time) (res []DailyApr, err error) {
+func (uc *getAprByAddressUseCase) Execute(stash string, start, end types.Time, destination RewardDestination, aggregate bool) (res []DailyApr, err error) {
 	mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
 	if err != nil {
 		return res, err
@@ -38,5 +39,15 @@ func (uc *getAprByAddressUseCase) Execute(stash string, start, end types.Time) (
 		return res, err
 	}
 
-	return toAPRView(summaries, accountSeqs)
+	eraSeqs := make([]*model.EraSequence, 0, len(summaries))
+	for _, summary := range summaries {
+		eraSeqs = append(eraSeqs, summary.EraSequence)
+	}
+
+	eraDurations, err := EraDurations(uc.syncablesDb, eraSeqs)
+	if err != nil {
+		return res, err
+	}
+
+	return toAPRView(summaries, accountSeqs, eraDurations, destination, aggregate)
 }
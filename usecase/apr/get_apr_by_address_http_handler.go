@@ -34,6 +34,10 @@ type queryParams struct {
 	Account string    `form:"account" binding:"required"`
 	Start   time.Time `form:"start" binding:"required" time_format:"2006-01-02"`
 	End     time.Time `form:"end" binding:"-" time_format:"2006-01-02"`
+	// Reward destination of account, rewards aren't compounded when it's missing. Payee isn't indexed, so it's taken from caller
+	RewardDestination RewardDestination `form:"reward_destination" binding:"-"`
+	// Aggregate rewards of all validators in era weighted by stake
+	Aggregate bool `form:"aggregate" binding:"-"`
 }
 
 func (h *getAprByAddressHttpHandler) Handle(c *gin.Context) {
@@ -43,7 +47,14 @@ func (h *getAprByAddressHttpHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.getUseCase().Execute(params.Account, *types.NewTimeFromTime(params.Start), *types.NewTimeFromTime(params.End))
+	if params.RewardDestination == "" {
+		params.RewardDestination = RewardDestinationUnknown
+	} else if !params.RewardDestination.Valid() {
+		http.BadRequest(c, errors.New("reward_destination must be one of: staked, stash, controller, account"))
+		return
+	}
+
+	resp, err := h.getUseCase().Execute(params.Account, *types.NewTimeFromTime(params.Start), *types.NewTimeFromTime(params.End), params.RewardDestination, params.Aggregate)
 	if http.ShouldReturn(c, err) {
 		return
 	}
@@ -1,67 +1,270 @@
 package apr
 
 import (
+	"math"
 	"math/big"
+	"time"
 
 	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
 )
 
 var (
-	daysInYear   = big.NewFloat(365)
+	year         = 365 * 24 * time.Hour
 	decPrecision = 4
 )
 
+// RewardDestination is where rewards of account are paid, only rewards paid as stake compound.
+// Payee of account isn't exposed by proxy, so destination is supplied by caller and returned with APRs.
+// When caller doesn't supply it, destination is unknown and rewards are not compounded
+type RewardDestination string
+
+const (
+	RewardDestinationUnknown    RewardDestination = "unknown"
+	RewardDestinationStaked     RewardDestination = "staked"
+	RewardDestinationStash      RewardDestination = "stash"
+	RewardDestinationController RewardDestination = "controller"
+	RewardDestinationAccount    RewardDestination = "account"
+)
+
+func (d RewardDestination) Valid() bool {
+	switch d {
+	case RewardDestinationStaked, RewardDestinationStash, RewardDestinationController, RewardDestinationAccount:
+		return true
+	}
+	return false
+}
+
+// Compounds reports whether rewards paid to destination are added to stake
+func (d RewardDestination) Compounds() bool {
+	return d == RewardDestinationStaked
+}
+
 type DailyApr struct {
 	TimeBucket   types.Time     `json:"time_bucket"`
 	Era          int64          `json:"era"`
 	Bonded       types.Quantity `json:"bonded"`
 	TotalRewards types.Quantity `json:"total_rewards"`
 	APR          string         `json:"apr"`
-	Validator    string         `json:"validator"`
+	APY          string         `json:"apy"`
+	// RewardDestination is destination APY was computed for
+	RewardDestination RewardDestination `json:"reward_destination"`
+	Validator         string            `json:"validator"`
+	Validators        []string          `json:"validators,omitempty"`
 }
 
-func dailyAPR(rewardSeq model.RewardEraSeq, stake types.Quantity) (DailyApr, error) {
-	reward, err := types.NewQuantityFromString(rewardSeq.Amount)
-	if err != nil {
-		return DailyApr{}, err
+// annualize multiplies reward ratio of era by number of eras in year, APY compounds ratio every era when rewards compound
+func annualize(ratio *big.Float, eraDuration time.Duration, compounds bool) (apr *big.Float, apy *big.Float) {
+	erasInYear := new(big.Float).Quo(big.NewFloat(float64(year)), big.NewFloat(float64(eraDuration)))
+	apr = new(big.Float).Mul(ratio, erasInYear)
+	if !compounds {
+		return apr, apr
 	}
 
+	r, _ := ratio.Float64()
+	n, _ := erasInYear.Float64()
+	return apr, big.NewFloat(math.Expm1(n * math.Log1p(r)))
+}
+
+func eraAPR(eraSeq *model.EraSequence, reward, stake types.Quantity, eraDuration time.Duration, destination RewardDestination) DailyApr {
 	r := reward.Clone()
 	rValue := new(big.Float).SetInt(&r.Int)
 	b := stake.Clone()
 	bValue := new(big.Float).SetInt(&b.Int)
-	apr := new(big.Float).Quo(rValue, bValue)
-	apr = apr.Mul(apr, daysInYear)
+	apr, apy := annualize(new(big.Float).Quo(rValue, bValue), eraDuration, destination.Compounds())
 
 	return DailyApr{
-		TimeBucket:   rewardSeq.Time,
-		Era:          rewardSeq.Era,
+		TimeBucket:   eraSeq.Time,
+		Era:          eraSeq.Era,
 		Bonded:       stake,
 		TotalRewards: reward,
 		APR:          apr.Text('f', decPrecision),
-		Validator:    rewardSeq.ValidatorStashAccount,
-	}, nil
+		APY:          apy.Text('f', decPrecision),
+
+		RewardDestination: destination,
+	}
 }
 
-func toAPRView(rewardSeqs []model.RewardEraSeq, accountSeqs []model.AccountEraSeq) (res []DailyApr, err error) {
-	stakeLookup := make(map[int64]types.Quantity)
+// toAPRView computes APR of rewards from every validator, or single APR of all validators in era weighted by stake when aggregate is set.
+// Eras with unknown duration and rewards without stake of account are skipped
+func toAPRView(rewardSeqs []model.RewardEraSeq, accountSeqs []model.AccountEraSeq, eraDurations map[int64]time.Duration, destination RewardDestination, aggregate bool) (res []DailyApr, err error) {
+	type key struct {
+		era       int64
+		validator string
+	}
+	stakeLookup := make(map[key]types.Quantity)
 	for _, seq := range accountSeqs {
-		stakeLookup[seq.Era] = seq.Stake
+		stakeLookup[key{seq.Era, seq.ValidatorStashAccount}] = seq.Stake
 	}
 
-	var stake types.Quantity
+	var rewards []model.RewardEraSeq
 	for _, rewardSeq := range rewardSeqs {
 		if rewardSeq.Kind != model.RewardReward {
 			continue
 		}
-		stake = stakeLookup[rewardSeq.Era]
+		if _, ok := eraDurations[rewardSeq.Era]; !ok {
+			continue
+		}
+		if stake := stakeLookup[key{rewardSeq.Era, rewardSeq.ValidatorStashAccount}]; stake.Sign() <= 0 {
+			continue
+		}
+		rewards = append(rewards, rewardSeq)
+	}
 
-		apr, err := dailyAPR(rewardSeq, stake)
-		if err != nil {
-			return res, err
+	for i := 0; i < len(rewards); {
+		j := i + 1
+		for aggregate && j < len(rewards) && rewards[j].Era == rewards[i].Era {
+			j++
+		}
+
+		totalReward := types.NewQuantityFromInt64(0)
+		totalStake := types.NewQuantityFromInt64(0)
+		var validators []string
+		for _, seq := range rewards[i:j] {
+			reward, err := types.NewQuantityFromString(seq.Amount)
+			if err != nil {
+				return res, err
+			}
+			totalReward.Add(reward)
+			totalStake.Add(stakeLookup[key{seq.Era, seq.ValidatorStashAccount}])
+			validators = append(validators, seq.ValidatorStashAccount)
+		}
+
+		apr := eraAPR(rewards[i].EraSequence, totalReward, totalStake, eraDurations[rewards[i].Era], destination)
+		if aggregate {
+			apr.Validators = validators
+		} else {
+			apr.Validator = rewards[i].ValidatorStashAccount
 		}
 		res = append(res, apr)
+		i = j
+	}
+	return res, nil
+}
+
+// EraDurations returns durations of eras between times of last heights of previous era and of era,
+// era without synced previous era starts at time of its start height. Eras which are not synced are left out
+func EraDurations(syncablesDb store.Syncables, eraSeqs []*model.EraSequence) (map[int64]time.Duration, error) {
+	res := make(map[int64]time.Duration)
+	if len(eraSeqs) == 0 {
+		return res, nil
+	}
+
+	startHeight, endHeight := eraSeqs[0].StartHeight, eraSeqs[0].EndHeight
+	for _, seq := range eraSeqs {
+		if seq.StartHeight < startHeight {
+			startHeight = seq.StartHeight
+		}
+		if seq.EndHeight > endHeight {
+			endHeight = seq.EndHeight
+		}
+	}
+
+	lastInEras, err := syncablesDb.FindAllByLastInSessionOrEra(0, false, true, startHeight-1, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	endTimes := make(map[int64]time.Time)
+	for _, syncable := range lastInEras {
+		endTimes[syncable.Era] = syncable.Time.Time
+	}
+
+	for _, seq := range eraSeqs {
+		if _, ok := res[seq.Era]; ok {
+			continue
+		}
+		end, ok := endTimes[seq.Era]
+		if !ok {
+			continue
+		}
+
+		start, ok := endTimes[seq.Era-1]
+		if !ok {
+			syncable, err := syncablesDb.FindByHeight(seq.StartHeight)
+			if err == store.ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			start = syncable.Time.Time
+		}
+
+		if d := end.Sub(start); d > 0 {
+			res[seq.Era] = d
+		}
 	}
 	return res, nil
 }
+
+// ValidatorEraApr is annualized yield of validator nominators in era, after validator took commission
+type ValidatorEraApr struct {
+	Era              int64          `json:"era"`
+	StakersStake     types.Quantity `json:"stakers_stake"`
+	Commission       int64          `json:"commission"`
+	NominatorRewards types.Quantity `json:"nominator_rewards"`
+	APR              string         `json:"apr"`
+
+	value *big.Float
+}
+
+// ValidatorEraAPR computes era APR of validator from rewards paid to its nominators, ok is false when validator had no nominators
+func ValidatorEraAPR(eraSeq model.ValidatorEraSeq, nominatorRewards types.Quantity, eraDuration time.Duration) (res ValidatorEraApr, ok bool) {
+	if eraSeq.StakersStake.Sign() <= 0 || eraDuration <= 0 {
+		return res, false
+	}
+
+	r := nominatorRewards.Clone()
+	rValue := new(big.Float).SetInt(&r.Int)
+	s := eraSeq.StakersStake.Clone()
+	sValue := new(big.Float).SetInt(&s.Int)
+	apr, _ := annualize(new(big.Float).Quo(rValue, sValue), eraDuration, false)
+
+	return ValidatorEraApr{
+		Era:              eraSeq.Era,
+		StakersStake:     eraSeq.StakersStake,
+		Commission:       eraSeq.Commission,
+		NominatorRewards: nominatorRewards,
+		APR:              apr.Text('f', decPrecision),
+		value:            apr,
+	}, true
+}
+
+// ToValidatorEraAPRs computes era APRs of validators grouped by stash account, in order of era seqs. Eras with unknown duration are skipped
+func ToValidatorEraAPRs(eraSeqs []model.ValidatorEraSeq, rewardRows []store.ValidatorEraRewardRow, eraDurations map[int64]time.Duration) map[string][]ValidatorEraApr {
+	type key struct {
+		era   int64
+		stash string
+	}
+	rewardLookup := make(map[key]types.Quantity)
+	for _, row := range rewardRows {
+		rewardLookup[key{row.Era, row.ValidatorStashAccount}] = row.Amount
+	}
+
+	res := make(map[string][]ValidatorEraApr)
+	for _, eraSeq := range eraSeqs {
+		rewards, ok := rewardLookup[key{eraSeq.Era, eraSeq.StashAccount}]
+		if !ok {
+			rewards = types.NewQuantityFromInt64(0)
+		}
+
+		if apr, ok := ValidatorEraAPR(eraSeq, rewards, eraDurations[eraSeq.Era]); ok {
+			res[eraSeq.StashAccount] = append(res[eraSeq.StashAccount], apr)
+		}
+	}
+	return res
+}
+
+// TrailingValidatorAPR returns average of validator era APRs, formatted and for comparing
+func TrailingValidatorAPR(eraAPRs []ValidatorEraApr) (string, *big.Float) {
+	sum := new(big.Float)
+	for _, eraAPR := range eraAPRs {
+		sum.Add(sum, eraAPR.value)
+	}
+	if len(eraAPRs) > 0 {
+		sum.Quo(sum, big.NewFloat(float64(len(eraAPRs))))
+	}
+	return sum.Text('f', decPrecision), sum
+}
@@ -0,0 +1,299 @@
+package apr
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var aprTime = time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+func newValidatorEraSeq(era int64, stash string, stakersStake int64) model.ValidatorEraSeq {
+	return model.ValidatorEraSeq{
+		EraSequence:  &model.EraSequence{Era: era},
+		StashAccount: stash,
+		StakersStake: types.NewQuantityFromInt64(stakersStake),
+		Commission:   100000000,
+	}
+}
+
+func TestValidatorEraAPR(t *testing.T) {
+	tests := []struct {
+		description  string
+		stakersStake int64
+		rewards      int64
+		eraDuration  time.Duration
+		wantOk       bool
+		wantAPR      string
+	}{
+		{"era of 24 hours", 1000, 1, 24 * time.Hour, true, "0.3650"},
+		{"era of 6 hours", 1000, 1, 6 * time.Hour, true, "1.4600"},
+		{"no rewards", 1000, 0, 24 * time.Hour, true, "0.0000"},
+		{"no nominators", 0, 1, 24 * time.Hour, false, ""},
+		{"unknown era duration", 1000, 1, 0, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			res, ok := ValidatorEraAPR(newValidatorEraSeq(5, "validator", tt.stakersStake), types.NewQuantityFromInt64(tt.rewards), tt.eraDuration)
+			if ok != tt.wantOk {
+				t.Fatalf("want ok %t; got %t", tt.wantOk, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if res.APR != tt.wantAPR {
+				t.Errorf("want apr %s; got %s", tt.wantAPR, res.APR)
+			}
+			if res.Era != 5 || res.Commission != 100000000 || res.NominatorRewards.Int64() != tt.rewards {
+				t.Errorf("want era, commission and rewards of validator era; got %+v", res)
+			}
+		})
+	}
+}
+
+func TestToValidatorEraAPRs(t *testing.T) {
+	eraSeqs := []model.ValidatorEraSeq{
+		newValidatorEraSeq(1, "a", 1000),
+		newValidatorEraSeq(1, "b", 1000),
+		newValidatorEraSeq(2, "a", 1000),
+		newValidatorEraSeq(2, "b", 0),
+		newValidatorEraSeq(3, "a", 1000),
+	}
+	rewardRows := []store.ValidatorEraRewardRow{
+		{Era: 1, ValidatorStashAccount: "a", Amount: types.NewQuantityFromInt64(1)},
+		{Era: 1, ValidatorStashAccount: "b", Amount: types.NewQuantityFromInt64(2)},
+		{Era: 3, ValidatorStashAccount: "a", Amount: types.NewQuantityFromInt64(1)},
+	}
+	// era 3 is not synced yet
+	eraDurations := map[int64]time.Duration{1: 24 * time.Hour, 2: 24 * time.Hour}
+
+	res := ToValidatorEraAPRs(eraSeqs, rewardRows, eraDurations)
+
+	wantAPRs := map[string][]string{
+		"a": {"0.3650", "0.0000"},
+		"b": {"0.7300"},
+	}
+	if len(res) != len(wantAPRs) {
+		t.Fatalf("want era aprs of %d validators; got %d", len(wantAPRs), len(res))
+	}
+	for stash, want := range wantAPRs {
+		got := res[stash]
+		if len(got) != len(want) {
+			t.Fatalf("%s: want %d era aprs; got %+v", stash, len(want), got)
+		}
+		for i := range want {
+			if got[i].APR != want[i] || got[i].Era != int64(i+1) {
+				t.Errorf("%s: want apr %s of era %d; got %s of era %d", stash, want[i], i+1, got[i].APR, got[i].Era)
+			}
+		}
+	}
+
+	apr, value := TrailingValidatorAPR(res["a"])
+	if apr != "0.1825" {
+		t.Errorf("want average of era aprs 0.1825; got %s", apr)
+	}
+	if f, _ := value.Float64(); f < 0.1824 || f > 0.1826 {
+		t.Errorf("want value of average apr; got %v", f)
+	}
+
+	if apr, _ := TrailingValidatorAPR(nil); apr != "0.0000" {
+		t.Errorf("want zero apr without eras; got %s", apr)
+	}
+}
+
+func TestAnnualize(t *testing.T) {
+	tests := []struct {
+		description string
+		eraDuration time.Duration
+		compounds   bool
+		wantAPR     string
+		wantAPY     string
+	}{
+		{"era of 24 hours", 24 * time.Hour, true, "0.3650", "0.4403"},
+		{"era of 6 hours", 6 * time.Hour, true, "1.4600", "3.3028"},
+		{"non-compounding era of 24 hours", 24 * time.Hour, false, "0.3650", "0.3650"},
+		{"non-compounding era of 6 hours", 6 * time.Hour, false, "1.4600", "1.4600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			apr, apy := annualize(big.NewFloat(0.001), tt.eraDuration, tt.compounds)
+
+			if got := apr.Text('f', decPrecision); got != tt.wantAPR {
+				t.Errorf("want apr %s; got %s", tt.wantAPR, got)
+			}
+			if got := apy.Text('f', decPrecision); got != tt.wantAPY {
+				t.Errorf("want apy %s; got %s", tt.wantAPY, got)
+			}
+		})
+	}
+}
+
+// newSyncablesStore returns syncables of heights 1 to 30 in eras of 10 heights
+func newSyncablesStore(t *testing.T, heightDuration time.Duration) store.Syncables {
+	db := memory.New().GetSyncables()
+	for h := int64(1); h <= 30; h++ {
+		err := db.CreateOrUpdate(&model.Syncable{
+			Model:     &model.Model{},
+			Height:    h,
+			Time:      *types.NewTimeFromTime(aprTime.Add(time.Duration(h) * heightDuration)),
+			Era:       (h-1)/10 + 1,
+			LastInEra: h%10 == 0,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestEraDurations(t *testing.T) {
+	eraSeq := func(era int64) *model.EraSequence {
+		return &model.EraSequence{Era: era, StartHeight: (era-1)*10 + 1, EndHeight: era * 10}
+	}
+
+	tests := []struct {
+		description    string
+		heightDuration time.Duration
+		eraSeqs        []*model.EraSequence
+		want           map[int64]time.Duration
+	}{
+		{
+			description:    "eras of 24 hours",
+			heightDuration: 144 * time.Minute,
+			eraSeqs:        []*model.EraSequence{eraSeq(2), eraSeq(3), eraSeq(3)},
+			want:           map[int64]time.Duration{2: 24 * time.Hour, 3: 24 * time.Hour},
+		},
+		{
+			description:    "eras of 6 hours",
+			heightDuration: 36 * time.Minute,
+			eraSeqs:        []*model.EraSequence{eraSeq(2), eraSeq(3)},
+			want:           map[int64]time.Duration{2: 6 * time.Hour, 3: 6 * time.Hour},
+		},
+		{
+			description:    "era without synced previous era starts at its start height",
+			heightDuration: 36 * time.Minute,
+			eraSeqs:        []*model.EraSequence{eraSeq(1)},
+			want:           map[int64]time.Duration{1: 9 * 36 * time.Minute},
+		},
+		{
+			description:    "era which is not synced is left out",
+			heightDuration: 144 * time.Minute,
+			eraSeqs:        []*model.EraSequence{eraSeq(3), eraSeq(4)},
+			want:           map[int64]time.Duration{3: 24 * time.Hour},
+		},
+		{
+			description:    "no eras",
+			heightDuration: 144 * time.Minute,
+			want:           map[int64]time.Duration{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			res, err := EraDurations(newSyncablesStore(t, tt.heightDuration), tt.eraSeqs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(res, tt.want) {
+				t.Errorf("want era durations %v; got %v", tt.want, res)
+			}
+		})
+	}
+}
+
+func TestToAPRView(t *testing.T) {
+	eraSeq := func(era int64) *model.EraSequence {
+		return &model.EraSequence{Era: era, Time: *types.NewTimeFromTime(aprTime.Add(time.Duration(era) * 24 * time.Hour))}
+	}
+	reward := func(era int64, validator, amount string, kind model.RewardKind) model.RewardEraSeq {
+		return model.RewardEraSeq{EraSequence: eraSeq(era), StashAccount: "nominator", ValidatorStashAccount: validator, Amount: amount, Kind: kind}
+	}
+	stake := func(era int64, validator string, amount int64) model.AccountEraSeq {
+		return model.AccountEraSeq{EraSequence: eraSeq(era), StashAccount: "nominator", ValidatorStashAccount: validator, Stake: types.NewQuantityFromInt64(amount)}
+	}
+
+	rewardSeqs := []model.RewardEraSeq{
+		reward(1, "a", "3", model.RewardReward),
+		reward(1, "b", "1", model.RewardReward),
+		reward(1, "a", "5", model.RewardCommission),
+		// no stake of account with validator
+		reward(1, "c", "1", model.RewardReward),
+		// era duration is unknown
+		reward(2, "a", "3", model.RewardReward),
+	}
+	accountSeqs := []model.AccountEraSeq{stake(1, "a", 1000), stake(1, "b", 3000), stake(2, "a", 1000)}
+	eraDurations := map[int64]time.Duration{1: 24 * time.Hour}
+
+	type apr struct {
+		validator   string
+		validators  []string
+		apr         string
+		apy         string
+		destination RewardDestination
+	}
+	tests := []struct {
+		description string
+		destination RewardDestination
+		aggregate   bool
+		want        []apr
+	}{
+		{
+			description: "apr of every validator",
+			destination: RewardDestinationStaked,
+			want: []apr{
+				{validator: "a", apr: "1.0950", apy: "1.9843", destination: RewardDestinationStaked},
+				{validator: "b", apr: "0.1217", apy: "0.1294", destination: RewardDestinationStaked},
+			},
+		},
+		{
+			description: "aggregate weighted by stake",
+			destination: RewardDestinationStaked,
+			aggregate:   true,
+			// 4 / 4000 of stake, rather than average of validator aprs
+			want: []apr{{validators: []string{"a", "b"}, apr: "0.3650", apy: "0.4403", destination: RewardDestinationStaked}},
+		},
+		{
+			description: "non-compounding aggregate",
+			destination: RewardDestinationStash,
+			aggregate:   true,
+			want:        []apr{{validators: []string{"a", "b"}, apr: "0.3650", apy: "0.3650", destination: RewardDestinationStash}},
+		},
+		{
+			description: "unknown destination is not compounded",
+			destination: RewardDestinationUnknown,
+			want: []apr{
+				{validator: "a", apr: "1.0950", apy: "1.0950", destination: RewardDestinationUnknown},
+				{validator: "b", apr: "0.1217", apy: "0.1217", destination: RewardDestinationUnknown},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			res, err := toAPRView(rewardSeqs, accountSeqs, eraDurations, tt.destination, tt.aggregate)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []apr
+			for _, r := range res {
+				if r.Era != 1 {
+					t.Errorf("want apr of era 1; got %d", r.Era)
+				}
+				got = append(got, apr{r.Validator, r.Validators, r.APR, r.APY, r.RewardDestination})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want aprs %+v; got %+v", tt.want, got)
+			}
+		})
+	}
+}
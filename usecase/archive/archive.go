@@ -0,0 +1,268 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const manifestFile = "manifest.jsonl"
+
+var (
+	ErrInvalidPartitionSize = errors.New("archive partition size must be positive")
+	ErrUnexpectedColumns    = errors.New("archive file has unexpected columns")
+)
+
+// Archive stores rows in gzipped CSV files partitioned by height, every written file is recorded in manifest
+type Archive struct {
+	dir           string
+	partitionSize int64
+}
+
+// Entry is manifest record of archive file
+type Entry struct {
+	Table       string    `json:"table"`
+	StartHeight int64     `json:"start_height"`
+	EndHeight   int64     `json:"end_height"`
+	Rows        int64     `json:"rows"`
+	File        string    `json:"file"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Row is archived row with height used for partitioning
+type Row struct {
+	Height int64
+	Values []string
+}
+
+func New(dir string, partitionSize int64) (*Archive, error) {
+	if partitionSize <= 0 {
+		return nil, ErrInvalidPartitionSize
+	}
+	return &Archive{
+		dir:           dir,
+		partitionSize: partitionSize,
+	}, nil
+}
+
+// Write writes rows of table to one file per height partition and records them in manifest
+func (a *Archive) Write(table string, columns []string, rows []Row) ([]Entry, error) {
+	partitions := map[int64][]Row{}
+	for _, row := range rows {
+		partition := row.Height / a.partitionSize
+		partitions[partition] = append(partitions[partition], row)
+	}
+
+	keys := make([]int64, 0, len(partitions))
+	for partition := range partitions {
+		keys = append(keys, partition)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var entries []Entry
+	for _, partition := range keys {
+		entry, err := a.writeFile(table, partition, columns, partitions[partition])
+		if err != nil {
+			return entries, err
+		}
+
+		if err := a.appendManifest(entry); err != nil {
+			return entries, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
+
+// Read calls fn with values of every archived row of table in files overlapping given height range
+func (a *Archive) Read(table string, columns []string, startHeight, endHeight int64, fn func(values []string) error) error {
+	entries, err := a.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.Table != table || entry.EndHeight < startHeight || entry.StartHeight > endHeight {
+			continue
+		}
+
+		if err := a.readFile(entry, columns, fn); err != nil {
+			return fmt.Errorf("reading archive file %s: %w", entry.File, err)
+		}
+	}
+
+	return nil
+}
+
+// Entries returns all files recorded in manifest
+func (a *Archive) Entries() ([]Entry, error) {
+	f, err := os.Open(filepath.Join(a.dir, manifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+func (a *Archive) writeFile(table string, partition int64, columns []string, rows []Row) (*Entry, error) {
+	entry := &Entry{
+		Table:       table,
+		StartHeight: rows[0].Height,
+		EndHeight:   rows[0].Height,
+		Rows:        int64(len(rows)),
+		CreatedAt:   time.Now().UTC(),
+	}
+	for _, row := range rows {
+		if row.Height < entry.StartHeight {
+			entry.StartHeight = row.Height
+		}
+		if row.Height > entry.EndHeight {
+			entry.EndHeight = row.Height
+		}
+	}
+
+	// partition can be archived by many purges, every one of them gets its own file
+	partitionStart := partition * a.partitionSize
+	entry.File = filepath.Join(table, fmt.Sprintf("%d-%d_%d.csv.gz", partitionStart, partitionStart+a.partitionSize-1, entry.CreatedAt.UnixNano()))
+
+	path := filepath.Join(a.dir, entry.File)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := writeCSV(tmpPath, columns, rows); err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	return entry, os.Rename(tmpPath, path)
+}
+
+func (a *Archive) appendManifest(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(a.dir, manifestFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (a *Archive) readFile(entry Entry, columns []string, fn func(values []string) error) error {
+	f, err := os.Open(filepath.Join(a.dir, entry.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	r := csv.NewReader(gz)
+
+	header, err := r.Read()
+	if err != nil {
+		return err
+	}
+	if !equalColumns(header, columns) {
+		return ErrUnexpectedColumns
+	}
+
+	for {
+		values, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+}
+
+func writeCSV(path string, columns []string, rows []Row) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	w := csv.NewWriter(gz)
+
+	if err := w.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.Write(row.Values); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
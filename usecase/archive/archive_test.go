@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func newRows(heights ...int64) []Row {
+	rows := make([]Row, len(heights))
+	for i, h := range heights {
+		rows[i] = Row{Height: h, Values: []string{strconv.FormatInt(h, 10), "value"}}
+	}
+	return rows
+}
+
+func TestArchive_Write(t *testing.T) {
+	a, err := New(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := a.Write("table", []string{"height", "value"}, newRows(12, 3, 15, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("want 2 entries; got %d", len(entries))
+	}
+	for i, want := range []Entry{
+		{Table: "table", StartHeight: 1, EndHeight: 3, Rows: 2},
+		{Table: "table", StartHeight: 12, EndHeight: 15, Rows: 2},
+	} {
+		got := entries[i]
+		if got.Table != want.Table || got.StartHeight != want.StartHeight || got.EndHeight != want.EndHeight || got.Rows != want.Rows {
+			t.Errorf("want entry %d %+v; got %+v", i, want, got)
+		}
+	}
+
+	manifest, err := a.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(manifest, entries) {
+		t.Errorf("want manifest %+v; got %+v", entries, manifest)
+	}
+}
+
+func TestArchive_Read(t *testing.T) {
+	a, err := New(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns := []string{"height", "value"}
+	// same partition archived by two purges
+	for _, rows := range [][]Row{newRows(1, 2, 11), newRows(3, 25)} {
+		if _, err := a.Write("table", columns, rows); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := a.Write("other", columns, newRows(4)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		description string
+		startHeight int64
+		endHeight   int64
+		want        []string
+	}{
+		{"returns all files of table", 0, 100, []string{"1", "2", "11", "3", "25"}},
+		{"returns files overlapping range", 2, 3, []string{"1", "2", "3"}},
+		{"returns nothing outside of archived heights", 26, 100, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var got []string
+			err := a.Read("table", columns, tt.startHeight, tt.endHeight, func(values []string) error {
+				got = append(got, values[0])
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want heights %v; got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestArchive_ReadUnexpectedColumns(t *testing.T) {
+	a, err := New(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.Write("table", []string{"height", "value"}, newRows(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = a.Read("table", []string{"height", "other"}, 0, 10, func([]string) error { return nil })
+	if !errors.Is(err, ErrUnexpectedColumns) {
+		t.Errorf("want error %v; got %v", ErrUnexpectedColumns, err)
+	}
+}
+
+func TestNew_InvalidPartitionSize(t *testing.T) {
+	if _, err := New(t.TempDir(), 0); err != ErrInvalidPartitionSize {
+		t.Errorf("want error %v; got %v", ErrInvalidPartitionSize, err)
+	}
+}
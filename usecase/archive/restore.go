@@ -0,0 +1,175 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+const restoreBatchSize = 1000
+
+var (
+	ErrArchiveDisabled    = errors.New("purge archive dir is not configured")
+	ErrInvalidHeightRange = errors.New("end height must not be lower than start height")
+)
+
+type restoreUseCase struct {
+	cfg *config.Config
+
+	blockDb     store.Blocks
+	databaseDb  store.Database
+	validatorDb store.Validators
+}
+
+func NewRestoreUseCase(cfg *config.Config, blockDb store.Blocks, databaseDb store.Database, validatorDb store.Validators) *restoreUseCase {
+	return &restoreUseCase{
+		cfg: cfg,
+
+		blockDb:     blockDb,
+		databaseDb:  databaseDb,
+		validatorDb: validatorDb,
+	}
+}
+
+func (uc *restoreUseCase) Execute(ctx context.Context, startHeight, endHeight int64) error {
+	defer metric.LogUseCaseDuration(time.Now(), "restore_archive")
+
+	if uc.cfg.PurgeArchiveDir == "" {
+		return ErrArchiveDisabled
+	}
+
+	if endHeight < startHeight {
+		return ErrInvalidHeightRange
+	}
+
+	archive, err := New(uc.cfg.PurgeArchiveDir, uc.cfg.PurgeArchivePartitionSize)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.restoreBlockSequences(archive, startHeight, endHeight); err != nil {
+		return err
+	}
+
+	if err := uc.restoreValidatorSequences(archive, startHeight, endHeight); err != nil {
+		return err
+	}
+
+	if err := uc.restoreValidatorSessionSequences(archive, startHeight, endHeight); err != nil {
+		return err
+	}
+
+	// restored sequences would be purged again on next purge run otherwise
+	return uc.databaseDb.CreateRestoredRange(&model.RestoredRange{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	})
+}
+
+func (uc *restoreUseCase) restoreBlockSequences(archive *Archive, startHeight, endHeight int64) error {
+	var count int64
+
+	err := archive.Read(model.BlockSeq{}.TableName(), BlockSeqColumns, startHeight, endHeight, func(values []string) error {
+		seq, err := ParseBlockSeq(values)
+		if err != nil {
+			return err
+		}
+		if seq.Height < startHeight || seq.Height > endHeight {
+			return nil
+		}
+		count++
+		return uc.blockDb.CreateIfNotExists(seq)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d block sequences restored", count))
+
+	return nil
+}
+
+func (uc *restoreUseCase) restoreValidatorSequences(archive *Archive, startHeight, endHeight int64) error {
+	var count int64
+	var batch []model.ValidatorSeq
+
+	err := archive.Read(model.ValidatorSeq{}.TableName(), ValidatorSeqColumns, startHeight, endHeight, func(values []string) error {
+		seq, err := ParseValidatorSeq(values)
+		if err != nil {
+			return err
+		}
+		if seq.Height < startHeight || seq.Height > endHeight {
+			return nil
+		}
+
+		batch = append(batch, *seq)
+		if len(batch) < restoreBatchSize {
+			return nil
+		}
+
+		count += int64(len(batch))
+		err = uc.validatorDb.BulkUpsertSeqs(batch)
+		batch = nil
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		if err := uc.validatorDb.BulkUpsertSeqs(batch); err != nil {
+			return err
+		}
+		count += int64(len(batch))
+	}
+
+	logger.Info(fmt.Sprintf("%d validator sequences restored", count))
+
+	return nil
+}
+
+func (uc *restoreUseCase) restoreValidatorSessionSequences(archive *Archive, startHeight, endHeight int64) error {
+	var count int64
+	var batch []model.ValidatorSessionSeq
+
+	err := archive.Read(model.ValidatorSessionSeq{}.TableName(), ValidatorSessionSeqColumns, startHeight, endHeight, func(values []string) error {
+		seq, err := ParseValidatorSessionSeq(values)
+		if err != nil {
+			return err
+		}
+		if seq.StartHeight < startHeight || seq.StartHeight > endHeight {
+			return nil
+		}
+
+		batch = append(batch, *seq)
+		if len(batch) < restoreBatchSize {
+			return nil
+		}
+
+		count += int64(len(batch))
+		err = uc.validatorDb.BulkUpsertSessionSeqs(batch)
+		batch = nil
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		if err := uc.validatorDb.BulkUpsertSessionSeqs(batch); err != nil {
+			return err
+		}
+		count += int64(len(batch))
+	}
+
+	logger.Info(fmt.Sprintf("%d validator session sequences restored", count))
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type RestoreCmdHandler struct {
+	cfg *config.Config
+
+	useCase *restoreUseCase
+
+	blockDb     store.Blocks
+	databaseDb  store.Database
+	validatorDb store.Validators
+}
+
+func NewRestoreCmdHandler(cfg *config.Config, blockDb store.Blocks, databaseDb store.Database, validatorDb store.Validators) *RestoreCmdHandler {
+	return &RestoreCmdHandler{
+		cfg: cfg,
+
+		blockDb:     blockDb,
+		databaseDb:  databaseDb,
+		validatorDb: validatorDb,
+	}
+}
+
+func (h *RestoreCmdHandler) Handle(ctx context.Context, startHeight, endHeight int64) {
+	logger.Info(fmt.Sprintf("running restore archive use case [handler=cmd] [start_height=%d] [end_height=%d]", startHeight, endHeight))
+
+	err := h.getUseCase().Execute(ctx, startHeight, endHeight)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *RestoreCmdHandler) getUseCase() *restoreUseCase {
+	if h.useCase == nil {
+		return NewRestoreUseCase(h.cfg, h.blockDb, h.databaseDb, h.validatorDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,236 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var testStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func heightTime(height int64) types.Time {
+	return *types.NewTimeFromTime(testStart.Add(time.Duration(height) * time.Minute))
+}
+
+// newTestStore returns memory store with block, validator and validator session sequences for heights 1 to 25,
+// every height starts new session
+func newTestStore(t *testing.T) *memory.Store {
+	s := memory.New()
+
+	var validatorSeqs []model.ValidatorSeq
+	var sessionSeqs []model.ValidatorSessionSeq
+	for h := int64(1); h <= 25; h++ {
+		seq := &model.Sequence{Height: h, Time: heightTime(h)}
+		if err := s.GetBlocks().CreateSeq(&model.BlockSeq{Sequence: seq, ExtrinsicsCount: h}); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, stash := range []string{"stash1", "stash2"} {
+			validatorSeqs = append(validatorSeqs, model.ValidatorSeq{Sequence: seq, StashAccount: stash, ActiveBalance: types.NewQuantityFromInt64(h)})
+			sessionSeqs = append(sessionSeqs, model.ValidatorSessionSeq{
+				SessionSequence: &model.SessionSequence{Session: h, StartHeight: h, EndHeight: h, Time: heightTime(h)},
+				StashAccount:    stash,
+				Online:          true,
+			})
+		}
+	}
+
+	if err := s.GetValidators().BulkUpsertSeqs(validatorSeqs); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.GetValidators().BulkUpsertSessionSeqs(sessionSeqs); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// purge archives and deletes sequences older than height in batches the same way purge does
+func purge(t *testing.T, s *memory.Store, a *Archive, height int64, limit int64) (blocks, validators, sessions int64) {
+	threshold := heightTime(height).Time
+	activityPeriods := []store.ActivityPeriodRow{{Min: heightTime(0), Max: heightTime(100)}}
+
+	for _, tt := range []struct {
+		count   *int64
+		archive func() (*int64, error)
+	}{
+		{&blocks, func() (*int64, error) {
+			return s.GetBlocks().ArchiveSeqsOlderThan(threshold, activityPeriods, limit, func(seqs []model.BlockSeq) error {
+				_, err := a.Write(model.BlockSeq{}.TableName(), BlockSeqColumns, ToBlockSeqRows(seqs))
+				return err
+			})
+		}},
+		{&validators, func() (*int64, error) {
+			return s.GetValidators().ArchiveSeqsOlderThan(threshold, limit, func(seqs []model.ValidatorSeq) error {
+				_, err := a.Write(model.ValidatorSeq{}.TableName(), ValidatorSeqColumns, ToValidatorSeqRows(seqs))
+				return err
+			})
+		}},
+		{&sessions, func() (*int64, error) {
+			return s.GetValidators().ArchiveSessionSeqsOlderThan(threshold, limit, func(seqs []model.ValidatorSessionSeq) error {
+				_, err := a.Write(model.ValidatorSessionSeq{}.TableName(), ValidatorSessionSeqColumns, ToValidatorSessionSeqRows(seqs))
+				return err
+			})
+		}},
+	} {
+		for {
+			count, err := tt.archive()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *count > limit {
+				t.Fatalf("want at most %d rows in batch; got %d", limit, *count)
+			}
+			*tt.count += *count
+			if *count < limit {
+				break
+			}
+		}
+	}
+	return
+}
+
+func blockHeights(t *testing.T, s *memory.Store) []int64 {
+	seqs, err := s.GetBlocks().FindSeqsForHeightRange(0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var heights []int64
+	for _, seq := range seqs {
+		heights = append(heights, seq.Height)
+	}
+	return heights
+}
+
+func heightRange(start, end int64) []int64 {
+	var heights []int64
+	for h := start; h <= end; h++ {
+		heights = append(heights, h)
+	}
+	return heights
+}
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{PurgeArchiveDir: dir, PurgeArchivePartitionSize: 10}
+	s := newTestStore(t)
+
+	a, err := New(dir, cfg.PurgeArchivePartitionSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks, validators, sessions := purge(t, s, a, 21, 7)
+	if blocks != 20 || validators != 40 || sessions != 40 {
+		t.Fatalf("want 20 blocks, 40 validators and 40 sessions purged; got %d, %d and %d", blocks, validators, sessions)
+	}
+	if got := blockHeights(t, s); !reflect.DeepEqual(got, heightRange(21, 25)) {
+		t.Fatalf("want heights %v left after purge; got %v", heightRange(21, 25), got)
+	}
+
+	uc := NewRestoreUseCase(cfg, s.GetBlocks(), s.GetDatabase(), s.GetValidators())
+	if err := uc.Execute(context.Background(), 8, 12); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := append(heightRange(8, 12), heightRange(21, 25)...)
+	if got := blockHeights(t, s); !reflect.DeepEqual(got, want) {
+		t.Errorf("want heights %v after restore; got %v", want, got)
+	}
+
+	block, err := s.GetBlocks().FindSeqByHeight(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.ExtrinsicsCount != 10 || !block.Time.Equal(heightTime(10)) {
+		t.Errorf("want restored block with 10 extrinsics at %s; got %d at %s", heightTime(10), block.ExtrinsicsCount, block.Time)
+	}
+
+	validatorSeqs, err := s.GetValidators().FindSeqsForHeightRange(8, 12, "stash1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validatorSeqs) != 5 || validatorSeqs[0].ActiveBalance.String() != "8" {
+		t.Errorf("want 5 restored validator sequences starting with balance 8; got %+v", validatorSeqs)
+	}
+
+	sessionSeqs, err := s.GetValidators().FindBySessionRange(8, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessionSeqs) != 10 {
+		t.Errorf("want 10 restored validator session sequences; got %d", len(sessionSeqs))
+	}
+
+	t.Run("restored ranges are not purged again", func(t *testing.T) {
+		blocks, validators, sessions := purge(t, s, a, 21, 7)
+		if blocks != 0 || validators != 0 || sessions != 0 {
+			t.Errorf("want nothing purged; got %d blocks, %d validators and %d sessions", blocks, validators, sessions)
+		}
+
+		deleted, err := s.GetBlocks().DeleteSeqOlderThan(heightTime(21).Time, []store.ActivityPeriodRow{{Min: heightTime(0), Max: heightTime(100)}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *deleted != 0 {
+			t.Errorf("want no block sequences deleted; got %d", *deleted)
+		}
+	})
+
+	t.Run("restore is idempotent", func(t *testing.T) {
+		if err := uc.Execute(context.Background(), 8, 12); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := blockHeights(t, s); !reflect.DeepEqual(got, want) {
+			t.Errorf("want heights %v after second restore; got %v", want, got)
+		}
+	})
+}
+
+func TestArchiveSeqsOlderThan_ArchiveFails(t *testing.T) {
+	s := newTestStore(t)
+	archiveErr := errors.New("disk full")
+
+	_, err := s.GetBlocks().ArchiveSeqsOlderThan(heightTime(21).Time, []store.ActivityPeriodRow{{Min: heightTime(0), Max: heightTime(100)}}, 7, func([]model.BlockSeq) error {
+		return archiveErr
+	})
+	if err != archiveErr {
+		t.Errorf("want error %v; got %v", archiveErr, err)
+	}
+
+	if got := blockHeights(t, s); !reflect.DeepEqual(got, heightRange(1, 25)) {
+		t.Errorf("want all heights kept; got %v", got)
+	}
+}
+
+func TestRestore_InvalidArguments(t *testing.T) {
+	s := memory.New()
+
+	tests := []struct {
+		description string
+		cfg         *config.Config
+		startHeight int64
+		endHeight   int64
+		want        error
+	}{
+		{"archive disabled", &config.Config{}, 1, 2, ErrArchiveDisabled},
+		{"invalid height range", &config.Config{PurgeArchiveDir: t.TempDir(), PurgeArchivePartitionSize: 10}, 2, 1, ErrInvalidHeightRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			uc := NewRestoreUseCase(tt.cfg, s.GetBlocks(), s.GetDatabase(), s.GetValidators())
+			if err := uc.Execute(context.Background(), tt.startHeight, tt.endHeight); err != tt.want {
+				t.Errorf("want error %v; got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package archive
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	BlockSeqColumns            = []string{"height", "time", "extrinsics_count", "unsigned_extrinsics_count", "signed_extrinsics_count"}
+	ValidatorSeqColumns        = []string{"height", "time", "stash_account", "active_balance"}
+	ValidatorSessionSeqColumns = []string{"session", "start_height", "end_height", "time", "stash_account", "online"}
+)
+
+func ToBlockSeqRows(seqs []model.BlockSeq) []Row {
+	rows := make([]Row, len(seqs))
+	for i, s := range seqs {
+		rows[i] = Row{
+			Height: s.Height,
+			Values: []string{
+				formatInt(s.Height),
+				formatTime(s.Time),
+				formatInt(s.ExtrinsicsCount),
+				formatInt(s.UnsignedExtrinsicsCount),
+				formatInt(s.SignedExtrinsicsCount),
+			},
+		}
+	}
+	return rows
+}
+
+func ParseBlockSeq(values []string) (*model.BlockSeq, error) {
+	var p parser
+	seq := &model.BlockSeq{
+		Sequence: &model.Sequence{
+			Height: p.int(values[0]),
+			Time:   p.time(values[1]),
+		},
+		ExtrinsicsCount:         p.int(values[2]),
+		UnsignedExtrinsicsCount: p.int(values[3]),
+		SignedExtrinsicsCount:   p.int(values[4]),
+	}
+	return seq, p.err
+}
+
+func ToValidatorSeqRows(seqs []model.ValidatorSeq) []Row {
+	rows := make([]Row, len(seqs))
+	for i, s := range seqs {
+		rows[i] = Row{
+			Height: s.Height,
+			Values: []string{
+				formatInt(s.Height),
+				formatTime(s.Time),
+				s.StashAccount,
+				s.ActiveBalance.String(),
+			},
+		}
+	}
+	return rows
+}
+
+func ParseValidatorSeq(values []string) (*model.ValidatorSeq, error) {
+	var p parser
+	seq := &model.ValidatorSeq{
+		Sequence: &model.Sequence{
+			Height: p.int(values[0]),
+			Time:   p.time(values[1]),
+		},
+		StashAccount:  values[2],
+		ActiveBalance: p.quantity(values[3]),
+	}
+	return seq, p.err
+}
+
+// ToValidatorSessionSeqRows returns rows of validator session sequences partitioned by session start height
+func ToValidatorSessionSeqRows(seqs []model.ValidatorSessionSeq) []Row {
+	rows := make([]Row, len(seqs))
+	for i, s := range seqs {
+		rows[i] = Row{
+			Height: s.StartHeight,
+			Values: []string{
+				formatInt(s.Session),
+				formatInt(s.StartHeight),
+				formatInt(s.EndHeight),
+				formatTime(s.Time),
+				s.StashAccount,
+				strconv.FormatBool(s.Online),
+			},
+		}
+	}
+	return rows
+}
+
+func ParseValidatorSessionSeq(values []string) (*model.ValidatorSessionSeq, error) {
+	var p parser
+	seq := &model.ValidatorSessionSeq{
+		SessionSequence: &model.SessionSequence{
+			Session:     p.int(values[0]),
+			StartHeight: p.int(values[1]),
+			EndHeight:   p.int(values[2]),
+			Time:        p.time(values[3]),
+		},
+		StashAccount: values[4],
+		Online:       p.bool(values[5]),
+	}
+	return seq, p.err
+}
+
+func formatInt(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+func formatTime(t types.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// parser keeps first error, so that values of whole row can be parsed before checking it
+type parser struct {
+	err error
+}
+
+func (p *parser) int(value string) int64 {
+	i, err := strconv.ParseInt(value, 10, 64)
+	p.setErr(err, value)
+	return i
+}
+
+func (p *parser) bool(value string) bool {
+	b, err := strconv.ParseBool(value)
+	p.setErr(err, value)
+	return b
+}
+
+func (p *parser) time(value string) types.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	p.setErr(err, value)
+	return *types.NewTimeFromTime(t)
+}
+
+func (p *parser) quantity(value string) types.Quantity {
+	q, err := types.NewQuantityFromString(value)
+	p.setErr(err, value)
+	return q
+}
+
+func (p *parser) setErr(err error, value string) {
+	if err != nil && p.err == nil {
+		p.err = errors.Wrapf(err, "invalid value %q", value)
+	}
+}
@@ -1,6 +1,7 @@
 package block
 
 import (
+	"context"
 	"errors"
 
 	"github.com/figment-networks/polkadothub-indexer/client"
@@ -20,7 +21,7 @@ func NewGetByHeightUseCase(c *client.Client, syncablesDb store.Syncables) *getBy
 	}
 }
 
-func (uc *getByHeightUseCase) Execute(height *int64) (*DetailsView, error) {
+func (uc *getByHeightUseCase) Execute(ctx context.Context, height *int64) (*DetailsView, error) {
 	// Get last indexed height
 	mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
 	if err != nil {
@@ -37,7 +38,7 @@ func (uc *getByHeightUseCase) Execute(height *int64) (*DetailsView, error) {
 		return nil, errors.New("height is not indexed yet")
 	}
 
-	res, err := uc.client.Block.GetByHeight(*height)
+	res, err := uc.client.Block.GetByHeight(ctx, *height)
 	if err != nil {
 		return nil, err
 	}
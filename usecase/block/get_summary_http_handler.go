@@ -35,8 +35,8 @@ type GetBlockTimesForIntervalRequest struct {
 	//
 	// required: true
 	// in: query
-	// example: hour
-	Interval types.SummaryInterval `json:"interval" :"interval" binding:"required"`
+	// example: week
+	Interval types.SummaryInterval `json:"interval" form:"interval" binding:"required"`
 	// Period
 	//
 	// required: true
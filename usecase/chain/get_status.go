@@ -1,6 +1,8 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/figment-networks/polkadothub-indexer/client"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-proxy/grpc/chain/chainpb"
@@ -19,7 +21,7 @@ func NewGetStatusUseCase(c *client.Client, syncablesDb store.Syncables) *getStat
 	}
 }
 
-func (uc *getStatusUseCase) Execute(includeChainStatus bool) (*DetailsView, error) {
+func (uc *getStatusUseCase) Execute(ctx context.Context, includeChainStatus bool) (*DetailsView, error) {
 	var lastEraHeight, lastSessionHeight int64
 	var getHeadRes *chainpb.GetHeadResponse
 	var getStatusRes *chainpb.GetStatusResponse
@@ -48,12 +50,12 @@ func (uc *getStatusUseCase) Execute(includeChainStatus bool) (*DetailsView, erro
 	}
 
 	if includeChainStatus {
-		getHeadRes, err = uc.client.Chain.GetHead()
+		getHeadRes, err = uc.client.Chain.GetHead(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		getStatusRes, err = uc.client.Chain.GeStatus()
+		getStatusRes, err = uc.client.Chain.GeStatus(ctx)
 		if err != nil {
 			return nil, err
 		}
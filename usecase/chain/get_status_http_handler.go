@@ -47,7 +47,7 @@ func (h *getStatusHttpHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.getUseCase().Execute(req.IncludeChainStatus)
+	resp, err := h.getUseCase().Execute(c.Request.Context(), req.IncludeChainStatus)
 	if err != nil {
 		logger.Error(err)
 		http.ServerError(c, err)
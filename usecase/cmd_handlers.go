@@ -4,20 +4,32 @@ import (
 	"github.com/figment-networks/polkadothub-indexer/client"
 	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/usecase/archive"
 	"github.com/figment-networks/polkadothub-indexer/usecase/chain"
+	"github.com/figment-networks/polkadothub-indexer/usecase/export"
 	"github.com/figment-networks/polkadothub-indexer/usecase/indexing"
+	"github.com/figment-networks/polkadothub-indexer/usecase/price"
+	"github.com/figment-networks/polkadothub-indexer/usecase/reward"
+	"github.com/figment-networks/polkadothub-indexer/usecase/system_event"
 )
 
-func NewCmdHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, reportDb store.Reports,
-	rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+func NewCmdHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, priceDb store.Prices,
+	reportDb store.Reports, rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
 ) *CmdHandlers {
 	return &CmdHandlers{
 		GetStatus:        chain.NewGetStatusCmdHandler(cli, syncableDb),
 		StartIndexer:     indexing.NewStartCmdHandler(cfg, cli, accountDb, blockDb, databaseDb, eventDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
 		BackfillIndexer:  indexing.NewBackfillCmdHandler(cfg, cli, accountDb, blockDb, databaseDb, eventDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
 		ReindexIndexer:   indexing.NewReindexCmdHandler(cfg, cli, accountDb, blockDb, databaseDb, eventDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
-		PurgeIndexer:     indexing.NewPurgeCmdHandler(cfg, blockDb, validatorDb),
-		SummarizeIndexer: indexing.NewSummarizeCmdHandler(cfg, blockDb, validatorDb),
+		PurgeIndexer:     indexing.NewPurgeCmdHandler(cfg, blockDb, eventDb, syncableDb, transactionDb, validatorDb),
+		SummarizeIndexer: indexing.NewSummarizeCmdHandler(cfg, blockDb, eventDb, transactionDb, validatorDb),
+		Inspect:          indexing.NewInspectCmdHandler(cfg, cli, accountDb, blockDb, databaseDb, eventDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
+
+		RewriteChangeSystemEvents: system_event.NewRewriteChangeEventsCmdHandler(cfg, systemEventDb),
+		RestoreArchive:            archive.NewRestoreCmdHandler(cfg, blockDb, databaseDb, validatorDb),
+		Export:                    export.NewExportCmdHandler(accountDb, blockDb, eventDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
+		ImportPrices:              price.NewImportCmdHandler(priceDb),
+		RewardsReport:             reward.NewGetReportCmdHandler(cfg, priceDb, rewardDb, syncableDb),
 	}
 }
 
@@ -28,4 +40,11 @@ type CmdHandlers struct {
 	ReindexIndexer   *indexing.ReindexCmdHandler
 	PurgeIndexer     *indexing.PurgeCmdHandler
 	SummarizeIndexer *indexing.SummarizeCmdHandler
+	Inspect          *indexing.InspectCmdHandler
+
+	RewriteChangeSystemEvents *system_event.RewriteChangeEventsCmdHandler
+	RestoreArchive            *archive.RestoreCmdHandler
+	Export                    *export.ExportCmdHandler
+	ImportPrices              *price.ImportCmdHandler
+	RewardsReport             *reward.GetReportCmdHandler
 }
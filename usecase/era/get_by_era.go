@@ -0,0 +1,23 @@
+package era
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+type getByEraUseCase struct {
+	eraSummaryDb store.EraSummary
+}
+
+func NewGetByEraUseCase(eraSummaryDb store.EraSummary) *getByEraUseCase {
+	return &getByEraUseCase{
+		eraSummaryDb: eraSummaryDb,
+	}
+}
+
+func (uc *getByEraUseCase) Execute(era int64) (*EraView, error) {
+	summary, err := uc.eraSummaryDb.FindEraSummaryByEra(era)
+	if err != nil {
+		return nil, err
+	}
+	return ToEraView(*summary), nil
+}
@@ -0,0 +1,60 @@
+package era
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getByEraHttpHandler)(nil)
+)
+
+type getByEraHttpHandler struct {
+	useCase *getByEraUseCase
+
+	eraSummaryDb store.EraSummary
+}
+
+func NewGetByEraHttpHandler(eraSummaryDb store.EraSummary) *getByEraHttpHandler {
+	return &getByEraHttpHandler{
+		eraSummaryDb: eraSummaryDb,
+	}
+}
+
+// swagger:parameters getEra
+type GetByEraRequest struct {
+	// Era
+	//
+	// required: true
+	// in: path
+	Era *int64 `json:"era" uri:"era" binding:"required"`
+}
+
+func (h *getByEraHttpHandler) Handle(c *gin.Context) {
+	var req GetByEraRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid era"))
+		return
+	}
+
+	resp, err := h.getUseCase().Execute(*req.Era)
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getByEraHttpHandler) getUseCase() *getByEraUseCase {
+	if h.useCase == nil {
+		return NewGetByEraUseCase(h.eraSummaryDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,23 @@
+package era
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+type getRecentUseCase struct {
+	eraSummaryDb store.EraSummary
+}
+
+func NewGetRecentUseCase(eraSummaryDb store.EraSummary) *getRecentUseCase {
+	return &getRecentUseCase{
+		eraSummaryDb: eraSummaryDb,
+	}
+}
+
+func (uc *getRecentUseCase) Execute(limit int64) (*ListView, error) {
+	summaries, err := uc.eraSummaryDb.FindEraSummaries(limit)
+	if err != nil {
+		return nil, err
+	}
+	return ToListView(summaries), nil
+}
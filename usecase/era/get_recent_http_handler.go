@@ -0,0 +1,72 @@
+package era
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultErasLimit = 50
+
+var (
+	_ types.HttpHandler = (*getRecentHttpHandler)(nil)
+)
+
+type getRecentHttpHandler struct {
+	useCase *getRecentUseCase
+
+	eraSummaryDb store.EraSummary
+}
+
+func NewGetRecentHttpHandler(eraSummaryDb store.EraSummary) *getRecentHttpHandler {
+	return &getRecentHttpHandler{
+		eraSummaryDb: eraSummaryDb,
+	}
+}
+
+// swagger:parameters getEras
+type GetRecentRequest struct {
+	// Limit of eras, defaults to 50
+	//
+	// in: query
+	Limit int64 `json:"limit" form:"limit"`
+}
+
+func (h *getRecentHttpHandler) Handle(c *gin.Context) {
+	var req GetRecentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit < 0 {
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultErasLimit
+	}
+
+	resp, err := h.getUseCase().Execute(req.Limit)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getRecentHttpHandler) getUseCase() *getRecentUseCase {
+	if h.useCase == nil {
+		return NewGetRecentUseCase(h.eraSummaryDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,61 @@
+package era
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// EraView is network wide summary of era with its heights and times
+// swagger:response EraView
+type EraView struct {
+	Era         int64       `json:"era"`
+	StartHeight int64       `json:"start_height"`
+	EndHeight   int64       `json:"end_height"`
+	StartTime   *types.Time `json:"start_time"`
+	EndTime     types.Time  `json:"end_time"`
+
+	TotalStake            types.Quantity `json:"total_stake"`
+	ActiveValidatorsCount int64          `json:"active_validators_count"`
+	NominatorsCount       int64          `json:"nominators_count"`
+	ValidatorStakeMin     types.Quantity `json:"validator_stake_min"`
+	ValidatorStakeMax     types.Quantity `json:"validator_stake_max"`
+	ValidatorStakeMedian  types.Quantity `json:"validator_stake_median"`
+	TotalRewardPayout     types.Quantity `json:"total_reward_payout"`
+	TotalRewardPoints     int64          `json:"total_reward_points"`
+	CommissionAvg         float64        `json:"commission_avg"`
+}
+
+// swagger:response EraListView
+type ListView struct {
+	Items []EraView `json:"items"`
+}
+
+func ToEraView(row store.EraSummaryRow) *EraView {
+	return &EraView{
+		Era:         row.Era,
+		StartHeight: row.StartHeight,
+		EndHeight:   row.EndHeight,
+		StartTime:   row.StartTime,
+		EndTime:     row.Time,
+
+		TotalStake:            row.TotalStake,
+		ActiveValidatorsCount: row.ActiveValidatorsCount,
+		NominatorsCount:       row.NominatorsCount,
+		ValidatorStakeMin:     row.ValidatorStakeMin,
+		ValidatorStakeMax:     row.ValidatorStakeMax,
+		ValidatorStakeMedian:  row.ValidatorStakeMedian,
+		TotalRewardPayout:     row.TotalRewardPayout,
+		TotalRewardPoints:     row.TotalRewardPoints,
+		CommissionAvg:         row.CommissionAvg,
+	}
+}
+
+func ToListView(rows []store.EraSummaryRow) *ListView {
+	items := make([]EraView, len(rows))
+	for i, row := range rows {
+		items[i] = *ToEraView(row)
+	}
+	return &ListView{
+		Items: items,
+	}
+}
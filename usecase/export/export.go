@@ -0,0 +1,339 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+const (
+	// heightPageSize is number of heights fetched at once for height tables, era tables are fetched era by era
+	heightPageSize = 1000
+
+	systemEventsTable = "system_events"
+)
+
+var (
+	ErrOutputRequired = errors.New("output directory is required")
+	ErrUnknownTable   = errors.New("unknown export table")
+	ErrInvalidRange   = errors.New("end of export range must not be lower than start")
+)
+
+// Tables lists all tables which can be exported
+var Tables = []string{
+	model.BlockSeq{}.TableName(),
+	model.ValidatorSeq{}.TableName(),
+	model.ValidatorEraSeq{}.TableName(),
+	model.AccountEraSeq{}.TableName(),
+	model.EventSeq{}.TableName(),
+	model.TransactionSeq{}.TableName(),
+	model.RewardEraSeq{}.TableName(),
+	systemEventsTable,
+}
+
+// Params holds export parameters, era range takes precedence over height range when any era is set
+type Params struct {
+	Tables  []string
+	Format  string
+	Output  string
+	Address string
+
+	StartHeight int64
+	EndHeight   int64
+	// StartEra and EndEra are -1 when not set
+	StartEra int64
+	EndEra   int64
+}
+
+type exportRange struct {
+	StartHeight int64
+	EndHeight   int64
+	StartEra    int64
+	EndEra      int64
+}
+
+type exportUseCase struct {
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	eventDb       store.Events
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewExportUseCase(accountDb store.Accounts, blockDb store.Blocks, eventDb store.Events, rewardDb store.Rewards, syncableDb store.Syncables,
+	systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *exportUseCase {
+	return &exportUseCase{
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+func (uc *exportUseCase) Execute(ctx context.Context, params Params) error {
+	defer metric.LogUseCaseDuration(time.Now(), "export")
+
+	if err := validateFormat(params.Format); err != nil {
+		return err
+	}
+
+	if params.Output == "" {
+		return ErrOutputRequired
+	}
+
+	tables := params.Tables
+	if len(tables) == 0 {
+		tables = Tables
+	}
+	for _, table := range tables {
+		if !isValidTable(table) {
+			return fmt.Errorf("%w: %s", ErrUnknownTable, table)
+		}
+	}
+
+	r, err := uc.getRange(params)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(params.Output, 0755); err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("exporting tables... [heights=%d-%d] [eras=%d-%d]", r.StartHeight, r.EndHeight, r.StartEra, r.EndEra))
+
+	for _, table := range tables {
+		if err := uc.exportTable(ctx, table, r, params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *exportUseCase) exportTable(ctx context.Context, table string, r *exportRange, params Params) error {
+	path := filepath.Join(params.Output, fmt.Sprintf("%s.%s", table, params.Format))
+
+	w, err := newFileWriter(path, params.Format)
+	if err != nil {
+		return err
+	}
+
+	start, end, pageSize := r.StartHeight, r.EndHeight, int64(heightPageSize)
+	if isEraTable(table) {
+		start, end, pageSize = r.StartEra, r.EndEra, 1
+	}
+
+	var count int64
+	for pageStart := start; pageStart <= end; pageStart += pageSize {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		pageEnd := pageStart + pageSize - 1
+		if pageEnd > end {
+			pageEnd = end
+		}
+
+		var records []interface{}
+		if records, err = uc.findPage(table, pageStart, pageEnd, params.Address); err != nil {
+			break
+		}
+
+		for _, record := range records {
+			if err = w.Write(record); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+		count += int64(len(records))
+	}
+
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d rows exported [table=%s] [file=%s]", count, table, path))
+
+	return nil
+}
+
+func (uc *exportUseCase) findPage(table string, start, end int64, address string) ([]interface{}, error) {
+	var records []interface{}
+
+	switch table {
+	case model.BlockSeq{}.TableName():
+		seqs, err := uc.blockDb.FindSeqsForHeightRange(start, end)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.ValidatorSeq{}.TableName():
+		seqs, err := uc.validatorDb.FindSeqsForHeightRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.ValidatorEraSeq{}.TableName():
+		seqs, err := uc.validatorDb.FindEraSeqsForEraRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.AccountEraSeq{}.TableName():
+		seqs, err := uc.accountDb.FindForEraRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.EventSeq{}.TableName():
+		seqs, err := uc.eventDb.FindForHeightRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.TransactionSeq{}.TableName():
+		seqs, err := uc.transactionDb.FindForHeightRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case model.RewardEraSeq{}.TableName():
+		seqs, err := uc.rewardDb.FindForEraRange(start, end, address)
+		if err != nil {
+			return nil, err
+		}
+		for i := range seqs {
+			records = append(records, &seqs[i])
+		}
+	case systemEventsTable:
+		var actors []string
+		if address != "" {
+			actors = []string{address}
+		}
+		events, err := uc.systemEventDb.FindForHeightRange(start, end, actors, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range events {
+			records = append(records, &events[i])
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTable, table)
+	}
+
+	return records, nil
+}
+
+// getRange resolves both height and era range from params using syncables
+func (uc *exportUseCase) getRange(params Params) (*exportRange, error) {
+	mostRecent, err := uc.syncableDb.FindMostRecent()
+	if err != nil {
+		return nil, err
+	}
+
+	r := &exportRange{}
+	if params.StartEra >= 0 || params.EndEra >= 0 {
+		r.StartEra, r.EndEra = params.StartEra, params.EndEra
+		if r.StartEra < 0 {
+			r.StartEra = 0
+		}
+		if r.EndEra < 0 {
+			r.EndEra = mostRecent.Era
+		}
+
+		if r.StartEra > 0 {
+			syncable, err := uc.syncableDb.FindLastInEra(r.StartEra - 1)
+			if err == nil {
+				r.StartHeight = syncable.Height + 1
+			} else if err != store.ErrNotFound {
+				return nil, err
+			}
+		}
+
+		r.EndHeight = mostRecent.Height
+		syncable, err := uc.syncableDb.FindLastInEra(r.EndEra)
+		if err == nil {
+			r.EndHeight = syncable.Height
+		} else if err != store.ErrNotFound {
+			return nil, err
+		}
+	} else {
+		r.StartHeight, r.EndHeight = params.StartHeight, params.EndHeight
+		if r.EndHeight == 0 {
+			r.EndHeight = mostRecent.Height
+		}
+
+		syncable, err := uc.syncableDb.FindByHeight(r.StartHeight)
+		if err == nil {
+			r.StartEra = syncable.Era
+		} else if err != store.ErrNotFound {
+			return nil, err
+		}
+
+		r.EndEra = mostRecent.Era
+		syncable, err = uc.syncableDb.FindByHeight(r.EndHeight)
+		if err == nil {
+			r.EndEra = syncable.Era
+		} else if err != store.ErrNotFound {
+			return nil, err
+		}
+	}
+
+	if r.EndHeight < r.StartHeight || r.EndEra < r.StartEra {
+		return nil, ErrInvalidRange
+	}
+
+	return r, nil
+}
+
+func isValidTable(table string) bool {
+	for _, t := range Tables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+func isEraTable(table string) bool {
+	switch table {
+	case model.ValidatorEraSeq{}.TableName(), model.AccountEraSeq{}.TableName(), model.RewardEraSeq{}.TableName():
+		return true
+	default:
+		return false
+	}
+}
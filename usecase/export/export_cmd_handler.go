@@ -0,0 +1,54 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type ExportCmdHandler struct {
+	useCase *exportUseCase
+
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	eventDb       store.Events
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewExportCmdHandler(accountDb store.Accounts, blockDb store.Blocks, eventDb store.Events, rewardDb store.Rewards, syncableDb store.Syncables,
+	systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *ExportCmdHandler {
+	return &ExportCmdHandler{
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+func (h *ExportCmdHandler) Handle(ctx context.Context, params Params) {
+	logger.Info(fmt.Sprintf("running export use case [handler=cmd] [tables=%v] [format=%s] [output=%s]", params.Tables, params.Format, params.Output))
+
+	err := h.getUseCase().Execute(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *ExportCmdHandler) getUseCase() *exportUseCase {
+	if h.useCase == nil {
+		return NewExportUseCase(h.accountDb, h.blockDb, h.eventDb, h.rewardDb, h.syncableDb, h.systemEventDb, h.transactionDb, h.validatorDb)
+	}
+	return h.useCase
+}
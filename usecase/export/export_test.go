@@ -0,0 +1,195 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// recordingBlocks records height ranges export fetches block sequences for
+type recordingBlocks struct {
+	store.Blocks
+
+	pages [][2]int64
+}
+
+func (b *recordingBlocks) FindSeqsForHeightRange(startHeight, endHeight int64) ([]model.BlockSeq, error) {
+	b.pages = append(b.pages, [2]int64{startHeight, endHeight})
+	return b.Blocks.FindSeqsForHeightRange(startHeight, endHeight)
+}
+
+// recordingRewards records era ranges export fetches rewards for
+type recordingRewards struct {
+	store.Rewards
+
+	pages [][2]int64
+}
+
+func (r *recordingRewards) FindForEraRange(startEra, endEra int64, stashAccount string) ([]model.RewardEraSeq, error) {
+	r.pages = append(r.pages, [2]int64{startEra, endEra})
+	return r.Rewards.FindForEraRange(startEra, endEra, stashAccount)
+}
+
+// newTestStore returns memory store with syncables and block sequences for heights 1 to 2500 in eras of 1000 heights
+// and rewards of two accounts for every era
+func newTestStore(t *testing.T) *memory.Store {
+	s := memory.New()
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for h := int64(1); h <= 2500; h++ {
+		tm := *types.NewTimeFromTime(start.Add(time.Duration(h) * time.Minute))
+		if err := s.GetSyncables().CreateOrUpdate(&model.Syncable{Height: h, Time: tm, Era: h / 1000}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.GetBlocks().CreateSeq(&model.BlockSeq{Sequence: &model.Sequence{Height: h, Time: tm}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rewards []model.RewardEraSeq
+	for era := int64(0); era <= 2; era++ {
+		for _, stash := range []string{"stash1", "stash2"} {
+			rewards = append(rewards, model.RewardEraSeq{
+				EraSequence:           &model.EraSequence{Era: era},
+				StashAccount:          stash,
+				ValidatorStashAccount: "validator",
+				Amount:                "100",
+				Kind:                  model.RewardReward,
+			})
+		}
+	}
+	if err := s.GetRewards().BulkUpsert(rewards); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func newTestUseCase(s *memory.Store, blocks store.Blocks, rewards store.Rewards) *exportUseCase {
+	return NewExportUseCase(s.GetAccounts(), blocks, s.GetEvents(), rewards, s.GetSyncables(), s.GetSystemEvents(), s.GetTransactions(), s.GetValidators())
+}
+
+func readJSONL(t *testing.T, path string) []map[string]interface{} {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var rows []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var row map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestExport_HeightTablesArePaged(t *testing.T) {
+	s := newTestStore(t)
+	blocks := &recordingBlocks{Blocks: s.GetBlocks()}
+	output := t.TempDir()
+
+	err := newTestUseCase(s, blocks, s.GetRewards()).Execute(context.Background(), Params{
+		Tables:      []string{model.BlockSeq{}.TableName()},
+		Format:      FormatJSONL,
+		Output:      output,
+		StartHeight: 1,
+		StartEra:    -1,
+		EndEra:      -1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantPages := [][2]int64{{1, 1000}, {1001, 2000}, {2001, 2500}}
+	if !reflect.DeepEqual(blocks.pages, wantPages) {
+		t.Errorf("want pages %v; got %v", wantPages, blocks.pages)
+	}
+
+	rows := readJSONL(t, filepath.Join(output, "block_sequences.jsonl"))
+	if len(rows) != 2500 {
+		t.Fatalf("want 2500 rows; got %d", len(rows))
+	}
+	for i, row := range rows {
+		if height := int64(row["height"].(float64)); height != int64(i+1) {
+			t.Fatalf("want row %d at height %d; got %d", i, i+1, height)
+		}
+	}
+}
+
+func TestExport_EraTablesArePagedByEra(t *testing.T) {
+	s := newTestStore(t)
+	rewards := &recordingRewards{Rewards: s.GetRewards()}
+	output := t.TempDir()
+
+	err := newTestUseCase(s, s.GetBlocks(), rewards).Execute(context.Background(), Params{
+		Tables:   []string{model.RewardEraSeq{}.TableName()},
+		Format:   FormatCSV,
+		Output:   output,
+		Address:  "stash2",
+		StartEra: 1,
+		EndEra:   -1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantPages := [][2]int64{{1, 1}, {2, 2}}
+	if !reflect.DeepEqual(rewards.pages, wantPages) {
+		t.Errorf("want pages %v; got %v", wantPages, rewards.pages)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(output, "reward_era_sequences.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "era,start_height,end_height,time,stash_account,validator_stash_account,amount,kind,claimed,tx_hash\n" +
+		"1,0,0,0001-01-01T00:00:00Z,stash2,validator,100,reward,false,\n" +
+		"2,0,0,0001-01-01T00:00:00Z,stash2,validator,100,reward,false,\n"
+	if string(data) != want {
+		t.Errorf("want file content %q; got %q", want, string(data))
+	}
+}
+
+func TestExport_InvalidParams(t *testing.T) {
+	s := newTestStore(t)
+
+	tests := []struct {
+		description string
+		params      Params
+		want        error
+	}{
+		{"invalid format", Params{Format: "parquet", Output: t.TempDir(), StartEra: -1, EndEra: -1}, ErrInvalidFormat},
+		{"missing output", Params{Format: FormatCSV, StartEra: -1, EndEra: -1}, ErrOutputRequired},
+		{"unknown table", Params{Tables: []string{"syncables"}, Format: FormatCSV, Output: t.TempDir(), StartEra: -1, EndEra: -1}, ErrUnknownTable},
+		{"invalid height range", Params{Format: FormatCSV, Output: t.TempDir(), StartHeight: 20, EndHeight: 10, StartEra: -1, EndEra: -1}, ErrInvalidRange},
+		{"invalid era range", Params{Format: FormatCSV, Output: t.TempDir(), StartEra: 2, EndEra: 1}, ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			err := newTestUseCase(s, s.GetBlocks(), s.GetRewards()).Execute(context.Background(), tt.params)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("want error %v; got %v", tt.want, err)
+			}
+		})
+	}
+}
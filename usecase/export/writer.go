@@ -0,0 +1,187 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Parquet is not supported, there is no Parquet encoder among module dependencies
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid export format, use jsonl or csv")
+)
+
+// rowWriter writes exported records to output file
+type rowWriter interface {
+	Write(record interface{}) error
+	Close() error
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case FormatJSONL, FormatCSV:
+		return nil
+	default:
+		return ErrInvalidFormat
+	}
+}
+
+// newFileWriter creates writer for given format, file is written to temporary path and renamed on Close
+func newFileWriter(path, format string) (rowWriter, error) {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fw := &fileWriter{file: f, buf: bufio.NewWriter(f), path: path, tmpPath: tmpPath}
+	switch format {
+	case FormatJSONL:
+		fw.rowWriter = &jsonlWriter{encoder: json.NewEncoder(fw.buf)}
+	case FormatCSV:
+		fw.rowWriter = &csvWriter{writer: csv.NewWriter(fw.buf)}
+	default:
+		f.Close()
+		os.Remove(tmpPath)
+		return nil, ErrInvalidFormat
+	}
+	return fw, nil
+}
+
+type fileWriter struct {
+	rowWriter
+
+	file    *os.File
+	buf     *bufio.Writer
+	path    string
+	tmpPath string
+}
+
+func (w *fileWriter) Close() error {
+	err := w.rowWriter.Close()
+	if err == nil {
+		err = w.buf.Flush()
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(w.tmpPath)
+		return err
+	}
+	return os.Rename(w.tmpPath, w.path)
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlWriter) Write(record interface{}) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlWriter) Close() error {
+	return nil
+}
+
+// csvWriter writes one column per json field of record, nested values are written as json
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []string
+}
+
+func (w *csvWriter) Write(record interface{}) error {
+	columns, values, err := flatten(record)
+	if err != nil {
+		return err
+	}
+
+	if w.columns == nil {
+		w.columns = columns
+		if err := w.writer.Write(columns); err != nil {
+			return err
+		}
+	}
+
+	return w.writer.Write(values)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// flatten returns json field names and values of struct, embedded structs are flattened like in encoding/json.
+// Record should be passed as pointer, so values with pointer receiver marshalers are formatted correctly
+func flatten(record interface{}) ([]string, []string, error) {
+	var columns, values []string
+
+	v := reflect.Indirect(reflect.ValueOf(record))
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("cannot export %T as csv", record)
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+
+		if field.Anonymous {
+			if value.Kind() == reflect.Ptr && value.IsNil() {
+				value = reflect.New(field.Type.Elem())
+			}
+			c, vs, err := flatten(value.Interface())
+			if err != nil {
+				return nil, nil, err
+			}
+			columns = append(columns, c...)
+			values = append(values, vs...)
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if value.CanAddr() {
+			value = value.Addr()
+		}
+		s, err := formatValue(value.Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+		columns = append(columns, name)
+		values = append(values, s)
+	}
+
+	return columns, values, nil
+}
+
+func formatValue(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	if string(data) == "null" {
+		return "", nil
+	}
+	return string(data), nil
+}
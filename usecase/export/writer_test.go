@@ -0,0 +1,127 @@
+package export
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func newBlockSeq(height int64, extrinsics int64) *model.BlockSeq {
+	return &model.BlockSeq{
+		ID:              types.ID(height),
+		Sequence:        &model.Sequence{Height: height, Time: *types.NewTimeFromTime(time.Unix(1600000000+height, 0))},
+		ExtrinsicsCount: extrinsics,
+	}
+}
+
+func writeFile(t *testing.T, format string, records ...interface{}) string {
+	path := filepath.Join(t.TempDir(), "out."+format)
+
+	w, err := newFileWriter(path, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("want file to be written only on close; got %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFileWriter_JSONL(t *testing.T) {
+	got := writeFile(t, FormatJSONL, newBlockSeq(1, 2), newBlockSeq(2, 0))
+
+	want := `{"id":1,"height":1,"time":"2020-09-13T12:26:41Z","extrinsics_count":2,"unsigned_extrinsics_count":0,"signed_extrinsics_count":0}` + "\n" +
+		`{"id":2,"height":2,"time":"2020-09-13T12:26:42Z","extrinsics_count":0,"unsigned_extrinsics_count":0,"signed_extrinsics_count":0}` + "\n"
+	if got != want {
+		t.Errorf("want file content %q; got %q", want, got)
+	}
+}
+
+func TestFileWriter_CSV(t *testing.T) {
+	t.Run("writes header once and flattens embedded structs", func(t *testing.T) {
+		got := writeFile(t, FormatCSV, newBlockSeq(1, 2), newBlockSeq(2, 0))
+
+		want := "id,height,time,extrinsics_count,unsigned_extrinsics_count,signed_extrinsics_count\n" +
+			"1,1,2020-09-13T12:26:41Z,2,0,0\n" +
+			"2,2,2020-09-13T12:26:42Z,0,0,0\n"
+		if got != want {
+			t.Errorf("want file content %q; got %q", want, got)
+		}
+	})
+
+	t.Run("writes nested values as json and nil embedded structs as zero values", func(t *testing.T) {
+		got := writeFile(t, FormatCSV, &model.ValidatorEraSeq{
+			StashAccount:    "stash",
+			SessionAccounts: []string{"a", "b"},
+			TotalStake:      types.NewQuantityFromInt64(100),
+		})
+
+		want := "id,era,start_height,end_height,time,stash_account,controller_account,session_accounts,index,total_stake,own_stake,stakers_stake,reward_points,commission,stakers_count\n" +
+			`0,0,0,0,0001-01-01T00:00:00Z,stash,,"[""a"",""b""]",0,100,0,0,0,0,0` + "\n"
+		if got != want {
+			t.Errorf("want file content %q; got %q", want, got)
+		}
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	columns, values, err := flatten(newBlockSeq(3, 4))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantColumns := []string{"id", "height", "time", "extrinsics_count", "unsigned_extrinsics_count", "signed_extrinsics_count"}
+	if !reflect.DeepEqual(columns, wantColumns) {
+		t.Errorf("want columns %v; got %v", wantColumns, columns)
+	}
+	wantValues := []string{"3", "3", "2020-09-13T12:26:43Z", "4", "0", "0"}
+	if !reflect.DeepEqual(values, wantValues) {
+		t.Errorf("want values %v; got %v", wantValues, values)
+	}
+
+	if _, _, err := flatten("not a struct"); err == nil {
+		t.Error("want error for non struct record")
+	}
+}
+
+func TestNewFileWriter_InvalidFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, format := range []string{"parquet", "xml", ""} {
+		if _, err := newFileWriter(filepath.Join(dir, "out"), format); err != ErrInvalidFormat {
+			t.Errorf("want error %v for format %q; got %v", ErrInvalidFormat, format, err)
+		}
+		if err := validateFormat(format); err != ErrInvalidFormat {
+			t.Errorf("want validation error %v for format %q; got %v", ErrInvalidFormat, format, err)
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("want no files left behind; got %d", len(files))
+	}
+}
@@ -0,0 +1,202 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var (
+	ErrIndexingNotStarted = errors.New("indexing has not started yet")
+	ErrChainHeadUnknown   = errors.New("chain head is unknown")
+	ErrMigrationMismatch  = errors.New("database migration version does not match binary")
+	ErrLagTooHigh         = errors.New("indexer lag exceeds threshold")
+	ErrReportTooOld       = errors.New("last completed report exceeds max age")
+)
+
+type getDetailsUseCase struct {
+	cfg    *config.Config
+	client *client.Client
+
+	databaseDb store.Database
+	reportDb   store.Reports
+	syncableDb store.Syncables
+}
+
+func NewGetDetailsUseCase(cfg *config.Config, c *client.Client, databaseDb store.Database, reportDb store.Reports, syncableDb store.Syncables) *getDetailsUseCase {
+	return &getDetailsUseCase{
+		cfg:    cfg,
+		client: c,
+
+		databaseDb: databaseDb,
+		reportDb:   reportDb,
+		syncableDb: syncableDb,
+	}
+}
+
+func (uc *getDetailsUseCase) Execute(ctx context.Context) (*DetailsView, error) {
+	maxLagInterval, err := parseThreshold(uc.cfg.ReadinessMaxLagInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	maxReportAge, err := parseThreshold(uc.cfg.ReadinessMaxReportAge)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &DetailsView{
+		Database:   uc.checkDatabase(),
+		Migration:  uc.checkMigration(),
+		Proxy:      uc.checkProxy(ctx),
+		LastReport: uc.checkLastReport(maxReportAge),
+	}
+	view.Lag = uc.checkLag(view.Proxy, maxLagInterval)
+
+	view.Ready = view.Database.Healthy &&
+		view.Migration.Healthy &&
+		view.LastReport.Healthy
+
+	// indexed data can still be served while proxy is down, lag is only known when chain head is
+	if view.Proxy.Required || view.Proxy.Healthy {
+		view.Ready = view.Ready && view.Proxy.Healthy && view.Lag.Healthy
+	}
+
+	return view, nil
+}
+
+func (uc *getDetailsUseCase) checkDatabase() CheckView {
+	if err := uc.databaseDb.Ping(); err != nil {
+		return newFailedCheck(err)
+	}
+	return CheckView{Healthy: true}
+}
+
+func (uc *getDetailsUseCase) checkMigration() MigrationCheckView {
+	view := MigrationCheckView{
+		ExpectedVersion: config.MigrationVersion,
+	}
+
+	res, err := uc.databaseDb.GetMigrationVersion()
+	if err != nil {
+		view.CheckView = newFailedCheck(err)
+		return view
+	}
+
+	view.CurrentVersion = res.Version
+	view.Dirty = res.Dirty
+
+	if res.Dirty || res.Version != config.MigrationVersion {
+		view.CheckView = newFailedCheck(ErrMigrationMismatch)
+		return view
+	}
+
+	view.Healthy = true
+	return view
+}
+
+func (uc *getDetailsUseCase) checkProxy(ctx context.Context) ProxyCheckView {
+	view := ProxyCheckView{
+		Required: uc.cfg.ReadinessRequireProxy,
+	}
+
+	res, err := uc.client.Chain.GetHead(ctx)
+	if err != nil {
+		view.CheckView = newFailedCheck(err)
+		return view
+	}
+
+	view.Healthy = true
+	view.HeadHeight = res.GetHeight()
+	if res.GetTime() != nil {
+		view.HeadTime = *types.NewTimeFromTimestamp(*res.GetTime())
+	}
+
+	return view
+}
+
+func (uc *getDetailsUseCase) checkLag(head ProxyCheckView, maxLagInterval time.Duration) LagCheckView {
+	view := LagCheckView{
+		MaxBlocks:  uc.cfg.ReadinessMaxLagBlocks,
+		MaxSeconds: maxLagInterval.Seconds(),
+	}
+
+	mostRecentSyncable, err := uc.syncableDb.FindMostRecent()
+	if err != nil {
+		if err == store.ErrNotFound {
+			err = ErrIndexingNotStarted
+		}
+		view.CheckView = newFailedCheck(err)
+		return view
+	}
+	view.LastIndexedHeight = mostRecentSyncable.Height
+	view.LastIndexedTime = mostRecentSyncable.Time
+
+	if !head.Healthy {
+		view.CheckView = newFailedCheck(ErrChainHeadUnknown)
+		return view
+	}
+
+	view.Blocks = head.HeadHeight - mostRecentSyncable.Height
+	if !head.HeadTime.IsZero() {
+		view.Seconds = head.HeadTime.Sub(mostRecentSyncable.Time.Time).Seconds()
+	}
+
+	if (view.MaxBlocks > 0 && view.Blocks > view.MaxBlocks) || (view.MaxSeconds > 0 && view.Seconds > view.MaxSeconds) {
+		view.CheckView = newFailedCheck(ErrLagTooHigh)
+		return view
+	}
+
+	view.Healthy = true
+	return view
+}
+
+func (uc *getDetailsUseCase) checkLastReport(maxReportAge time.Duration) ReportCheckView {
+	view := ReportCheckView{
+		MaxAgeSeconds: maxReportAge.Seconds(),
+	}
+	enforced := maxReportAge > 0
+
+	report, err := uc.reportDb.FindLastCompleted(model.ReportKindIndex)
+	if err != nil {
+		if err != store.ErrNotFound || enforced {
+			view.CheckView = newFailedCheck(err)
+			return view
+		}
+		view.Healthy = true
+		return view
+	}
+
+	view.ReportID = report.ID
+	view.CompletedAt = report.CompletedAt
+	view.AgeSeconds = time.Since(report.CompletedAt.Time).Seconds()
+
+	if enforced && view.AgeSeconds > view.MaxAgeSeconds {
+		view.CheckView = newFailedCheck(ErrReportTooOld)
+		return view
+	}
+
+	view.Healthy = true
+	return view
+}
+
+func newFailedCheck(err error) CheckView {
+	return CheckView{
+		Healthy: false,
+		Error:   err.Error(),
+	}
+}
+
+// parseThreshold parses duration threshold, empty value disables the threshold
+func parseThreshold(threshold string) (time.Duration, error) {
+	if threshold == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(threshold)
+}
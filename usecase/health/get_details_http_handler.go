@@ -0,0 +1,56 @@
+package health
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getDetailsHttpHandler)(nil)
+)
+
+type getDetailsHttpHandler struct {
+	cfg    *config.Config
+	client *client.Client
+
+	useCase *getDetailsUseCase
+
+	databaseDb store.Database
+	reportDb   store.Reports
+	syncableDb store.Syncables
+}
+
+func NewGetDetailsHttpHandler(cfg *config.Config, c *client.Client, databaseDb store.Database, reportDb store.Reports, syncableDb store.Syncables) *getDetailsHttpHandler {
+	return &getDetailsHttpHandler{
+		cfg:    cfg,
+		client: c,
+
+		databaseDb: databaseDb,
+		reportDb:   reportDb,
+		syncableDb: syncableDb,
+	}
+}
+
+func (h *getDetailsHttpHandler) Handle(c *gin.Context) {
+	resp, err := h.getUseCase().Execute(c.Request.Context())
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getDetailsHttpHandler) getUseCase() *getDetailsUseCase {
+	if h.useCase == nil {
+		h.useCase = NewGetDetailsUseCase(h.cfg, h.client, h.databaseDb, h.reportDb, h.syncableDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	nethttp "net/http"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getReadyHttpHandler)(nil)
+)
+
+type getReadyHttpHandler struct {
+	cfg    *config.Config
+	client *client.Client
+
+	useCase *getDetailsUseCase
+
+	databaseDb store.Database
+	reportDb   store.Reports
+	syncableDb store.Syncables
+}
+
+func NewGetReadyHttpHandler(cfg *config.Config, c *client.Client, databaseDb store.Database, reportDb store.Reports, syncableDb store.Syncables) *getReadyHttpHandler {
+	return &getReadyHttpHandler{
+		cfg:    cfg,
+		client: c,
+
+		databaseDb: databaseDb,
+		reportDb:   reportDb,
+		syncableDb: syncableDb,
+	}
+}
+
+// Handle responds with 503 when any of the checks fails so load balancer can drain the replica
+func (h *getReadyHttpHandler) Handle(c *gin.Context) {
+	resp, err := h.getUseCase().Execute(c.Request.Context())
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	if !resp.Ready {
+		c.JSON(nethttp.StatusServiceUnavailable, resp)
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getReadyHttpHandler) getUseCase() *getDetailsUseCase {
+	if h.useCase == nil {
+		h.useCase = NewGetDetailsUseCase(h.cfg, h.client, h.databaseDb, h.reportDb, h.syncableDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// swagger:response HealthDetailsView
+type DetailsView struct {
+	// Ready is true when all checks are healthy, proxy and lag checks are skipped when proxy is unreachable and not required
+	Ready bool `json:"ready"`
+
+	Database   CheckView          `json:"database"`
+	Migration  MigrationCheckView `json:"migration"`
+	Proxy      ProxyCheckView     `json:"proxy"`
+	Lag        LagCheckView       `json:"lag"`
+	LastReport ReportCheckView    `json:"last_report"`
+}
+
+type CheckView struct {
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+type MigrationCheckView struct {
+	CheckView
+
+	// ExpectedVersion is migration version binary was built for
+	ExpectedVersion uint `json:"expected_version"`
+	// CurrentVersion is migration version of the database
+	CurrentVersion uint `json:"current_version"`
+	// Dirty is true when last migration failed
+	Dirty bool `json:"dirty"`
+}
+
+type ProxyCheckView struct {
+	CheckView
+
+	// Required is true when unreachable proxy fails readiness
+	Required bool `json:"required"`
+
+	HeadHeight int64      `json:"head_height,omitempty"`
+	HeadTime   types.Time `json:"head_time,omitempty"`
+}
+
+type LagCheckView struct {
+	CheckView
+
+	LastIndexedHeight int64      `json:"last_indexed_height,omitempty"`
+	LastIndexedTime   types.Time `json:"last_indexed_time,omitempty"`
+	// Blocks is how many blocks the indexer is behind the head of the chain
+	Blocks int64 `json:"blocks"`
+	// Seconds is how many seconds the indexer is behind the head of the chain
+	Seconds    float64 `json:"seconds"`
+	MaxBlocks  int64   `json:"max_blocks"`
+	MaxSeconds float64 `json:"max_seconds"`
+}
+
+type ReportCheckView struct {
+	CheckView
+
+	ReportID    types.ID    `json:"report_id,omitempty"`
+	CompletedAt *types.Time `json:"completed_at,omitempty"`
+	// AgeSeconds is how many seconds ago the last index report completed
+	AgeSeconds    float64 `json:"age_seconds"`
+	MaxAgeSeconds float64 `json:"max_age_seconds"`
+}
@@ -9,23 +9,30 @@ import (
 	"github.com/figment-networks/polkadothub-indexer/usecase/apr"
 	"github.com/figment-networks/polkadothub-indexer/usecase/block"
 	"github.com/figment-networks/polkadothub-indexer/usecase/chain"
+	"github.com/figment-networks/polkadothub-indexer/usecase/era"
 	"github.com/figment-networks/polkadothub-indexer/usecase/health"
+	"github.com/figment-networks/polkadothub-indexer/usecase/indexing"
 	"github.com/figment-networks/polkadothub-indexer/usecase/reward"
+	"github.com/figment-networks/polkadothub-indexer/usecase/stream"
 	"github.com/figment-networks/polkadothub-indexer/usecase/system_event"
 	"github.com/figment-networks/polkadothub-indexer/usecase/transaction"
 	"github.com/figment-networks/polkadothub-indexer/usecase/validator"
+	"github.com/figment-networks/polkadothub-indexer/usecase/webhook"
 )
 
-func NewHttpHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, reportDb store.Reports,
-	rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+func NewHttpHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, jobDb store.Jobs, priceDb store.Prices,
+	reportDb store.Reports, rewardDb store.Rewards, subscriptionDb store.Subscriptions, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
 ) *HttpHandlers {
 	return &HttpHandlers{
 		Health:                     health.NewHealthHttpHandler(),
+		GetHealthReady:             health.NewGetReadyHttpHandler(cfg, cli, databaseDb, reportDb, syncableDb),
+		GetHealthDetails:           health.NewGetDetailsHttpHandler(cfg, cli, databaseDb, reportDb, syncableDb),
 		GetStatus:                  chain.NewGetStatusHttpHandler(cli, syncableDb),
 		GetBlockByHeight:           block.NewGetByHeightHttpHandler(cli, syncableDb),
 		GetBlockTimes:              block.NewGetBlockTimesHttpHandler(blockDb),
 		GetBlockSummary:            block.NewGetBlockSummaryHttpHandler(blockDb),
 		GetTransactionsByHeight:    transaction.NewGetByHeightHttpHandler(cli, syncableDb),
+		GetTransactionsSummary:     transaction.NewGetSummaryHttpHandler(cfg, eventDb, transactionDb),
 		GetAccountByHeight:         account.NewGetByHeightHttpHandler(cli, syncableDb),
 		GetAccountDetails:          account.NewGetDetailsHttpHandler(cli, accountDb, eventDb, syncableDb),
 		GetAccountRewards:          account.NewGetRewardsHttpHandler(eventDb, syncableDb),
@@ -34,18 +41,35 @@ func NewHttpHandlers(cfg *config.Config, cli *client.Client, accountDb store.Acc
 		GetValidatorByStashAccount: validator.NewGetByStashAccountHttpHandler(accountDb, validatorDb),
 		GetValidatorSummary:        validator.NewGetSummaryHttpHandler(syncableDb, validatorDb),
 		GetValidatorsForMinHeight:  validator.NewGetForMinHeightHttpHandler(syncableDb, validatorDb),
+		GetValidatorRanking:        validator.NewGetRankingHttpHandler(cfg, eventDb, rewardDb, syncableDb, validatorDb),
 		GetRewardsForStashAccount:  reward.NewGetForStashAccountHttpHandler(rewardDb),
+		GetRewardsReport:           reward.NewGetReportHttpHandler(cfg, priceDb, rewardDb, syncableDb),
 		GetAPRByAddress:            apr.NewGetAprByAddressHttpHandler(accountDb, rewardDb, syncableDb),
+		GetEras:                    era.NewGetRecentHttpHandler(validatorDb),
+		GetEra:                     era.NewGetByEraHttpHandler(validatorDb),
+		GetStream:                  stream.NewGetStreamHttpHandler(cfg, syncableDb, systemEventDb),
+		CreateJob:                  indexing.NewCreateJobHttpHandler(cfg, jobDb),
+		GetJobs:                    indexing.NewGetJobsHttpHandler(jobDb),
+		CancelJob:                  indexing.NewCancelJobHttpHandler(jobDb),
+		GetReports:                 indexing.NewGetReportsHttpHandler(reportDb, syncableDb),
+		CreateSubscription:         webhook.NewCreateSubscriptionHttpHandler(subscriptionDb, syncableDb, systemEventDb),
+		GetSubscriptions:           webhook.NewGetSubscriptionsHttpHandler(subscriptionDb),
+		GetSubscription:            webhook.NewGetSubscriptionHttpHandler(subscriptionDb),
+		UpdateSubscription:         webhook.NewUpdateSubscriptionHttpHandler(subscriptionDb),
+		DeleteSubscription:         webhook.NewDeleteSubscriptionHttpHandler(subscriptionDb),
 	}
 }
 
 type HttpHandlers struct {
 	Health                     types.HttpHandler
+	GetHealthReady             types.HttpHandler
+	GetHealthDetails           types.HttpHandler
 	GetStatus                  types.HttpHandler
 	GetBlockTimes              types.HttpHandler
 	GetBlockSummary            types.HttpHandler
 	GetBlockByHeight           types.HttpHandler
 	GetTransactionsByHeight    types.HttpHandler
+	GetTransactionsSummary     types.HttpHandler
 	GetAccountByHeight         types.HttpHandler
 	GetAccountRewards          types.HttpHandler
 	GetAccountDetails          types.HttpHandler
@@ -54,6 +78,20 @@ type HttpHandlers struct {
 	GetValidatorByStashAccount types.HttpHandler
 	GetValidatorSummary        types.HttpHandler
 	GetValidatorsForMinHeight  types.HttpHandler
+	GetValidatorRanking        types.HttpHandler
 	GetRewardsForStashAccount  types.HttpHandler
+	GetRewardsReport           types.HttpHandler
 	GetAPRByAddress            types.HttpHandler
+	GetEras                    types.HttpHandler
+	GetEra                     types.HttpHandler
+	GetStream                  types.HttpHandler
+	CreateJob                  types.HttpHandler
+	GetJobs                    types.HttpHandler
+	CancelJob                  types.HttpHandler
+	GetReports                 types.HttpHandler
+	CreateSubscription         types.HttpHandler
+	GetSubscriptions           types.HttpHandler
+	GetSubscription            types.HttpHandler
+	UpdateSubscription         types.HttpHandler
+	DeleteSubscription         types.HttpHandler
 }
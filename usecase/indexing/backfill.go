@@ -55,6 +55,7 @@ type BackfillUseCaseConfig struct {
 	Parallel  bool
 	Force     bool
 	TargetIds []int64
+	Shadow    bool
 }
 
 func (uc *backfillUseCase) Execute(ctx context.Context, useCaseConfig BackfillUseCaseConfig) error {
@@ -62,16 +63,22 @@ func (uc *backfillUseCase) Execute(ctx context.Context, useCaseConfig BackfillUs
 		return err
 	}
 
+	backfillCfg := indexer.BackfillConfig{
+		Parallel:  useCaseConfig.Parallel,
+		Force:     useCaseConfig.Force,
+		TargetIds: useCaseConfig.TargetIds,
+	}
+
+	if useCaseConfig.Shadow {
+		return uc.executeShadow(ctx, backfillCfg)
+	}
+
 	indexingPipeline, err := indexer.NewPipeline(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb)
 	if err != nil {
 		return err
 	}
 
-	return indexingPipeline.Backfill(ctx, indexer.BackfillConfig{
-		Parallel:  useCaseConfig.Parallel,
-		Force:     useCaseConfig.Force,
-		TargetIds: useCaseConfig.TargetIds,
-	})
+	return indexingPipeline.Backfill(ctx, backfillCfg)
 }
 
 // canExecute checks if reindex is already running
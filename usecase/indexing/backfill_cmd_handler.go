@@ -47,13 +47,14 @@ func NewBackfillCmdHandler(cfg *config.Config, cli *client.Client, accountDb sto
 	}
 }
 
-func (h *BackfillCmdHandler) Handle(ctx context.Context, parallel bool, force bool, targetIds []int64) {
+func (h *BackfillCmdHandler) Handle(ctx context.Context, parallel bool, force bool, targetIds []int64, shadow bool) {
 	logger.Info("running backfill use case [handler=cmd]")
 
 	useCaseConfig := BackfillUseCaseConfig{
 		Parallel:  parallel,
 		Force:     force,
 		TargetIds: targetIds,
+		Shadow:    shadow,
 	}
 	err := h.getUseCase().Execute(ctx, useCaseConfig)
 	if err != nil {
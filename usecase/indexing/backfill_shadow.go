@@ -0,0 +1,128 @@
+package indexing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/indexer"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+// shadowSwapDetails is recorded in report of shadow swap
+type shadowSwapDetails struct {
+	BackfillReportID types.ID                 `json:"backfill_report_id"`
+	Tables           []store.ShadowTableCheck `json:"tables"`
+}
+
+// executeShadow backfills tables written by missing versions into shadow tables while live tables keep serving.
+// Once backfill completes and shadow tables pass checks, they replace live tables in one transaction
+func (uc *backfillUseCase) executeShadow(ctx context.Context, backfillCfg indexer.BackfillConfig) error {
+	livePipeline, err := indexer.NewPipeline(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb)
+	if err != nil {
+		return err
+	}
+
+	tables, err := livePipeline.ShadowTables()
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		logger.Info("backfill writes no tables, running it on live tables")
+		return livePipeline.Backfill(ctx, backfillCfg)
+	}
+
+	if err = uc.databaseDb.CreateShadowTables(tables); err != nil {
+		return err
+	}
+
+	shadowDb, err := uc.databaseDb.OpenShadowStore()
+	if err != nil {
+		return err
+	}
+	defer shadowDb.Close()
+
+	// Backfill progress is tracked in shadow syncables, live ones get new index version only when shadow tables are swapped.
+	// Reports stay live
+	shadowPipeline, err := indexer.NewPipeline(uc.cfg, uc.client, shadowDb.GetAccounts(), shadowDb.GetBlocks(), uc.databaseDb, shadowDb.GetEvents(), uc.reportDb, shadowDb.GetRewards(), shadowDb.GetSyncables(),
+		shadowDb.GetSystemEvents(), shadowDb.GetTransactions(), shadowDb.GetValidators())
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("starting shadow backfill [tables=%d]", len(tables)))
+
+	if err = shadowPipeline.Backfill(ctx, backfillCfg); err != nil {
+		return err
+	}
+
+	backfillReport, err := uc.reportDb.FindLastCompleted(model.ReportKindSequentialReindex, model.ReportKindParallelReindex)
+	if err != nil {
+		return err
+	}
+
+	return uc.swapShadowTables(tables, backfillReport)
+}
+
+// swapShadowTables checks shadow tables against live ones and swaps them when all checks pass. Outcome is recorded
+// in shadow swap report, shadow tables which failed checks are kept for inspection until next shadow backfill
+func (uc *backfillUseCase) swapShadowTables(tables []store.ShadowTable, backfillReport *model.Report) error {
+	report := &model.Report{
+		Kind:         model.ReportKindShadowSwap,
+		IndexVersion: backfillReport.IndexVersion,
+		StartHeight:  backfillReport.StartHeight,
+		EndHeight:    backfillReport.EndHeight,
+	}
+	if err := uc.reportDb.Create(report); err != nil {
+		return err
+	}
+
+	checks, err := uc.databaseDb.CheckShadowTables(tables, backfillReport.EndHeight)
+	if err == nil {
+		err = uc.verifyShadowTables(backfillReport, checks)
+	}
+	if err == nil {
+		err = uc.databaseDb.SwapShadowTables(tables, backfillReport.EndHeight)
+	}
+
+	var swapped int64
+	if err == nil {
+		swapped = int64(len(tables))
+		logger.Info(fmt.Sprintf("shadow tables swapped [tables=%d] [end=%d]", swapped, backfillReport.EndHeight))
+	}
+
+	details, detailsErr := json.Marshal(shadowSwapDetails{BackfillReportID: backfillReport.ID, Tables: checks})
+	if detailsErr != nil {
+		return detailsErr
+	}
+	report.Details = &types.Jsonb{RawMessage: details}
+	report.Complete(swapped, int64(len(tables))-swapped, err)
+
+	if saveErr := uc.reportDb.Save(report); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+// verifyShadowTables checks that backfill completed without errors and that shadow tables lost no heights
+// and no more rows than allowed
+func (uc *backfillUseCase) verifyShadowTables(backfillReport *model.Report, checks []store.ShadowTableCheck) error {
+	if backfillReport.ErrorMsg != nil || (backfillReport.ErrorCount != nil && *backfillReport.ErrorCount > 0) {
+		return fmt.Errorf("backfill report %d has errors", backfillReport.ID)
+	}
+
+	for _, check := range checks {
+		if check.MissingHeights > 0 {
+			return fmt.Errorf("shadow table %s misses %d heights of live table", check.Table, check.MissingHeights)
+		}
+
+		minCount := float64(check.LiveCount) * (1 - uc.cfg.ShadowMaxRowLoss)
+		if float64(check.ShadowCount) < minCount {
+			return fmt.Errorf("shadow table %s has %d rows, live table has %d", check.Table, check.ShadowCount, check.LiveCount)
+		}
+	}
+	return nil
+}
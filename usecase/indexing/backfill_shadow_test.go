@@ -0,0 +1,164 @@
+package indexing
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	mock "github.com/figment-networks/polkadothub-indexer/mock/store"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/golang/mock/gomock"
+)
+
+func TestBackfillUseCase_swapShadowTables(t *testing.T) {
+	tables := []store.ShadowTable{
+		{Name: "block_sequences", HeightColumn: "height"},
+		{Name: "syncables", HeightColumn: "height", Seeded: true},
+	}
+	passingChecks := []store.ShadowTableCheck{
+		{Table: "block_sequences", LiveCount: 100, ShadowCount: 100},
+		{Table: "syncables", LiveCount: 100, ShadowCount: 100},
+	}
+	errorCount := int64(1)
+	errorMsg := "proxy unavailable"
+
+	tests := []struct {
+		description    string
+		maxRowLoss     float64
+		backfillReport model.Report
+		checks         []store.ShadowTableCheck
+		checkErr       error
+		swapErr        error
+		wantSwap       bool
+		wantErr        string
+	}{
+		{
+			description: "swaps tables which pass checks",
+			checks:      passingChecks,
+			wantSwap:    true,
+		},
+		{
+			description: "swaps tables which lost rows within allowed loss",
+			maxRowLoss:  0.1,
+			checks:      []store.ShadowTableCheck{{Table: "block_sequences", LiveCount: 100, ShadowCount: 90}, passingChecks[1]},
+			wantSwap:    true,
+		},
+		{
+			description: "fails when table lost more rows than allowed",
+			maxRowLoss:  0.1,
+			checks:      []store.ShadowTableCheck{{Table: "block_sequences", LiveCount: 100, ShadowCount: 89}, passingChecks[1]},
+			wantErr:     "shadow table block_sequences has 89 rows, live table has 100",
+		},
+		{
+			description: "fails when table misses heights",
+			checks:      []store.ShadowTableCheck{{Table: "block_sequences", LiveCount: 100, ShadowCount: 100, MissingHeights: 2}, passingChecks[1]},
+			wantErr:     "shadow table block_sequences misses 2 heights of live table",
+		},
+		{
+			description:    "fails when backfill report has errors",
+			backfillReport: model.Report{ErrorCount: &errorCount},
+			checks:         passingChecks,
+			wantErr:        "backfill report 5 has errors",
+		},
+		{
+			description:    "fails when backfill report has error message",
+			backfillReport: model.Report{ErrorMsg: &errorMsg},
+			checks:         passingChecks,
+			wantErr:        "backfill report 5 has errors",
+		},
+		{
+			description: "fails when tables can't be checked",
+			checkErr:    errors.New("connection refused"),
+			wantErr:     "connection refused",
+		},
+		{
+			description: "fails when swap fails",
+			checks:      passingChecks,
+			swapErr:     errors.New("deadlock detected"),
+			wantSwap:    true,
+			wantErr:     "deadlock detected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			databaseDb := mock.NewMockDatabase(ctrl)
+			reportDb := mock.NewMockReports(ctrl)
+
+			backfillReport := tt.backfillReport
+			backfillReport.Model = &model.Model{ID: 5}
+			backfillReport.IndexVersion = 3
+			backfillReport.StartHeight = 1
+			backfillReport.EndHeight = 100
+
+			var saved *model.Report
+			reportDb.EXPECT().Create(gomock.Any()).DoAndReturn(func(report *model.Report) error {
+				report.Model = &model.Model{ID: 6}
+				return nil
+			})
+			reportDb.EXPECT().Save(gomock.Any()).DoAndReturn(func(report *model.Report) error {
+				saved = report
+				return nil
+			})
+
+			databaseDb.EXPECT().CheckShadowTables(tables, int64(100)).Return(tt.checks, tt.checkErr)
+			if tt.wantSwap {
+				databaseDb.EXPECT().SwapShadowTables(tables, int64(100)).Return(tt.swapErr)
+			}
+
+			uc := &backfillUseCase{
+				cfg:        &config.Config{ShadowMaxRowLoss: tt.maxRowLoss},
+				databaseDb: databaseDb,
+				reportDb:   reportDb,
+			}
+
+			err := uc.swapShadowTables(tables, &backfillReport)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("want error %q; got %v", tt.wantErr, err)
+			}
+
+			if saved == nil {
+				t.Fatal("want shadow swap report saved")
+			}
+			if saved.Kind != model.ReportKindShadowSwap || saved.IndexVersion != 3 || saved.StartHeight != 1 || saved.EndHeight != 100 {
+				t.Errorf("want shadow swap report of backfill range; got %+v", saved)
+			}
+			if saved.CompletedAt == nil {
+				t.Error("want shadow swap report completed")
+			}
+
+			wantSwapped, wantFailed := int64(len(tables)), int64(0)
+			if tt.wantErr != "" {
+				wantSwapped, wantFailed = 0, int64(len(tables))
+				if saved.ErrorMsg == nil || !strings.Contains(*saved.ErrorMsg, tt.wantErr) {
+					t.Errorf("want report error %q; got %v", tt.wantErr, saved.ErrorMsg)
+				}
+			} else if saved.ErrorMsg != nil {
+				t.Errorf("want no report error; got %s", *saved.ErrorMsg)
+			}
+			if *saved.SuccessCount != wantSwapped || *saved.ErrorCount != wantFailed {
+				t.Errorf("want %d swapped and %d failed tables; got %d and %d", wantSwapped, wantFailed, *saved.SuccessCount, *saved.ErrorCount)
+			}
+
+			var details shadowSwapDetails
+			if err := json.Unmarshal(saved.Details.RawMessage, &details); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if details.BackfillReportID != 5 {
+				t.Errorf("want backfill report id 5 in details; got %d", details.BackfillReportID)
+			}
+			if len(details.Tables) != len(tt.checks) {
+				t.Errorf("want %d table checks in details; got %d", len(tt.checks), len(details.Tables))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package indexing
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*cancelJobHttpHandler)(nil)
+
+	ErrJobNotCancelable = errors.New("job already finished")
+)
+
+type cancelJobHttpHandler struct {
+	jobDb store.Jobs
+}
+
+func NewCancelJobHttpHandler(jobDb store.Jobs) *cancelJobHttpHandler {
+	return &cancelJobHttpHandler{
+		jobDb: jobDb,
+	}
+}
+
+// swagger:parameters cancelJob
+type CancelJobRequest struct {
+	// ID of job
+	//
+	// required: true
+	// in: path
+	ID types.ID `json:"id" uri:"id" binding:"required"`
+}
+
+func (h *cancelJobHttpHandler) Handle(c *gin.Context) {
+	var req CancelJobRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	cancelled, err := h.jobDb.Cancel(req.ID)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	job, err := h.jobDb.FindByID(req.ID)
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	if !cancelled {
+		http.BadRequest(c, ErrJobNotCancelable)
+		return
+	}
+
+	http.JsonOK(c, job)
+}
@@ -0,0 +1,152 @@
+package indexing
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*createJobHttpHandler)(nil)
+)
+
+type createJobHttpHandler struct {
+	cfg *config.Config
+
+	jobDb store.Jobs
+}
+
+func NewCreateJobHttpHandler(cfg *config.Config, jobDb store.Jobs) *createJobHttpHandler {
+	return &createJobHttpHandler{
+		cfg:   cfg,
+		jobDb: jobDb,
+	}
+}
+
+// swagger:parameters createJob
+type CreateJobRequest struct {
+	// Kind of job: index, backfill, reindex, purge or summarize
+	//
+	// required: true
+	// in: path
+	Kind string `json:"kind" uri:"kind" binding:"required"`
+}
+
+// swagger:parameters createJob
+type CreateJobBody struct {
+	// BatchSize
+	//
+	// in: body
+	BatchSize int64 `json:"batch_size"`
+	// Parallel
+	//
+	// in: body
+	Parallel bool `json:"parallel"`
+	// Force
+	//
+	// in: body
+	Force bool `json:"force"`
+	// Shadow backfill writes into shadow tables and swaps them with live ones
+	//
+	// in: body
+	Shadow bool `json:"shadow"`
+	// TargetIds
+	//
+	// in: body
+	TargetIds []int64 `json:"target_ids"`
+	// TrxKinds in the format section.method
+	//
+	// in: body
+	TrxKinds []string `json:"trx_kinds"`
+	// LastInSession
+	//
+	// in: body
+	LastInSession bool `json:"last_in_session"`
+	// LastInEra
+	//
+	// in: body
+	LastInEra bool `json:"last_in_era"`
+	// StartHeight
+	//
+	// in: body
+	StartHeight int64 `json:"start_height"`
+	// EndHeight
+	//
+	// in: body
+	EndHeight int64 `json:"end_height"`
+}
+
+func (h *createJobHttpHandler) Handle(c *gin.Context) {
+	var req CreateJobRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid kind"))
+		return
+	}
+
+	var body CreateJobBody
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			logger.Error(err)
+			http.BadRequest(c, errors.New("invalid job params"))
+			return
+		}
+	}
+
+	params, err := body.toParams()
+	if err != nil {
+		http.BadRequest(c, err)
+		return
+	}
+
+	kind := model.JobKind(req.Kind)
+	if !kind.Valid() {
+		http.BadRequest(c, ErrUnknownJobKind)
+		return
+	}
+
+	job, err := model.NewJob(kind, params, h.cfg.JobMaxAttempts)
+	if err != nil {
+		http.BadRequest(c, err)
+		return
+	}
+
+	if err := h.jobDb.Create(job); err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, job)
+}
+
+func (b CreateJobBody) toParams() (model.JobParams, error) {
+	params := model.JobParams{
+		BatchSize:     b.BatchSize,
+		Parallel:      b.Parallel,
+		Force:         b.Force,
+		Shadow:        b.Shadow,
+		TargetIds:     b.TargetIds,
+		LastInSession: b.LastInSession,
+		LastInEra:     b.LastInEra,
+		StartHeight:   b.StartHeight,
+		EndHeight:     b.EndHeight,
+	}
+
+	for _, raw := range b.TrxKinds {
+		kind, err := model.ParseTransactionKind(raw)
+		if err != nil {
+			return params, err
+		}
+		params.TrxKinds = append(params.TrxKinds, kind)
+	}
+
+	return params, nil
+}
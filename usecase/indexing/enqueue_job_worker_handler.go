@@ -0,0 +1,53 @@
+package indexing
+
+import (
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	_ types.WorkerHandler = (*enqueueJobWorkerHandler)(nil)
+)
+
+// enqueueJobWorkerHandler produces scheduled job, it's skipped when the same job is still queued or running
+type enqueueJobWorkerHandler struct {
+	cfg   *config.Config
+	kind  model.JobKind
+	jobDb store.Jobs
+}
+
+func NewEnqueueJobWorkerHandler(cfg *config.Config, kind model.JobKind, jobDb store.Jobs) *enqueueJobWorkerHandler {
+	return &enqueueJobWorkerHandler{
+		cfg:   cfg,
+		kind:  kind,
+		jobDb: jobDb,
+	}
+}
+
+func (h *enqueueJobWorkerHandler) Handle() {
+	params := model.JobParams{}
+	if h.kind == model.JobKindIndex {
+		params.BatchSize = h.cfg.DefaultBatchSize
+	}
+
+	job, err := model.NewJob(h.kind, params, h.cfg.JobMaxAttempts)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	created, err := h.jobDb.CreateUnique(job, h.kind.String())
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if !created {
+		logger.Debug(fmt.Sprintf("job already scheduled [kind=%s]", h.kind))
+	}
+}
@@ -0,0 +1,63 @@
+package indexing
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultJobsLimit = 50
+
+var (
+	_ types.HttpHandler = (*getJobsHttpHandler)(nil)
+)
+
+type getJobsHttpHandler struct {
+	jobDb store.Jobs
+}
+
+func NewGetJobsHttpHandler(jobDb store.Jobs) *getJobsHttpHandler {
+	return &getJobsHttpHandler{
+		jobDb: jobDb,
+	}
+}
+
+// swagger:parameters getJobs
+type GetJobsRequest struct {
+	// Limit of jobs, defaults to 50
+	//
+	// in: query
+	Limit int64 `json:"limit" form:"limit"`
+}
+
+func (h *getJobsHttpHandler) Handle(c *gin.Context) {
+	var req GetJobsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit < 0 {
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultJobsLimit
+	}
+
+	jobs, err := h.jobDb.FindRecent(req.Limit)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, jobs)
+}
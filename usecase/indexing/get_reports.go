@@ -0,0 +1,93 @@
+package indexing
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+type getReportsUseCase struct {
+	reportDb   store.Reports
+	syncableDb store.Syncables
+}
+
+func NewGetReportsUseCase(reportDb store.Reports, syncableDb store.Syncables) *getReportsUseCase {
+	return &getReportsUseCase{
+		reportDb:   reportDb,
+		syncableDb: syncableDb,
+	}
+}
+
+func (uc *getReportsUseCase) Execute(limit int64) ([]ReportView, error) {
+	reports, err := uc.reportDb.FindRecent(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]ReportView, 0, len(reports))
+	for _, report := range reports {
+		progress, err := uc.syncableDb.GetReportProgress(report.ID)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, toReportView(report, progress, time.Now()))
+	}
+	return views, nil
+}
+
+type ReportView struct {
+	ID                  types.ID     `json:"id"`
+	Kind                string       `json:"kind"`
+	IndexVersion        int64        `json:"index_version"`
+	StartHeight         int64        `json:"start_height"`
+	EndHeight           int64        `json:"end_height"`
+	ProcessedCount      int64        `json:"processed_count"`
+	LastProcessedHeight int64        `json:"last_processed_height"`
+	Progress            float64      `json:"progress"`
+	Eta                 string       `json:"eta,omitempty"`
+	SuccessCount        *int64       `json:"success_count"`
+	ErrorCount          *int64       `json:"error_count"`
+	ErrorMsg            *string      `json:"error_msg"`
+	CreatedAt           types.Time   `json:"created_at"`
+	CompletedAt         *types.Time  `json:"completed_at"`
+	Details             *types.Jsonb `json:"details,omitempty"`
+}
+
+func toReportView(report model.Report, progress *store.ReportProgressRow, now time.Time) ReportView {
+	view := ReportView{
+		ID:                  report.ID,
+		Kind:                report.Kind.String(),
+		IndexVersion:        report.IndexVersion,
+		StartHeight:         report.StartHeight,
+		EndHeight:           report.EndHeight,
+		ProcessedCount:      progress.ProcessedCount,
+		LastProcessedHeight: progress.LastProcessedHeight,
+		SuccessCount:        report.SuccessCount,
+		ErrorCount:          report.ErrorCount,
+		ErrorMsg:            report.ErrorMsg,
+		CreatedAt:           report.CreatedAt,
+		CompletedAt:         report.CompletedAt,
+		Details:             report.Details,
+	}
+
+	if report.CompletedAt != nil {
+		view.Progress = 1
+		return view
+	}
+
+	total := report.EndHeight - report.StartHeight + 1
+	done := progress.LastProcessedHeight - report.StartHeight + 1
+	if total <= 0 || done <= 0 {
+		return view
+	}
+	if done > total {
+		done = total
+	}
+
+	view.Progress = float64(done) / float64(total)
+	elapsed := now.Sub(report.CreatedAt.Time)
+	view.Eta = time.Duration(float64(elapsed) * (1 - view.Progress) / view.Progress).Round(time.Second).String()
+	return view
+}
@@ -0,0 +1,74 @@
+package indexing
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultReportsLimit = 20
+
+var (
+	_ types.HttpHandler = (*getReportsHttpHandler)(nil)
+)
+
+type getReportsHttpHandler struct {
+	useCase *getReportsUseCase
+
+	reportDb   store.Reports
+	syncableDb store.Syncables
+}
+
+func NewGetReportsHttpHandler(reportDb store.Reports, syncableDb store.Syncables) *getReportsHttpHandler {
+	return &getReportsHttpHandler{
+		reportDb:   reportDb,
+		syncableDb: syncableDb,
+	}
+}
+
+// swagger:parameters getReports
+type GetReportsRequest struct {
+	// Limit of reports, defaults to 20
+	//
+	// in: query
+	Limit int64 `json:"limit" form:"limit"`
+}
+
+func (h *getReportsHttpHandler) Handle(c *gin.Context) {
+	var req GetReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit < 0 {
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultReportsLimit
+	}
+
+	resp, err := h.getUseCase().Execute(req.Limit)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getReportsHttpHandler) getUseCase() *getReportsUseCase {
+	if h.useCase == nil {
+		h.useCase = NewGetReportsUseCase(h.reportDb, h.syncableDb)
+	}
+	return h.useCase
+}
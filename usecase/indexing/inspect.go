@@ -0,0 +1,566 @@
+package indexing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/figment-networks/indexing-engine/pipeline"
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/indexer"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+)
+
+var (
+	// ignoredDiffFields are assigned by database and are never set on would-be writes
+	ignoredDiffFields = []string{"id", "created_at", "updated_at"}
+)
+
+type inspectUseCase struct {
+	cfg    *config.Config
+	client *client.Client
+
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	databaseDb    store.Database
+	eventDb       store.Events
+	reportDb      store.Reports
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewInspectUseCase(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events,
+	reportDb store.Reports, rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *inspectUseCase {
+	return &inspectUseCase{
+		cfg:    cfg,
+		client: cli,
+
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		databaseDb:    databaseDb,
+		eventDb:       eventDb,
+		reportDb:      reportDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+// Inspection is the result of dry pipeline run for single height
+type Inspection struct {
+	Height int64
+	Stages []StageOutput
+	Diffs  []TableDiff
+}
+
+// StageOutput holds payload fields populated by stage
+type StageOutput struct {
+	Stage  pipeline.StageName
+	Output json.RawMessage
+}
+
+// TableDiff compares rows which would be written to table with rows currently stored. Era tables are only upserted,
+// so their stored rows are compared only with rows which would be written and they are never removed
+type TableDiff struct {
+	Table     string
+	Added     []json.RawMessage
+	Removed   []json.RawMessage
+	Changed   []RowChange
+	Unchanged int
+}
+
+// HasChanges returns true when stored rows differ from would-be writes
+func (d TableDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// RowChange holds stored and would-be version of row
+type RowChange struct {
+	Key     string
+	Stored  json.RawMessage
+	Pending json.RawMessage
+}
+
+// keyedRow is a row identified by its natural key
+type keyedRow struct {
+	key string
+	row interface{}
+}
+
+func (uc *inspectUseCase) Execute(ctx context.Context, height int64, targetIds []int64) (*Inspection, error) {
+	indexingPipeline, err := indexer.NewPipeline(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &Inspection{Height: height}
+
+	var observerErr error
+	observer := func(stage pipeline.StageName, output interface{}) {
+		raw, err := json.Marshal(output)
+		if err != nil {
+			observerErr = err
+			return
+		}
+		inspection.Stages = append(inspection.Stages, StageOutput{Stage: stage, Output: raw})
+	}
+
+	payload, err := indexingPipeline.Run(ctx, indexer.RunConfig{
+		Height:           height,
+		DesiredTargetIDs: targetIds,
+		Dry:              true,
+		StageObserver:    observer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if observerErr != nil {
+		return nil, observerErr
+	}
+
+	// Tables keyed by era or session are only written at the end of era or session,
+	// tables keyed by height are skipped only when some of the tasks were not run
+	skipEmptyHeightTables := len(targetIds) > 0
+
+	var pendingBlockSeqs []keyedRow
+	if payload.NewBlockSequence != nil {
+		pendingBlockSeqs = append(pendingBlockSeqs, keyedRow{blockSeqKey(*payload.NewBlockSequence), payload.NewBlockSequence})
+	}
+	if payload.UpdatedBlockSequence != nil {
+		pendingBlockSeqs = append(pendingBlockSeqs, keyedRow{blockSeqKey(*payload.UpdatedBlockSequence), payload.UpdatedBlockSequence})
+	}
+	if err := uc.addDiff(inspection, "block_sequences", pendingBlockSeqs, skipEmptyHeightTables, uc.storedBlockSeqs); err != nil {
+		return nil, err
+	}
+
+	var pendingValidatorSeqs []keyedRow
+	for i := range payload.ValidatorSequences {
+		pendingValidatorSeqs = append(pendingValidatorSeqs, keyedRow{validatorSeqKey(payload.ValidatorSequences[i]), payload.ValidatorSequences[i]})
+	}
+	if err := uc.addDiff(inspection, "validator_sequences", pendingValidatorSeqs, skipEmptyHeightTables, uc.storedValidatorSeqs); err != nil {
+		return nil, err
+	}
+
+	var pendingEventSeqs []keyedRow
+	for i := range payload.EventSequences {
+		pendingEventSeqs = append(pendingEventSeqs, keyedRow{eventSeqKey(payload.EventSequences[i]), payload.EventSequences[i]})
+	}
+	if err := uc.addDiff(inspection, "event_sequences", pendingEventSeqs, skipEmptyHeightTables, uc.storedEventSeqs); err != nil {
+		return nil, err
+	}
+
+	var pendingTransactionSeqs []keyedRow
+	for i := range payload.TransactionSequences {
+		pendingTransactionSeqs = append(pendingTransactionSeqs, keyedRow{transactionSeqKey(payload.TransactionSequences[i]), payload.TransactionSequences[i]})
+	}
+	if err := uc.addDiff(inspection, "transaction_sequences", pendingTransactionSeqs, skipEmptyHeightTables, uc.storedTransactionSeqs); err != nil {
+		return nil, err
+	}
+
+	var pendingSystemEvents []keyedRow
+	for i := range payload.SystemEvents {
+		pendingSystemEvents = append(pendingSystemEvents, keyedRow{systemEventKey(payload.SystemEvents[i]), payload.SystemEvents[i]})
+	}
+	if err := uc.addDiff(inspection, "system_events", pendingSystemEvents, skipEmptyHeightTables, uc.storedSystemEvents); err != nil {
+		return nil, err
+	}
+
+	var pendingSessionSeqs []keyedRow
+	for i := range payload.ValidatorSessionSequences {
+		pendingSessionSeqs = append(pendingSessionSeqs, keyedRow{validatorSessionSeqKey(payload.ValidatorSessionSequences[i]), payload.ValidatorSessionSequences[i]})
+	}
+	if err := uc.addDiff(inspection, "validator_session_sequences", pendingSessionSeqs, true, uc.storedValidatorSessionSeqs); err != nil {
+		return nil, err
+	}
+
+	var pendingEraSeqs []keyedRow
+	eraSeqEras := map[int64]bool{}
+	for i := range payload.ValidatorEraSequences {
+		pendingEraSeqs = append(pendingEraSeqs, keyedRow{validatorEraSeqKey(payload.ValidatorEraSequences[i]), payload.ValidatorEraSequences[i]})
+		eraSeqEras[payload.ValidatorEraSequences[i].Era] = true
+	}
+	if err := uc.addDiff(inspection, "validator_era_sequences", pendingEraSeqs, true, func(int64) ([]keyedRow, error) {
+		stored, err := uc.storedValidatorEraSeqs(eraSeqEras)
+		return pendingKeysOnly(stored, pendingEraSeqs), err
+	}); err != nil {
+		return nil, err
+	}
+
+	var pendingAccountEraSeqs []keyedRow
+	accountEraSeqEras := map[int64]bool{}
+	for i := range payload.AccountEraSequences {
+		pendingAccountEraSeqs = append(pendingAccountEraSeqs, keyedRow{accountEraSeqKey(payload.AccountEraSequences[i]), payload.AccountEraSequences[i]})
+		accountEraSeqEras[payload.AccountEraSequences[i].Era] = true
+	}
+	if err := uc.addDiff(inspection, "account_era_sequences", pendingAccountEraSeqs, true, func(int64) ([]keyedRow, error) {
+		stored, err := uc.storedAccountEraSeqs(accountEraSeqEras)
+		return pendingKeysOnly(stored, pendingAccountEraSeqs), err
+	}); err != nil {
+		return nil, err
+	}
+
+	pendingRewards, storedRewards, err := uc.rewardEraSeqRows(payload.RewardEraSequences, payload.RewardsClaimed)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.addDiff(inspection, "reward_era_sequences", pendingRewards, true, func(int64) ([]keyedRow, error) {
+		return storedRewards, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var pendingEraSummaries []keyedRow
+	if payload.EraSummary != nil {
+		pendingEraSummaries = append(pendingEraSummaries, keyedRow{eraSummaryKey(*payload.EraSummary), payload.EraSummary})
+	}
+	if err := uc.addDiff(inspection, "era_summaries", pendingEraSummaries, true, func(int64) ([]keyedRow, error) {
+		return uc.storedEraSummaries(payload.EraSummary.Era)
+	}); err != nil {
+		return nil, err
+	}
+
+	return inspection, nil
+}
+
+// addDiff compares pending rows with stored rows of table and adds result to inspection
+func (uc *inspectUseCase) addDiff(inspection *Inspection, table string, pending []keyedRow, skipEmpty bool, findStored func(height int64) ([]keyedRow, error)) error {
+	if len(pending) == 0 && skipEmpty {
+		return nil
+	}
+
+	stored, err := findStored(inspection.Height)
+	if err != nil {
+		return err
+	}
+
+	diff, err := diffRows(table, stored, pending)
+	if err != nil {
+		return err
+	}
+	inspection.Diffs = append(inspection.Diffs, *diff)
+	return nil
+}
+
+func (uc *inspectUseCase) storedBlockSeqs(height int64) ([]keyedRow, error) {
+	seq, err := uc.blockDb.FindSeqByHeight(height)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return []keyedRow{{blockSeqKey(*seq), seq}}, nil
+}
+
+func (uc *inspectUseCase) storedValidatorSeqs(height int64) ([]keyedRow, error) {
+	seqs, err := uc.validatorDb.FindAllByHeight(height)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var rows []keyedRow
+	for i := range seqs {
+		rows = append(rows, keyedRow{validatorSeqKey(seqs[i]), seqs[i]})
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedEventSeqs(height int64) ([]keyedRow, error) {
+	seqs, err := uc.eventDb.FindForHeightRange(height, height, "")
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var rows []keyedRow
+	for i := range seqs {
+		rows = append(rows, keyedRow{eventSeqKey(seqs[i]), seqs[i]})
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedTransactionSeqs(height int64) ([]keyedRow, error) {
+	seqs, err := uc.transactionDb.FindForHeightRange(height, height, "")
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var rows []keyedRow
+	for i := range seqs {
+		rows = append(rows, keyedRow{transactionSeqKey(seqs[i]), seqs[i]})
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedSystemEvents(height int64) ([]keyedRow, error) {
+	events, err := uc.systemEventDb.FindForHeightRange(height, height, nil, nil)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var rows []keyedRow
+	for i := range events {
+		rows = append(rows, keyedRow{systemEventKey(events[i]), events[i]})
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedValidatorSessionSeqs(height int64) ([]keyedRow, error) {
+	seqs, err := uc.validatorDb.FindSessionSeqsByHeight(height)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+
+	var rows []keyedRow
+	for i := range seqs {
+		rows = append(rows, keyedRow{validatorSessionSeqKey(seqs[i]), seqs[i]})
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedValidatorEraSeqs(eras map[int64]bool) ([]keyedRow, error) {
+	var rows []keyedRow
+	for era := range eras {
+		seqs, err := uc.validatorDb.FindByEra(era)
+		if err != nil && err != store.ErrNotFound {
+			return nil, err
+		}
+		for i := range seqs {
+			rows = append(rows, keyedRow{validatorEraSeqKey(seqs[i]), seqs[i]})
+		}
+	}
+	return rows, nil
+}
+
+func (uc *inspectUseCase) storedAccountEraSeqs(eras map[int64]bool) ([]keyedRow, error) {
+	var rows []keyedRow
+	for era := range eras {
+		seqs, err := uc.accountDb.FindByEra(era)
+		if err != nil && err != store.ErrNotFound {
+			return nil, err
+		}
+		for i := range seqs {
+			rows = append(rows, keyedRow{accountEraSeqKey(seqs[i]), seqs[i]})
+		}
+	}
+	return rows, nil
+}
+
+// rewardEraSeqRows returns rewards which would be written at height, including stored rewards of validators whose
+// rewards would be marked as claimed, and stored versions of them. Rewards of other validators in era are left out
+func (uc *inspectUseCase) rewardEraSeqRows(rewards []model.RewardEraSeq, claims []indexer.RewardsClaim) (pending, stored []keyedRow, err error) {
+	type eraValidator struct {
+		era       int64
+		validator string
+	}
+	storedSeqs := map[eraValidator][]model.RewardEraSeq{}
+	findStored := func(k eraValidator) ([]model.RewardEraSeq, error) {
+		if seqs, ok := storedSeqs[k]; ok {
+			return seqs, nil
+		}
+		seqs, err := uc.rewardDb.FindForEraRange(k.era, k.era, k.validator)
+		if err != nil && err != store.ErrNotFound {
+			return nil, err
+		}
+		var validatorSeqs []model.RewardEraSeq
+		for _, seq := range seqs {
+			if seq.ValidatorStashAccount == k.validator {
+				validatorSeqs = append(validatorSeqs, seq)
+			}
+		}
+		storedSeqs[k] = validatorSeqs
+		return validatorSeqs, nil
+	}
+
+	var pendingSeqs []model.RewardEraSeq
+	pendingIndex := map[string]int{}
+	for _, seq := range rewards {
+		if _, err := findStored(eraValidator{seq.Era, seq.ValidatorStashAccount}); err != nil {
+			return nil, nil, err
+		}
+		pendingIndex[rewardEraSeqKey(seq)] = len(pendingSeqs)
+		pendingSeqs = append(pendingSeqs, seq)
+	}
+
+	// claims mark all rewards of validator in era as claimed, both stored and written at height
+	for _, claim := range claims {
+		seqs, err := findStored(eraValidator{claim.Era, claim.ValidatorStash})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, seq := range seqs {
+			if _, ok := pendingIndex[rewardEraSeqKey(seq)]; !ok {
+				pendingIndex[rewardEraSeqKey(seq)] = len(pendingSeqs)
+				pendingSeqs = append(pendingSeqs, seq)
+			}
+		}
+		for i := range pendingSeqs {
+			if pendingSeqs[i].Era == claim.Era && pendingSeqs[i].ValidatorStashAccount == claim.ValidatorStash {
+				pendingSeqs[i].Claimed = true
+				pendingSeqs[i].TxHash = claim.TxHash
+			}
+		}
+	}
+
+	for _, seq := range pendingSeqs {
+		pending = append(pending, keyedRow{rewardEraSeqKey(seq), seq})
+	}
+	for _, seqs := range storedSeqs {
+		for _, seq := range seqs {
+			stored = append(stored, keyedRow{rewardEraSeqKey(seq), seq})
+		}
+	}
+	return pending, pendingKeysOnly(stored, pending), nil
+}
+
+func (uc *inspectUseCase) storedEraSummaries(era int64) ([]keyedRow, error) {
+	summary, err := uc.validatorDb.FindEraSummaryByEra(era)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []keyedRow{{eraSummaryKey(summary.EraSummary), summary.EraSummary}}, nil
+}
+
+// pendingKeysOnly leaves out stored rows which would not be written, tables which are only upserted never remove them
+func pendingKeysOnly(stored, pending []keyedRow) []keyedRow {
+	keys := map[string]bool{}
+	for _, r := range pending {
+		keys[r.key] = true
+	}
+
+	var rows []keyedRow
+	for _, r := range stored {
+		if keys[r.key] {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// diffRows matches stored and pending rows by key and compares them ignoring fields assigned by database
+func diffRows(table string, stored, pending []keyedRow) (*TableDiff, error) {
+	diff := &TableDiff{Table: table}
+
+	storedByKey := map[string]map[string]interface{}{}
+	for _, r := range stored {
+		normalized, err := normalizeRow(r.row)
+		if err != nil {
+			return nil, err
+		}
+		storedByKey[r.key] = normalized
+	}
+
+	seen := map[string]bool{}
+	for _, r := range pending {
+		normalized, err := normalizeRow(r.row)
+		if err != nil {
+			return nil, err
+		}
+		seen[r.key] = true
+
+		pendingRaw, err := json.Marshal(normalized)
+		if err != nil {
+			return nil, err
+		}
+
+		storedRow, ok := storedByKey[r.key]
+		if !ok {
+			diff.Added = append(diff.Added, pendingRaw)
+			continue
+		}
+
+		if reflect.DeepEqual(storedRow, normalized) {
+			diff.Unchanged++
+			continue
+		}
+
+		storedRaw, err := json.Marshal(storedRow)
+		if err != nil {
+			return nil, err
+		}
+		diff.Changed = append(diff.Changed, RowChange{Key: r.key, Stored: storedRaw, Pending: pendingRaw})
+	}
+
+	for _, r := range stored {
+		if seen[r.key] {
+			continue
+		}
+		storedRaw, err := json.Marshal(storedByKey[r.key])
+		if err != nil {
+			return nil, err
+		}
+		diff.Removed = append(diff.Removed, storedRaw)
+	}
+	return diff, nil
+}
+
+// normalizeRow converts row to its JSON representation without fields assigned by database
+func normalizeRow(row interface{}) (map[string]interface{}, error) {
+	// Row is marshaled through pointer to its copy, otherwise quantities which only marshal from pointer would all be {}
+	addressable := reflect.New(reflect.TypeOf(row))
+	addressable.Elem().Set(reflect.ValueOf(row))
+
+	raw, err := json.Marshal(addressable.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+	for _, field := range ignoredDiffFields {
+		delete(normalized, field)
+	}
+	return normalized, nil
+}
+
+func blockSeqKey(s model.BlockSeq) string {
+	return fmt.Sprintf("height=%d", s.Height)
+}
+
+func validatorSeqKey(s model.ValidatorSeq) string {
+	return fmt.Sprintf("stash=%s", s.StashAccount)
+}
+
+func eventSeqKey(s model.EventSeq) string {
+	return fmt.Sprintf("index=%d", s.Index)
+}
+
+func transactionSeqKey(s model.TransactionSeq) string {
+	return fmt.Sprintf("index=%d", s.Index)
+}
+
+func systemEventKey(e model.SystemEvent) string {
+	return fmt.Sprintf("actor=%s kind=%s", e.Actor, e.Kind)
+}
+
+func validatorSessionSeqKey(s model.ValidatorSessionSeq) string {
+	return fmt.Sprintf("session=%d stash=%s", s.Session, s.StashAccount)
+}
+
+func validatorEraSeqKey(s model.ValidatorEraSeq) string {
+	return fmt.Sprintf("era=%d stash=%s", s.Era, s.StashAccount)
+}
+
+func accountEraSeqKey(s model.AccountEraSeq) string {
+	return fmt.Sprintf("era=%d stash=%s validator=%s", s.Era, s.StashAccount, s.ValidatorStashAccount)
+}
+
+func rewardEraSeqKey(s model.RewardEraSeq) string {
+	return fmt.Sprintf("era=%d stash=%s validator=%s kind=%s", s.Era, s.StashAccount, s.ValidatorStashAccount, s.Kind)
+}
+
+func eraSummaryKey(s model.EraSummary) string {
+	return fmt.Sprintf("era=%d", s.Era)
+}
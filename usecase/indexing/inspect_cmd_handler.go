@@ -0,0 +1,104 @@
+package indexing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type InspectCmdHandler struct {
+	cfg    *config.Config
+	client *client.Client
+
+	useCase *inspectUseCase
+
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	databaseDb    store.Database
+	eventDb       store.Events
+	reportDb      store.Reports
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewInspectCmdHandler(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, reportDb store.Reports,
+	rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *InspectCmdHandler {
+	return &InspectCmdHandler{
+		cfg:    cfg,
+		client: cli,
+
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		databaseDb:    databaseDb,
+		eventDb:       eventDb,
+		reportDb:      reportDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+func (h *InspectCmdHandler) Handle(ctx context.Context, height int64, targetIds []int64) {
+	logger.Info(fmt.Sprintf("running inspect use case [handler=cmd] [height=%d] [targets=%v]", height, targetIds))
+
+	inspection, err := h.getUseCase().Execute(ctx, height, targetIds)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	for _, stage := range inspection.Stages {
+		fmt.Printf("=== %s ===\n", stage.Stage)
+		fmt.Println(indentJSON(stage.Output))
+		fmt.Println("")
+	}
+
+	for _, diff := range inspection.Diffs {
+		fmt.Printf("=== Diff %s ===\n", diff.Table)
+		fmt.Println("Unchanged:", diff.Unchanged)
+		if !diff.HasChanges() {
+			fmt.Println("")
+			continue
+		}
+
+		for _, row := range diff.Added {
+			fmt.Println("+", string(row))
+		}
+		for _, row := range diff.Removed {
+			fmt.Println("-", string(row))
+		}
+		for _, change := range diff.Changed {
+			fmt.Printf("~ %s\n", change.Key)
+			fmt.Println("  stored: ", string(change.Stored))
+			fmt.Println("  pending:", string(change.Pending))
+		}
+		fmt.Println("")
+	}
+}
+
+func (h *InspectCmdHandler) getUseCase() *inspectUseCase {
+	if h.useCase == nil {
+		return NewInspectUseCase(h.cfg, h.client, h.accountDb, h.blockDb, h.databaseDb, h.eventDb, h.reportDb, h.rewardDb, h.syncableDb, h.systemEventDb, h.transactionDb, h.validatorDb)
+	}
+	return h.useCase
+}
+
+func indentJSON(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
@@ -0,0 +1,255 @@
+package indexing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/indexer"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var inspectTime = time.Date(2020, 11, 10, 12, 0, 0, 0, time.UTC)
+
+func TestDiffRows(t *testing.T) {
+	seq := func(id types.ID, stash string, balance int64) keyedRow {
+		s := model.ValidatorSeq{
+			ID:            id,
+			Sequence:      &model.Sequence{Height: 10, Time: *types.NewTimeFromTime(inspectTime)},
+			StashAccount:  stash,
+			ActiveBalance: types.NewQuantityFromInt64(balance),
+		}
+		return keyedRow{validatorSeqKey(s), s}
+	}
+
+	tests := []struct {
+		description   string
+		stored        []keyedRow
+		pending       []keyedRow
+		wantAdded     []string
+		wantRemoved   []string
+		wantChanged   []string
+		wantUnchanged int
+	}{
+		{description: "no rows"},
+		{
+			description: "added row",
+			pending:     []keyedRow{seq(0, "stash1", 100)},
+			wantAdded:   []string{"stash1"},
+		},
+		{
+			description: "removed row",
+			stored:      []keyedRow{seq(1, "stash1", 100)},
+			wantRemoved: []string{"stash1"},
+		},
+		{
+			description: "changed row",
+			stored:      []keyedRow{seq(1, "stash1", 100)},
+			pending:     []keyedRow{seq(0, "stash1", 200)},
+			wantChanged: []string{"stash=stash1"},
+		},
+		{
+			description:   "unchanged row with id assigned by database",
+			stored:        []keyedRow{seq(1, "stash1", 100)},
+			pending:       []keyedRow{seq(0, "stash1", 100)},
+			wantUnchanged: 1,
+		},
+		{
+			description:   "all kinds of rows",
+			stored:        []keyedRow{seq(1, "stash1", 100), seq(2, "stash2", 100), seq(3, "stash3", 100)},
+			pending:       []keyedRow{seq(0, "stash1", 100), seq(0, "stash2", 300), seq(0, "stash4", 100)},
+			wantAdded:     []string{"stash4"},
+			wantRemoved:   []string{"stash3"},
+			wantChanged:   []string{"stash=stash2"},
+			wantUnchanged: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			diff, err := diffRows("validator_sequences", tt.stored, tt.pending)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff.Table != "validator_sequences" {
+				t.Errorf("want table validator_sequences; got %s", diff.Table)
+			}
+			checkStashes(t, "added", diff.Added, tt.wantAdded)
+			checkStashes(t, "removed", diff.Removed, tt.wantRemoved)
+
+			if len(diff.Changed) != len(tt.wantChanged) {
+				t.Fatalf("want %d changed rows; got %d", len(tt.wantChanged), len(diff.Changed))
+			}
+			for i, change := range diff.Changed {
+				if change.Key != tt.wantChanged[i] {
+					t.Errorf("want changed key %s; got %s", tt.wantChanged[i], change.Key)
+				}
+				if string(change.Stored) == string(change.Pending) {
+					t.Errorf("want stored and pending versions to differ; got %s", change.Stored)
+				}
+			}
+
+			if diff.Unchanged != tt.wantUnchanged {
+				t.Errorf("want %d unchanged rows; got %d", tt.wantUnchanged, diff.Unchanged)
+			}
+
+			wantChanges := len(tt.wantAdded)+len(tt.wantRemoved)+len(tt.wantChanged) > 0
+			if diff.HasChanges() != wantChanges {
+				t.Errorf("want HasChanges %t; got %t", wantChanges, diff.HasChanges())
+			}
+		})
+	}
+}
+
+func TestDiffRows_IgnoresFieldsAssignedByDatabase(t *testing.T) {
+	event := func(m *model.Model, kind model.SystemEventKind) keyedRow {
+		e := model.SystemEvent{
+			Model:  m,
+			Height: 10,
+			Time:   *types.NewTimeFromTime(inspectTime),
+			Actor:  "stash1",
+			Kind:   kind,
+		}
+		return keyedRow{systemEventKey(e), e}
+	}
+
+	stored := &model.Model{
+		ID:        5,
+		CreatedAt: *types.NewTimeFromTime(inspectTime.Add(-time.Hour)),
+		UpdatedAt: *types.NewTimeFromTime(inspectTime.Add(-time.Minute)),
+	}
+
+	diff, err := diffRows("system_events", []keyedRow{event(stored, model.SystemEventJoinedSet)}, []keyedRow{event(&model.Model{}, model.SystemEventJoinedSet)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.HasChanges() || diff.Unchanged != 1 {
+		t.Errorf("want 1 unchanged row; got %+v", diff)
+	}
+
+	diff, err = diffRows("system_events", []keyedRow{event(stored, model.SystemEventJoinedSet)}, []keyedRow{event(&model.Model{}, model.SystemEventLeftSet)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diff.Added) != 1 || len(diff.Removed) != 1 {
+		t.Fatalf("want 1 added and 1 removed row; got %+v", diff)
+	}
+	for _, raw := range []json.RawMessage{diff.Added[0], diff.Removed[0]} {
+		var row map[string]interface{}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, field := range ignoredDiffFields {
+			if _, ok := row[field]; ok {
+				t.Errorf("want %s to be left out; got %s", field, raw)
+			}
+		}
+		if row["actor"] != "stash1" {
+			t.Errorf("want actor stash1; got %v", row["actor"])
+		}
+	}
+}
+
+func TestInspectUseCase_RewardEraSeqRows(t *testing.T) {
+	reward := func(stash, validator string) model.RewardEraSeq {
+		return model.RewardEraSeq{
+			EraSequence:           &model.EraSequence{Era: 5, StartHeight: 1, EndHeight: 10, Time: *types.NewTimeFromTime(inspectTime)},
+			StashAccount:          stash,
+			ValidatorStashAccount: validator,
+			Amount:                "100",
+			Kind:                  model.RewardReward,
+		}
+	}
+
+	db := memory.New()
+	if err := db.GetRewards().BulkUpsert([]model.RewardEraSeq{reward("nominator1", "validator1"), reward("nominator2", "validator2")}); err != nil {
+		t.Fatal(err)
+	}
+	uc := &inspectUseCase{rewardDb: db.GetRewards()}
+
+	// nominator3 of validator1 is rewarded and claim of validator1 marks its stored reward of nominator1 as claimed
+	pending, stored, err := uc.rewardEraSeqRows(
+		[]model.RewardEraSeq{reward("nominator3", "validator1")},
+		[]indexer.RewardsClaim{{Era: 5, ValidatorStash: "validator1", TxHash: "0x01"}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	diff, err := diffRows("reward_era_sequences", stored, pending)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(diff.Removed) != 0 {
+		t.Errorf("want rewards of validator2 to be left out; got removed %s", diff.Removed)
+	}
+	if diff.Unchanged != 0 {
+		t.Errorf("want no unchanged rewards; got %d", diff.Unchanged)
+	}
+
+	if len(diff.Added) != 1 {
+		t.Fatalf("want 1 added reward; got %d", len(diff.Added))
+	}
+	var added model.RewardEraSeq
+	if err := json.Unmarshal(diff.Added[0], &added); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added.StashAccount != "nominator3" || !added.Claimed || added.TxHash != "0x01" {
+		t.Errorf("want claimed reward of nominator3; got %s", diff.Added[0])
+	}
+
+	if len(diff.Changed) != 1 {
+		t.Fatalf("want 1 changed reward; got %d", len(diff.Changed))
+	}
+	change := diff.Changed[0]
+	if change.Key != "era=5 stash=nominator1 validator=validator1 kind=reward" {
+		t.Errorf("want reward of nominator1 to change; got %s", change.Key)
+	}
+	var storedReward, pendingReward model.RewardEraSeq
+	if err := json.Unmarshal(change.Stored, &storedReward); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(change.Pending, &pendingReward); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if storedReward.Claimed || !pendingReward.Claimed || pendingReward.TxHash != "0x01" {
+		t.Errorf("want stored reward to be claimed by 0x01; got %s -> %s", change.Stored, change.Pending)
+	}
+}
+
+func TestPendingKeysOnly(t *testing.T) {
+	stored := []keyedRow{{key: "a"}, {key: "b"}, {key: "c"}}
+	pending := []keyedRow{{key: "c"}, {key: "d"}, {key: "a"}}
+
+	var got []string
+	for _, r := range pendingKeysOnly(stored, pending) {
+		got = append(got, r.key)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Errorf("want stored rows a and c; got %v", got)
+	}
+}
+
+func checkStashes(t *testing.T, kind string, rows []json.RawMessage, want []string) {
+	t.Helper()
+
+	if len(rows) != len(want) {
+		t.Fatalf("want %d %s rows; got %d", len(want), kind, len(rows))
+	}
+	for i, raw := range rows {
+		var row map[string]interface{}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if row["stash_account"] != want[i] {
+			t.Errorf("want %s row with stash %s; got %s", kind, want[i], raw)
+		}
+		if _, ok := row["id"]; ok {
+			t.Errorf("want id to be left out of %s row; got %s", kind, raw)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package indexing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	ErrUnknownJobKind = errors.New("unknown job kind")
+)
+
+type processJobsUseCase struct {
+	cfg    *config.Config
+	client *client.Client
+
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	databaseDb    store.Database
+	eventDb       store.Events
+	jobDb         store.Jobs
+	reportDb      store.Reports
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewProcessJobsUseCase(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, jobDb store.Jobs,
+	reportDb store.Reports, rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *processJobsUseCase {
+	return &processJobsUseCase{
+		cfg:    cfg,
+		client: cli,
+
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		databaseDb:    databaseDb,
+		eventDb:       eventDb,
+		jobDb:         jobDb,
+		reportDb:      reportDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+// Execute claims and runs jobs of given kinds one by one until there are no such jobs ready to run
+func (uc *processJobsUseCase) Execute(ctx context.Context, workerID string, kinds []model.JobKind) error {
+	lockTimeout, err := time.ParseDuration(uc.cfg.JobLockTimeout)
+	if err != nil {
+		return err
+	}
+
+	retryDelay, err := time.ParseDuration(uc.cfg.JobRetryDelay)
+	if err != nil {
+		return err
+	}
+
+	for {
+		job, err := uc.jobDb.Claim(workerID, kinds, lockTimeout)
+		if err == store.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := uc.process(ctx, job, lockTimeout, retryDelay); err != nil {
+			return err
+		}
+	}
+}
+
+func (uc *processJobsUseCase) process(ctx context.Context, job *model.Job, lockTimeout, retryDelay time.Duration) error {
+	logger.Info(fmt.Sprintf("running job [id=%d] [kind=%s] [attempt=%d]", job.ID, job.Kind, job.Attempts))
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// lock is extended several times before it times out, lock timeouts under 3ns are extended as often as they time out
+	interval := lockTimeout / 3
+	if interval <= 0 {
+		interval = lockTimeout
+	}
+	go uc.heartbeat(jobCtx, cancel, job, interval)
+
+	err := uc.execute(jobCtx, job)
+	if jobCtx.Err() != nil {
+		// job was cancelled or its lock was lost, result is not ours to save
+		logger.Info(fmt.Sprintf("job stopped [id=%d] [kind=%s]", job.ID, job.Kind))
+		return ctx.Err()
+	}
+
+	if err != nil {
+		logger.Error(err, logger.Field("job", job.ID))
+		job.Fail(err, retryDelay)
+	} else {
+		job.Succeed()
+	}
+
+	if err := uc.jobDb.Finish(job); err != nil && err != store.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// heartbeat extends lock of running job and cancels it once it's no longer running
+func (uc *processJobsUseCase) heartbeat(ctx context.Context, cancel context.CancelFunc, job *model.Job, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status, err := uc.jobDb.Heartbeat(job)
+			if err == store.ErrNotFound || (err == nil && status != model.JobStatusRunning) {
+				cancel()
+				return
+			}
+			if err != nil {
+				logger.Error(err, logger.Field("job", job.ID))
+			}
+		}
+	}
+}
+
+func (uc *processJobsUseCase) execute(ctx context.Context, job *model.Job) error {
+	params, err := job.GetParams()
+	if err != nil {
+		return err
+	}
+
+	switch job.Kind {
+	case model.JobKindIndex:
+		return NewStartUseCase(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb).
+			Execute(ctx, params.BatchSize)
+	case model.JobKindBackfill:
+		return NewBackfillUseCase(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb).
+			Execute(ctx, BackfillUseCaseConfig{
+				Parallel:  params.Parallel,
+				Force:     params.Force,
+				TargetIds: params.TargetIds,
+				Shadow:    params.Shadow,
+			})
+	case model.JobKindReindex:
+		return NewReindexUseCase(uc.cfg, uc.client, uc.accountDb, uc.blockDb, uc.databaseDb, uc.eventDb, uc.reportDb, uc.rewardDb, uc.syncableDb, uc.systemEventDb, uc.transactionDb, uc.validatorDb).
+			Execute(ctx, ReindexUseCaseConfig{
+				Parallel:      params.Parallel,
+				Force:         params.Force,
+				TargetIds:     params.TargetIds,
+				LastInSession: params.LastInSession,
+				LastInEra:     params.LastInEra,
+				TrxKinds:      params.TrxKinds,
+				StartHeight:   params.StartHeight,
+				EndHeight:     params.EndHeight,
+			})
+	case model.JobKindPurge:
+		return NewPurgeUseCase(uc.cfg, uc.blockDb, uc.eventDb, uc.syncableDb, uc.transactionDb, uc.validatorDb).Execute(ctx)
+	case model.JobKindSummarize:
+		return NewSummarizeUseCase(uc.cfg, uc.blockDb, uc.eventDb, uc.transactionDb, uc.validatorDb).Execute(ctx)
+	default:
+		return ErrUnknownJobKind
+	}
+}
@@ -0,0 +1,82 @@
+package indexing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/figment-networks/polkadothub-indexer/client"
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	_ types.WorkerHandler = (*processJobsWorkerHandler)(nil)
+)
+
+// processJobsWorkerHandler runs jobs of given kinds, handlers of other kinds run their jobs independently
+type processJobsWorkerHandler struct {
+	cfg      *config.Config
+	client   *client.Client
+	workerID string
+	kinds    []model.JobKind
+
+	useCase *processJobsUseCase
+
+	accountDb     store.Accounts
+	blockDb       store.Blocks
+	databaseDb    store.Database
+	eventDb       store.Events
+	jobDb         store.Jobs
+	reportDb      store.Reports
+	rewardDb      store.Rewards
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+	transactionDb store.Transactions
+	validatorDb   store.Validators
+}
+
+func NewProcessJobsWorkerHandler(cfg *config.Config, kinds []model.JobKind, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, jobDb store.Jobs,
+	reportDb store.Reports, rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+) *processJobsWorkerHandler {
+	hostname, _ := os.Hostname()
+
+	return &processJobsWorkerHandler{
+		cfg:      cfg,
+		client:   cli,
+		workerID: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		kinds:    kinds,
+
+		accountDb:     accountDb,
+		blockDb:       blockDb,
+		databaseDb:    databaseDb,
+		eventDb:       eventDb,
+		jobDb:         jobDb,
+		reportDb:      reportDb,
+		rewardDb:      rewardDb,
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
+	}
+}
+
+func (h *processJobsWorkerHandler) Handle() {
+	ctx := context.Background()
+
+	err := h.getUseCase().Execute(ctx, h.workerID, h.kinds)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *processJobsWorkerHandler) getUseCase() *processJobsUseCase {
+	if h.useCase == nil {
+		h.useCase = NewProcessJobsUseCase(h.cfg, h.client, h.accountDb, h.blockDb, h.databaseDb, h.eventDb, h.jobDb, h.reportDb, h.rewardDb, h.syncableDb, h.systemEventDb, h.transactionDb, h.validatorDb)
+	}
+	return h.useCase
+}
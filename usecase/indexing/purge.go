@@ -9,11 +9,16 @@ import (
 	"github.com/figment-networks/polkadothub-indexer/config"
 	"github.com/figment-networks/polkadothub-indexer/indexer"
 	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/archive"
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 )
 
+// purgeArchiveBatchSize is maximum number of sequences archived and deleted in one transaction
+const purgeArchiveBatchSize = 50000
+
 var (
 	ErrPurgingDisabled = errors.New("purging disabled")
 )
@@ -21,16 +26,22 @@ var (
 type purgeUseCase struct {
 	cfg *config.Config
 
-	blockDb     store.Blocks
-	validatorDb store.Validators
+	blockDb       store.Blocks
+	eventDb       store.Events
+	syncableDb    store.Syncables
+	transactionDb store.Transactions
+	validatorDb   store.Validators
 }
 
-func NewPurgeUseCase(cfg *config.Config, blockDb store.Blocks, validatorDb store.Validators) *purgeUseCase {
+func NewPurgeUseCase(cfg *config.Config, blockDb store.Blocks, eventDb store.Events, syncableDb store.Syncables, transactionDb store.Transactions, validatorDb store.Validators) *purgeUseCase {
 	return &purgeUseCase{
 		cfg: cfg,
 
-		blockDb:     blockDb,
-		validatorDb: validatorDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		syncableDb:    syncableDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
 	}
 }
 
@@ -43,45 +54,64 @@ func (uc *purgeUseCase) Execute(ctx context.Context) error {
 	}
 	currentIndexVersion := configParser.GetCurrentVersionId()
 
-	if err := uc.purgeBlocks(currentIndexVersion); err != nil {
+	var purgeArchive *archive.Archive
+	if uc.cfg.PurgeArchiveDir != "" {
+		if purgeArchive, err = archive.New(uc.cfg.PurgeArchiveDir, uc.cfg.PurgeArchivePartitionSize); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.purgeBlocks(currentIndexVersion, purgeArchive); err != nil {
 		return err
 	}
 
-	if err := uc.purgeValidators(currentIndexVersion); err != nil {
+	if err := uc.purgeValidators(currentIndexVersion, purgeArchive); err != nil {
+		return err
+	}
+
+	if err := uc.purgeActivitySummaries(); err != nil {
+		return err
+	}
+
+	if err := uc.purgeHeightUpdates(); uc.checkErr(err) {
 		return err
 	}
 
 	return nil
 }
 
-func (uc *purgeUseCase) purgeBlocks(currentIndexVersion int64) error {
-	if err := uc.purgeBlockSequences(currentIndexVersion); uc.checkErr(err) {
+func (uc *purgeUseCase) purgeBlocks(currentIndexVersion int64, purgeArchive *archive.Archive) error {
+	if err := uc.purgeBlockSequences(currentIndexVersion, purgeArchive); uc.checkErr(err) {
 		return err
 	}
-	if err := uc.purgeBlockSummaries(types.IntervalHourly, uc.cfg.PurgeHourlySummariesInterval); uc.checkErr(err) {
-		return err
+	for interval, purgeInterval := range uc.getSummariesPurgeIntervals() {
+		if err := uc.purgeBlockSummaries(interval, purgeInterval); uc.checkErr(err) {
+			return err
+		}
 	}
 	return nil
 }
 
-func (uc *purgeUseCase) purgeValidators(currentIndexVersion int64) error {
-	if err := uc.purgeValidatorSequences(currentIndexVersion); uc.checkErr(err) {
+func (uc *purgeUseCase) purgeValidators(currentIndexVersion int64, purgeArchive *archive.Archive) error {
+	if err := uc.purgeValidatorSequences(currentIndexVersion, purgeArchive); uc.checkErr(err) {
 		return err
 	}
 
-	if err := uc.purgeValidatorSessionSequences(currentIndexVersion); uc.checkErr(err) {
+	if err := uc.purgeValidatorSessionSequences(currentIndexVersion, purgeArchive); uc.checkErr(err) {
 		return err
 	}
 
-	if err := uc.purgeValidatorSummaries(types.IntervalHourly, uc.cfg.PurgeHourlySummariesInterval); uc.checkErr(err) {
-		return err
+	for interval, purgeInterval := range uc.getSummariesPurgeIntervals() {
+		if err := uc.purgeValidatorSummaries(interval, purgeInterval); uc.checkErr(err) {
+			return err
+		}
 	}
 
 	// never purge validator era sequences because refetching data is very expensive
 	return nil
 }
 
-func (uc *purgeUseCase) purgeBlockSequences(currentIndexVersion int64) error {
+func (uc *purgeUseCase) purgeBlockSequences(currentIndexVersion int64, purgeArchive *archive.Archive) error {
 	blockSeq, err := uc.blockDb.FindMostRecentSeq()
 	if err != nil {
 		return err
@@ -96,7 +126,10 @@ func (uc *purgeUseCase) purgeBlockSequences(currentIndexVersion int64) error {
 		return err
 	}
 
-	purgeThresholdFromLastSeq := lastSeqTime.Add(-*duration)
+	purgeThresholdFromLastSeq, err := uc.getSequencesPurgeThreshold(lastSeqTime.Add(-*duration), uc.findLastBlockSummaryTimeBucket)
+	if err != nil {
+		return err
+	}
 
 	activityPeriods, err := uc.blockDb.FindActivityPeriods(types.IntervalDaily, currentIndexVersion)
 	if err != nil {
@@ -105,7 +138,16 @@ func (uc *purgeUseCase) purgeBlockSequences(currentIndexVersion int64) error {
 
 	logger.Info(fmt.Sprintf("purging summarized block sequences... [older than=%s]", purgeThresholdFromLastSeq))
 
-	deletedCount, err := uc.blockDb.DeleteSeqOlderThan(purgeThresholdFromLastSeq, activityPeriods)
+	var deletedCount *int64
+	if purgeArchive != nil {
+		deletedCount, err = uc.archiveInBatches(func() (*int64, error) {
+			return uc.blockDb.ArchiveSeqsOlderThan(purgeThresholdFromLastSeq, activityPeriods, purgeArchiveBatchSize, func(seqs []model.BlockSeq) error {
+				return uc.archiveRows(purgeArchive, model.BlockSeq{}.TableName(), archive.BlockSeqColumns, archive.ToBlockSeqRows(seqs))
+			})
+		})
+	} else {
+		deletedCount, err = uc.blockDb.DeleteSeqOlderThan(purgeThresholdFromLastSeq, activityPeriods)
+	}
 	if err != nil {
 		return err
 	}
@@ -144,7 +186,7 @@ func (uc *purgeUseCase) purgeBlockSummaries(interval types.SummaryInterval, purg
 	return nil
 }
 
-func (uc *purgeUseCase) purgeValidatorSequences(currentIndexVersion int64) error {
+func (uc *purgeUseCase) purgeValidatorSequences(currentIndexVersion int64, purgeArchive *archive.Archive) error {
 	validatorSeq, err := uc.validatorDb.FindMostRecentSeq()
 	if err != nil {
 		return err
@@ -163,7 +205,16 @@ func (uc *purgeUseCase) purgeValidatorSequences(currentIndexVersion int64) error
 
 	logger.Info(fmt.Sprintf("purging validator sequences... [older than=%s]", purgeThreshold))
 
-	deletedCount, err := uc.validatorDb.DeleteSeqsOlderThan(purgeThreshold)
+	var deletedCount *int64
+	if purgeArchive != nil {
+		deletedCount, err = uc.archiveInBatches(func() (*int64, error) {
+			return uc.validatorDb.ArchiveSeqsOlderThan(purgeThreshold, purgeArchiveBatchSize, func(seqs []model.ValidatorSeq) error {
+				return uc.archiveRows(purgeArchive, model.ValidatorSeq{}.TableName(), archive.ValidatorSeqColumns, archive.ToValidatorSeqRows(seqs))
+			})
+		})
+	} else {
+		deletedCount, err = uc.validatorDb.DeleteSeqsOlderThan(purgeThreshold)
+	}
 	if err != nil {
 		return err
 	}
@@ -173,7 +224,7 @@ func (uc *purgeUseCase) purgeValidatorSequences(currentIndexVersion int64) error
 	return nil
 }
 
-func (uc *purgeUseCase) purgeValidatorSessionSequences(currentIndexVersion int64) error {
+func (uc *purgeUseCase) purgeValidatorSessionSequences(currentIndexVersion int64, purgeArchive *archive.Archive) error {
 	validatorSeq, err := uc.validatorDb.FindMostRecentSessionSeq()
 	if err != nil {
 		return err
@@ -203,9 +254,23 @@ func (uc *purgeUseCase) purgeValidatorSessionSequences(currentIndexVersion int64
 		purgeThreshold = lastSummaryTimeBucket
 	}
 
+	purgeThreshold, err = uc.getSequencesPurgeThreshold(purgeThreshold, uc.findLastValidatorSummaryTimeBucket)
+	if err != nil {
+		return err
+	}
+
 	logger.Info(fmt.Sprintf("purging validator session sequences... [older than=%s]", purgeThreshold))
 
-	deletedCount, err := uc.validatorDb.DeleteSessionSeqsOlderThan(purgeThreshold)
+	var deletedCount *int64
+	if purgeArchive != nil {
+		deletedCount, err = uc.archiveInBatches(func() (*int64, error) {
+			return uc.validatorDb.ArchiveSessionSeqsOlderThan(purgeThreshold, purgeArchiveBatchSize, func(seqs []model.ValidatorSessionSeq) error {
+				return uc.archiveRows(purgeArchive, model.ValidatorSessionSeq{}.TableName(), archive.ValidatorSessionSeqColumns, archive.ToValidatorSessionSeqRows(seqs))
+			})
+		})
+	} else {
+		deletedCount, err = uc.validatorDb.DeleteSessionSeqsOlderThan(purgeThreshold)
+	}
 	if err != nil {
 		return err
 	}
@@ -244,6 +309,186 @@ func (uc *purgeUseCase) purgeValidatorSummaries(interval types.SummaryInterval,
 	return nil
 }
 
+// archiveInBatches calls archiveBatch until it deletes less than full batch and returns total number of deleted rows.
+// Every batch is archived and deleted in its own transaction, so only batch which fails is kept
+func (uc *purgeUseCase) archiveInBatches(archiveBatch func() (*int64, error)) (*int64, error) {
+	var deletedCount int64
+	for {
+		count, err := archiveBatch()
+		if err != nil {
+			return nil, err
+		}
+
+		deletedCount += *count
+		if *count < purgeArchiveBatchSize {
+			return &deletedCount, nil
+		}
+	}
+}
+
+// archiveRows writes rows to archive before they are purged, rows must not be deleted when it fails.
+// Files written before rows failed to be deleted stay in archive, restore tolerates duplicated rows
+func (uc *purgeUseCase) archiveRows(purgeArchive *archive.Archive, table string, columns []string, rows []archive.Row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("archiving %s... [rows=%d]", table, len(rows)))
+
+	entries, err := purgeArchive.Write(table, columns, rows)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d %s rows archived [files=%d]", len(rows), table, len(entries)))
+
+	return nil
+}
+
+func (uc *purgeUseCase) purgeActivitySummaries() error {
+	purgeIntervals := uc.getSummariesPurgeIntervals()
+	for _, interval := range []types.SummaryInterval{types.IntervalHourly, types.IntervalDaily} {
+		if err := uc.purgeTransactionSummaries(interval, purgeIntervals[interval]); uc.checkErr(err) {
+			return err
+		}
+		if err := uc.purgeEventSummaries(interval, purgeIntervals[interval]); uc.checkErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *purgeUseCase) purgeTransactionSummaries(interval types.SummaryInterval, purgeInterval string) error {
+	transactionSummary, err := uc.transactionDb.FindMostRecentTransactionSummary(interval)
+	if err != nil {
+		return err
+	}
+	lastSummaryTimeBucket := transactionSummary.TimeBucket.Time
+
+	duration, err := uc.parseDuration(purgeInterval)
+	if err != nil {
+		if err == ErrPurgingDisabled {
+			logger.Info(fmt.Sprintf("purging transaction summaries disabled [interval=%s] [purge_interval=%s]", interval, purgeInterval))
+		}
+		return err
+	}
+
+	purgeThreshold := lastSummaryTimeBucket.Add(-*duration)
+
+	logger.Info(fmt.Sprintf("purging transaction summaries... [interval=%s] [older than=%s]", interval, purgeThreshold))
+
+	deletedCount, err := uc.transactionDb.DeleteTransactionSummariesOlderThan(interval, purgeThreshold)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d transaction summaries purged [interval=%s]", *deletedCount, interval))
+
+	return nil
+}
+
+func (uc *purgeUseCase) purgeEventSummaries(interval types.SummaryInterval, purgeInterval string) error {
+	eventSummary, err := uc.eventDb.FindMostRecentEventSummary(interval)
+	if err != nil {
+		return err
+	}
+	lastSummaryTimeBucket := eventSummary.TimeBucket.Time
+
+	duration, err := uc.parseDuration(purgeInterval)
+	if err != nil {
+		if err == ErrPurgingDisabled {
+			logger.Info(fmt.Sprintf("purging event summaries disabled [interval=%s] [purge_interval=%s]", interval, purgeInterval))
+		}
+		return err
+	}
+
+	purgeThreshold := lastSummaryTimeBucket.Add(-*duration)
+
+	logger.Info(fmt.Sprintf("purging event summaries... [interval=%s] [older than=%s]", interval, purgeThreshold))
+
+	deletedCount, err := uc.eventDb.DeleteEventSummariesOlderThan(interval, purgeThreshold)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d event summaries purged [interval=%s]", *deletedCount, interval))
+
+	return nil
+}
+
+func (uc *purgeUseCase) purgeHeightUpdates() error {
+	heightUpdate, err := uc.syncableDb.FindLastHeightUpdate()
+	if err != nil {
+		return err
+	}
+	lastUpdateTime := heightUpdate.Time.Time
+
+	duration, err := uc.parseDuration(uc.cfg.PurgeSequencesInterval)
+	if err != nil {
+		if err == ErrPurgingDisabled {
+			logger.Info("purging height updates disabled. Purge interval set to 0.")
+		}
+		return err
+	}
+
+	purgeThreshold := lastUpdateTime.Add(-*duration)
+
+	logger.Info(fmt.Sprintf("purging height updates... [older than=%s]", purgeThreshold))
+
+	deletedCount, err := uc.syncableDb.DeleteHeightUpdatesOlderThan(purgeThreshold)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%d height updates purged", *deletedCount))
+
+	return nil
+}
+
+// getSummariesPurgeIntervals returns how long summaries are kept for each summary interval
+func (uc *purgeUseCase) getSummariesPurgeIntervals() map[types.SummaryInterval]string {
+	return map[types.SummaryInterval]string{
+		types.IntervalHourly:  uc.cfg.PurgeHourlySummariesInterval,
+		types.IntervalDaily:   uc.cfg.PurgeDailySummariesInterval,
+		types.IntervalWeekly:  uc.cfg.PurgeWeeklySummariesInterval,
+		types.IntervalMonthly: uc.cfg.PurgeMonthlySummariesInterval,
+		types.IntervalEra:     uc.cfg.PurgeEraSummariesInterval,
+	}
+}
+
+// getSequencesPurgeThreshold makes sure that sequences of most recent era are kept, because its summary is recreated
+// from sequences until era is over. Weekly and monthly summaries are built from daily ones, so they don't hold sequences back
+func (uc *purgeUseCase) getSequencesPurgeThreshold(purgeThreshold time.Time, findLastTimeBucket func(types.SummaryInterval) (time.Time, error)) (time.Time, error) {
+	lastTimeBucket, err := findLastTimeBucket(types.IntervalEra)
+	if err != nil {
+		if err == store.ErrNotFound {
+			return purgeThreshold, nil
+		}
+		return purgeThreshold, err
+	}
+
+	if lastTimeBucket.Before(purgeThreshold) {
+		purgeThreshold = lastTimeBucket
+	}
+	return purgeThreshold, nil
+}
+
+func (uc *purgeUseCase) findLastBlockSummaryTimeBucket(interval types.SummaryInterval) (time.Time, error) {
+	blockSummary, err := uc.blockDb.FindMostRecentByInterval(interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return blockSummary.TimeBucket.Time, nil
+}
+
+func (uc *purgeUseCase) findLastValidatorSummaryTimeBucket(interval types.SummaryInterval) (time.Time, error) {
+	validatorSummary, err := uc.validatorDb.FindMostRecentByInterval(interval)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return validatorSummary.TimeBucket.Time, nil
+}
+
 func (uc *purgeUseCase) parseDuration(interval string) (*time.Duration, error) {
 	duration, err := time.ParseDuration(interval)
 	if err != nil {
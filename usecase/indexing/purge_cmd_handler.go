@@ -13,16 +13,22 @@ type PurgeCmdHandler struct {
 
 	useCase *purgeUseCase
 
-	blockDb     store.Blocks
-	validatorDb store.Validators
+	blockDb       store.Blocks
+	eventDb       store.Events
+	syncableDb    store.Syncables
+	transactionDb store.Transactions
+	validatorDb   store.Validators
 }
 
-func NewPurgeCmdHandler(cfg *config.Config, blockDb store.Blocks, validatorDb store.Validators) *PurgeCmdHandler {
+func NewPurgeCmdHandler(cfg *config.Config, blockDb store.Blocks, eventDb store.Events, syncableDb store.Syncables, transactionDb store.Transactions, validatorDb store.Validators) *PurgeCmdHandler {
 	return &PurgeCmdHandler{
 		cfg: cfg,
 
-		blockDb:     blockDb,
-		validatorDb: validatorDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		syncableDb:    syncableDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
 	}
 }
 
@@ -38,7 +44,7 @@ func (h *PurgeCmdHandler) Handle(ctx context.Context) {
 
 func (h *PurgeCmdHandler) getUseCase() *purgeUseCase {
 	if h.useCase == nil {
-		return NewPurgeUseCase(h.cfg, h.blockDb, h.validatorDb)
+		return NewPurgeUseCase(h.cfg, h.blockDb, h.eventDb, h.syncableDb, h.transactionDb, h.validatorDb)
 	}
 	return h.useCase
 }
@@ -1,74 +0,0 @@
-package indexing
-
-import (
-	"context"
-	"fmt"
-
-	"github.com/figment-networks/polkadothub-indexer/client"
-	"github.com/figment-networks/polkadothub-indexer/config"
-	"github.com/figment-networks/polkadothub-indexer/store"
-	"github.com/figment-networks/polkadothub-indexer/types"
-	"github.com/figment-networks/polkadothub-indexer/utils/logger"
-)
-
-var (
-	_ types.WorkerHandler = (*runWorkerHandler)(nil)
-)
-
-type runWorkerHandler struct {
-	cfg    *config.Config
-	client *client.Client
-
-	useCase *startUseCase
-
-	accountDb     store.Accounts
-	blockDb       store.Blocks
-	databaseDb    store.Database
-	eventDb       store.Events
-	reportDb      store.Reports
-	rewardDb      store.Rewards
-	syncableDb    store.Syncables
-	systemEventDb store.SystemEvents
-	transactionDb store.Transactions
-	validatorDb   store.Validators
-}
-
-func NewRunWorkerHandler(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, reportDb store.Reports,
-	rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
-) *runWorkerHandler {
-	return &runWorkerHandler{
-		cfg:    cfg,
-		client: cli,
-
-		accountDb:     accountDb,
-		blockDb:       blockDb,
-		databaseDb:    databaseDb,
-		eventDb:       eventDb,
-		reportDb:      reportDb,
-		rewardDb:      rewardDb,
-		syncableDb:    syncableDb,
-		systemEventDb: systemEventDb,
-		transactionDb: transactionDb,
-		validatorDb:   validatorDb,
-	}
-}
-
-func (h *runWorkerHandler) Handle() {
-	batchSize := h.cfg.DefaultBatchSize
-	ctx := context.Background()
-
-	logger.Info(fmt.Sprintf("running indexer use case [handler=worker] [batchSize=%d]", batchSize))
-
-	err := h.getUseCase().Execute(ctx, batchSize)
-	if err != nil {
-		logger.Error(err)
-		return
-	}
-}
-
-func (h *runWorkerHandler) getUseCase() *startUseCase {
-	if h.useCase == nil {
-		return NewStartUseCase(h.cfg, h.client, h.accountDb, h.blockDb, h.databaseDb, h.eventDb, h.reportDb, h.rewardDb, h.syncableDb, h.systemEventDb, h.transactionDb, h.validatorDb)
-	}
-	return h.useCase
-}
@@ -14,19 +14,28 @@ import (
 	"github.com/figment-networks/polkadothub-indexer/utils/logger"
 )
 
+var (
+	summaryIntervals         = []types.SummaryInterval{types.IntervalHourly, types.IntervalDaily, types.IntervalWeekly, types.IntervalMonthly, types.IntervalEra}
+	activitySummaryIntervals = []types.SummaryInterval{types.IntervalHourly, types.IntervalDaily}
+)
+
 type summarizeUseCase struct {
 	cfg *config.Config
 
-	blockDb     store.Blocks
-	validatorDb store.Validators
+	blockDb       store.Blocks
+	eventDb       store.Events
+	transactionDb store.Transactions
+	validatorDb   store.Validators
 }
 
-func NewSummarizeUseCase(cfg *config.Config, blockDb store.Blocks, validatorDb store.Validators) *summarizeUseCase {
+func NewSummarizeUseCase(cfg *config.Config, blockDb store.Blocks, eventDb store.Events, transactionDb store.Transactions, validatorDb store.Validators) *summarizeUseCase {
 	return &summarizeUseCase{
 		cfg: cfg,
 
-		blockDb:     blockDb,
-		validatorDb: validatorDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
 	}
 }
 
@@ -39,20 +48,22 @@ func (uc *summarizeUseCase) Execute(ctx context.Context) error {
 	}
 	currentIndexVersion := configParser.GetCurrentVersionId()
 
-	if err := uc.summarizeBlockSeq(types.IntervalHourly, currentIndexVersion); err != nil {
-		return err
-	}
-
-	if err := uc.summarizeBlockSeq(types.IntervalDaily, currentIndexVersion); err != nil {
-		return err
+	for _, interval := range summaryIntervals {
+		if err := uc.summarizeBlockSeq(interval, currentIndexVersion); err != nil {
+			return err
+		}
 	}
 
-	if err := uc.summarizeValidatorSeq(types.IntervalHourly, currentIndexVersion); err != nil {
-		return err
+	for _, interval := range summaryIntervals {
+		if err := uc.summarizeValidatorSeq(interval, currentIndexVersion); err != nil {
+			return err
+		}
 	}
 
-	if err := uc.summarizeValidatorSeq(types.IntervalDaily, currentIndexVersion); err != nil {
-		return err
+	for _, interval := range activitySummaryIntervals {
+		if err := uc.summarizeActivity(interval, currentIndexVersion); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -61,12 +72,7 @@ func (uc *summarizeUseCase) Execute(ctx context.Context) error {
 func (uc *summarizeUseCase) summarizeBlockSeq(interval types.SummaryInterval, currentIndexVersion int64) error {
 	logger.Info(fmt.Sprintf("summarizing block sequences... [interval=%s]", interval))
 
-	activityPeriods, err := uc.blockDb.FindActivityPeriods(interval, currentIndexVersion)
-	if err != nil {
-		return err
-	}
-
-	rawSummaryItems, err := uc.blockDb.Summarize(interval, activityPeriods)
+	rawSummaryItems, err := uc.getRawBlockSummaries(interval, currentIndexVersion)
 	if err != nil {
 		return err
 	}
@@ -118,17 +124,7 @@ func (uc *summarizeUseCase) summarizeBlockSeq(interval types.SummaryInterval, cu
 func (uc *summarizeUseCase) summarizeValidatorSeq(interval types.SummaryInterval, currentIndexVersion int64) error {
 	logger.Info(fmt.Sprintf("summarizing validator sequences... [interval=%s]", interval))
 
-	activityPeriods, err := uc.validatorDb.FindActivityPeriods(interval, currentIndexVersion)
-	if err != nil {
-		return err
-	}
-
-	rawSessionSummaryItems, err := uc.validatorDb.SummarizeSessionSeqs(interval, activityPeriods)
-	if err != nil {
-		return err
-	}
-
-	rawEraSummaryItems, err := uc.validatorDb.SummarizeEraSeqs(interval, activityPeriods)
+	rawSessionSummaryItems, rawEraSummaryItems, err := uc.getRawValidatorSummaries(interval, currentIndexVersion)
 	if err != nil {
 		return err
 	}
@@ -228,3 +224,92 @@ func (uc *summarizeUseCase) summarizeValidatorSeq(interval types.SummaryInterval
 
 	return nil
 }
+
+// summarizeActivity summarizes transaction and event sequences
+func (uc *summarizeUseCase) summarizeActivity(interval types.SummaryInterval, currentIndexVersion int64) error {
+	logger.Info(fmt.Sprintf("summarizing transaction and event sequences... [interval=%s]", interval))
+
+	transactionCount, err := uc.transactionDb.SummarizeTransactionSeqs(interval, currentIndexVersion)
+	if err != nil {
+		return err
+	}
+
+	eventCount, err := uc.eventDb.SummarizeEventSeqs(interval, currentIndexVersion)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("transaction and event sequences summarized [transaction_summaries=%d] [event_summaries=%d]", *transactionCount, *eventCount))
+
+	return nil
+}
+
+// getRawBlockSummaries summarizes block sequences which are not summarized yet.
+// Era summaries are recreated starting with the most recent one, since that era could still be in progress when it was summarized.
+// Weekly and monthly summaries are rolled up from daily summaries the same way, so they don't need sequences of whole week or month
+func (uc *summarizeUseCase) getRawBlockSummaries(interval types.SummaryInterval, currentIndexVersion int64) ([]model.BlockSeqSummary, error) {
+	if interval.IsEra() || interval.IsRollup() {
+		var since time.Time
+		lastSummary, err := uc.blockDb.FindMostRecentByInterval(interval)
+		if err == nil {
+			since = lastSummary.TimeBucket.Time
+		} else if err != store.ErrNotFound {
+			return nil, err
+		}
+
+		if interval.IsRollup() {
+			return uc.blockDb.SummarizeDailySummaries(interval, since, currentIndexVersion)
+		}
+		return uc.blockDb.SummarizeByEra(since)
+	}
+
+	activityPeriods, err := uc.blockDb.FindActivityPeriods(interval, currentIndexVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.blockDb.Summarize(interval, activityPeriods)
+}
+
+func (uc *summarizeUseCase) getRawValidatorSummaries(interval types.SummaryInterval, currentIndexVersion int64) ([]model.ValidatorSessionSeqSummary, []model.ValidatorEraSeqSummary, error) {
+	if interval.IsEra() || interval.IsRollup() {
+		var since time.Time
+		lastSummary, err := uc.validatorDb.FindMostRecentByInterval(interval)
+		if err == nil {
+			since = lastSummary.TimeBucket.Time
+		} else if err != store.ErrNotFound {
+			return nil, nil, err
+		}
+
+		if interval.IsRollup() {
+			rawSessionSummaryItems, err := uc.validatorDb.SummarizeDailySessionSummaries(interval, since, currentIndexVersion)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rawEraSummaryItems, err := uc.validatorDb.SummarizeDailyEraSummaries(interval, since, currentIndexVersion)
+			return rawSessionSummaryItems, rawEraSummaryItems, err
+		}
+
+		rawSessionSummaryItems, err := uc.validatorDb.SummarizeSessionSeqsByEra(since)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rawEraSummaryItems, err := uc.validatorDb.SummarizeEraSeqsByEra(since)
+		return rawSessionSummaryItems, rawEraSummaryItems, err
+	}
+
+	activityPeriods, err := uc.validatorDb.FindActivityPeriods(interval, currentIndexVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawSessionSummaryItems, err := uc.validatorDb.SummarizeSessionSeqs(interval, activityPeriods)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawEraSummaryItems, err := uc.validatorDb.SummarizeEraSeqs(interval, activityPeriods)
+	return rawSessionSummaryItems, rawEraSummaryItems, err
+}
@@ -14,16 +14,20 @@ type SummarizeCmdHandler struct {
 
 	useCase *summarizeUseCase
 
-	blockDb     store.Blocks
-	validatorDb store.Validators
+	blockDb       store.Blocks
+	eventDb       store.Events
+	transactionDb store.Transactions
+	validatorDb   store.Validators
 }
 
-func NewSummarizeCmdHandler(cfg *config.Config, blockDb store.Blocks, validatorDb store.Validators) *SummarizeCmdHandler {
+func NewSummarizeCmdHandler(cfg *config.Config, blockDb store.Blocks, eventDb store.Events, transactionDb store.Transactions, validatorDb store.Validators) *SummarizeCmdHandler {
 	return &SummarizeCmdHandler{
 		cfg: cfg,
 
-		blockDb:     blockDb,
-		validatorDb: validatorDb,
+		blockDb:       blockDb,
+		eventDb:       eventDb,
+		transactionDb: transactionDb,
+		validatorDb:   validatorDb,
 	}
 }
 
@@ -39,7 +43,7 @@ func (h *SummarizeCmdHandler) Handle(ctx context.Context) {
 
 func (h *SummarizeCmdHandler) getUseCase() *summarizeUseCase {
 	if h.useCase == nil {
-		return NewSummarizeUseCase(h.cfg, h.blockDb, h.validatorDb)
+		return NewSummarizeUseCase(h.cfg, h.blockDb, h.eventDb, h.transactionDb, h.validatorDb)
 	}
 	return h.useCase
 }
@@ -0,0 +1,150 @@
+package price
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	ErrFileRequired     = errors.New("price file is required")
+	ErrInvalidFileType  = errors.New("price file must be .csv or .json")
+	ErrCurrencyRequired = errors.New("currency is required when price file has no currency column")
+	ErrInvalidPrice     = errors.New("invalid price")
+)
+
+// priceRecord is price history entry as it's written in imported file
+type priceRecord struct {
+	Date     string      `json:"date"`
+	Currency string      `json:"currency"`
+	Price    json.Number `json:"price"`
+}
+
+type importUseCase struct {
+	priceDb store.Prices
+}
+
+func NewImportUseCase(priceDb store.Prices) *importUseCase {
+	return &importUseCase{
+		priceDb: priceDb,
+	}
+}
+
+// Execute imports prices from CSV or JSON file, currency is used for records without one
+func (uc *importUseCase) Execute(ctx context.Context, path, currency string) (int, error) {
+	defer metric.LogUseCaseDuration(time.Now(), "price_import")
+
+	if path == "" {
+		return 0, ErrFileRequired
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var records []priceRecord
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = readCSV(f)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&records)
+	default:
+		return 0, ErrInvalidFileType
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	prices, err := toPrices(records, currency)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info(fmt.Sprintf("importing prices... [count=%d]", len(prices)))
+
+	return len(prices), uc.priceDb.BulkUpsert(prices)
+}
+
+// readCSV reads price records from CSV with header, columns are matched by name and currency column is optional
+func readCSV(r io.Reader) ([]priceRecord, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"date", "price"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("price file has no %s column", name)
+		}
+	}
+
+	var records []priceRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := priceRecord{
+			Date:  row[columns["date"]],
+			Price: json.Number(row[columns["price"]]),
+		}
+		if i, ok := columns["currency"]; ok {
+			record.Currency = row[i]
+		}
+		records = append(records, record)
+	}
+}
+
+func toPrices(records []priceRecord, currency string) ([]model.Price, error) {
+	prices := make([]model.Price, 0, len(records))
+	for _, record := range records {
+		date, err := time.Parse(model.PriceDateFormat, record.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		if record.Currency == "" {
+			record.Currency = currency
+		}
+		if record.Currency == "" {
+			return nil, ErrCurrencyRequired
+		}
+
+		price := model.Price{
+			Date:     *types.NewTimeFromTime(date),
+			Currency: strings.ToUpper(record.Currency),
+			Price:    record.Price.String(),
+		}
+		if !price.Valid() {
+			return nil, fmt.Errorf("%w: %s %s %s", ErrInvalidPrice, record.Date, record.Currency, record.Price)
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
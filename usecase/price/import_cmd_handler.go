@@ -0,0 +1,40 @@
+package price
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type ImportCmdHandler struct {
+	useCase *importUseCase
+
+	priceDb store.Prices
+}
+
+func NewImportCmdHandler(priceDb store.Prices) *ImportCmdHandler {
+	return &ImportCmdHandler{
+		priceDb: priceDb,
+	}
+}
+
+func (h *ImportCmdHandler) Handle(ctx context.Context, path, currency string) {
+	logger.Info(fmt.Sprintf("running price import use case [handler=cmd] [file=%s] [currency=%s]", path, currency))
+
+	count, err := h.getUseCase().Execute(ctx, path, currency)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	logger.Info(fmt.Sprintf("prices imported [count=%d]", count))
+}
+
+func (h *ImportCmdHandler) getUseCase() *importUseCase {
+	if h.useCase == nil {
+		return NewImportUseCase(h.priceDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,46 @@
+package publishing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/publisher"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type publishUseCase struct {
+	cfg   *config.Config
+	relay *publisher.Relay
+
+	outboxDb store.Outbox
+}
+
+func NewPublishUseCase(cfg *config.Config, outboxDb store.Outbox) *publishUseCase {
+	return &publishUseCase{
+		cfg:      cfg,
+		outboxDb: outboxDb,
+	}
+}
+
+// Execute publishes messages left in outbox, i.e. when broker was down while heights were indexed
+func (uc *publishUseCase) Execute(ctx context.Context) error {
+	if !uc.cfg.PublisherEnabled() {
+		return nil
+	}
+
+	if uc.relay == nil {
+		pub, err := publisher.New(uc.cfg)
+		if err != nil {
+			return err
+		}
+		uc.relay = publisher.NewRelay(pub, uc.outboxDb, uc.cfg.PublisherBatchSize)
+	}
+
+	published, err := uc.relay.Flush(ctx)
+	if published > 0 {
+		logger.Info(fmt.Sprintf("published outbox messages [count=%d]", published), logger.Field("process", "publisher"))
+	}
+	return err
+}
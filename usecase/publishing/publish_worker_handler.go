@@ -0,0 +1,47 @@
+package publishing
+
+import (
+	"context"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	_ types.WorkerHandler = (*publishWorkerHandler)(nil)
+)
+
+type publishWorkerHandler struct {
+	cfg *config.Config
+
+	useCase *publishUseCase
+
+	outboxDb store.Outbox
+}
+
+func NewPublishWorkerHandler(cfg *config.Config, outboxDb store.Outbox) *publishWorkerHandler {
+	return &publishWorkerHandler{
+		cfg: cfg,
+
+		outboxDb: outboxDb,
+	}
+}
+
+func (h *publishWorkerHandler) Handle() {
+	ctx := context.Background()
+
+	err := h.getUseCase().Execute(ctx)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *publishWorkerHandler) getUseCase() *publishUseCase {
+	if h.useCase == nil {
+		h.useCase = NewPublishUseCase(h.cfg, h.outboxDb)
+	}
+	return h.useCase
+}
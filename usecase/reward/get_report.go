@@ -0,0 +1,79 @@
+package reward
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var (
+	ErrReportStashRequired    = errors.New("stash account is required")
+	ErrReportCurrencyRequired = errors.New("currency is required")
+)
+
+// ReportParams selects rewards of report, start and end are days included in report and zero end means most recently indexed time
+type ReportParams struct {
+	StashAccount string
+	Currency     string
+	Period       string
+	Start        time.Time
+	End          time.Time
+}
+
+type getReportUseCase struct {
+	cfg *config.Config
+
+	priceDb     store.Prices
+	rewardDb    store.Rewards
+	syncablesDb store.Syncables
+}
+
+func NewGetReportUseCase(cfg *config.Config, priceDb store.Prices, rewardDb store.Rewards, syncablesDb store.Syncables) *getReportUseCase {
+	return &getReportUseCase{
+		cfg: cfg,
+
+		priceDb:     priceDb,
+		rewardDb:    rewardDb,
+		syncablesDb: syncablesDb,
+	}
+}
+
+func (uc *getReportUseCase) Execute(params ReportParams) (*Report, error) {
+	if params.StashAccount == "" {
+		return nil, ErrReportStashRequired
+	}
+
+	if params.Currency == "" {
+		return nil, ErrReportCurrencyRequired
+	}
+	currency := strings.ToUpper(params.Currency)
+
+	if err := validatePeriod(params.Period); err != nil {
+		return nil, err
+	}
+
+	end := params.End.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	if params.End.IsZero() {
+		mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
+		if err != nil {
+			return nil, err
+		}
+		end = mostRecentSynced.Time.Time
+	}
+
+	rewards, err := uc.rewardDb.GetAllByTime(params.StashAccount, *types.NewTimeFromTime(params.Start), *types.NewTimeFromTime(end))
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := uc.priceDb.FindForPeriod(currency, params.Start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReport(rewards, prices, currency, params.Period, int(uc.cfg.TokenDecimals))
+}
@@ -0,0 +1,75 @@
+package reward
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type GetReportCmdHandler struct {
+	cfg *config.Config
+
+	priceDb     store.Prices
+	rewardDb    store.Rewards
+	syncablesDb store.Syncables
+
+	useCase *getReportUseCase
+}
+
+func NewGetReportCmdHandler(cfg *config.Config, priceDb store.Prices, rewardDb store.Rewards, syncablesDb store.Syncables) *GetReportCmdHandler {
+	return &GetReportCmdHandler{
+		cfg: cfg,
+
+		priceDb:     priceDb,
+		rewardDb:    rewardDb,
+		syncablesDb: syncablesDb,
+	}
+}
+
+// Handle writes rewards report CSV to output file, or to stdout when output is empty
+func (h *GetReportCmdHandler) Handle(ctx context.Context, params ReportParams, detailed bool, output string) {
+	logger.Info(fmt.Sprintf("running rewards report use case [handler=cmd] [stash_account=%s] [currency=%s] [period=%s] [output=%s]",
+		params.StashAccount, params.Currency, params.Period, output))
+
+	report, err := h.getUseCase().Execute(params)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := writeReport(report, detailed, output); err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func writeReport(report *Report, detailed bool, output string) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	buf := bufio.NewWriter(w)
+	if err := report.WriteCSV(buf, detailed); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+func (h *GetReportCmdHandler) getUseCase() *getReportUseCase {
+	if h.useCase == nil {
+		return NewGetReportUseCase(h.cfg, h.priceDb, h.rewardDb, h.syncablesDb)
+	}
+	return h.useCase
+}
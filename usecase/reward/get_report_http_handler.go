@@ -0,0 +1,117 @@
+package reward
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getReportHttpHandler)(nil)
+)
+
+type getReportHttpHandler struct {
+	cfg *config.Config
+
+	priceDb     store.Prices
+	rewardDb    store.Rewards
+	syncablesDb store.Syncables
+
+	useCase *getReportUseCase
+}
+
+func NewGetReportHttpHandler(cfg *config.Config, priceDb store.Prices, rewardDb store.Rewards, syncablesDb store.Syncables) *getReportHttpHandler {
+	return &getReportHttpHandler{
+		cfg: cfg,
+
+		priceDb:     priceDb,
+		rewardDb:    rewardDb,
+		syncablesDb: syncablesDb,
+	}
+}
+
+// swagger:parameters getRewardsReport
+type reportQueryParams struct {
+	// Currency of prices
+	//
+	// required: true
+	// in: query
+	Currency string `json:"currency" form:"currency" binding:"required"`
+	// Start day in format 2006-01-02
+	//
+	// in: query
+	Start time.Time `json:"start" form:"start" binding:"-" time_format:"2006-01-02"`
+	// End day in format 2006-01-02, defaults to most recently indexed time
+	//
+	// in: query
+	End time.Time `json:"end" form:"end" binding:"-" time_format:"2006-01-02"`
+	// Period of sums, one of day, week, month or year, defaults to month
+	//
+	// in: query
+	Period string `json:"period" form:"period" binding:"-"`
+	// Detailed lists every reward instead of sums of periods
+	//
+	// in: query
+	Detailed bool `json:"detailed" form:"detailed" binding:"-"`
+}
+
+func (h *getReportHttpHandler) Handle(c *gin.Context) {
+	var uri uriParams
+	if err := c.ShouldBindUri(&uri); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid stash account"))
+		return
+	}
+	var query reportQueryParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("currency is required, and/or start end must be in format: 2006-01-02"))
+		return
+	}
+
+	if query.Period == "" {
+		query.Period = ReportPeriodMonth
+	}
+	if err := validatePeriod(query.Period); err != nil {
+		http.BadRequest(c, err)
+		return
+	}
+
+	report, err := h.getUseCase().Execute(ReportParams{
+		StashAccount: uri.StashAccount,
+		Currency:     query.Currency,
+		Period:       query.Period,
+		Start:        query.Start,
+		End:          query.End,
+	})
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf, query.Detailed); err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=rewards_%s.csv", uri.StashAccount))
+	c.Data(nethttp.StatusOK, "text/csv", buf.Bytes())
+}
+
+func (h *getReportHttpHandler) getUseCase() *getReportUseCase {
+	if h.useCase == nil {
+		h.useCase = NewGetReportUseCase(h.cfg, h.priceDb, h.rewardDb, h.syncablesDb)
+	}
+	return h.useCase
+}
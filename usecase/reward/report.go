@@ -0,0 +1,193 @@
+package reward
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+const (
+	ReportPeriodDay   = "day"
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+	ReportPeriodYear  = "year"
+
+	fiatPrecision  = 2
+	pricePrecision = 6
+)
+
+var (
+	ErrInvalidReportPeriod = errors.New("report period must be day, week, month or year")
+)
+
+// ReportRow is reward of account with its value at price of reward day. Reward day is UTC day of era end, not of payout
+// claim, claim transaction isn't indexed with its time and rewards are earned in era whether they are claimed later or not
+type ReportRow struct {
+	Period                string
+	Era                   int64
+	Time                  types.Time
+	ValidatorStashAccount string
+	Kind                  model.RewardKind
+	Claimed               bool
+	TxHash                string
+	Amount                *big.Rat
+	Price                 *big.Rat
+}
+
+// Fiat returns value of reward in report currency, it's nil when price of reward day is missing
+func (r ReportRow) Fiat() *big.Rat {
+	if r.Price == nil {
+		return nil
+	}
+	return new(big.Rat).Mul(r.Amount, r.Price)
+}
+
+// ReportPeriod is sum of rewards of period
+type ReportPeriod struct {
+	Period        string
+	Count         int64
+	MissingPrices int64
+	Amount        *big.Rat
+	Fiat          *big.Rat
+}
+
+// Report is rewards report of account in currency, amounts are in tokens
+type Report struct {
+	Currency string
+	Decimals int
+	Rows     []ReportRow
+	Periods  []ReportPeriod
+}
+
+func validatePeriod(period string) error {
+	switch period {
+	case ReportPeriodDay, ReportPeriodWeek, ReportPeriodMonth, ReportPeriodYear:
+		return nil
+	default:
+		return ErrInvalidReportPeriod
+	}
+}
+
+// periodStart returns first day of period time belongs to, weeks start on monday
+func periodStart(t time.Time, period string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case ReportPeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case ReportPeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case ReportPeriodYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// toReport joins rewards with prices of UTC days of their eras and sums them by period
+func toReport(rewardSeqs []model.RewardEraSeq, prices []model.Price, currency, period string, decimals int) (*Report, error) {
+	priceLookup := make(map[string]*big.Rat)
+	for _, price := range prices {
+		value, ok := new(big.Rat).SetString(price.Price)
+		if !ok {
+			return nil, errors.New("invalid price " + price.Price)
+		}
+		priceLookup[price.Date.UTC().Format(model.PriceDateFormat)] = value
+	}
+
+	unit := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+
+	report := &Report{
+		Currency: currency,
+		Decimals: decimals,
+	}
+
+	periodLookup := make(map[string]int)
+	for _, rewardSeq := range rewardSeqs {
+		amount, ok := new(big.Rat).SetString(rewardSeq.Amount)
+		if !ok {
+			return nil, errors.New("invalid reward amount " + rewardSeq.Amount)
+		}
+
+		row := ReportRow{
+			Period:                periodStart(rewardSeq.Time.Time, period).Format(model.PriceDateFormat),
+			Era:                   rewardSeq.Era,
+			Time:                  rewardSeq.Time,
+			ValidatorStashAccount: rewardSeq.ValidatorStashAccount,
+			Kind:                  rewardSeq.Kind,
+			Claimed:               rewardSeq.Claimed,
+			TxHash:                rewardSeq.TxHash,
+			Amount:                amount.Quo(amount, unit),
+			Price:                 priceLookup[rewardSeq.Time.UTC().Format(model.PriceDateFormat)],
+		}
+		report.Rows = append(report.Rows, row)
+
+		i, ok := periodLookup[row.Period]
+		if !ok {
+			i = len(report.Periods)
+			periodLookup[row.Period] = i
+			report.Periods = append(report.Periods, ReportPeriod{Period: row.Period, Amount: new(big.Rat), Fiat: new(big.Rat)})
+		}
+
+		p := &report.Periods[i]
+		p.Count++
+		p.Amount.Add(p.Amount, row.Amount)
+		if fiat := row.Fiat(); fiat != nil {
+			p.Fiat.Add(p.Fiat, fiat)
+		} else {
+			p.MissingPrices++
+		}
+	}
+	return report, nil
+}
+
+// WriteCSV writes sums of periods, or every reward with its period when detailed is set
+func (r *Report) WriteCSV(w io.Writer, detailed bool) error {
+	writer := csv.NewWriter(w)
+
+	if detailed {
+		writer.Write([]string{"period", "era", "time", "validator_stash_account", "kind", "claimed", "tx_hash", "amount_dot", "currency", "price", "amount_fiat"})
+		for _, row := range r.Rows {
+			var price, fiat string
+			if row.Price != nil {
+				price = row.Price.FloatString(pricePrecision)
+				fiat = row.Fiat().FloatString(fiatPrecision)
+			}
+			writer.Write([]string{
+				row.Period,
+				strconv.FormatInt(row.Era, 10),
+				row.Time.UTC().Format(time.RFC3339),
+				row.ValidatorStashAccount,
+				row.Kind.String(),
+				strconv.FormatBool(row.Claimed),
+				row.TxHash,
+				row.Amount.FloatString(r.Decimals),
+				r.Currency,
+				price,
+				fiat,
+			})
+		}
+	} else {
+		writer.Write([]string{"period", "rewards_count", "amount_dot", "currency", "amount_fiat", "missing_prices"})
+		for _, p := range r.Periods {
+			writer.Write([]string{
+				p.Period,
+				strconv.FormatInt(p.Count, 10),
+				p.Amount.FloatString(r.Decimals),
+				r.Currency,
+				p.Fiat.FloatString(fiatPrecision),
+				strconv.FormatInt(p.MissingPrices, 10),
+			})
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
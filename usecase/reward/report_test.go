@@ -0,0 +1,199 @@
+package reward
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+func TestPeriodStart(t *testing.T) {
+	plus2 := time.FixedZone("UTC+2", 2*60*60)
+	minus5 := time.FixedZone("UTC-5", -5*60*60)
+
+	tests := []struct {
+		description string
+		time        time.Time
+		period      string
+		want        string
+	}{
+		{"day", time.Date(2020, 11, 4, 23, 59, 59, 0, time.UTC), ReportPeriodDay, "2020-11-04"},
+		{"day of UTC", time.Date(2020, 11, 5, 1, 0, 0, 0, plus2), ReportPeriodDay, "2020-11-04"},
+		{"week of monday", time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC), ReportPeriodWeek, "2020-11-02"},
+		{"week of wednesday", time.Date(2020, 11, 4, 12, 0, 0, 0, time.UTC), ReportPeriodWeek, "2020-11-02"},
+		{"week of sunday", time.Date(2020, 11, 8, 23, 59, 59, 0, time.UTC), ReportPeriodWeek, "2020-11-02"},
+		{"week of monday which is sunday in UTC", time.Date(2020, 11, 9, 1, 0, 0, 0, plus2), ReportPeriodWeek, "2020-11-02"},
+		{"week across months", time.Date(2020, 11, 1, 12, 0, 0, 0, time.UTC), ReportPeriodWeek, "2020-10-26"},
+		{"week across years", time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC), ReportPeriodWeek, "2020-12-28"},
+		{"month", time.Date(2020, 11, 30, 23, 0, 0, 0, time.UTC), ReportPeriodMonth, "2020-11-01"},
+		{"month of UTC", time.Date(2020, 11, 30, 20, 0, 0, 0, minus5), ReportPeriodMonth, "2020-12-01"},
+		{"year", time.Date(2020, 12, 31, 23, 0, 0, 0, time.UTC), ReportPeriodYear, "2020-01-01"},
+		{"year of UTC", time.Date(2020, 12, 31, 20, 0, 0, 0, minus5), ReportPeriodYear, "2021-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			got := periodStart(tt.time, tt.period)
+			if got.Format(model.PriceDateFormat) != tt.want || got.Location() != time.UTC {
+				t.Errorf("want period start %s UTC; got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func newReportRewards() []model.RewardEraSeq {
+	reward := func(era int64, t time.Time, amount string) model.RewardEraSeq {
+		return model.RewardEraSeq{
+			EraSequence:           &model.EraSequence{Era: era, Time: *types.NewTimeFromTime(t)},
+			StashAccount:          "stash",
+			ValidatorStashAccount: "validator",
+			Amount:                amount,
+			Kind:                  model.RewardReward,
+			Claimed:               true,
+			TxHash:                "0x01",
+		}
+	}
+
+	return []model.RewardEraSeq{
+		// sunday
+		reward(1, time.Date(2020, 11, 1, 18, 0, 0, 0, time.UTC), "20000000000"),
+		// monday
+		reward(2, time.Date(2020, 11, 2, 0, 0, 0, 0, time.UTC), "10000000000"),
+		// sunday without price
+		reward(3, time.Date(2020, 11, 8, 23, 59, 0, 0, time.UTC), "5000000000"),
+		// monday in UTC+2 is sunday in UTC, price of monday isn't used
+		reward(4, time.Date(2020, 11, 9, 0, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)), "25000000000"),
+	}
+}
+
+func newReportPrices() []model.Price {
+	price := func(day int, value string) model.Price {
+		return model.Price{Date: *types.NewTimeFromTime(time.Date(2020, 11, day, 0, 0, 0, 0, time.UTC)), Currency: "USD", Price: value}
+	}
+	return []model.Price{price(1, "4.5"), price(2, "5"), price(9, "6")}
+}
+
+func TestToReport(t *testing.T) {
+	report, err := toReport(newReportRewards(), newReportPrices(), "USD", ReportPeriodWeek, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Currency != "USD" || report.Decimals != 10 {
+		t.Errorf("want currency USD with 10 decimals; got %s with %d", report.Currency, report.Decimals)
+	}
+
+	wantRows := []struct {
+		period string
+		amount string
+		price  string
+		fiat   string
+	}{
+		{"2020-10-26", "2.0", "4.50", "9.00"},
+		{"2020-11-02", "1.0", "5.00", "5.00"},
+		{"2020-11-02", "0.5", "", ""},
+		{"2020-11-02", "2.5", "", ""},
+	}
+	if len(report.Rows) != len(wantRows) {
+		t.Fatalf("want %d rows; got %d", len(wantRows), len(report.Rows))
+	}
+	for i, want := range wantRows {
+		row := report.Rows[i]
+		if row.Period != want.period {
+			t.Errorf("row %d: want period %s; got %s", i, want.period, row.Period)
+		}
+		if got := row.Amount.FloatString(1); got != want.amount {
+			t.Errorf("row %d: want amount %s; got %s", i, want.amount, got)
+		}
+
+		var price, fiat string
+		if row.Price != nil {
+			price = row.Price.FloatString(2)
+			fiat = row.Fiat().FloatString(2)
+		}
+		if price != want.price || fiat != want.fiat {
+			t.Errorf("row %d: want price %q and fiat %q; got %q and %q", i, want.price, want.fiat, price, fiat)
+		}
+	}
+
+	wantPeriods := []struct {
+		period        string
+		count         int64
+		missingPrices int64
+		amount        string
+		fiat          string
+	}{
+		{"2020-10-26", 1, 0, "2.0", "9.00"},
+		{"2020-11-02", 3, 2, "4.0", "5.00"},
+	}
+	if len(report.Periods) != len(wantPeriods) {
+		t.Fatalf("want %d periods; got %d", len(wantPeriods), len(report.Periods))
+	}
+	for i, want := range wantPeriods {
+		p := report.Periods[i]
+		if p.Period != want.period || p.Count != want.count || p.MissingPrices != want.missingPrices {
+			t.Errorf("want period %s with %d rewards and %d missing prices; got %s with %d and %d",
+				want.period, want.count, want.missingPrices, p.Period, p.Count, p.MissingPrices)
+		}
+		if p.Amount.FloatString(1) != want.amount || p.Fiat.FloatString(2) != want.fiat {
+			t.Errorf("%s: want amount %s and fiat %s; got %s and %s", want.period, want.amount, want.fiat, p.Amount.FloatString(1), p.Fiat.FloatString(2))
+		}
+	}
+}
+
+func TestToReport_InvalidValues(t *testing.T) {
+	rewards := newReportRewards()
+	rewards[0].Amount = "invalid"
+	if _, err := toReport(rewards, newReportPrices(), "USD", ReportPeriodWeek, 10); err == nil {
+		t.Error("want error of invalid reward amount")
+	}
+
+	prices := newReportPrices()
+	prices[0].Price = "invalid"
+	if _, err := toReport(newReportRewards(), prices, "USD", ReportPeriodWeek, 10); err == nil {
+		t.Error("want error of invalid price")
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	report, err := toReport(newReportRewards(), newReportPrices(), "USD", ReportPeriodWeek, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		description string
+		detailed    bool
+		want        string
+	}{
+		{
+			description: "sums of periods",
+			want: "period,rewards_count,amount_dot,currency,amount_fiat,missing_prices\n" +
+				"2020-10-26,1,2.0000000000,USD,9.00,0\n" +
+				"2020-11-02,3,4.0000000000,USD,5.00,2\n",
+		},
+		{
+			description: "every reward",
+			detailed:    true,
+			want: "period,era,time,validator_stash_account,kind,claimed,tx_hash,amount_dot,currency,price,amount_fiat\n" +
+				"2020-10-26,1,2020-11-01T18:00:00Z,validator,reward,true,0x01,2.0000000000,USD,4.500000,9.00\n" +
+				"2020-11-02,2,2020-11-02T00:00:00Z,validator,reward,true,0x01,1.0000000000,USD,5.000000,5.00\n" +
+				"2020-11-02,3,2020-11-08T23:59:00Z,validator,reward,true,0x01,0.5000000000,USD,,\n" +
+				"2020-11-02,4,2020-11-08T22:30:00Z,validator,reward,true,0x01,2.5000000000,USD,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := report.WriteCSV(&buf, tt.detailed); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("want csv\n%s\ngot\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	replayBatchSize   = 100
+	keepAliveInterval = 15 * time.Second
+)
+
+var (
+	_ types.HttpHandler = (*getStreamHttpHandler)(nil)
+)
+
+type getStreamHttpHandler struct {
+	cfg *config.Config
+
+	useCase  *streamUseCase
+	listener *Listener
+
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+}
+
+func NewGetStreamHttpHandler(cfg *config.Config, syncableDb store.Syncables, systemEventDb store.SystemEvents) *getStreamHttpHandler {
+	return &getStreamHttpHandler{
+		cfg: cfg,
+
+		listener: NewListener(cfg.DatabaseDSN),
+
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+	}
+}
+
+// swagger:parameters getStream
+type GetStreamRequest struct {
+	// After is height cursor, stream starts with height following it. Last-Event-ID header takes precedence
+	//
+	// in: query
+	After *int64 `json:"after" form:"after" binding:"-"`
+	// Actors is comma separated list of stash accounts
+	//
+	// in: query
+	Actors string `json:"actors" form:"actors" binding:"-"`
+	// Kinds is comma separated list of system event kinds
+	//
+	// in: query
+	// example: active_balance_increase_1,missed_n_consecutive
+	Kinds string `json:"kinds" form:"kinds" binding:"-"`
+	// Types is comma separated list of event types
+	//
+	// in: query
+	// example: height,system_event
+	Types string `json:"types" form:"types" binding:"-"`
+}
+
+func (h *getStreamHttpHandler) Handle(c *gin.Context) {
+	var req GetStreamRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid after"))
+		return
+	}
+
+	filter := Filter{
+		Actors: splitList(req.Actors),
+		Kinds:  splitList(req.Kinds),
+		Types:  splitList(req.Types),
+	}
+	for _, t := range filter.Types {
+		if !IsValidEventType(t) {
+			http.BadRequest(c, fmt.Errorf("invalid type %s", t))
+			return
+		}
+	}
+
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		height, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			http.BadRequest(c, errors.New("invalid Last-Event-ID"))
+			return
+		}
+		req.After = &height
+	}
+
+	// subscribe before replay so no height update published in the meantime is missed
+	wake := h.listener.Subscribe()
+	defer h.listener.Unsubscribe(wake)
+
+	var after int64
+	if req.After != nil {
+		after = *req.After
+	} else {
+		height, err := h.getUseCase().GetStartHeight()
+		if err != nil {
+			logger.Error(err)
+			http.ServerError(c, err)
+			return
+		}
+		after = height
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		var err error
+		after, err = h.catchUp(c.Writer, after, filter)
+		if err != nil {
+			logger.Error(err)
+			return
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-wake:
+		case <-keepAlive.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// catchUp sends events for all height updates after given height and returns last sent height
+func (h *getStreamHttpHandler) catchUp(w gin.ResponseWriter, after int64, filter Filter) (int64, error) {
+	for {
+		events, last, err := h.getUseCase().Execute(after, replayBatchSize, filter)
+		if err != nil {
+			return after, err
+		}
+		if last == after {
+			return after, nil
+		}
+
+		for _, event := range events {
+			if err := writeEvent(w, event); err != nil {
+				return after, err
+			}
+		}
+		w.Flush()
+
+		after = last
+	}
+}
+
+func (h *getStreamHttpHandler) getUseCase() *streamUseCase {
+	if h.useCase == nil {
+		h.useCase = NewStreamUseCase(h.syncableDb, h.systemEventDb)
+	}
+	return h.useCase
+}
+
+func writeEvent(w io.Writer, event Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if event.Height != nil {
+		fmt.Fprintf(&b, "id: %d\n", *event.Height)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event.Type, data)
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
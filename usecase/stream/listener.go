@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+	"github.com/lib/pq"
+)
+
+const (
+	minReconnectInterval = 10 * time.Second
+	maxReconnectInterval = time.Minute
+)
+
+// Listener listens to height updates published by indexer and wakes up subscribed streams.
+// Notifications carry no data, streams read height updates from database so they never miss one
+type Listener struct {
+	dsn string
+
+	once        sync.Once
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func NewListener(dsn string) *Listener {
+	return &Listener{
+		dsn:         dsn,
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns channel which receives signal whenever new height update is published.
+// Listening starts with first subscription
+func (l *Listener) Subscribe() chan struct{} {
+	l.once.Do(l.start)
+
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe stops sending signals to channel
+func (l *Listener) Unsubscribe(ch chan struct{}) {
+	l.mu.Lock()
+	delete(l.subscribers, ch)
+	l.mu.Unlock()
+}
+
+func (l *Listener) start() {
+	if l.dsn == "" {
+		// in-memory store has no database to listen to, streams only replay height updates and keep connection alive
+		logger.Info("height updates listener disabled, no database configured")
+		return
+	}
+
+	listener := pq.NewListener(l.dsn, minReconnectInterval, maxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Error(fmt.Errorf("height updates listener: %w", err))
+		}
+	})
+
+	if err := listener.Listen(model.HeightUpdatesChannel); err != nil {
+		logger.Error(fmt.Errorf("height updates listener: %w", err))
+	}
+
+	go func() {
+		// nil notification is sent after reconnect, streams catch up with whatever was missed in the meantime
+		for range listener.Notify {
+			l.broadcast()
+		}
+	}()
+}
+
+func (l *Listener) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+			// stream has pending signal already
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package stream
+
+import (
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	EventHeight        = "height"
+	EventSessionEnded  = "session_ended"
+	EventEraEnded      = "era_ended"
+	EventRewardClaimed = "reward_claimed"
+	EventSystemEvent   = "system_event"
+
+	// EventReset is sent when height updates after client's cursor were already purged, it's not subject to filter
+	EventReset = "reset"
+
+	// gapHoldBack is how long replay stops before missing height, in case it's still being committed
+	gapHoldBack = 30 * time.Second
+)
+
+var eventTypes = map[string]struct{}{
+	EventHeight:        {},
+	EventSessionEnded:  {},
+	EventEraEnded:      {},
+	EventRewardClaimed: {},
+	EventSystemEvent:   {},
+}
+
+// Event is single message sent over stream
+type Event struct {
+	// Type is event name
+	Type string
+	// Height is a cursor which stream can be resumed from, it's set only for height and reset events
+	Height *int64
+	Data   interface{}
+}
+
+// Filter narrows down events sent over stream, empty fields match everything
+type Filter struct {
+	Actors []string
+	Kinds  []string
+	Types  []string
+}
+
+// IsValidEventType returns true if event type is known
+func IsValidEventType(eventType string) bool {
+	_, ok := eventTypes[eventType]
+	return ok
+}
+
+func (f Filter) hasType(eventType string) bool {
+	return contains(f.Types, eventType)
+}
+
+func (f Filter) hasActor(actor string) bool {
+	return contains(f.Actors, actor)
+}
+
+type streamUseCase struct {
+	syncableDb    store.Syncables
+	systemEventDb store.SystemEvents
+}
+
+func NewStreamUseCase(syncableDb store.Syncables, systemEventDb store.SystemEvents) *streamUseCase {
+	return &streamUseCase{
+		syncableDb:    syncableDb,
+		systemEventDb: systemEventDb,
+	}
+}
+
+// GetStartHeight returns height which stream starts after when no cursor is given
+func (uc *streamUseCase) GetStartHeight() (int64, error) {
+	update, err := uc.syncableDb.FindLastHeightUpdate()
+	if err != nil {
+		if err == store.ErrNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return update.Height, nil
+}
+
+// Execute returns events for up to limit heights after given height, and height of last included update.
+// Events of each height are ordered so that height event comes last, which makes it safe to resume from
+//
+// When height updates after cursor were purged, reset event comes first and stream continues from oldest retained height.
+// Replay stops before missing height until the update following it is older than gapHoldBack, so heights committed
+// out of order are not skipped
+func (uc *streamUseCase) Execute(after int64, limit int64, filter Filter) ([]Event, int64, error) {
+	var events []Event
+
+	first, err := uc.syncableDb.FindFirstHeightUpdate()
+	if err != nil {
+		if err == store.ErrNotFound {
+			return nil, after, nil
+		}
+		return nil, after, err
+	}
+
+	if after < first.Height-1 {
+		resetHeight := first.Height - 1
+		events = append(events, Event{Type: EventReset, Height: &resetHeight, Data: ToResetView(after, first.Height)})
+		after = resetHeight
+	}
+
+	updates, err := uc.syncableDb.FindHeightUpdatesAfter(after, limit)
+	if err != nil {
+		return nil, after, err
+	}
+
+	updates = takeContiguous(updates, after, time.Now().Add(-gapHoldBack))
+	if len(updates) == 0 {
+		return events, after, nil
+	}
+
+	startHeight, endHeight := updates[0].Height, updates[len(updates)-1].Height
+
+	var systemEvents []model.SystemEvent
+	if filter.hasType(EventSystemEvent) {
+		systemEvents, err = uc.systemEventDb.FindForHeightRange(startHeight, endHeight, filter.Actors, filter.Kinds)
+		if err != nil {
+			return nil, after, err
+		}
+	}
+
+	systemEventsByHeight := make(map[int64][]model.SystemEvent)
+	for _, e := range systemEvents {
+		systemEventsByHeight[e.Height] = append(systemEventsByHeight[e.Height], e)
+	}
+
+	for i := range updates {
+		update := updates[i]
+
+		heightEvents, err := uc.getEventsForHeight(update, systemEventsByHeight[update.Height], filter)
+		if err != nil {
+			return nil, after, err
+		}
+		events = append(events, heightEvents...)
+	}
+
+	return events, endHeight, nil
+}
+
+func (uc *streamUseCase) getEventsForHeight(update model.HeightUpdate, systemEvents []model.SystemEvent, filter Filter) ([]Event, error) {
+	var events []Event
+
+	for _, e := range systemEvents {
+		events = append(events, Event{Type: EventSystemEvent, Data: ToSystemEventView(e)})
+	}
+
+	if filter.hasType(EventRewardClaimed) {
+		claims, err := update.GetRewardsClaimed()
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding rewards claimed")
+		}
+		for _, claim := range claims {
+			if !filter.hasActor(claim.ValidatorStash) {
+				continue
+			}
+			events = append(events, Event{Type: EventRewardClaimed, Data: ToRewardClaimedView(update, claim)})
+		}
+	}
+
+	if update.LastInSession && filter.hasType(EventSessionEnded) {
+		events = append(events, Event{Type: EventSessionEnded, Data: ToHeightView(update)})
+	}
+
+	if update.LastInEra && filter.hasType(EventEraEnded) {
+		events = append(events, Event{Type: EventEraEnded, Data: ToHeightView(update)})
+	}
+
+	// height event is always sent so that client's cursor advances even when everything else is filtered out
+	height := update.Height
+	events = append(events, Event{Type: EventHeight, Height: &height, Data: ToHeightView(update)})
+
+	return events, nil
+}
+
+// takeContiguous returns updates up to first missing height, unless update following it was created before holdBackUntil
+func takeContiguous(updates []model.HeightUpdate, after int64, holdBackUntil time.Time) []model.HeightUpdate {
+	prev := after
+	for i, update := range updates {
+		if update.Height != prev+1 && update.Model != nil && update.CreatedAt.After(holdBackUntil) {
+			return updates[:i]
+		}
+		prev = update.Height
+	}
+	return updates
+}
+
+func contains(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package stream
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+// HeightView is sent for every indexed height, and when session or era ends at height
+type HeightView struct {
+	Height  int64      `json:"height"`
+	Time    types.Time `json:"time"`
+	Session int64      `json:"session"`
+	Era     int64      `json:"era"`
+}
+
+func ToHeightView(update model.HeightUpdate) *HeightView {
+	return &HeightView{
+		Height:  update.Height,
+		Time:    update.Time,
+		Session: update.Session,
+		Era:     update.Era,
+	}
+}
+
+type RewardClaimedView struct {
+	Height         int64      `json:"height"`
+	Time           types.Time `json:"time"`
+	Era            int64      `json:"era"`
+	ValidatorStash string     `json:"validator_stash"`
+	TxHash         string     `json:"tx_hash"`
+}
+
+func ToRewardClaimedView(update model.HeightUpdate, claim model.RewardClaim) *RewardClaimedView {
+	return &RewardClaimedView{
+		Height:         update.Height,
+		Time:           update.Time,
+		Era:            claim.Era,
+		ValidatorStash: claim.ValidatorStash,
+		TxHash:         claim.TxHash,
+	}
+}
+
+type SystemEventView struct {
+	ID     types.ID    `json:"id"`
+	Height int64       `json:"height"`
+	Time   types.Time  `json:"time"`
+	Actor  string      `json:"actor"`
+	Kind   string      `json:"kind"`
+	Data   types.Jsonb `json:"data"`
+}
+
+func ToSystemEventView(e model.SystemEvent) *SystemEventView {
+	return &SystemEventView{
+		ID:     e.ID,
+		Height: e.Height,
+		Time:   e.Time,
+		Actor:  e.Actor,
+		Kind:   e.Kind.String(),
+		Data:   e.Data,
+	}
+}
+
+// ResetView tells client that height updates after its cursor were purged and stream continues from oldest retained height
+type ResetView struct {
+	After        int64 `json:"after"`
+	OldestHeight int64 `json:"oldest_height"`
+}
+
+func ToResetView(after int64, oldestHeight int64) *ResetView {
+	return &ResetView{
+		After:        after,
+		OldestHeight: oldestHeight,
+	}
+}
@@ -0,0 +1,83 @@
+package system_event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/indexer"
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+const defaultRewriteBatchSize = 1000
+
+type rewriteChangeEventsUseCase struct {
+	cfg *config.Config
+
+	systemEventDb store.SystemEvents
+}
+
+func NewRewriteChangeEventsUseCase(cfg *config.Config, systemEventDb store.SystemEvents) *rewriteChangeEventsUseCase {
+	return &rewriteChangeEventsUseCase{
+		cfg: cfg,
+
+		systemEventDb: systemEventDb,
+	}
+}
+
+// Execute replaces legacy change system events with direction aware ones.
+// Legacy events whose change doesn't fall in any enabled bucket are deleted
+func (uc *rewriteChangeEventsUseCase) Execute(ctx context.Context, batchSize int64) error {
+	defer metric.LogUseCaseDuration(time.Now(), "rewrite_change_events")
+
+	if batchSize <= 0 {
+		batchSize = defaultRewriteBatchSize
+	}
+
+	legacyKinds := append(append([]model.SystemEventKind{}, model.LegacyActiveBalanceChangeKinds...), model.LegacyCommissionChangeKinds...)
+
+	var rewritten, deleted int
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		legacyEvents, err := uc.systemEventDb.FindByKinds(legacyKinds, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(legacyEvents) == 0 {
+			break
+		}
+
+		newEvents := make([]model.SystemEvent, 0, len(legacyEvents))
+		ids := make([]types.ID, len(legacyEvents))
+		for i, legacyEvent := range legacyEvents {
+			ids[i] = legacyEvent.ID
+
+			newEvent, err := indexer.RewriteLegacyChangeSystemEvent(uc.cfg, legacyEvent)
+			if err != nil {
+				return err
+			}
+			if newEvent != nil {
+				newEvents = append(newEvents, *newEvent)
+			}
+		}
+
+		if err := uc.systemEventDb.ReplaceByIDs(ids, newEvents); err != nil {
+			return err
+		}
+
+		rewritten += len(newEvents)
+		deleted += len(ids) - len(newEvents)
+	}
+
+	logger.Info(fmt.Sprintf("legacy change system events rewritten [rewritten=%d] [deleted=%d]", rewritten, deleted))
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package system_event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+type RewriteChangeEventsCmdHandler struct {
+	cfg *config.Config
+
+	useCase *rewriteChangeEventsUseCase
+
+	systemEventDb store.SystemEvents
+}
+
+func NewRewriteChangeEventsCmdHandler(cfg *config.Config, systemEventDb store.SystemEvents) *RewriteChangeEventsCmdHandler {
+	return &RewriteChangeEventsCmdHandler{
+		cfg: cfg,
+
+		systemEventDb: systemEventDb,
+	}
+}
+
+func (h *RewriteChangeEventsCmdHandler) Handle(ctx context.Context, batchSize int64) {
+	logger.Info(fmt.Sprintf("running rewrite change system events use case [handler=cmd] [batchSize=%d]", batchSize))
+
+	err := h.getUseCase().Execute(ctx, batchSize)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *RewriteChangeEventsCmdHandler) getUseCase() *rewriteChangeEventsUseCase {
+	if h.useCase == nil {
+		return NewRewriteChangeEventsUseCase(h.cfg, h.systemEventDb)
+	}
+	return h.useCase
+}
@@ -16,7 +16,7 @@ type SystemEventItem struct {
 	Actor string `json:"actor"`
 	// Kind is system event kind
 	//
-	// example: active_balance_change_1
+	// example: active_balance_increase_1
 	Kind string `json:"kind"`
 	// Data contains event specific data for kind
 	Data types.Jsonb `json:"data"`
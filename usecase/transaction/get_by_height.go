@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 
 	"github.com/figment-networks/polkadothub-indexer/client"
@@ -21,7 +22,7 @@ func NewGetByHeightUseCase(c *client.Client, syncablesDb store.Syncables) *getBy
 	}
 }
 
-func (uc *getByHeightUseCase) Execute(height *int64) (*ListView, error) {
+func (uc *getByHeightUseCase) Execute(ctx context.Context, height *int64) (*ListView, error) {
 	// Get last indexed height
 	mostRecentSynced, err := uc.syncablesDb.FindMostRecent()
 	if err != nil {
@@ -38,7 +39,7 @@ func (uc *getByHeightUseCase) Execute(height *int64) (*ListView, error) {
 		return nil, errors.New("height is not indexed yet")
 	}
 
-	res, err := uc.client.Transaction.GetByHeight(*height)
+	res, err := uc.client.Transaction.GetByHeight(ctx, *height)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,54 @@
+package transaction
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/indexer"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+type getSummaryUseCase struct {
+	cfg *config.Config
+
+	eventDb       store.EventSummary
+	transactionDb store.TransactionSummary
+}
+
+func NewGetSummaryUseCase(cfg *config.Config, eventDb store.EventSummary, transactionDb store.TransactionSummary) *getSummaryUseCase {
+	return &getSummaryUseCase{
+		cfg: cfg,
+
+		eventDb:       eventDb,
+		transactionDb: transactionDb,
+	}
+}
+
+func (uc *getSummaryUseCase) Execute(interval types.SummaryInterval, period string, section, method string) (*SummaryView, error) {
+	configParser, err := indexer.NewConfigParser(uc.cfg.IndexerConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	currentIndexVersion := configParser.GetCurrentVersionId()
+
+	transactionSummaries, err := uc.transactionDb.FindTransactionSummaries(interval, period, currentIndexVersion, section, method)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionTotals, err := uc.transactionDb.FindTransactionTotals(interval, period, currentIndexVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	eventSummaries, err := uc.eventDb.FindEventSummaries(interval, period, currentIndexVersion, section, method)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTotals, err := uc.eventDb.FindEventTotals(interval, period, currentIndexVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToSummaryView(interval, transactionSummaries, transactionTotals, eventSummaries, eventTotals), nil
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getSummaryHttpHandler)(nil)
+
+	ErrInvalidIntervalPeriod = errors.New("invalid interval and/or period")
+)
+
+type getSummaryHttpHandler struct {
+	cfg *config.Config
+
+	eventDb       store.EventSummary
+	transactionDb store.TransactionSummary
+
+	useCase *getSummaryUseCase
+}
+
+func NewGetSummaryHttpHandler(cfg *config.Config, eventDb store.EventSummary, transactionDb store.TransactionSummary) *getSummaryHttpHandler {
+	return &getSummaryHttpHandler{
+		cfg: cfg,
+
+		eventDb:       eventDb,
+		transactionDb: transactionDb,
+	}
+}
+
+// swagger:parameters getTransactionsSummary
+type GetSummaryRequest struct {
+	// Interval
+	//
+	// required: true
+	// in: query
+	// example: hour
+	Interval types.SummaryInterval `json:"interval" form:"interval" binding:"required"`
+	// Period
+	//
+	// required: true
+	// in: query
+	// example: 24 hours
+	Period string `json:"period" form:"period" binding:"required"`
+	// Section
+	//
+	// in: query
+	// example: balances
+	Section string `json:"section" form:"section" binding:"-"`
+	// Method
+	//
+	// in: query
+	// example: transfer
+	Method string `json:"method" form:"method" binding:"-"`
+}
+
+func (h *getSummaryHttpHandler) Handle(c *gin.Context) {
+	var req GetSummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, ErrInvalidIntervalPeriod)
+		return
+	}
+
+	if req.Interval != types.IntervalHourly && req.Interval != types.IntervalDaily {
+		http.BadRequest(c, ErrInvalidIntervalPeriod)
+		return
+	}
+
+	resp, err := h.getUseCase().Execute(req.Interval, req.Period, req.Section, req.Method)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getSummaryHttpHandler) getUseCase() *getSummaryUseCase {
+	if h.useCase == nil {
+		h.useCase = NewGetSummaryUseCase(h.cfg, h.eventDb, h.transactionDb)
+	}
+	return h.useCase
+}
@@ -1,6 +1,8 @@
 package transaction
 
 import (
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/types"
 	"github.com/figment-networks/polkadothub-proxy/grpc/transaction/transactionpb"
 )
 
@@ -41,3 +43,76 @@ func ToListView(rawTransactions []*transactionpb.Transaction) *ListView {
 		Items: items,
 	}
 }
+
+type TransactionSummaryItem struct {
+	TimeBucket types.Time `json:"time_bucket"`
+	// Section and Method are empty in totals of all transactions in time bucket
+	Section string `json:"section"`
+	Method  string `json:"method"`
+	// Count is number of signed transactions
+	Count int64 `json:"count"`
+	// FailedCount is number of transactions which failed
+	FailedCount int64 `json:"failed_count"`
+	// SignersCount is number of unique signers
+	SignersCount int64 `json:"signers_count"`
+}
+
+type EventSummaryItem struct {
+	TimeBucket types.Time `json:"time_bucket"`
+	// Section and Method are empty in totals of all events in time bucket
+	Section string `json:"section"`
+	Method  string `json:"method"`
+	// Count is number of events
+	Count int64 `json:"count"`
+	// TransferVolume is sum of amounts of balances.Transfer events
+	TransferVolume types.Quantity `json:"transfer_volume"`
+}
+
+// swagger:response TransactionsSummaryView
+type SummaryView struct {
+	Interval     types.SummaryInterval    `json:"interval"`
+	Transactions []TransactionSummaryItem `json:"transactions"`
+	Events       []EventSummaryItem       `json:"events"`
+	// TransactionTotals and EventTotals hold totals of all sections and methods, they are not filtered by section and method
+	TransactionTotals []TransactionSummaryItem `json:"transaction_totals"`
+	EventTotals       []EventSummaryItem       `json:"event_totals"`
+}
+
+func ToSummaryView(interval types.SummaryInterval, transactionSummaries, transactionTotals []model.TransactionSummary, eventSummaries, eventTotals []model.EventSummary) *SummaryView {
+	return &SummaryView{
+		Interval:          interval,
+		Transactions:      toTransactionSummaryItems(transactionSummaries),
+		Events:            toEventSummaryItems(eventSummaries),
+		TransactionTotals: toTransactionSummaryItems(transactionTotals),
+		EventTotals:       toEventSummaryItems(eventTotals),
+	}
+}
+
+func toTransactionSummaryItems(summaries []model.TransactionSummary) []TransactionSummaryItem {
+	items := make([]TransactionSummaryItem, len(summaries))
+	for i, s := range summaries {
+		items[i] = TransactionSummaryItem{
+			TimeBucket:   s.TimeBucket,
+			Section:      s.Section,
+			Method:       s.Method,
+			Count:        s.Count,
+			FailedCount:  s.FailedCount,
+			SignersCount: s.SignersCount,
+		}
+	}
+	return items
+}
+
+func toEventSummaryItems(summaries []model.EventSummary) []EventSummaryItem {
+	items := make([]EventSummaryItem, len(summaries))
+	for i, s := range summaries {
+		items[i] = EventSummaryItem{
+			TimeBucket:     s.TimeBucket,
+			Section:        s.Section,
+			Method:         s.Method,
+			Count:          s.Count,
+			TransferVolume: s.TransferVolume,
+		}
+	}
+	return items
+}
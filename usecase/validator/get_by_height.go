@@ -45,7 +45,7 @@ func NewGetByHeightUseCase(cfg *config.Config, cli *client.Client, accountDb sto
 	}
 }
 
-func (uc *getByHeightUseCase) Execute(height *int64) (SeqListView, error) {
+func (uc *getByHeightUseCase) Execute(ctx context.Context, height *int64) (SeqListView, error) {
 	// Get last indexed height
 	mostRecentSynced, err := uc.syncableDb.FindMostRecent()
 	if err != nil {
@@ -79,7 +79,6 @@ func (uc *getByHeightUseCase) Execute(height *int64) (SeqListView, error) {
 			return SeqListView{}, err
 		}
 
-		ctx := context.Background()
 		payload, err := indexingPipeline.Run(ctx, indexer.RunConfig{
 			Height:           syncable.Height,
 			DesiredTargetIDs: []int64{indexer.TargetIndexValidatorSessionSequences},
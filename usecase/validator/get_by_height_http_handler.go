@@ -71,7 +71,7 @@ func (h *getByHeightHttpHandler) Handle(c *gin.Context) {
 		return
 	}
 
-	ds, err := h.getUseCase().Execute(req.Height)
+	ds, err := h.getUseCase().Execute(c.Request.Context(), req.Height)
 	if err != nil {
 		logger.Error(err)
 		http.ServerError(c, err)
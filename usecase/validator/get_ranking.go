@@ -0,0 +1,259 @@
+package validator
+
+import (
+	"sort"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/usecase/apr"
+)
+
+const (
+	RankingSortAPR        = "apr"
+	RankingSortUptime     = "uptime"
+	RankingSortCommission = "commission"
+	RankingSortSaturation = "saturation"
+	RankingSortSlashes    = "slashes"
+)
+
+// RankingQuery selects and orders validators of ranking, nil filters are not applied
+type RankingQuery struct {
+	Eras          int64
+	Sort          string
+	MaxCommission *int64
+	MinUptime     *float64
+	MaxSaturation *float64
+	MaxSlashes    *int64
+	Limit         int64
+}
+
+type getRankingUseCase struct {
+	cfg *config.Config
+
+	eventDb     store.EventSeq
+	rewardDb    store.Rewards
+	syncablesDb store.Syncables
+	validatorDb store.Validators
+}
+
+func NewGetRankingUseCase(cfg *config.Config, eventDb store.EventSeq, rewardDb store.Rewards, syncablesDb store.Syncables, validatorDb store.Validators) *getRankingUseCase {
+	return &getRankingUseCase{
+		cfg: cfg,
+
+		eventDb:     eventDb,
+		rewardDb:    rewardDb,
+		syncablesDb: syncablesDb,
+		validatorDb: validatorDb,
+	}
+}
+
+func (uc *getRankingUseCase) Execute(query RankingQuery) (*RankingView, error) {
+	mostRecent, err := uc.validatorDb.FindMostRecentEraSeq()
+	if err != nil {
+		return nil, err
+	}
+	endEra := mostRecent.Era
+	startEra := endEra - query.Eras + 1
+	if startEra < 0 {
+		startEra = 0
+	}
+
+	eraSeqs, err := uc.validatorDb.FindEraSeqsForEraRange(startEra, endEra, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rewardRows, err := uc.rewardDb.SumNominatorRewardsForEraRange(startEra, endEra)
+	if err != nil {
+		return nil, err
+	}
+
+	startHeight, endHeight := mostRecent.StartHeight, mostRecent.EndHeight
+	eras := make([]*model.EraSequence, 0, len(eraSeqs))
+	for _, seq := range eraSeqs {
+		if seq.StartHeight < startHeight {
+			startHeight = seq.StartHeight
+		}
+		eras = append(eras, seq.EraSequence)
+	}
+
+	eraDurations, err := apr.EraDurations(uc.syncablesDb, eras)
+	if err != nil {
+		return nil, err
+	}
+
+	// Slashes are counted over whole history, slash of validator long ago is still a risk for its nominators
+	slashes, err := uc.eventDb.FindSlashesForHeightRange(0, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionSeqs, err := uc.findSessionSeqs(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	validatorAggs, err := uc.validatorDb.GetAllForHeightGreaterThan(mostRecent.StartHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	items := toRankingItems(endEra, eraSeqs, apr.ToValidatorEraAPRs(eraSeqs, rewardRows, eraDurations), validatorAggs, countSlashes(slashes), countUptime(sessionSeqs), uc.cfg.MaxNominatorRewardedPerValidator)
+	items = filterRankingItems(items, query)
+	sortRankingItems(items, query.Sort)
+
+	if query.Limit > 0 && int64(len(items)) > query.Limit {
+		items = items[:query.Limit]
+	}
+
+	return &RankingView{
+		StartEra: startEra,
+		EndEra:   endEra,
+		Items:    items,
+	}, nil
+}
+
+// findSessionSeqs returns validator session sequences of sessions between given heights
+func (uc *getRankingUseCase) findSessionSeqs(startHeight, endHeight int64) ([]model.ValidatorSessionSeq, error) {
+	start, err := uc.syncablesDb.FindByHeight(startHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := uc.syncablesDb.FindByHeight(endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	seqs, err := uc.validatorDb.FindBySessionRange(start.Session, end.Session)
+	if err != nil && err != store.ErrNotFound {
+		return nil, err
+	}
+	return seqs, nil
+}
+
+// toRankingItems creates ranking items for validators active in end era
+func toRankingItems(endEra int64, eraSeqs []model.ValidatorEraSeq, eraAPRs map[string][]apr.ValidatorEraApr, validatorAggs []model.ValidatorAgg, slashes map[string]int64,
+	uptimes map[string]float64, maxNominatorRewarded int64) []RankingItem {
+	aggLookup := make(map[string]model.ValidatorAgg)
+	for _, agg := range validatorAggs {
+		aggLookup[agg.StashAccount] = agg
+	}
+
+	var current []model.ValidatorEraSeq
+	activeEras := make(map[string]int64)
+	for _, seq := range eraSeqs {
+		activeEras[seq.StashAccount]++
+		if seq.Era == endEra {
+			current = append(current, seq)
+		}
+	}
+	if len(current) == 0 {
+		return nil
+	}
+
+	items := make([]RankingItem, 0, len(current))
+	for _, seq := range current {
+		item := RankingItem{
+			StashAccount: seq.StashAccount,
+			Commission:   seq.Commission,
+			TotalStake:   seq.TotalStake,
+			StakersCount: seq.StakersCount,
+			ActiveEras:   activeEras[seq.StashAccount],
+			Uptime:       uptimes[seq.StashAccount],
+			Slashes:      slashes[seq.StashAccount],
+			EraAPRs:      eraAPRs[seq.StashAccount],
+		}
+
+		if agg, ok := aggLookup[seq.StashAccount]; ok {
+			item.DisplayName = agg.DisplayName
+		}
+
+		if maxNominatorRewarded > 0 {
+			item.Saturation = float64(seq.StakersCount) / float64(maxNominatorRewarded)
+		}
+
+		item.APR, item.aprValue = apr.TrailingValidatorAPR(item.EraAPRs)
+
+		items = append(items, item)
+	}
+	return items
+}
+
+// countSlashes returns number of slashes by slashed stash account
+func countSlashes(events []model.EventSeq) map[string]int64 {
+	res := make(map[string]int64)
+	for _, e := range events {
+		data, err := unmarshalEventData(e)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+		res[data[0].Value]++
+	}
+	return res
+}
+
+// countUptime returns share of sessions in which validator was online by stash account
+func countUptime(seqs []model.ValidatorSessionSeq) map[string]float64 {
+	online := make(map[string]int64)
+	total := make(map[string]int64)
+	for _, seq := range seqs {
+		total[seq.StashAccount]++
+		if seq.Online {
+			online[seq.StashAccount]++
+		}
+	}
+
+	res := make(map[string]float64, len(total))
+	for stash, count := range total {
+		res[stash] = float64(online[stash]) / float64(count)
+	}
+	return res
+}
+
+func filterRankingItems(items []RankingItem, query RankingQuery) []RankingItem {
+	res := items[:0]
+	for _, item := range items {
+		if query.MaxCommission != nil && item.Commission > *query.MaxCommission {
+			continue
+		}
+		if query.MinUptime != nil && item.Uptime < *query.MinUptime {
+			continue
+		}
+		if query.MaxSaturation != nil && item.Saturation > *query.MaxSaturation {
+			continue
+		}
+		if query.MaxSlashes != nil && item.Slashes > *query.MaxSlashes {
+			continue
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+// sortRankingItems orders items from best to worst by given criteria, ties are ordered by APR
+func sortRankingItems(items []RankingItem, by string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		switch by {
+		case RankingSortUptime:
+			if a.Uptime != b.Uptime {
+				return a.Uptime > b.Uptime
+			}
+		case RankingSortCommission:
+			if a.Commission != b.Commission {
+				return a.Commission < b.Commission
+			}
+		case RankingSortSaturation:
+			if a.Saturation != b.Saturation {
+				return a.Saturation < b.Saturation
+			}
+		case RankingSortSlashes:
+			if a.Slashes != b.Slashes {
+				return a.Slashes < b.Slashes
+			}
+		}
+		return a.aprValue.Cmp(b.aprValue) > 0
+	})
+}
@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultRankingEras  = 30
+	defaultRankingLimit = 100
+)
+
+var (
+	_ types.HttpHandler = (*getRankingHttpHandler)(nil)
+
+	ErrInvalidRankingParams = errors.New("invalid eras, sort or filters")
+)
+
+type getRankingHttpHandler struct {
+	cfg     *config.Config
+	useCase *getRankingUseCase
+
+	eventDb     store.EventSeq
+	rewardDb    store.Rewards
+	syncablesDb store.Syncables
+	validatorDb store.Validators
+}
+
+func NewGetRankingHttpHandler(cfg *config.Config, eventDb store.EventSeq, rewardDb store.Rewards, syncablesDb store.Syncables, validatorDb store.Validators) *getRankingHttpHandler {
+	return &getRankingHttpHandler{
+		cfg:         cfg,
+		eventDb:     eventDb,
+		rewardDb:    rewardDb,
+		syncablesDb: syncablesDb,
+		validatorDb: validatorDb,
+	}
+}
+
+// swagger:parameters getValidatorRanking
+type GetRankingRequest struct {
+	// Number of trailing eras, defaults to 30
+	//
+	// in: query
+	Eras int64 `json:"eras" form:"eras"`
+	// Sort by apr, uptime, commission, saturation or slashes, defaults to apr
+	//
+	// in: query
+	Sort string `json:"sort" form:"sort"`
+	// Maximum commission in perbill
+	//
+	// in: query
+	MaxCommission *int64 `json:"max_commission" form:"max_commission"`
+	// Minimum uptime between 0 and 1
+	//
+	// in: query
+	MinUptime *float64 `json:"min_uptime" form:"min_uptime"`
+	// Maximum saturation, number of nominators relative to max rewarded nominators
+	//
+	// in: query
+	MaxSaturation *float64 `json:"max_saturation" form:"max_saturation"`
+	// Maximum number of slashes
+	//
+	// in: query
+	MaxSlashes *int64 `json:"max_slashes" form:"max_slashes"`
+	// Limit of validators, defaults to 100
+	//
+	// in: query
+	Limit int64 `json:"limit" form:"limit"`
+}
+
+func (h *getRankingHttpHandler) Handle(c *gin.Context) {
+	req, err := h.validateParams(c)
+	if err != nil {
+		logger.Error(err)
+		http.BadRequest(c, ErrInvalidRankingParams)
+		return
+	}
+
+	resp, err := h.getUseCase().Execute(RankingQuery{
+		Eras:          req.Eras,
+		Sort:          req.Sort,
+		MaxCommission: req.MaxCommission,
+		MinUptime:     req.MinUptime,
+		MaxSaturation: req.MaxSaturation,
+		MaxSlashes:    req.MaxSlashes,
+		Limit:         req.Limit,
+	})
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	http.JsonOK(c, resp)
+}
+
+func (h *getRankingHttpHandler) validateParams(c *gin.Context) (*GetRankingRequest, error) {
+	var req GetRankingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		return nil, err
+	}
+
+	if req.Eras < 0 || req.Limit < 0 {
+		return nil, ErrInvalidRankingParams
+	}
+
+	if req.Eras == 0 {
+		req.Eras = defaultRankingEras
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultRankingLimit
+	}
+
+	switch req.Sort {
+	case "":
+		req.Sort = RankingSortAPR
+	case RankingSortAPR, RankingSortUptime, RankingSortCommission, RankingSortSaturation, RankingSortSlashes:
+	default:
+		return nil, ErrInvalidRankingParams
+	}
+
+	return &req, nil
+}
+
+func (h *getRankingHttpHandler) getUseCase() *getRankingUseCase {
+	if h.useCase == nil {
+		return NewGetRankingUseCase(h.cfg, h.eventDb, h.rewardDb, h.syncablesDb, h.validatorDb)
+	}
+	return h.useCase
+}
@@ -0,0 +1,258 @@
+package validator
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/store/memory"
+	"github.com/figment-networks/polkadothub-indexer/types"
+)
+
+var rankingTime = time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+type rankingValidator struct {
+	stash      string
+	commission int64
+	stakers    int
+	reward     string
+}
+
+// newRankingStore returns store with 3 eras of 10 heights and 24 hours, every era has 2 sessions. Validators
+// "fast" and "slow" validate eras 2 and 3, "gone" validated only era 1
+func newRankingStore(t *testing.T) store.Store {
+	db := memory.New()
+
+	for h := int64(1); h <= 30; h++ {
+		err := db.GetSyncables().CreateOrUpdate(&model.Syncable{
+			Model:         &model.Model{},
+			Height:        h,
+			Time:          *types.NewTimeFromTime(rankingTime.Add(time.Duration(h) * 144 * time.Minute)),
+			Session:       (h-1)/5 + 1,
+			Era:           (h-1)/10 + 1,
+			LastInSession: h%5 == 0,
+			LastInEra:     h%10 == 0,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var eraSeqs []model.ValidatorEraSeq
+	var rewards []model.RewardEraSeq
+	for era := int64(1); era <= 3; era++ {
+		eraSeq := &model.EraSequence{
+			Era:         era,
+			StartHeight: (era-1)*10 + 1,
+			EndHeight:   era * 10,
+			Time:        *types.NewTimeFromTime(rankingTime.Add(time.Duration(era*10) * 144 * time.Minute)),
+		}
+
+		validators := []rankingValidator{{"fast", 500000000, 300, "2"}, {"slow", 100000000, 128, "1"}}
+		if era == 1 {
+			validators = []rankingValidator{{"gone", 0, 10, "5"}}
+		}
+
+		for _, v := range validators {
+			eraSeqs = append(eraSeqs, model.ValidatorEraSeq{
+				EraSequence:  eraSeq,
+				StashAccount: v.stash,
+				TotalStake:   types.NewQuantityFromInt64(1500),
+				StakersStake: types.NewQuantityFromInt64(1000),
+				Commission:   v.commission,
+				StakersCount: v.stakers,
+			})
+			rewards = append(rewards, model.RewardEraSeq{
+				EraSequence:           eraSeq,
+				StashAccount:          "nominator",
+				ValidatorStashAccount: v.stash,
+				Amount:                v.reward,
+				Kind:                  model.RewardReward,
+			})
+		}
+	}
+	if err := db.GetValidators().BulkUpsertEraSeqs(eraSeqs); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.GetRewards().BulkUpsert(rewards); err != nil {
+		t.Fatal(err)
+	}
+
+	// "slow" was offline in session 1, which is outside of ranked eras, and in last session
+	var sessionSeqs []model.ValidatorSessionSeq
+	for session := int64(1); session <= 6; session++ {
+		seq := &model.SessionSequence{Session: session, StartHeight: (session-1)*5 + 1, EndHeight: session * 5}
+		sessionSeqs = append(sessionSeqs,
+			model.ValidatorSessionSeq{SessionSequence: seq, StashAccount: "fast", Online: true},
+			model.ValidatorSessionSeq{SessionSequence: seq, StashAccount: "slow", Online: session != 1 && session != 6},
+		)
+	}
+	if err := db.GetValidators().BulkUpsertSessionSeqs(sessionSeqs); err != nil {
+		t.Fatal(err)
+	}
+
+	// "slow" was slashed in era 1, which is outside of ranked eras
+	err := db.GetEvents().BulkUpsert([]model.EventSeq{{
+		Sequence: &model.Sequence{Height: 3, Time: *types.NewTimeFromTime(rankingTime)},
+		Section:  "staking",
+		Method:   "Slashed",
+		Data:     types.Jsonb{RawMessage: []byte(`[{"name":"AccountId","value":"slow"}]`)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stash := range []string{"fast", "slow"} {
+		err := db.GetValidators().CreateAgg(&model.ValidatorAgg{
+			Model:                   &model.Model{},
+			Aggregate:               &model.Aggregate{},
+			StashAccount:            stash,
+			DisplayName:             stash + " validator",
+			RecentAsValidatorHeight: 30,
+			AccumulatedUptime:       1,
+			AccumulatedUptimeCount:  100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func TestGetRankingUseCase_Execute(t *testing.T) {
+	db := newRankingStore(t)
+	uc := NewGetRankingUseCase(&config.Config{MaxNominatorRewardedPerValidator: 256}, db.GetEvents(), db.GetRewards(), db.GetSyncables(), db.GetValidators())
+
+	res, err := uc.Execute(RankingQuery{Eras: 2, Sort: RankingSortAPR})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.StartEra != 2 || res.EndEra != 3 {
+		t.Errorf("want eras 2-3; got %d-%d", res.StartEra, res.EndEra)
+	}
+	if len(res.Items) != 2 {
+		t.Fatalf("want 2 validators of most recent era; got %+v", res.Items)
+	}
+
+	fast, slow := res.Items[0], res.Items[1]
+	if fast.StashAccount != "fast" || slow.StashAccount != "slow" {
+		t.Fatalf("want validators ordered by apr; got %s, %s", fast.StashAccount, slow.StashAccount)
+	}
+
+	tests := []struct {
+		item           RankingItem
+		wantAPR        string
+		wantUptime     float64
+		wantSaturation float64
+		wantSlashes    int64
+	}{
+		// 2 / 1000 of stake in era of 24 hours
+		{item: fast, wantAPR: "0.7300", wantUptime: 1, wantSaturation: 300.0 / 256, wantSlashes: 0},
+		{item: slow, wantAPR: "0.3650", wantUptime: 0.75, wantSaturation: 0.5, wantSlashes: 1},
+	}
+	for _, tt := range tests {
+		if tt.item.APR != tt.wantAPR {
+			t.Errorf("%s: want apr %s; got %s", tt.item.StashAccount, tt.wantAPR, tt.item.APR)
+		}
+		if tt.item.Uptime != tt.wantUptime {
+			t.Errorf("%s: want uptime of ranked eras %v; got %v", tt.item.StashAccount, tt.wantUptime, tt.item.Uptime)
+		}
+		if tt.item.Saturation != tt.wantSaturation {
+			t.Errorf("%s: want saturation %v; got %v", tt.item.StashAccount, tt.wantSaturation, tt.item.Saturation)
+		}
+		if tt.item.Slashes != tt.wantSlashes {
+			t.Errorf("%s: want %d slashes of whole history; got %d", tt.item.StashAccount, tt.wantSlashes, tt.item.Slashes)
+		}
+		if tt.item.ActiveEras != 2 || len(tt.item.EraAPRs) != 2 {
+			t.Errorf("%s: want 2 active eras with apr; got %d and %d", tt.item.StashAccount, tt.item.ActiveEras, len(tt.item.EraAPRs))
+		}
+		if tt.item.DisplayName != tt.item.StashAccount+" validator" {
+			t.Errorf("%s: want display name of aggregate; got %s", tt.item.StashAccount, tt.item.DisplayName)
+		}
+	}
+}
+
+func TestGetRankingUseCase_ExecuteFilters(t *testing.T) {
+	db := newRankingStore(t)
+	uc := NewGetRankingUseCase(&config.Config{MaxNominatorRewardedPerValidator: 256}, db.GetEvents(), db.GetRewards(), db.GetSyncables(), db.GetValidators())
+
+	maxCommission := int64(200000000)
+	minUptime := 0.8
+	maxSaturation := 1.0
+	maxSlashes := int64(0)
+
+	tests := []struct {
+		description string
+		query       RankingQuery
+		want        []string
+	}{
+		{"sorts by apr", RankingQuery{Eras: 2, Sort: RankingSortAPR}, []string{"fast", "slow"}},
+		{"sorts by uptime", RankingQuery{Eras: 2, Sort: RankingSortUptime}, []string{"fast", "slow"}},
+		{"sorts by commission", RankingQuery{Eras: 2, Sort: RankingSortCommission}, []string{"slow", "fast"}},
+		{"sorts by saturation", RankingQuery{Eras: 2, Sort: RankingSortSaturation}, []string{"slow", "fast"}},
+		{"sorts by slashes", RankingQuery{Eras: 2, Sort: RankingSortSlashes}, []string{"fast", "slow"}},
+		{"filters by commission", RankingQuery{Eras: 2, MaxCommission: &maxCommission}, []string{"slow"}},
+		{"filters by uptime", RankingQuery{Eras: 2, MinUptime: &minUptime}, []string{"fast"}},
+		{"filters by saturation", RankingQuery{Eras: 2, MaxSaturation: &maxSaturation}, []string{"slow"}},
+		{"filters by slashes", RankingQuery{Eras: 2, MaxSlashes: &maxSlashes}, []string{"fast"}},
+		{"limits validators", RankingQuery{Eras: 2, Limit: 1}, []string{"fast"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			res, err := uc.Execute(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, item := range res.Items {
+				got = append(got, item.StashAccount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want validators %v; got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSortRankingItems(t *testing.T) {
+	items := func() []RankingItem {
+		return []RankingItem{
+			{StashAccount: "a", Uptime: 0.9, Commission: 10, Saturation: 0.5, Slashes: 1, aprValue: big.NewFloat(0.1)},
+			{StashAccount: "b", Uptime: 0.9, Commission: 20, Saturation: 0.9, Slashes: 0, aprValue: big.NewFloat(0.3)},
+			{StashAccount: "c", Uptime: 1, Commission: 10, Saturation: 0.5, Slashes: 0, aprValue: big.NewFloat(0.2)},
+		}
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{RankingSortAPR, []string{"b", "c", "a"}},
+		{RankingSortUptime, []string{"c", "b", "a"}},
+		{RankingSortCommission, []string{"c", "a", "b"}},
+		{RankingSortSaturation, []string{"c", "a", "b"}},
+		{RankingSortSlashes, []string{"b", "c", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			res := items()
+			sortRankingItems(res, tt.by)
+
+			var got []string
+			for _, item := range res {
+				got = append(got, item.StashAccount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want order %v; got %v", tt.want, got)
+			}
+		})
+	}
+}
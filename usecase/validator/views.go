@@ -1,9 +1,13 @@
 package validator
 
 import (
+	"encoding/json"
+	"math/big"
+
 	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/apr"
 	"github.com/figment-networks/polkadothub-indexer/usecase/common"
 	"github.com/lib/pq"
 )
@@ -144,3 +148,39 @@ func toSummaryListView(summaries []store.ValidatorSummaryRow, lastIndexedSession
 
 	return view
 }
+
+// swagger:response ValidatorRankingView
+type RankingView struct {
+	StartEra int64         `json:"start_era"`
+	EndEra   int64         `json:"end_era"`
+	Items    []RankingItem `json:"items"`
+}
+
+// RankingItem is validator of most recent era with nominator APR and uptime of trailing eras, saturation and slashes
+type RankingItem struct {
+	StashAccount string         `json:"stash_account"`
+	DisplayName  string         `json:"display_name"`
+	APR          string         `json:"apr"`
+	Uptime       float64        `json:"uptime"`
+	Commission   int64          `json:"commission"`
+	TotalStake   types.Quantity `json:"total_stake"`
+	StakersCount int            `json:"stakers_count"`
+	Saturation   float64        `json:"saturation"`
+	Slashes      int64          `json:"slashes"`
+	ActiveEras   int64          `json:"active_eras"`
+
+	EraAPRs []apr.ValidatorEraApr `json:"era_aprs"`
+
+	aprValue *big.Float
+}
+
+type eventData struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func unmarshalEventData(e model.EventSeq) ([]eventData, error) {
+	var data []eventData
+	err := json.Unmarshal(e.Data.RawMessage, &data)
+	return data, err
+}
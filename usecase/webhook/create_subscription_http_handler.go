@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*createSubscriptionHttpHandler)(nil)
+)
+
+type createSubscriptionHttpHandler struct {
+	subscriptionDb store.Subscriptions
+	syncableDb     store.Syncables
+	systemEventDb  store.SystemEvents
+}
+
+func NewCreateSubscriptionHttpHandler(subscriptionDb store.Subscriptions, syncableDb store.Syncables, systemEventDb store.SystemEvents) *createSubscriptionHttpHandler {
+	return &createSubscriptionHttpHandler{
+		subscriptionDb: subscriptionDb,
+		syncableDb:     syncableDb,
+		systemEventDb:  systemEventDb,
+	}
+}
+
+// swagger:parameters createSubscription
+type CreateSubscriptionBody struct {
+	// URL which system events are posted to
+	//
+	// required: true
+	// in: body
+	URL string `json:"url" binding:"required"`
+	// Secret used to sign payloads with HMAC-SHA256
+	//
+	// required: true
+	// in: body
+	Secret string `json:"secret" binding:"required"`
+	// Actors whose system events are delivered, all actors when empty
+	//
+	// in: body
+	Actors []string `json:"actors"`
+	// Kinds of system events which are delivered, all kinds when empty
+	//
+	// in: body
+	Kinds []string `json:"kinds"`
+}
+
+func (h *createSubscriptionHttpHandler) Handle(c *gin.Context) {
+	var body CreateSubscriptionBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid subscription"))
+		return
+	}
+
+	// only system events created from now on at most recently indexed height or above it are delivered
+	lastSystemEventID, err := h.systemEventDb.FindLastID()
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	var lastSystemEventHeight int64
+	mostRecentSyncable, err := h.syncableDb.FindMostRecent()
+	if err != nil && err != store.ErrNotFound {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+	if err == nil {
+		lastSystemEventHeight = mostRecentSyncable.Height
+	}
+
+	subscription := model.NewSubscription(body.URL, body.Secret, body.Actors, body.Kinds, lastSystemEventHeight, lastSystemEventID)
+	if err := subscription.Validate(); err != nil {
+		http.BadRequest(c, err)
+		return
+	}
+
+	if err := h.subscriptionDb.Create(subscription); err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, subscription)
+}
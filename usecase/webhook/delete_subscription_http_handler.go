@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*deleteSubscriptionHttpHandler)(nil)
+)
+
+type deleteSubscriptionHttpHandler struct {
+	subscriptionDb store.Subscriptions
+}
+
+func NewDeleteSubscriptionHttpHandler(subscriptionDb store.Subscriptions) *deleteSubscriptionHttpHandler {
+	return &deleteSubscriptionHttpHandler{
+		subscriptionDb: subscriptionDb,
+	}
+}
+
+// swagger:parameters deleteSubscription
+type DeleteSubscriptionRequest struct {
+	// ID of subscription
+	//
+	// required: true
+	// in: path
+	ID types.ID `json:"id" uri:"id" binding:"required"`
+}
+
+func (h *deleteSubscriptionHttpHandler) Handle(c *gin.Context) {
+	var req DeleteSubscriptionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	subscription, err := h.subscriptionDb.FindByID(req.ID)
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	if err := h.subscriptionDb.Delete(req.ID); http.ShouldReturn(c, err) {
+		return
+	}
+
+	http.JsonOK(c, subscription)
+}
@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/metric"
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/system_event"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventIDHeader   = "X-Webhook-Event-Id"
+)
+
+// Payload is body of webhook request
+type Payload struct {
+	SubscriptionID int64                        `json:"subscription_id"`
+	SystemEvent    system_event.SystemEventItem `json:"system_event"`
+}
+
+type deliverUseCase struct {
+	cfg    *config.Config
+	client *http.Client
+
+	subscriptionDb store.Subscriptions
+	syncableDb     store.Syncables
+	systemEventDb  store.SystemEvents
+}
+
+func NewDeliverUseCase(cfg *config.Config, subscriptionDb store.Subscriptions, syncableDb store.Syncables, systemEventDb store.SystemEvents) *deliverUseCase {
+	// timeout is checked when config is validated
+	timeout, _ := time.ParseDuration(cfg.WebhookTimeout)
+
+	return &deliverUseCase{
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+
+		subscriptionDb: subscriptionDb,
+		syncableDb:     syncableDb,
+		systemEventDb:  systemEventDb,
+	}
+}
+
+// Execute claims subscriptions due for delivery one by one and posts them new system events
+func (uc *deliverUseCase) Execute(ctx context.Context, workerID string) error {
+	defer metric.LogUseCaseDuration(time.Now(), "deliver_webhooks")
+
+	lockTimeout, err := time.ParseDuration(uc.cfg.WebhookLockTimeout)
+	if err != nil {
+		return err
+	}
+
+	retryDelay, err := time.ParseDuration(uc.cfg.WebhookRetryDelay)
+	if err != nil {
+		return err
+	}
+
+	maxRetryDelay, err := time.ParseDuration(uc.cfg.WebhookMaxRetryDelay)
+	if err != nil {
+		return err
+	}
+
+	maxHeight, err := uc.getMaxHeight()
+	if err == store.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// subscriptions caught up during this run are due after it started, so they are not claimed again until next run
+	runStart := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		subscription, err := uc.subscriptionDb.Claim(workerID, lockTimeout, runStart)
+		if err == store.ErrNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		deliverErr := uc.deliver(ctx, subscription, maxHeight, time.Now().Add(lockTimeout/2), retryDelay, maxRetryDelay)
+
+		if err := uc.subscriptionDb.Release(subscription); err != nil && err != store.ErrNotFound {
+			return err
+		}
+
+		if deliverErr != nil {
+			return deliverErr
+		}
+	}
+}
+
+// getMaxHeight returns height up to which system events are delivered. Events are delivered in order of height and id,
+// so heights still being written, and heights WEBHOOK_HEIGHT_LAG below them, are held back until no more events are
+// committed for them
+func (uc *deliverUseCase) getMaxHeight() (int64, error) {
+	mostRecentSyncable, err := uc.syncableDb.FindMostRecent()
+	if err != nil {
+		return 0, err
+	}
+
+	maxHeight := mostRecentSyncable.Height
+	if mostRecentSyncable.ProcessedAt == nil {
+		maxHeight--
+	}
+	return maxHeight - uc.cfg.WebhookHeightLag, nil
+}
+
+// deliver posts new system events to subscription until first failure, deadline or until there are no more events.
+// Subscription which is caught up is due again right away, it's picked up by next run
+func (uc *deliverUseCase) deliver(ctx context.Context, subscription *model.Subscription, maxHeight int64, deadline time.Time, retryDelay, maxRetryDelay time.Duration) error {
+	systemEvents, err := uc.systemEventDb.FindForSubscription(subscription, maxHeight, uc.cfg.WebhookBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, systemEvent := range systemEvents {
+		if ctx.Err() != nil || time.Now().After(deadline) {
+			return nil
+		}
+
+		if err := uc.post(ctx, subscription, systemEvent); err != nil {
+			logger.Info(fmt.Sprintf("webhook delivery failed [subscription=%d] [system_event=%d] [failures=%d]: %s",
+				subscription.ID, systemEvent.ID, subscription.Failures+1, err.Error()))
+
+			subscription.Fail(retryDelay, maxRetryDelay, uc.cfg.WebhookMaxFailures)
+			if !subscription.Enabled {
+				logger.Info(fmt.Sprintf("webhook subscription disabled after %d failures [subscription=%d]", subscription.Failures, subscription.ID))
+			}
+			return nil
+		}
+
+		subscription.Delivered(systemEvent)
+	}
+
+	if int64(len(systemEvents)) < uc.cfg.WebhookBatchSize {
+		subscription.NextAttemptAt = *types.NewTimeFromTime(time.Now())
+	}
+	return nil
+}
+
+// post sends system event to subscription url and logs delivery attempt
+func (uc *deliverUseCase) post(ctx context.Context, subscription *model.Subscription, systemEvent model.SystemEvent) error {
+	delivery := &model.SubscriptionDelivery{
+		SubscriptionID: subscription.ID,
+		SystemEventID:  systemEvent.ID,
+		Attempt:        subscription.Failures + 1,
+	}
+
+	start := time.Now()
+	statusCode, err := uc.send(ctx, subscription, systemEvent)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+
+	if statusCode != 0 {
+		code := int64(statusCode)
+		delivery.StatusCode = &code
+	}
+	if err == nil && (statusCode < 200 || statusCode > 299) {
+		err = fmt.Errorf("unexpected status code %d", statusCode)
+	}
+	if err != nil {
+		errMsg := err.Error()
+		delivery.Error = &errMsg
+	}
+
+	if logErr := uc.subscriptionDb.CreateDelivery(delivery); logErr != nil {
+		logger.Error(logErr, logger.Field("subscription", subscription.ID))
+	}
+
+	return err
+}
+
+func (uc *deliverUseCase) send(ctx context.Context, subscription *model.Subscription, systemEvent model.SystemEvent) (int, error) {
+	body, err := json.Marshal(Payload{
+		SubscriptionID: int64(subscription.ID),
+		SystemEvent: system_event.SystemEventItem{
+			Model:  systemEvent.Model,
+			Height: systemEvent.Height,
+			Time:   systemEvent.Time,
+			Actor:  systemEvent.Actor,
+			Kind:   systemEvent.Kind.String(),
+			Data:   systemEvent.Data,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(EventIDHeader, strconv.FormatInt(int64(systemEvent.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := uc.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain body so connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns HMAC-SHA256 signature of timestamp and body in the format sha256=<hex>.
+// Receivers should compute it over "<X-Webhook-Timestamp>.<raw body>" with subscription secret
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
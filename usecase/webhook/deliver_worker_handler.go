@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+)
+
+var (
+	_ types.WorkerHandler = (*deliverWorkerHandler)(nil)
+)
+
+type deliverWorkerHandler struct {
+	cfg      *config.Config
+	workerID string
+
+	useCase *deliverUseCase
+
+	subscriptionDb store.Subscriptions
+	syncableDb     store.Syncables
+	systemEventDb  store.SystemEvents
+}
+
+func NewDeliverWorkerHandler(cfg *config.Config, subscriptionDb store.Subscriptions, syncableDb store.Syncables, systemEventDb store.SystemEvents) *deliverWorkerHandler {
+	hostname, _ := os.Hostname()
+
+	return &deliverWorkerHandler{
+		cfg:      cfg,
+		workerID: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+
+		subscriptionDb: subscriptionDb,
+		syncableDb:     syncableDb,
+		systemEventDb:  systemEventDb,
+	}
+}
+
+func (h *deliverWorkerHandler) Handle() {
+	ctx := context.Background()
+
+	err := h.getUseCase().Execute(ctx, h.workerID)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+}
+
+func (h *deliverWorkerHandler) getUseCase() *deliverUseCase {
+	if h.useCase == nil {
+		h.useCase = NewDeliverUseCase(h.cfg, h.subscriptionDb, h.syncableDb, h.systemEventDb)
+	}
+	return h.useCase
+}
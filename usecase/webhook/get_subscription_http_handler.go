@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/model"
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultDeliveriesLimit = 50
+
+var (
+	_ types.HttpHandler = (*getSubscriptionHttpHandler)(nil)
+)
+
+type getSubscriptionHttpHandler struct {
+	subscriptionDb store.Subscriptions
+}
+
+func NewGetSubscriptionHttpHandler(subscriptionDb store.Subscriptions) *getSubscriptionHttpHandler {
+	return &getSubscriptionHttpHandler{
+		subscriptionDb: subscriptionDb,
+	}
+}
+
+// swagger:parameters getSubscription
+type GetSubscriptionRequest struct {
+	// ID of subscription
+	//
+	// required: true
+	// in: path
+	ID types.ID `json:"id" uri:"id" binding:"required"`
+	// Limit of delivery logs, defaults to 50
+	//
+	// in: query
+	Limit int64 `json:"limit" form:"limit"`
+}
+
+// SubscriptionView is subscription with its most recent deliveries
+// swagger:response SubscriptionView
+type SubscriptionView struct {
+	*model.Subscription
+
+	Deliveries []model.SubscriptionDelivery `json:"deliveries"`
+}
+
+func (h *getSubscriptionHttpHandler) Handle(c *gin.Context) {
+	var req GetSubscriptionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit < 0 {
+		http.BadRequest(c, errors.New("invalid limit"))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultDeliveriesLimit
+	}
+
+	subscription, err := h.subscriptionDb.FindByID(req.ID)
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	deliveries, err := h.subscriptionDb.FindDeliveries(req.ID, req.Limit)
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, SubscriptionView{
+		Subscription: subscription,
+		Deliveries:   deliveries,
+	})
+}
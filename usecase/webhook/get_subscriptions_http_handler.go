@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	_ types.HttpHandler = (*getSubscriptionsHttpHandler)(nil)
+)
+
+type getSubscriptionsHttpHandler struct {
+	subscriptionDb store.Subscriptions
+}
+
+func NewGetSubscriptionsHttpHandler(subscriptionDb store.Subscriptions) *getSubscriptionsHttpHandler {
+	return &getSubscriptionsHttpHandler{
+		subscriptionDb: subscriptionDb,
+	}
+}
+
+func (h *getSubscriptionsHttpHandler) Handle(c *gin.Context) {
+	subscriptions, err := h.subscriptionDb.FindAll()
+	if err != nil {
+		logger.Error(err)
+		http.ServerError(c, err)
+		return
+	}
+
+	http.JsonOK(c, subscriptions)
+}
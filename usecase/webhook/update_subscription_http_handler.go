@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"errors"
+
+	"github.com/figment-networks/polkadothub-indexer/store"
+	"github.com/figment-networks/polkadothub-indexer/types"
+	"github.com/figment-networks/polkadothub-indexer/usecase/http"
+	"github.com/figment-networks/polkadothub-indexer/utils/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var (
+	_ types.HttpHandler = (*updateSubscriptionHttpHandler)(nil)
+)
+
+type updateSubscriptionHttpHandler struct {
+	subscriptionDb store.Subscriptions
+}
+
+func NewUpdateSubscriptionHttpHandler(subscriptionDb store.Subscriptions) *updateSubscriptionHttpHandler {
+	return &updateSubscriptionHttpHandler{
+		subscriptionDb: subscriptionDb,
+	}
+}
+
+// swagger:parameters updateSubscription
+type UpdateSubscriptionRequest struct {
+	// ID of subscription
+	//
+	// required: true
+	// in: path
+	ID types.ID `json:"id" uri:"id" binding:"required"`
+}
+
+// swagger:parameters updateSubscription
+type UpdateSubscriptionBody struct {
+	// URL which system events are posted to
+	//
+	// in: body
+	URL *string `json:"url"`
+	// Secret used to sign payloads with HMAC-SHA256
+	//
+	// in: body
+	Secret *string `json:"secret"`
+	// Actors whose system events are delivered, all actors when empty
+	//
+	// in: body
+	Actors *[]string `json:"actors"`
+	// Kinds of system events which are delivered, all kinds when empty
+	//
+	// in: body
+	Kinds *[]string `json:"kinds"`
+	// Enabled, enabling subscription resets its failures
+	//
+	// in: body
+	Enabled *bool `json:"enabled"`
+}
+
+func (h *updateSubscriptionHttpHandler) Handle(c *gin.Context) {
+	var req UpdateSubscriptionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid id"))
+		return
+	}
+
+	var body UpdateSubscriptionBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		logger.Error(err)
+		http.BadRequest(c, errors.New("invalid subscription"))
+		return
+	}
+
+	subscription, err := h.subscriptionDb.FindByID(req.ID)
+	if http.ShouldReturn(c, err) {
+		return
+	}
+
+	if body.URL != nil {
+		subscription.URL = *body.URL
+	}
+	if body.Secret != nil {
+		subscription.Secret = *body.Secret
+	}
+	if body.Actors != nil {
+		subscription.Actors = pq.StringArray(*body.Actors)
+	}
+	if body.Kinds != nil {
+		subscription.Kinds = pq.StringArray(*body.Kinds)
+	}
+	if body.Enabled != nil && *body.Enabled != subscription.Enabled {
+		if *body.Enabled {
+			subscription.Enable()
+		} else {
+			subscription.Disable()
+		}
+	}
+
+	if err := subscription.Validate(); err != nil {
+		http.BadRequest(c, err)
+		return
+	}
+
+	if err := h.subscriptionDb.SaveSettings(subscription); http.ShouldReturn(c, err) {
+		return
+	}
+
+	http.JsonOK(c, subscription)
+}
@@ -3,18 +3,29 @@ package usecase
 import (
 	"github.com/figment-networks/polkadothub-indexer/client"
 	"github.com/figment-networks/polkadothub-indexer/config"
+	"github.com/figment-networks/polkadothub-indexer/model"
 	"github.com/figment-networks/polkadothub-indexer/store"
 	"github.com/figment-networks/polkadothub-indexer/types"
 	"github.com/figment-networks/polkadothub-indexer/usecase/indexing"
+	"github.com/figment-networks/polkadothub-indexer/usecase/publishing"
+	"github.com/figment-networks/polkadothub-indexer/usecase/webhook"
 )
 
-func NewWorkerHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, reportDb store.Reports,
-	rewardDb store.Rewards, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
+func NewWorkerHandlers(cfg *config.Config, cli *client.Client, accountDb store.Accounts, blockDb store.Blocks, databaseDb store.Database, eventDb store.Events, jobDb store.Jobs, reportDb store.Reports,
+	rewardDb store.Rewards, subscriptionDb store.Subscriptions, syncableDb store.Syncables, systemEventDb store.SystemEvents, transactionDb store.Transactions, validatorDb store.Validators,
 ) *WorkerHandlers {
+	var processJobs []types.WorkerHandler
+	for _, kinds := range model.JobKindGroups {
+		processJobs = append(processJobs, indexing.NewProcessJobsWorkerHandler(cfg, kinds, cli, accountDb, blockDb, databaseDb, eventDb, jobDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb))
+	}
+
 	return &WorkerHandlers{
-		RunIndexer:       indexing.NewRunWorkerHandler(cfg, cli, accountDb, blockDb, databaseDb, eventDb, reportDb, rewardDb, syncableDb, systemEventDb, transactionDb, validatorDb),
-		SummarizeIndexer: indexing.NewSummarizeWorkerHandler(cfg, blockDb, validatorDb),
-		PurgeIndexer:     indexing.NewPurgeWorkerHandler(cfg, blockDb, validatorDb),
+		RunIndexer:       indexing.NewEnqueueJobWorkerHandler(cfg, model.JobKindIndex, jobDb),
+		SummarizeIndexer: indexing.NewEnqueueJobWorkerHandler(cfg, model.JobKindSummarize, jobDb),
+		PurgeIndexer:     indexing.NewEnqueueJobWorkerHandler(cfg, model.JobKindPurge, jobDb),
+		ProcessJobs:      processJobs,
+		DeliverWebhooks:  webhook.NewDeliverWorkerHandler(cfg, subscriptionDb, syncableDb, systemEventDb),
+		PublishOutbox:    publishing.NewPublishWorkerHandler(cfg, syncableDb),
 	}
 }
 
@@ -22,4 +33,8 @@ type WorkerHandlers struct {
 	RunIndexer       types.WorkerHandler
 	SummarizeIndexer types.WorkerHandler
 	PurgeIndexer     types.WorkerHandler
+	// ProcessJobs run jobs of every group of job kinds
+	ProcessJobs     []types.WorkerHandler
+	DeliverWebhooks types.WorkerHandler
+	PublishOutbox   types.WorkerHandler
 }
@@ -19,3 +19,27 @@ func (w *Worker) addPurgeIndexerJob() (cron.EntryID, error) {
 	job = cron.NewChain(cron.SkipIfStillRunning(w.logger)).Then(job)
 	return w.cronJob.AddJob(w.cfg.PurgeWorkerInterval, job)
 }
+
+// addProcessJobsJobs adds job for every group of job kinds, each group is skipped only while its own jobs are still running
+func (w *Worker) addProcessJobsJobs() error {
+	for _, handler := range w.handlers.ProcessJobs {
+		job = cron.FuncJob(handler.Handle)
+		job = cron.NewChain(cron.SkipIfStillRunning(w.logger)).Then(job)
+		if _, err := w.cronJob.AddJob(w.cfg.ProcessJobsWorkerInterval, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Worker) addDeliverWebhooksJob() (cron.EntryID, error) {
+	job = cron.FuncJob(w.handlers.DeliverWebhooks.Handle)
+	job = cron.NewChain(cron.SkipIfStillRunning(w.logger)).Then(job)
+	return w.cronJob.AddJob(w.cfg.WebhookWorkerInterval, job)
+}
+
+func (w *Worker) addPublishOutboxJob() (cron.EntryID, error) {
+	job = cron.FuncJob(w.handlers.PublishOutbox.Handle)
+	job = cron.NewChain(cron.SkipIfStillRunning(w.logger)).Then(job)
+	return w.cronJob.AddJob(w.cfg.PublisherWorkerInterval, job)
+}
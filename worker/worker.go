@@ -56,6 +56,21 @@ func (w *Worker) init() (*Worker, error) {
 		return nil, err
 	}
 
+	err = w.addProcessJobsJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.addDeliverWebhooksJob()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.addPublishOutboxJob()
+	if err != nil {
+		return nil, err
+	}
+
 	return w, nil
 }
 